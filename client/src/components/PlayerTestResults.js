@@ -14,7 +14,8 @@ import {
   Typography,
 } from "@mui/material";
 import { fetchPlayerTestResults } from "../http/testAPI";
-import { buildAnswerRows } from "../features/tests/formatTestAnswers";
+import { buildAnswerRows, buildScoreRows, formatDuration } from "../features/tests/formatTestAnswers";
+import { TEST_CONTEXT_LABELS } from "../features/tests/testTypes";
 import { COLORS } from "../utils/constants";
 
 function formatCompletedAt(value) {
@@ -30,8 +31,16 @@ function formatCompletedAt(value) {
   return date.toLocaleString("ru-RU");
 }
 
+function formatAttempt(result) {
+  const context = TEST_CONTEXT_LABELS[result.context] ?? result.context;
+  const phase = result.phase ? ` «${result.phase}»` : "";
+  const version = result.test_version ? ` | Версия теста ${result.test_version}` : "";
+  return `Попытка ${result.attempt ?? 1}${context ? ` | ${context}${phase}` : ""}${version}`;
+}
+
 function TestResultCard({ result, answerRows }) {
   const [expanded, setExpanded] = useState(false);
+  const itemTimes = result.item_times ?? {};
 
   return (
     <Paper sx={{ p: 2 }}>
@@ -40,10 +49,23 @@ function TestResultCard({ result, answerRows }) {
           <Typography sx={{ color: "#232E4A", fontSize: 16, fontWeight: "bold" }}>
             {result.title}
           </Typography>
+          <Typography sx={{ color: "#232E4A", fontSize: 14 }}>{formatAttempt(result)}</Typography>
           <Typography sx={{ color: "#232E4A", fontSize: 14 }}>
             Пройден: {formatCompletedAt(result.completed_at)}
-            {result.score != null ? ` | Средний балл: ${result.score.toFixed(2)}` : ""}
+            {result.score != null ? ` | Балл: ${result.score.toFixed(2)}` : ""}
+            {result.duration_ms != null ? ` | Время: ${formatDuration(result.duration_ms)}` : ""}
           </Typography>
+          {result.speeder ? (
+            <Typography sx={{ color: "#C62828", fontSize: 14, fontWeight: "bold" }}>
+              Пройден быстрее минимального времени, ответы могут быть недостоверны
+            </Typography>
+          ) : null}
+          {buildScoreRows(result.score_details).map((row) => (
+            <Typography key={`${result.id}-${row.title}`} sx={{ color: "#232E4A", fontSize: 14 }}>
+              {row.title}: {Number(row.score).toFixed(2)}
+              {row.interpretation ? ` — ${row.interpretation}` : ""}
+            </Typography>
+          ))}
         </Stack>
         {result.description ? (
           <Typography sx={{ color: "#232E4A", fontSize: 14 }}>{result.description}</Typography>
@@ -67,6 +89,7 @@ function TestResultCard({ result, answerRows }) {
                   <TableCell width={48}>№</TableCell>
                   <TableCell>Вопрос</TableCell>
                   <TableCell width="35%">Ответ</TableCell>
+                  <TableCell width={96}>Время</TableCell>
                 </TableRow>
               </TableHead>
               <TableBody>
@@ -75,6 +98,7 @@ function TestResultCard({ result, answerRows }) {
                     <TableCell>{row.number}</TableCell>
                     <TableCell>{row.question}</TableCell>
                     <TableCell>{row.answer}</TableCell>
+                    <TableCell>{formatDuration(itemTimes[row.id])}</TableCell>
                   </TableRow>
                 ))}
               </TableBody>
@@ -135,7 +159,7 @@ export default function PlayerTestResults({ userId }) {
   return (
     <Stack spacing={2}>
       {results.map((result) => {
-        const answerRows = buildAnswerRows(result.slug, result.config, result.answers);
+        const answerRows = buildAnswerRows(result.type, result.config, result.answers);
 
         return <TestResultCard key={result.id} result={result} answerRows={answerRows} />;
       })}
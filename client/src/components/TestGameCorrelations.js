@@ -0,0 +1,159 @@
+import React, { useEffect, useState } from "react";
+import {
+  Button,
+  MenuItem,
+  Paper,
+  Stack,
+  Table,
+  TableBody,
+  TableCell,
+  TableContainer,
+  TableHead,
+  TableRow,
+  TextField,
+  Typography,
+} from "@mui/material";
+import { getTestGameCorrelations } from "../http/statisticsAPI";
+import { TEST_CONTEXT_LABELS } from "../features/tests/testTypes";
+
+const SIGNIFICANCE_LEVEL = 0.05;
+
+function formatValue(value) {
+  return value == null ? "—" : Number(value).toFixed(2);
+}
+
+function formatPValue(value) {
+  if (value == null) {
+    return "—";
+  }
+  return value < 0.001 ? "< 0.001" : Number(value).toFixed(3);
+}
+
+// Correlations of the group's test scores with the game metrics of the
+// parameter set, the significant ones are shown in bold.
+export default function TestGameCorrelations({ group, onClose }) {
+  const [parSetId, setParSetId] = useState(group.parameter_set_id);
+  const [context, setContext] = useState("");
+  const [phase, setPhase] = useState("");
+  const [data, setData] = useState(null);
+  const [error, setError] = useState("");
+
+  const load = async () => {
+    setError("");
+    try {
+      setData(await getTestGameCorrelations(group.id, parSetId, context, phase));
+    } catch (e) {
+      setData(null);
+      setError(e.response?.data?.error ?? "Не удалось загрузить данные");
+    }
+  };
+
+  useEffect(() => {
+    load();
+  }, [group.id]);
+
+  const variables = data?.variables ?? [];
+
+  return (
+    <Paper sx={{ p: 3 }}>
+      <Stack spacing={2}>
+        <Typography variant="h6">Тесты и поведение в игре: {group.name}</Typography>
+        <Stack direction={{ xs: "column", md: "row" }} spacing={2}>
+          <TextField
+            label="ID набора параметров"
+            type="number"
+            value={parSetId}
+            onChange={(event) => setParSetId(event.target.value)}
+          />
+          <TextField
+            select
+            label="Контекст тестов"
+            value={context}
+            onChange={(event) => {
+              setContext(event.target.value);
+              setPhase("");
+            }}
+            sx={{ minWidth: 220 }}
+          >
+            <MenuItem value="">Все</MenuItem>
+            {Object.entries(TEST_CONTEXT_LABELS).map(([value, label]) => (
+              <MenuItem key={value} value={value}>
+                {label}
+              </MenuItem>
+            ))}
+          </TextField>
+          {context === "study_phase" ? (
+            <TextField label="Этап" value={phase} onChange={(event) => setPhase(event.target.value)} />
+          ) : null}
+        </Stack>
+        <Stack direction="row" spacing={2}>
+          <Button variant="contained" onClick={load}>
+            Обновить
+          </Button>
+          <Button variant="outlined" onClick={onClose}>
+            Закрыть
+          </Button>
+        </Stack>
+        {error ? <Typography color="error">{error}</Typography> : null}
+        {data ? (
+          <>
+            <Typography variant="subtitle1">Корреляции Пирсона (r, p, n)</Typography>
+            <TableContainer>
+              <Table size="small">
+                <TableHead>
+                  <TableRow>
+                    <TableCell />
+                    {variables.map((variable) => (
+                      <TableCell key={variable.id}>{variable.title}</TableCell>
+                    ))}
+                  </TableRow>
+                </TableHead>
+                <TableBody>
+                  {variables.map((row, i) => (
+                    <TableRow key={row.id}>
+                      <TableCell>{row.title}</TableCell>
+                      {variables.map((column, j) => {
+                        const p = data.p[i][j];
+                        const significant = i !== j && p != null && p < SIGNIFICANCE_LEVEL;
+                        return (
+                          <TableCell key={column.id} sx={{ fontWeight: significant ? "bold" : "normal" }}>
+                            {i === j ? "—" : `${formatValue(data.r[i][j])} (p ${formatPValue(p)}, n ${data.n[i][j]})`}
+                          </TableCell>
+                        );
+                      })}
+                    </TableRow>
+                  ))}
+                </TableBody>
+              </Table>
+            </TableContainer>
+            <Typography variant="subtitle1">Участники</Typography>
+            <TableContainer>
+              <Table size="small">
+                <TableHead>
+                  <TableRow>
+                    <TableCell>ID</TableCell>
+                    <TableCell>Имя</TableCell>
+                    {variables.map((variable) => (
+                      <TableCell key={variable.id}>{variable.title}</TableCell>
+                    ))}
+                  </TableRow>
+                </TableHead>
+                <TableBody>
+                  {data.participants.map((participant) => (
+                    <TableRow key={participant.user_id}>
+                      <TableCell>{participant.user_id}</TableCell>
+                      <TableCell>{participant.name}</TableCell>
+                      {variables.map((variable) => (
+                        <TableCell key={variable.id}>{formatValue(participant.values[variable.id])}</TableCell>
+                      ))}
+                    </TableRow>
+                  ))}
+                </TableBody>
+              </Table>
+            </TableContainer>
+          </>
+        ) : null}
+      </Stack>
+    </Paper>
+  );
+}
@@ -1,5 +1,6 @@
 import React from "react";
 import { Button, Typography } from "@mui/material";
+import { useMessages } from "../../../hooks/useMessages";
 
 const noSelectSx = { userSelect: "none" };
 const backButtonSx = {
@@ -8,8 +9,10 @@ const backButtonSx = {
   flexGrow: 1,
 };
 
-export default function CrashProbabilityHint({ crashProb, hintModalDataFetched, onBack }) {
-  if (!hintModalDataFetched) {
+export default function CrashProbabilityHint({ crashHint, hintModalDataFetched, onBack }) {
+  const { riskLevelLabel } = useMessages();
+
+  if (!hintModalDataFetched || crashHint == null) {
     return (
       <Typography sx={noSelectSx}>Расчет вероятности...</Typography>
     );
@@ -17,7 +20,12 @@ export default function CrashProbabilityHint({ crashProb, hintModalDataFetched,
 
   return (
     <>
-      <Typography sx={noSelectSx}>{crashProb}</Typography>
+      <Typography sx={noSelectSx}>{riskLevelLabel(crashHint.risk_level)}</Typography>
+      {crashHint.crash_probability != null && (
+        <Typography sx={noSelectSx}>
+          Вероятность взрыва: {Math.round(crashHint.crash_probability * 100)}%
+        </Typography>
+      )}
       <Button sx={backButtonSx} onClick={onBack}>
         Назад
       </Button>
@@ -8,49 +8,30 @@ const backButtonSx = {
   backgroundColor: "#9356A0",
   flexGrow: 1,
 };
+const hintButtonSx = {
+  color: "#FFFFFF",
+  backgroundColor: COLORS.takeHintButton,
+  flexGrow: 1,
+};
 
-export default function HintMenu({ hintCost, onSelectCrashProbability, onClose }) {
+const hintLabels = {
+  crash_probability: "Показать рискованность продолжения",
+  current_session: "Показать весь текущий гейм",
+  all_sessions: "Показать все свои предыдущие геймы",
+};
+
+// Набор параметров сам задаёт, какие подсказки доступны и сколько они стоят
+export default function HintMenu({ hintOptions, onSelectHint, onClose }) {
   return (
     <>
       <Typography sx={noSelectSx}>Какую подсказку хотите купить?</Typography>
-      {/* <Button
-        sx={{
-          color: "#FFFFFF",
-          backgroundColor: COLORS.takeHintButton,
-          flexGrow: 1,
-        }}
-        onClick={() => {
-          setChosenHint("CurrentSession");
-          chart.chartData.chartHintUsed(50);
-          changeScore(-50);
-        }}
-      >
-        Показать весь текущий гейм (50 очков)
-      </Button>
-      <Button
-        sx={{
-          color: "#FFFFFF",
-          backgroundColor: COLORS.takeHintButton,
-          flexGrow: 1,
-        }}
-        onClick={() => {
-          setChosenHint("AllSessions");
-          chart.chartData.chartHintUsed(200);
-          changeScore(-200);
-        }}
-      >
-        Показать все свои предыдущие геймы (200 очков)
-      </Button> */}
-      <Button
-        sx={{
-          color: "#FFFFFF",
-          backgroundColor: COLORS.takeHintButton,
-          flexGrow: 1,
-        }}
-        onClick={onSelectCrashProbability}
-      >
-        Показать рискованность продолжения ({hintCost} очков)
-      </Button>
+      {hintOptions
+        .filter((option) => hintLabels[option.type] != null)
+        .map((option) => (
+          <Button key={option.type} sx={hintButtonSx} onClick={() => onSelectHint(option)}>
+            {hintLabels[option.type]} ({option.cost} очков)
+          </Button>
+        ))}
       <Button sx={backButtonSx} onClick={onClose}>
         Назад
       </Button>
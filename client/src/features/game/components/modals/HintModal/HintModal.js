@@ -15,14 +15,13 @@ export default function HintModal({
   hintCharts,
   hintModalDataFetched,
   endGameCause,
-  crashProb,
+  crashHint,
   curHintChartNum,
   countHintCharts,
   curLocalHintChartNum,
   onPrevHintChart,
   onNextHintChart,
-  onSelectCrashProbability,
-  hintCost,
+  onSelectHint,
 }) {
   const renderContent = () => {
     switch (chosenHint) {
@@ -46,7 +45,7 @@ export default function HintModal({
       case "CrashProbability":
         return (
           <CrashProbabilityHint
-            crashProb={crashProb}
+            crashHint={crashHint}
             hintModalDataFetched={hintModalDataFetched}
             onBack={onBack}
           />
@@ -54,8 +53,8 @@ export default function HintModal({
       default:
         return (
           <HintMenu
-            hintCost={hintCost}
-            onSelectCrashProbability={onSelectCrashProbability}
+            hintOptions={chartData.hintOptions}
+            onSelectHint={onSelectHint}
             onClose={onClose}
           />
         );
@@ -1,8 +1,9 @@
-import React from "react";
+import React, { useEffect, useState } from "react";
 import { Button } from "@mui/material";
 import GameModal from "./GameModal";
-import RulesTextContent from "./RulesTextContent";
+import RulesTextContent, { RulesHtmlContent } from "./RulesTextContent";
 import { DEFAULT_RULES_TEXT } from "../../constants/defaultRulesText";
+import { getParSetRules } from "../../../../http/graphAPI";
 
 const backButtonSx = {
   color: "#FFFFFF",
@@ -10,21 +11,22 @@ const backButtonSx = {
   flexGrow: 1,
 };
 
-export default function RulesModal({ open, onClose, rulesText }) {
-  if (rulesText?.trim()) {
-    return (
-      <GameModal open={open} onClose={onClose} contentSx={{ height: "90%", width: 800, overflow: "scroll" }}>
-        <RulesTextContent text={rulesText} />
-        <Button sx={backButtonSx} onClick={onClose}>
-          К игре
-        </Button>
-      </GameModal>
+export default function RulesModal({ open, onClose, parSetId }) {
+  const [rulesHtml, setRulesHtml] = useState("");
+
+  useEffect(() => {
+    if (!open || !parSetId) {
+      return;
+    }
+    getParSetRules(parSetId).then(
+      (rules) => setRulesHtml(rules.data.html),
+      (_) => setRulesHtml("")
     );
-  }
+  }, [open, parSetId]);
 
   return (
     <GameModal open={open} onClose={onClose} contentSx={{ height: "90%", width: 800, overflow: "scroll" }}>
-      <RulesTextContent text={DEFAULT_RULES_TEXT} />
+      {rulesHtml?.trim() ? <RulesHtmlContent html={rulesHtml} /> : <RulesTextContent text={DEFAULT_RULES_TEXT} />}
       <Button sx={backButtonSx} onClick={onClose}>
         К игре
       </Button>
@@ -237,6 +237,17 @@ function RulesHtmlTable({ html }) {
   return <Box sx={rulesHtmlTableSx} dangerouslySetInnerHTML={{ __html: sanitizedHtml }} />;
 }
 
+// RulesHtmlContent shows the rules that the server has already rendered from
+// markdown and sanitized.
+export function RulesHtmlContent({ html }) {
+  return (
+    <Box
+      sx={{ ...rulesHtmlTableSx, "& img": { maxWidth: "100%" }, "& p": { mb: 2 } }}
+      dangerouslySetInnerHTML={{ __html: html }}
+    />
+  );
+}
+
 export default function RulesTextContent({ text }) {
   const blocks = useMemo(() => parseRulesBlocks(text), [text]);
 
@@ -23,3 +23,11 @@ export const chartOptions = {
 export const trainingTimeLimitMs = 15 * 60 * 1000;
 export const gameTimeLimitMs = 60 * 60 * 1000;
 export const speedOptions = [0.5, 1, 1.5, 2];
+
+// Reasons a game session is closed with, as the server expects them.
+export const endReasons = {
+  crash: "crash",
+  stop: "stop",
+  timeUp: "time_up",
+  exit: "exit",
+};
@@ -0,0 +1,146 @@
+import { useCallback, useEffect, useMemo, useRef } from "react";
+import { closeGameSession, getGraphScenario, openGameSession, streamGamePoints } from "../../../http/graphAPI";
+import { updateScore } from "../../../http/userAPI";
+import { endReasons } from "../constants";
+
+// Сколько завершённых точек копится перед отправкой на сервер
+const STREAM_BATCH_SIZE = 5;
+
+// Сценарий игры выдаёт сервер; если у набора параметров нет сценариев,
+// график генерируется в клиенте
+const loadScenario = async (graphId) => {
+  try {
+    return await getGraphScenario(graphId);
+  } catch (e) {
+    if (e.response?.status === 404) {
+      return null;
+    }
+    throw e;
+  }
+};
+
+// useChartSession сохраняет игру на сервере по ходу игры: сессия открывается
+// в начале игры, точки, которые больше не изменятся, отправляются пачками, а в
+// конце сессия закрывается с причиной окончания. Запросы идут строго по
+// очереди, поэтому точки не обгоняют открытие сессии.
+export function useChartSession({ chartData, userId, enqueueSnackbar }) {
+  const queueRef = useRef(Promise.resolve());
+  const sessionRef = useRef(null);
+  const startingRef = useRef(null);
+
+  const enqueue = useCallback(
+    (task) => {
+      queueRef.current = queueRef.current.then(task).catch(() => {
+        enqueueSnackbar("Не удалось сохранить ход игры на сервере", {
+          variant: "error",
+          preventDuplicate: true,
+        });
+      });
+      return queueRef.current;
+    },
+    [enqueueSnackbar]
+  );
+
+  // Id графика на сервере появляется после открытия сессии, поэтому его
+  // ждут через очередь запросов
+  const chartId = useCallback(() => {
+    const session = sessionRef.current;
+    return queueRef.current.then(() => session?.id ?? null);
+  }, []);
+
+  // Сессия запоминает массив точек графика: после перезапуска графика
+  // она отправляет только свои точки
+  const isCurrent = useCallback(() => sessionRef.current?.points === chartData.points, [chartData]);
+
+  // Текущая точка ещё может получить отметки (подсказка, остановка, взрыв),
+  // поэтому до конца игры отправляются только точки перед ней
+  const flush = useCallback(
+    (all = false) => {
+      const session = sessionRef.current;
+      if (session == null) {
+        return;
+      }
+      const end = all ? session.points.length : session.points.length - chartData.checkDangerNum - 1;
+      if (end <= session.sent || (!all && end - session.sent < STREAM_BATCH_SIZE)) {
+        return;
+      }
+      const points = session.points.slice(session.sent, end).map((point) => ({ ...point }));
+      session.sent = end;
+      enqueue(async () => {
+        if (session.id != null) {
+          await streamGamePoints(session.id, points);
+        }
+      });
+    },
+    [chartData, enqueue]
+  );
+
+  const close = useCallback(
+    (endReason, totalScore = null) => {
+      const session = sessionRef.current;
+      if (session == null) {
+        return queueRef.current;
+      }
+      flush(true);
+      sessionRef.current = null;
+      return enqueue(async () => {
+        if (session.id == null) {
+          return;
+        }
+        await closeGameSession(session.id, endReason);
+        if (!session.isTraining && totalScore != null) {
+          await updateScore(userId, session.parSetId, totalScore);
+        }
+      });
+    },
+    [userId, flush, enqueue]
+  );
+
+  // Новая игра начинается только после того, как сервер открыл сессию и
+  // выдал сценарий: график перезапускается уже по сценарию
+  const start = useCallback(
+    (isTraining) => {
+      if (startingRef.current != null) {
+        return startingRef.current;
+      }
+      if (sessionRef.current != null) {
+        close(endReasons.exit);
+      }
+      const session = {
+        id: null,
+        isTraining: isTraining,
+        parSetId: chartData.parSet.id,
+        points: null,
+        sent: chartData.maxPointsToShow,
+      };
+      startingRef.current = enqueue(async () => {
+        let scenario = null;
+        try {
+          session.id = await openGameSession(userId, session.parSetId, isTraining);
+          scenario = await loadScenario(session.id);
+        } finally {
+          // Без сессии на сервере игра всё равно продолжается
+          chartData.setScenario(scenario);
+          chartData.restart();
+          session.points = chartData.points;
+          sessionRef.current = session;
+        }
+      }).finally(() => {
+        startingRef.current = null;
+      });
+      return startingRef.current;
+    },
+    [chartData, userId, close, enqueue]
+  );
+
+  // Уход со страницы посреди игры закрывает сессию, а закрытую вкладку
+  // сервер закроет сам по таймауту
+  const closeRef = useRef(close);
+  closeRef.current = close;
+  useEffect(() => () => closeRef.current(endReasons.exit), []);
+
+  return useMemo(
+    () => ({ isCurrent, chartId, start, flush, close }),
+    [isCurrent, chartId, start, flush, close]
+  );
+}
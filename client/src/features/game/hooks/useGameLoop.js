@@ -1,5 +1,6 @@
 import { useEffect, useRef } from "react";
-import { createGraph } from "../../../http/graphAPI";
+import { getAdvice } from "../../../http/graphAPI";
+import { endReasons } from "../constants";
 
 export function useGameLoop({
   chartData,
@@ -9,7 +10,7 @@ export function useGameLoop({
   isDanger,
   isTimeUp,
   userParSet,
-  userId,
+  chartSession,
   totalScore,
   changeScore,
   changeTotalScore,
@@ -26,6 +27,7 @@ export function useGameLoop({
   const isDangerRef = useRef(isDanger);
   const userParSetRef = useRef(userParSet);
   const totalScoreRef = useRef(totalScore);
+  const adviceRef = useRef(null);
 
   useEffect(() => {
     isTimeUpRef.current = isTimeUp;
@@ -49,23 +51,25 @@ export function useGameLoop({
     }
 
     const interval = setInterval(() => {
+      const currentUserParSet = userParSetRef.current;
+      if (currentUserParSet != null && !chartSession.isCurrent()) {
+        // График перезапущен: новая игра ждёт сессию и сценарий с сервера
+        chartSession.start(currentUserParSet.is_training);
+        return;
+      }
+      // Следующая точка появляется только после совета ИИ для текущей
+      if (adviceRef.current != null) {
+        return;
+      }
+
       const oldScore = chartData.score;
       chartData.generateNextPoint();
+      chartSession.flush();
 
       if (chartData.isCrashed()) {
         chartData.chartCrashed();
         const totalScoreDiff = chartData.computeEndGameScore();
-        const currentUserParSet = userParSetRef.current;
-
-        if (currentUserParSet != null) {
-          createGraph(
-            chartData.points.slice(chartData.maxPointsToShow),
-            userId,
-            chartData.parSet.id,
-            totalScoreRef.current + totalScoreDiff,
-            currentUserParSet.is_training
-          );
-        }
+        chartSession.close(endReasons.crash, totalScoreRef.current + totalScoreDiff);
 
         changeTotalScore(totalScoreDiff);
         setIsChartPaused(true);
@@ -85,10 +89,29 @@ export function useGameLoop({
         }
       }
 
-      if (!isDangerRef.current && chartData.isDanger()) {
-        playAlertSound();
-        setIsChartPaused(true);
-        setIsDanger(true);
+      if (!isDangerRef.current && !chartData.isCrashed() && chartData.shouldSentAlert) {
+        const point = chartData.currentPoint();
+        const nextPoint = chartData.nextPoint();
+        adviceRef.current = chartSession
+          .chartId()
+          .then((chartId) => (chartId == null ? null : getAdvice(chartId, point.x, point.y, nextPoint.y)))
+          .then((advice) => {
+            // Пока шёл запрос, игра могла закончиться или перейти к другой точке
+            if (advice == null || isDangerRef.current || chartData.currentPoint() !== point) {
+              return;
+            }
+            if (chartData.applyAdvice(advice)) {
+              playAlertSound();
+              setIsChartPaused(true);
+              setIsDanger(true);
+            }
+          })
+          .catch(() => {
+            enqueueSnackbar("Не удалось получить совет ИИ", { variant: "error", preventDuplicate: true });
+          })
+          .finally(() => {
+            adviceRef.current = null;
+          });
       }
 
       if (chartData.score !== oldScore) {
@@ -112,7 +135,7 @@ export function useGameLoop({
     isChartPaused,
     curSpeed,
     chartData,
-    userId,
+    chartSession,
     changeScore,
     changeTotalScore,
     setIsChartPaused,
@@ -132,16 +155,11 @@ export function useGameLoop({
 
     const isStopNeeded = chartData.chartStopped();
     const totalScoreDiff = chartData.computeEndGameScore();
-    const currentUserParSet = userParSetRef.current;
-
-    if (currentUserParSet != null && !isTimeUpRef.current) {
-      createGraph(
-        chartData.points.slice(chartData.maxPointsToShow),
-        userId,
-        chartData.parSet.id,
-        totalScoreRef.current + totalScoreDiff,
-        currentUserParSet.is_training
-      );
+
+    if (isTimeUpRef.current) {
+      chartSession.close(endReasons.timeUp);
+    } else {
+      chartSession.close(endReasons.stop, totalScoreRef.current + totalScoreDiff);
     }
 
     chartData.generateNextPoint(false);
@@ -176,7 +194,7 @@ export function useGameLoop({
   }, [
     isChartStopped,
     chartData,
-    userId,
+    chartSession,
     changeTotalScore,
     setIsHintModalOpened,
     setIsChartPaused,
@@ -0,0 +1,46 @@
+import { useEffect, useMemo, useState } from "react";
+import { fetchMessages } from "../../../http/i18nAPI";
+
+// Интерфейс на русском, поэтому каталог запрашивается на русском
+const UI_LOCALE = "ru";
+
+// Каталог загружается один раз на всё приложение
+let messagesRequest = null;
+
+const loadMessages = () => {
+  if (messagesRequest == null) {
+    messagesRequest = fetchMessages(UI_LOCALE).catch((e) => {
+      messagesRequest = null;
+      throw e;
+    });
+  }
+  return messagesRequest;
+};
+
+// useMessages подписывает коды событий и уровней риска по каталогу сервера.
+// Пока каталог не загружен, вместо подписи показывается сам код
+export function useMessages() {
+  const [messages, setMessages] = useState({});
+
+  useEffect(() => {
+    let isMounted = true;
+    loadMessages()
+      .then((data) => {
+        if (isMounted) {
+          setMessages(data);
+        }
+      })
+      .catch(() => {});
+    return () => {
+      isMounted = false;
+    };
+  }, []);
+
+  return useMemo(
+    () => ({
+      eventLabel: (code) => messages["event." + code] ?? code,
+      riskLevelLabel: (level) => messages["risk_level." + level] ?? level,
+    }),
+    [messages]
+  );
+}
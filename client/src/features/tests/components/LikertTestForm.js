@@ -1,7 +1,8 @@
-import React, { useMemo, useState } from "react";
+import React, { useMemo } from "react";
 import { Box, Button, Stack, TextField, Typography } from "@mui/material";
 
 import { parseTestConfig } from "../testTypes";
+import useTestAnswers from "../useTestAnswers";
 
 function resolveScale(question, config) {
   return {
@@ -35,15 +36,15 @@ function getOptionLabel(value, scale) {
   return String(value);
 }
 
-export default function LikertTestForm({ test, onSubmit, submitting }) {
+export default function LikertTestForm({ test, onSubmit, onProgress, draft, submitting, errors = {} }) {
   const config = useMemo(() => parseTestConfig(test.config), [test.config]);
-  const [answers, setAnswers] = useState({});
+  const { answers, itemTimes, setAnswer } = useTestAnswers({}, draft, onProgress);
 
   const handleChange = (questionId, value) => {
-    setAnswers((prev) => ({ ...prev, [questionId]: Number(value) }));
+    setAnswer(questionId, Number(value));
   };
 
-  const isComplete = config.questions.every((question) => answers[question.id] != null);
+  const isComplete = config.questions.every((question) => question.optional || answers[question.id] != null);
 
   return (
     <Stack spacing={3}>
@@ -59,6 +60,8 @@ export default function LikertTestForm({ test, onSubmit, submitting }) {
               value={answers[question.id] ?? ""}
               onChange={(event) => handleChange(question.id, event.target.value)}
               SelectProps={{ native: true }}
+              error={Boolean(errors[question.id])}
+              helperText={errors[question.id]?.error}
             >
               <option value="" disabled />
               {Array.from({ length: scale.max - scale.min + 1 }, (_, index) => {
@@ -73,7 +76,7 @@ export default function LikertTestForm({ test, onSubmit, submitting }) {
           </Box>
         );
       })}
-      <Button variant="contained" disabled={!isComplete || submitting} onClick={() => onSubmit(answers)}>
+      <Button variant="contained" disabled={!isComplete || submitting} onClick={() => onSubmit(answers, itemTimes)}>
         Сохранить ответы
       </Button>
     </Stack>
@@ -0,0 +1,98 @@
+import React, { useEffect, useMemo, useState } from "react";
+import { Button, Stack, Typography } from "@mui/material";
+
+import { fetchTestVisibility } from "../../../http/testAPI";
+import { ITEM_TYPE_RANKING, parseTestConfig } from "../testTypes";
+import useTestAnswers from "../useTestAnswers";
+import TestItemInput, { isItemAnswered } from "./TestItemInput";
+
+const VISIBILITY_DELAY_MS = 300;
+
+// A ranking starts in the order of its options, so it is answered even if
+// the player keeps that order.
+function initialAnswers(config) {
+  const answers = {};
+  (config.items ?? []).forEach((item) => {
+    if (item.type === ITEM_TYPE_RANKING) {
+      answers[item.id] = [...item.options];
+    }
+  });
+  return answers;
+}
+
+// Until the server has evaluated the conditions only the unconditional items
+// are shown.
+function unconditionalItems(config) {
+  const conditionalSections = new Set(
+    (config.sections ?? []).filter((section) => section.show_if).map((section) => section.id)
+  );
+  return new Set(
+    (config.items ?? [])
+      .filter((item) => !item.show_if && !conditionalSections.has(item.section))
+      .map((item) => item.id)
+  );
+}
+
+function hasConditions(config) {
+  return (config.items ?? []).some((item) => item.show_if) || (config.sections ?? []).some((section) => section.show_if);
+}
+
+export default function MixedTestForm({ test, onSubmit, onProgress, draft, submitting, errors = {} }) {
+  const config = useMemo(() => parseTestConfig(test.config), [test.config]);
+  const { answers, setAnswer, timesOf } = useTestAnswers(initialAnswers(config), draft, onProgress);
+  const [visible, setVisible] = useState(() => unconditionalItems(config));
+
+  useEffect(() => {
+    if (!hasConditions(config)) {
+      return undefined;
+    }
+    const timer = setTimeout(() => {
+      fetchTestVisibility(test.id, answers)
+        .then((data) => setVisible(new Set(data.visible ?? [])))
+        .catch(() => {});
+    }, VISIBILITY_DELAY_MS);
+    return () => clearTimeout(timer);
+  }, [test.id, config, answers]);
+
+  const handleChange = (itemId, value) => {
+    setAnswer(itemId, value);
+  };
+
+  const visibleItems = (config.items ?? []).filter((item) => visible.has(item.id));
+  const isComplete = visibleItems.every((item) => isItemAnswered(item, answers[item.id]));
+  const sectionTitles = Object.fromEntries((config.sections ?? []).map((section) => [section.id, section.title]));
+
+  // The answers to the items hidden by a later change are not sent, the
+  // server rejects answers to hidden questions.
+  const handleSubmit = () => {
+    const visibleAnswers = {};
+    visibleItems.forEach((item) => {
+      if (answers[item.id] !== undefined) {
+        visibleAnswers[item.id] = answers[item.id];
+      }
+    });
+    onSubmit(visibleAnswers, timesOf(visibleItems.map((item) => item.id)));
+  };
+
+  return (
+    <Stack spacing={3}>
+      {visibleItems.map((item, index) => (
+        <React.Fragment key={item.id}>
+          {item.section && item.section !== visibleItems[index - 1]?.section && sectionTitles[item.section] ? (
+            <Typography variant="h6">{sectionTitles[item.section]}</Typography>
+          ) : null}
+          <TestItemInput
+            item={item}
+            config={config}
+            value={answers[item.id]}
+            onChange={(value) => handleChange(item.id, value)}
+            errors={errors}
+          />
+        </React.Fragment>
+      ))}
+      <Button variant="contained" disabled={!isComplete || submitting} onClick={handleSubmit}>
+        Сохранить ответы
+      </Button>
+    </Stack>
+  );
+}
@@ -1,17 +1,27 @@
-import React, { useMemo, useState } from "react";
-import { Box, Button, FormControlLabel, Radio, RadioGroup, Stack, Typography } from "@mui/material";
+import React, { useMemo } from "react";
+import {
+  Box,
+  Button,
+  FormControlLabel,
+  FormHelperText,
+  Radio,
+  RadioGroup,
+  Stack,
+  Typography,
+} from "@mui/material";
 
 import { parseTestConfig } from "../testTypes";
+import useTestAnswers from "../useTestAnswers";
 
-export default function SingleChoiceTestForm({ test, onSubmit, submitting }) {
+export default function SingleChoiceTestForm({ test, onSubmit, onProgress, draft, submitting, errors = {} }) {
   const config = useMemo(() => parseTestConfig(test.config), [test.config]);
-  const [answers, setAnswers] = useState({});
+  const { answers, itemTimes, setAnswer } = useTestAnswers({}, draft, onProgress);
 
   const handleChange = (questionId, value) => {
-    setAnswers((prev) => ({ ...prev, [questionId]: value }));
+    setAnswer(questionId, value);
   };
 
-  const isComplete = config.questions.every((question) => answers[question.id]);
+  const isComplete = config.questions.every((question) => question.optional || answers[question.id]);
 
   return (
     <Stack spacing={3}>
@@ -26,9 +36,10 @@ export default function SingleChoiceTestForm({ test, onSubmit, submitting }) {
               <FormControlLabel key={option} value={option} control={<Radio />} label={option} />
             ))}
           </RadioGroup>
+          {errors[question.id] ? <FormHelperText error>{errors[question.id].error}</FormHelperText> : null}
         </Box>
       ))}
-      <Button variant="contained" disabled={!isComplete || submitting} onClick={() => onSubmit(answers)}>
+      <Button variant="contained" disabled={!isComplete || submitting} onClick={() => onSubmit(answers, itemTimes)}>
         Сохранить ответы
       </Button>
     </Stack>
@@ -0,0 +1,256 @@
+import React from "react";
+import {
+  Box,
+  Checkbox,
+  FormControlLabel,
+  FormGroup,
+  FormHelperText,
+  IconButton,
+  List,
+  ListItem,
+  ListItemText,
+  Radio,
+  RadioGroup,
+  Slider,
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableRow,
+  TextField,
+  Typography,
+} from "@mui/material";
+
+import { formatLikertAnswer, resolveScale } from "../formatTestAnswers";
+import {
+  ITEM_TYPE_LIKERT,
+  ITEM_TYPE_MATRIX,
+  ITEM_TYPE_MULTI_SELECT,
+  ITEM_TYPE_RANKING,
+  ITEM_TYPE_SINGLE_CHOICE,
+  ITEM_TYPE_SLIDER,
+  ITEM_TYPE_TEXT,
+} from "../testTypes";
+
+function scaleValues(scale) {
+  return Array.from({ length: scale.max - scale.min + 1 }, (_, index) => scale.min + index);
+}
+
+// isItemAnswered mirrors the completeness check of the server, the optional
+// items never block the submission.
+export function isItemAnswered(item, value) {
+  if (item.optional) {
+    return true;
+  }
+  switch (item.type) {
+    case ITEM_TYPE_MULTI_SELECT:
+      return Array.isArray(value) && value.length >= Math.max(item.min_selected ?? 0, 1);
+    case ITEM_TYPE_MATRIX:
+      return (item.rows ?? []).every((row) => value?.[row.id] != null);
+    case ITEM_TYPE_TEXT:
+      return (value || "").trim() !== "";
+    default:
+      return value != null && value !== "";
+  }
+}
+
+function LikertItem({ item, config, value, onChange, error }) {
+  const scale = resolveScale(item, config);
+  return (
+    <TextField
+      select
+      fullWidth
+      value={value ?? ""}
+      onChange={(event) => onChange(Number(event.target.value))}
+      SelectProps={{ native: true }}
+      error={Boolean(error)}
+      helperText={error}
+    >
+      <option value="" disabled />
+      {scaleValues(scale).map((option) => (
+        <option key={option} value={option}>
+          {formatLikertAnswer(option, item, config)}
+        </option>
+      ))}
+    </TextField>
+  );
+}
+
+function SliderItem({ item, config, value, onChange }) {
+  const scale = resolveScale(item, config);
+  const marks = [
+    { value: scale.min, label: scale.labels?.[scale.min] ?? String(scale.min) },
+    { value: scale.max, label: scale.labels?.[scale.max] ?? String(scale.max) },
+  ];
+  return (
+    <Box sx={{ px: 2 }}>
+      <Slider
+        value={value ?? scale.min}
+        min={scale.min}
+        max={scale.max}
+        step={item.step || null}
+        marks={marks}
+        valueLabelDisplay="auto"
+        onChange={(_, newValue) => onChange(newValue)}
+      />
+      {value == null ? (
+        <Typography variant="caption" color="text.secondary">
+          Передвиньте ползунок, чтобы ответить
+        </Typography>
+      ) : null}
+    </Box>
+  );
+}
+
+function SingleChoiceItem({ item, value, onChange }) {
+  return (
+    <RadioGroup value={value || ""} onChange={(event) => onChange(event.target.value)}>
+      {item.options.map((option) => (
+        <FormControlLabel key={option} value={option} control={<Radio />} label={option} />
+      ))}
+    </RadioGroup>
+  );
+}
+
+function MultiSelectItem({ item, value, onChange }) {
+  const selected = value ?? [];
+  const toggle = (option) => {
+    onChange(selected.includes(option) ? selected.filter((o) => o !== option) : [...selected, option]);
+  };
+  return (
+    <FormGroup>
+      {item.options.map((option) => (
+        <FormControlLabel
+          key={option}
+          control={<Checkbox checked={selected.includes(option)} onChange={() => toggle(option)} />}
+          label={option}
+        />
+      ))}
+    </FormGroup>
+  );
+}
+
+function RankingItem({ item, value, onChange }) {
+  const order = value ?? item.options;
+  const move = (index, shift) => {
+    const next = [...order];
+    [next[index], next[index + shift]] = [next[index + shift], next[index]];
+    onChange(next);
+  };
+  return (
+    <List dense>
+      {order.map((option, index) => (
+        <ListItem
+          key={option}
+          secondaryAction={
+            <>
+              <IconButton size="small" disabled={index === 0} onClick={() => move(index, -1)}>
+                ↑
+              </IconButton>
+              <IconButton size="small" disabled={index === order.length - 1} onClick={() => move(index, 1)}>
+                ↓
+              </IconButton>
+            </>
+          }
+        >
+          <ListItemText primary={`${index + 1}. ${option}`} />
+        </ListItem>
+      ))}
+    </List>
+  );
+}
+
+function MatrixItem({ item, config, value, onChange, errors }) {
+  const scale = resolveScale(item, config);
+  const rows = value ?? {};
+  return (
+    <Table size="small">
+      <TableHead>
+        <TableRow>
+          <TableCell />
+          {scaleValues(scale).map((option) => (
+            <TableCell key={option} align="center">
+              {formatLikertAnswer(option, item, config)}
+            </TableCell>
+          ))}
+        </TableRow>
+      </TableHead>
+      <TableBody>
+        {item.rows.map((row) => (
+          <TableRow key={row.id}>
+            <TableCell>
+              {row.text}
+              {errors[`${item.id}.${row.id}`] ? (
+                <FormHelperText error>{errors[`${item.id}.${row.id}`].error}</FormHelperText>
+              ) : null}
+            </TableCell>
+            {scaleValues(scale).map((option) => (
+              <TableCell key={option} align="center">
+                <Radio
+                  checked={rows[row.id] === option}
+                  onChange={() => onChange({ ...rows, [row.id]: option })}
+                />
+              </TableCell>
+            ))}
+          </TableRow>
+        ))}
+      </TableBody>
+    </Table>
+  );
+}
+
+function TextItem({ item, value, onChange, error }) {
+  return (
+    <TextField
+      fullWidth
+      multiline
+      minRows={3}
+      value={value || ""}
+      onChange={(event) => onChange(event.target.value)}
+      inputProps={item.max_length ? { maxLength: item.max_length } : undefined}
+      error={Boolean(error)}
+      helperText={error}
+    />
+  );
+}
+
+export default function TestItemInput({ item, config, value, onChange, errors = {} }) {
+  const error = errors[item.id]?.error;
+  const props = { item, config, value, onChange, errors };
+
+  let input;
+  switch (item.type) {
+    case ITEM_TYPE_LIKERT:
+      input = <LikertItem {...props} error={error} />;
+      break;
+    case ITEM_TYPE_SLIDER:
+      input = <SliderItem {...props} />;
+      break;
+    case ITEM_TYPE_SINGLE_CHOICE:
+      input = <SingleChoiceItem {...props} />;
+      break;
+    case ITEM_TYPE_MULTI_SELECT:
+      input = <MultiSelectItem {...props} />;
+      break;
+    case ITEM_TYPE_RANKING:
+      input = <RankingItem {...props} />;
+      break;
+    case ITEM_TYPE_MATRIX:
+      input = <MatrixItem {...props} />;
+      break;
+    case ITEM_TYPE_TEXT:
+      input = <TextItem {...props} error={error} />;
+      break;
+    default:
+      input = <Typography>Неизвестный тип вопроса: {item.type}</Typography>;
+  }
+
+  const showsOwnError = item.type === ITEM_TYPE_LIKERT || item.type === ITEM_TYPE_TEXT;
+  return (
+    <Box>
+      <Typography sx={{ mb: 1 }}>{item.text}</Typography>
+      {input}
+      {error && !showsOwnError ? <FormHelperText error>{error}</FormHelperText> : null}
+    </Box>
+  );
+}
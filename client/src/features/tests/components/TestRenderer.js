@@ -2,22 +2,26 @@ import React from "react";
 import { Typography } from "@mui/material";
 import {
   TEST_TYPE_LIKERT,
+  TEST_TYPE_MIXED,
   TEST_TYPE_SINGLE_CHOICE,
   TEST_TYPE_TEXT,
 } from "../testTypes";
 import LikertTestForm from "./LikertTestForm";
+import MixedTestForm from "./MixedTestForm";
 import SingleChoiceTestForm from "./SingleChoiceTestForm";
 import TextTestForm from "./TextTestForm";
 
-export default function TestRenderer({ test, onSubmit, submitting }) {
-  switch (test.slug) {
+export default function TestRenderer({ test, onSubmit, onProgress, draft, submitting, errors = {} }) {
+  switch (test.type) {
     case TEST_TYPE_LIKERT:
-      return <LikertTestForm test={test} onSubmit={onSubmit} submitting={submitting} />;
+      return <LikertTestForm test={test} onSubmit={onSubmit} onProgress={onProgress} draft={draft} submitting={submitting} errors={errors} />;
     case TEST_TYPE_SINGLE_CHOICE:
-      return <SingleChoiceTestForm test={test} onSubmit={onSubmit} submitting={submitting} />;
+      return <SingleChoiceTestForm test={test} onSubmit={onSubmit} onProgress={onProgress} draft={draft} submitting={submitting} errors={errors} />;
     case TEST_TYPE_TEXT:
-      return <TextTestForm test={test} onSubmit={onSubmit} submitting={submitting} />;
+      return <TextTestForm test={test} onSubmit={onSubmit} onProgress={onProgress} draft={draft} submitting={submitting} errors={errors} />;
+    case TEST_TYPE_MIXED:
+      return <MixedTestForm test={test} onSubmit={onSubmit} onProgress={onProgress} draft={draft} submitting={submitting} errors={errors} />;
     default:
-      return <Typography>Неизвестный тип теста: {test.slug}</Typography>;
+      return <Typography>Неизвестный тип теста: {test.type}</Typography>;
   }
 }
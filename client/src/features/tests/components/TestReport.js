@@ -0,0 +1,179 @@
+import React, { useEffect, useState } from "react";
+import {
+  Button,
+  MenuItem,
+  Paper,
+  Stack,
+  Table,
+  TableBody,
+  TableCell,
+  TableContainer,
+  TableHead,
+  TableRow,
+  TextField,
+  Typography,
+} from "@mui/material";
+
+import { fetchTestReport } from "../../../http/testAPI";
+import { getAllGroups } from "../../../http/userAPI";
+import { TEST_CONTEXT_LABELS } from "../testTypes";
+
+const emptyFilters = {
+  group_id: "",
+  par_set_id: "",
+  context: "",
+  phase: "",
+  version: "",
+};
+
+function formatStatistic(value) {
+  return value == null ? "—" : Number(value).toFixed(2);
+}
+
+function formatDistribution(distribution) {
+  return (distribution ?? []).map((entry) => `${entry.value}: ${entry.count}`).join(", ");
+}
+
+function ReliabilityLine({ reliability, title }) {
+  return (
+    <Typography>
+      {title}: α = {formatStatistic(reliability.alpha)}, ω = {formatStatistic(reliability.omega)} (полных ответов:{" "}
+      {reliability.complete_cases}, вопросов: {reliability.items?.length ?? 0})
+    </Typography>
+  );
+}
+
+export default function TestReport({ test, onClose }) {
+  const [groups, setGroups] = useState([]);
+  const [filters, setFilters] = useState({ ...emptyFilters, version: test.version });
+  const [report, setReport] = useState(null);
+  const [error, setError] = useState("");
+
+  useEffect(() => {
+    getAllGroups()
+      .then(setGroups)
+      .catch(() => setGroups([]));
+  }, []);
+
+  const loadReport = async () => {
+    setError("");
+    try {
+      setReport(await fetchTestReport(test.id, filters));
+    } catch (e) {
+      setReport(null);
+      setError(e.response?.data?.error ?? "Не удалось построить отчёт");
+    }
+  };
+
+  useEffect(() => {
+    loadReport();
+  }, [test.id]);
+
+  const setFilter = (name, value) => {
+    setFilters((prev) => ({ ...prev, [name]: value, ...(name === "context" ? { phase: "" } : {}) }));
+  };
+
+  return (
+    <Paper sx={{ p: 3 }}>
+      <Stack spacing={2}>
+        <Typography variant="h6">Психометрический отчёт: {test.title}</Typography>
+        <Stack direction={{ xs: "column", md: "row" }} spacing={2}>
+          <TextField
+            select
+            label="Группа"
+            value={filters.group_id}
+            onChange={(event) => setFilter("group_id", event.target.value)}
+            sx={{ minWidth: 180 }}
+          >
+            <MenuItem value="">Все</MenuItem>
+            {groups.map((group) => (
+              <MenuItem key={group.id} value={group.id}>
+                {group.name}
+              </MenuItem>
+            ))}
+          </TextField>
+          <TextField
+            label="Набор параметров"
+            type="number"
+            value={filters.par_set_id}
+            onChange={(event) => setFilter("par_set_id", event.target.value)}
+          />
+          <TextField
+            select
+            label="Контекст"
+            value={filters.context}
+            onChange={(event) => setFilter("context", event.target.value)}
+            sx={{ minWidth: 220 }}
+          >
+            <MenuItem value="">Все</MenuItem>
+            {Object.entries(TEST_CONTEXT_LABELS).map(([value, label]) => (
+              <MenuItem key={value} value={value}>
+                {label}
+              </MenuItem>
+            ))}
+          </TextField>
+          {filters.context === "study_phase" ? (
+            <TextField label="Этап" value={filters.phase} onChange={(event) => setFilter("phase", event.target.value)} />
+          ) : null}
+          <TextField
+            label="Версия"
+            type="number"
+            value={filters.version}
+            onChange={(event) => setFilter("version", event.target.value)}
+          />
+        </Stack>
+        <Stack direction="row" spacing={2}>
+          <Button variant="contained" onClick={loadReport}>
+            Построить
+          </Button>
+          <Button variant="outlined" onClick={onClose}>
+            Закрыть
+          </Button>
+        </Stack>
+        {error ? <Typography color="error">{error}</Typography> : null}
+        {report ? (
+          <Stack spacing={1}>
+            <Typography>
+              Версия {report.version}, результатов: {report.results}
+            </Typography>
+            <ReliabilityLine reliability={report.scale} title="Вся шкала" />
+            {report.subscales.map((subscale) => (
+              <ReliabilityLine key={subscale.id} reliability={subscale} title={subscale.title || subscale.id} />
+            ))}
+            <TableContainer>
+              <Table size="small">
+                <TableHead>
+                  <TableRow>
+                    <TableCell>Вопрос</TableCell>
+                    <TableCell>Ответов</TableCell>
+                    <TableCell>Среднее</TableCell>
+                    <TableCell>SD</TableCell>
+                    <TableCell>r с суммой</TableCell>
+                    <TableCell>α без вопроса</TableCell>
+                    <TableCell>Распределение</TableCell>
+                  </TableRow>
+                </TableHead>
+                <TableBody>
+                  {(report.items ?? []).map((item) => (
+                    <TableRow key={item.id}>
+                      <TableCell>
+                        {item.text}
+                        {item.reverse ? " (обратный)" : ""}
+                      </TableCell>
+                      <TableCell>{item.answered}</TableCell>
+                      <TableCell>{formatStatistic(item.mean)}</TableCell>
+                      <TableCell>{formatStatistic(item.sd)}</TableCell>
+                      <TableCell>{formatStatistic(item.item_total)}</TableCell>
+                      <TableCell>{formatStatistic(item.alpha_if_deleted)}</TableCell>
+                      <TableCell>{formatDistribution(item.distribution)}</TableCell>
+                    </TableRow>
+                  ))}
+                </TableBody>
+              </Table>
+            </TableContainer>
+          </Stack>
+        ) : null}
+      </Stack>
+    </Paper>
+  );
+}
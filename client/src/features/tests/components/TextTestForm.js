@@ -1,17 +1,20 @@
-import React, { useMemo, useState } from "react";
+import React, { useMemo } from "react";
 import { Box, Button, Stack, TextField, Typography } from "@mui/material";
 
 import { parseTestConfig } from "../testTypes";
+import useTestAnswers from "../useTestAnswers";
 
-export default function TextTestForm({ test, onSubmit, submitting }) {
+export default function TextTestForm({ test, onSubmit, onProgress, draft, submitting, errors = {} }) {
   const config = useMemo(() => parseTestConfig(test.config), [test.config]);
-  const [answers, setAnswers] = useState({});
+  const { answers, itemTimes, setAnswer } = useTestAnswers({}, draft, onProgress);
 
   const handleChange = (questionId, value) => {
-    setAnswers((prev) => ({ ...prev, [questionId]: value }));
+    setAnswer(questionId, value);
   };
 
-  const isComplete = config.questions.every((question) => (answers[question.id] || "").trim() !== "");
+  const isComplete = config.questions.every(
+    (question) => question.optional || (answers[question.id] || "").trim() !== ""
+  );
 
   return (
     <Stack spacing={3}>
@@ -24,10 +27,13 @@ export default function TextTestForm({ test, onSubmit, submitting }) {
             minRows={3}
             value={answers[question.id] || ""}
             onChange={(event) => handleChange(question.id, event.target.value)}
+            inputProps={question.max_length ? { maxLength: question.max_length } : undefined}
+            error={Boolean(errors[question.id])}
+            helperText={errors[question.id]?.error}
           />
         </Box>
       ))}
-      <Button variant="contained" disabled={!isComplete || submitting} onClick={() => onSubmit(answers)}>
+      <Button variant="contained" disabled={!isComplete || submitting} onClick={() => onSubmit(answers, itemTimes)}>
         Сохранить ответы
       </Button>
     </Stack>
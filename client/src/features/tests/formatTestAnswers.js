@@ -1,16 +1,25 @@
-import { parseTestConfig, TEST_TYPE_LIKERT } from "./testTypes";
+import {
+  ITEM_TYPE_LIKERT,
+  ITEM_TYPE_MATRIX,
+  ITEM_TYPE_MULTI_SELECT,
+  ITEM_TYPE_RANKING,
+  parseTestConfig,
+  TEST_TYPE_LIKERT,
+  TEST_TYPE_MIXED,
+} from "./testTypes";
 
-function resolveScale(question, config) {
+export function resolveScale(question, config) {
+  const scale = question.scale ?? config.scale;
   return {
-    min: question.min ?? config.scale?.min ?? 1,
-    max: question.max ?? config.scale?.max ?? 5,
-    labels: question.labels ?? config.scale?.labels,
-    min_label: question.min_label ?? config.scale?.min_label,
-    max_label: question.max_label ?? config.scale?.max_label,
+    min: question.min ?? scale?.min ?? 1,
+    max: question.max ?? scale?.max ?? 5,
+    labels: question.labels ?? scale?.labels,
+    min_label: question.min_label ?? scale?.min_label,
+    max_label: question.max_label ?? scale?.max_label,
   };
 }
 
-function formatLikertAnswer(value, question, config) {
+export function formatLikertAnswer(value, question, config) {
   if (value == null || value === "") {
     return "—";
   }
@@ -38,25 +47,80 @@ function formatLikertAnswer(value, question, config) {
   return String(numericValue);
 }
 
-function formatAnswer(slug, question, config, value) {
+function formatItemAnswer(item, config, value) {
+  switch (item.type) {
+    case ITEM_TYPE_LIKERT:
+      return formatLikertAnswer(value, item, config);
+    case ITEM_TYPE_MULTI_SELECT:
+      return value.length > 0 ? value.join(", ") : "—";
+    case ITEM_TYPE_RANKING:
+      return value.map((option, index) => `${index + 1}. ${option}`).join("; ");
+    case ITEM_TYPE_MATRIX:
+      return (item.rows ?? [])
+        .map((row) => `${row.text}: ${formatLikertAnswer(value[row.id], item, config)}`)
+        .join("; ");
+    default:
+      return String(value);
+  }
+}
+
+function formatAnswer(type, question, config, value) {
   if (value == null || value === "") {
     return "—";
   }
 
-  if (slug === TEST_TYPE_LIKERT) {
+  if (type === TEST_TYPE_LIKERT) {
     return formatLikertAnswer(value, question, config);
   }
+  if (type === TEST_TYPE_MIXED) {
+    return formatItemAnswer(question, config, value);
+  }
 
   return String(value);
 }
 
-export function buildAnswerRows(slug, config, answers) {
+export function buildAnswerRows(type, config, answers) {
   const parsedConfig = parseTestConfig(config);
   const parsedAnswers = typeof answers === "string" ? JSON.parse(answers) : answers ?? {};
+  const questions = type === TEST_TYPE_MIXED ? parsedConfig.items : parsedConfig.questions;
 
-  return (parsedConfig.questions ?? []).map((question, index) => ({
+  return (questions ?? []).map((question, index) => ({
+    id: question.id,
     number: index + 1,
     question: question.text,
-    answer: formatAnswer(slug, question, parsedConfig, parsedAnswers[question.id]),
+    answer: formatAnswer(type, question, parsedConfig, parsedAnswers[question.id]),
   }));
 }
+
+export function buildScoreRows(scoreDetails) {
+  const details = typeof scoreDetails === "string" ? JSON.parse(scoreDetails) : scoreDetails;
+  if (!details) {
+    return [];
+  }
+
+  // Tests without a total score, such as the Big Five, only have subscales.
+  const total =
+    details.total != null ? [{ title: "Итог", score: details.total, interpretation: details.interpretation }] : [];
+  return [
+    ...total,
+    ...(details.subscales ?? []).map((subscale) => ({
+      title:
+        subscale.weight != null
+          ? `${subscale.title || subscale.id} (вес ${subscale.weight})`
+          : subscale.title || subscale.id,
+      score: subscale.score,
+      interpretation: subscale.interpretation,
+    })),
+  ];
+}
+
+export function formatDuration(ms) {
+  if (ms == null) {
+    return "—";
+  }
+  const seconds = Math.round(Number(ms) / 1000);
+  if (seconds < 60) {
+    return `${seconds} с`;
+  }
+  return `${Math.floor(seconds / 60)} мин ${seconds % 60} с`;
+}
@@ -1,6 +1,15 @@
 export const TEST_TYPE_LIKERT = "likert";
 export const TEST_TYPE_SINGLE_CHOICE = "single_choice";
 export const TEST_TYPE_TEXT = "text";
+export const TEST_TYPE_MIXED = "mixed";
+
+export const ITEM_TYPE_LIKERT = "likert";
+export const ITEM_TYPE_SINGLE_CHOICE = "single_choice";
+export const ITEM_TYPE_TEXT = "text";
+export const ITEM_TYPE_SLIDER = "slider";
+export const ITEM_TYPE_MULTI_SELECT = "multi_select";
+export const ITEM_TYPE_RANKING = "ranking";
+export const ITEM_TYPE_MATRIX = "matrix";
 
 export const TEST_CONFIG_EXAMPLES = {
   [TEST_TYPE_LIKERT]: {
@@ -42,14 +51,69 @@ export const TEST_CONFIG_EXAMPLES = {
       },
     ],
   },
+  [TEST_TYPE_MIXED]: {
+    scale: { min: 1, max: 7, labels: { "1": "Совсем не согласен", "7": "Полностью согласен" } },
+    items: [
+      {
+        id: "mental_demand",
+        type: ITEM_TYPE_SLIDER,
+        text: "Насколько умственно напряжённой была задача?",
+        scale: { min: 0, max: 100, labels: { "0": "Очень низко", "100": "Очень высоко" } },
+        step: 5,
+      },
+      {
+        id: "trust",
+        type: ITEM_TYPE_MATRIX,
+        text: "Оцените утверждения о системе",
+        rows: [
+          { id: "reliable", text: "Система надёжна" },
+          { id: "suspicious", text: "Я отношусь к системе с подозрением", reverse: true },
+        ],
+      },
+      {
+        id: "used_hints",
+        type: ITEM_TYPE_MULTI_SELECT,
+        text: "Какими подсказками вы пользовались?",
+        options: ["Совет ИИ", "Прогноз", "Пауза"],
+        optional: true,
+      },
+      {
+        id: "hint_usefulness",
+        type: ITEM_TYPE_LIKERT,
+        text: "Насколько полезны были советы ИИ?",
+        show_if: "contains(used_hints, 'Совет ИИ')",
+      },
+      {
+        id: "priorities",
+        type: ITEM_TYPE_RANKING,
+        text: "Упорядочьте цели по важности",
+        options: ["Не допустить аварии", "Набрать очки", "Не тратить подсказки"],
+      },
+    ],
+    subscales: [{ id: "trust", title: "Доверие", items: ["trust"] }],
+  },
 };
 
 export const TEST_TYPE_OPTIONS = [
   { value: TEST_TYPE_LIKERT, label: "Шкала Лайкерта" },
   { value: TEST_TYPE_SINGLE_CHOICE, label: "Один вариант ответа" },
   { value: TEST_TYPE_TEXT, label: "Текстовые ответы" },
+  { value: TEST_TYPE_MIXED, label: "Смешанный опросник" },
 ];
 
+export const RETAKE_POLICY_OPTIONS = [
+  { value: "never", label: "Однократно" },
+  { value: "per_context", label: "Один раз в каждом контексте" },
+  { value: "always", label: "Без ограничений" },
+];
+
+export const TEST_CONTEXT_LABELS = {
+  onboarding: "Вступительное тестирование",
+  after_training: "После обучения",
+  after_game: "После игры",
+  study_phase: "Этап исследования",
+};
+
 export function parseTestConfig(config) {
   if (typeof config === "string") {
     return JSON.parse(config);
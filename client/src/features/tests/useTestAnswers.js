@@ -0,0 +1,41 @@
+import { useEffect, useRef, useState } from "react";
+
+const PROGRESS_DELAY_MS = 1000;
+
+// useTestAnswers keeps the answers of a test form together with the time
+// spent on each question. The time between two changes is credited to the
+// question that changed last, so a question the player returns to collects
+// the time of every visit. A saved draft restores both the answers and the
+// times, and onProgress receives them a moment after each change.
+export default function useTestAnswers(initialAnswers, draft, onProgress) {
+  const [answers, setAnswers] = useState(() => ({ ...initialAnswers, ...(draft?.answers ?? {}) }));
+  const [itemTimes, setItemTimes] = useState(() => ({ ...(draft?.item_times ?? {}) }));
+  const lastChangeRef = useRef(Date.now());
+  const changedRef = useRef(false);
+  const onProgressRef = useRef(onProgress);
+  onProgressRef.current = onProgress;
+
+  const setAnswer = (itemId, value) => {
+    const now = Date.now();
+    const elapsed = now - lastChangeRef.current;
+    lastChangeRef.current = now;
+    changedRef.current = true;
+    setItemTimes((prev) => ({ ...prev, [itemId]: (prev[itemId] ?? 0) + elapsed }));
+    setAnswers((prev) => ({ ...prev, [itemId]: value }));
+  };
+
+  useEffect(() => {
+    if (!changedRef.current || !onProgressRef.current) {
+      return undefined;
+    }
+    const timer = setTimeout(() => onProgressRef.current(answers, itemTimes), PROGRESS_DELAY_MS);
+    return () => clearTimeout(timer);
+  }, [answers, itemTimes]);
+
+  // timesOf keeps only the times of the given questions, the times of the
+  // questions hidden by a later answer are not submitted.
+  const timesOf = (itemIds) =>
+    Object.fromEntries(itemIds.filter((id) => itemTimes[id] != null).map((id) => [id, itemTimes[id]]));
+
+  return { answers, itemTimes, setAnswer, timesOf };
+}
@@ -1,11 +1,12 @@
 import { $authHost } from "./index";
 
-export const createGraph = async (points, user_id, par_set_id, totalScore, isTraining) => {
+export const createGraph = async (points, user_id, par_set_id, totalScore, isTraining, scenarioId = null) => {
   try {
     const { data } = await $authHost.post("api/chart/", {
       user_id: user_id,
       par_set_id: par_set_id,
       is_training: isTraining,
+      scenario_id: scenarioId,
     });
     if (!isTraining) {
       await $authHost.post("api/user/score", {
@@ -27,7 +28,13 @@ export const createGraph = async (points, user_id, par_set_id, totalScore, isTra
         is_stop: point.is_stop,
         is_pause: point.is_pause,
         is_check: point.is_check,
-        check_info: point.check_info,
+        hint_type: point.hint_type,
+        risk_level: point.risk_level,
+        crash_probability: point.crash_probability,
+        ai_confidence: point.ai_confidence,
+        reaction_time_ms: point.reaction_time_ms,
+        signal_shown_at: point.signal_shown_at,
+        responded_at: point.responded_at,
       });
     }
     return data;
@@ -36,6 +43,115 @@ export const createGraph = async (points, user_id, par_set_id, totalScore, isTra
   }
 };
 
+export const openGameSession = async (user_id, par_set_id, isTraining) => {
+  try {
+    const { data } = await $authHost.post("api/chart/session", {
+      user_id: user_id,
+      par_set_id: par_set_id,
+      is_training: isTraining,
+    });
+    return data.id;
+  } catch (e) {
+    throw e;
+  }
+};
+
+export const streamGamePoints = async (graphId, points) => {
+  try {
+    const { data } = await $authHost.post(`api/chart/session/${graphId}/points`, {
+      points: points.map((point) => ({
+        x: parseFloat(point.x),
+        y: parseFloat(point.y),
+        score: point.score,
+        is_crash: point.is_crash,
+        is_useful_ai_signal: point.is_useful_ai_signal,
+        is_deceptive_ai_signal: point.is_deceptive_ai_signal,
+        is_stop: point.is_stop,
+        is_pause: point.is_pause,
+        is_check: point.is_check,
+        hint_type: point.hint_type,
+        risk_level: point.risk_level,
+        crash_probability: point.crash_probability,
+        ai_confidence: point.ai_confidence,
+        reaction_time_ms: point.reaction_time_ms,
+        signal_shown_at: point.signal_shown_at,
+        responded_at: point.responded_at,
+      })),
+    });
+    return data.ids;
+  } catch (e) {
+    throw e;
+  }
+};
+
+export const closeGameSession = async (graphId, endReason) => {
+  try {
+    const { data } = await $authHost.post(`api/chart/session/${graphId}/close`, {
+      end_reason: endReason,
+    });
+    return data;
+  } catch (e) {
+    throw e;
+  }
+};
+
+export const getCrashHint = async (graphId, x, y, type = "crash_probability") => {
+  try {
+    const { data } = await $authHost.post(`api/chart/${graphId}/hint`, {
+      type: type,
+      x: parseFloat(x),
+      y: parseFloat(y),
+    });
+    return data.data;
+  } catch (e) {
+    throw e;
+  }
+};
+
+export const getAdvice = async (graphId, x, y, nextY) => {
+  try {
+    const { data } = await $authHost.post(`api/chart/${graphId}/advice`, {
+      x: parseFloat(x),
+      y: parseFloat(y),
+      next_y: parseFloat(nextY),
+    });
+    return data.data;
+  } catch (e) {
+    throw e;
+  }
+};
+
+export const getGraphScenario = async (graphId) => {
+  try {
+    const { data } = await $authHost.get(`api/chart/${graphId}/scenario`);
+    return data.data;
+  } catch (e) {
+    throw e;
+  }
+};
+
+export const generateScenarios = async (parSetId, count, length = null, seed = null) => {
+  try {
+    const { data } = await $authHost.post(`api/chart/parSet/${parSetId}/scenarios`, {
+      count: count,
+      length: length,
+      seed: seed,
+    });
+    return data.ids;
+  } catch (e) {
+    throw e;
+  }
+};
+
+export const getParSetScenarios = async (parSetId) => {
+  try {
+    const { data } = await $authHost.get(`api/chart/parSet/${parSetId}/scenarios`);
+    return data.data;
+  } catch (e) {
+    throw e;
+  }
+};
+
 export const getGraphsPageCount = async (filterTag = null, filterValue = null) => {
   try {
     const pageCount = await $authHost.post("api/chart/pageCount", {
@@ -121,3 +237,78 @@ export const createParSet = async (parSet) => {
     throw e;
   }
 };
+
+export const getParSet = async (id) => {
+  try {
+    const { data } = await $authHost.get(`api/chart/parSet/${id}`);
+    return data.data;
+  } catch (e) {
+    throw e;
+  }
+};
+
+export const updateParSet = async (id, parSet) => {
+  try {
+    const { data } = await $authHost.put(`api/chart/parSet/${id}`, parSet);
+    return data;
+  } catch (e) {
+    throw e;
+  }
+};
+
+export const cloneParSet = async (id, overrides = {}) => {
+  try {
+    const { data } = await $authHost.post(`api/chart/parSet/${id}/clone`, overrides);
+    return data;
+  } catch (e) {
+    throw e;
+  }
+};
+
+export const getParSetRules = async (id) => {
+  try {
+    const { data } = await $authHost.get(`api/chart/parSet/${id}/rules`);
+    return data;
+  } catch (e) {
+    throw e;
+  }
+};
+
+export const previewParSet = async (parSet, options = {}) => {
+  try {
+    const { data } = await $authHost.post(`api/chart/parSet/preview`, {
+      par_set: parSet,
+      ...options,
+    });
+    return data;
+  } catch (e) {
+    throw e;
+  }
+};
+
+export const archiveParSet = async (id) => {
+  try {
+    const { data } = await $authHost.post(`api/chart/parSet/${id}/archive`);
+    return data;
+  } catch (e) {
+    throw e;
+  }
+};
+
+export const deleteParSet = async (id) => {
+  try {
+    const { data } = await $authHost.delete(`api/chart/parSet/${id}`);
+    return data;
+  } catch (e) {
+    throw e;
+  }
+};
+
+export const createParSetVersion = async (parentId, parSet) => {
+  try {
+    const { data } = await $authHost.post(`api/chart/parSet/${parentId}/version`, parSet);
+    return data;
+  } catch (e) {
+    throw e;
+  }
+};
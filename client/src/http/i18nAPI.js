@@ -0,0 +1,13 @@
+import { $host } from "./index";
+
+// Каталог сообщений сервера: подписи событий, уровней риска и ошибок
+export const fetchMessages = async (locale) => {
+  try {
+    const { data } = await $host.get("api/i18n/messages", {
+      headers: { "Accept-Language": locale },
+    });
+    return data.data;
+  } catch (e) {
+    throw e;
+  }
+};
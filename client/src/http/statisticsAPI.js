@@ -20,3 +20,15 @@ export const computeStatistics = async (userId, parSetId) => {
     throw e;
   }
 };
+
+export const getTestGameCorrelations = async (groupId, parSetId, context = "", phase = "") => {
+  const params = {};
+  if (context) {
+    params.context = context;
+  }
+  if (phase) {
+    params.phase = phase;
+  }
+  const { data } = await $authHost.get(`api/statistics/tests/group_id/${groupId}/par_set_id/${parSetId}`, { params });
+  return data;
+};
@@ -10,19 +10,63 @@ export const getTestSessionStatus = async () => {
   return data;
 };
 
-export const submitTestResult = async (testId, answers) => {
+export const submitTestResult = async (
+  testId,
+  answers,
+  context = "onboarding",
+  phase = "",
+  itemTimes = {}
+) => {
   const { data } = await $authHost.post("api/test/results", {
     test_id: testId,
     answers,
+    item_times: itemTimes,
+    context,
+    phase,
   });
   return data;
 };
 
+export const saveTestProgress = async (
+  testId,
+  answers,
+  itemTimes = {},
+  context = "onboarding",
+  phase = ""
+) => {
+  const { data } = await $authHost.put(`api/test/${testId}/progress`, {
+    answers,
+    item_times: itemTimes,
+    context,
+    phase,
+  });
+  return data;
+};
+
+export const fetchTestProgress = async (testId) => {
+  const { data } = await $authHost.get(`api/test/${testId}/progress`);
+  return data.data ?? [];
+};
+
+export const fetchTestVisibility = async (testId, answers) => {
+  const { data } = await $authHost.post(`api/test/${testId}/visibility`, { answers });
+  return data;
+};
+
 export const fetchAllTests = async () => {
   const { data } = await $authHost.get("api/test/");
   return data.data ?? [];
 };
 
+// Filters that are not set are left out of the query.
+export const fetchTestReport = async (testId, filters = {}) => {
+  const params = Object.fromEntries(
+    Object.entries(filters).filter(([, value]) => value !== "" && value != null)
+  );
+  const { data } = await $authHost.get(`api/test/${testId}/report`, { params });
+  return data;
+};
+
 export const fetchPlayerTestResults = async (userId) => {
   const { data } = await $authHost.get(`api/test/results/user/${userId}`);
   return data.data ?? [];
@@ -33,6 +77,16 @@ export const createTest = async (test) => {
   return data;
 };
 
+export const fetchInstruments = async () => {
+  const { data } = await $authHost.get("api/test/instruments");
+  return data.data ?? [];
+};
+
+export const createTestFromInstrument = async (instrumentId, options) => {
+  const { data } = await $authHost.post(`api/test/instruments/${instrumentId}`, options);
+  return data;
+};
+
 export const updateTest = async (id, test) => {
   const { data } = await $authHost.put(`api/test/${id}`, test);
   return data;
@@ -91,6 +91,18 @@ export const getScore = async (userId, parSetId) => {
   }
 };
 
+export const updateScore = async (userId, parSetId, score) => {
+  try {
+    await $authHost.post("api/user/score", {
+      userId: userId,
+      parSetId: parSetId,
+      score: score,
+    });
+  } catch (e) {
+    throw new Error("Error on updateScore\n" + e);
+  }
+};
+
 export const getUserParSet = async (userId, parSetId) => {
   try {
     const { data } = await $authHost.get(`api/user/userParSet/${userId}/${parSetId}`);
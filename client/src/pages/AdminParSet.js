@@ -22,7 +22,7 @@ import NavBarDrawer from "../components/NavBarDrawer";
 import ImageButton from "../components/ImageButton/ImageButton";
 import DeleteIcon from "../components/icons/DeleteIcon";
 import { useSnackbar } from "notistack";
-import { createParSet, getParSets, getParSetsPageCount } from "../http/graphAPI";
+import { createParSet, deleteParSet, getParSets, getParSetsPageCount } from "../http/graphAPI";
 import { ChartData } from "../utils/ChartData";
 import {
   DEFAULT_FALSE_ALARM_THRESHOLD,
@@ -78,11 +78,23 @@ function formatScoringConfig(scoringConfig) {
   }
 }
 
-function formatRulesText(rulesText) {
-  if (!rulesText?.trim()) {
+function formatRules(rules) {
+  const locales = Object.keys(rules || {});
+  if (locales.length === 0) {
     return "Стандартные правила";
   }
-  return rulesText;
+  return locales.map((locale) => `[${locale}]\n${rules[locale]}`).join("\n\n");
+}
+
+function buildRules(rulesRu, rulesEn) {
+  const rules = {};
+  if (rulesRu.trim()) {
+    rules.ru = rulesRu;
+  }
+  if (rulesEn.trim()) {
+    rules.en = rulesEn;
+  }
+  return rules;
 }
 
 const scrollableCellSx = {
@@ -103,6 +115,8 @@ const AdminParset = () => {
   const [page, setPage] = React.useState(1);
   const [pageCount, setPageCount] = React.useState(1);
 
+  const [name, setName] = React.useState("");
+  const [description, setDescription] = React.useState("");
   const [a, setA] = React.useState(-1);
   const [b, setB] = React.useState(-1);
   const [noiseMean, setNoiseMean] = React.useState(-1);
@@ -112,7 +126,8 @@ const AdminParset = () => {
   const [hintCost, setHintCost] = React.useState(DEFAULT_HINT_COST);
   const [falseAlarmThreshold, setFalseAlarmThreshold] = React.useState(DEFAULT_FALSE_ALARM_THRESHOLD);
   const [scoringConfig, setScoringConfig] = React.useState(JSON.stringify(DEFAULT_SCORING_CONFIG, null, 2));
-  const [rulesText, setRulesText] = React.useState("");
+  const [rulesRu, setRulesRu] = React.useState("");
+  const [rulesEn, setRulesEn] = React.useState("");
 
   const [parSetShowTrigger, setParSetShowTrigger] = useState(false);
   const chartRef = useRef < ChartJS > null;
@@ -209,6 +224,8 @@ const AdminParset = () => {
     }
 
     createParSet({
+      name: name,
+      description: description,
       a: parseLocalizedNumber(a),
       b: parseLocalizedNumber(b),
       noise_mean: parseLocalizedNumber(noiseMean),
@@ -218,7 +235,7 @@ const AdminParset = () => {
       scoring_config: parsedScoringConfig,
       hint_cost: parsedHintCost,
       false_alarm_threshold: parsedFalseAlarmThreshold,
-      rules_text: rulesText,
+      rules: buildRules(rulesRu, rulesEn),
     }).then(
       (_) => {
         enqueueSnackbar("Пользователь добавлен", {
@@ -226,6 +243,8 @@ const AdminParset = () => {
           autoHideDuration: 3000,
           preventDuplicate: true,
         });
+        setName("");
+        setDescription("");
         setA(-1);
         setB(-1);
         setNoiseMean(-1);
@@ -235,7 +254,8 @@ const AdminParset = () => {
         setHintCost(DEFAULT_HINT_COST);
         setFalseAlarmThreshold(DEFAULT_FALSE_ALARM_THRESHOLD);
         setScoringConfig(JSON.stringify(DEFAULT_SCORING_CONFIG, null, 2));
-        setRulesText("");
+        setRulesRu("");
+        setRulesEn("");
         setUpdateTrigger(!updateTrigger);
       },
       (_) => {
@@ -248,6 +268,30 @@ const AdminParset = () => {
     );
   };
 
+  const deleteParSetUI = (id) => {
+    deleteParSet(id).then(
+      (_) => {
+        enqueueSnackbar("Набор параметров удален", {
+          variant: "success",
+          autoHideDuration: 3000,
+          preventDuplicate: true,
+        });
+        setUpdateTrigger(!updateTrigger);
+      },
+      (e) => {
+        const references = e.response?.data?.references;
+        const text = references
+          ? `Набор параметров используется: графиков ${references.chart_count}, группы [${references.group_ids.join(", ")}], пользователи [${references.user_ids.join(", ")}]`
+          : "Ошибка при удалении набора параметров";
+        enqueueSnackbar(text, {
+          variant: "error",
+          autoHideDuration: 5000,
+          preventDuplicate: true,
+        });
+      }
+    );
+  };
+
   const getParSetsUI = async () => {
     const filteredDataFromQuery = await getParSets();
     const newPageCount = await getParSetsPageCount();
@@ -303,6 +347,20 @@ const AdminParset = () => {
               <Typography variant="h4" noWrap component="div">
                 Добавление набора параметров
               </Typography>
+              <TextField
+                onChange={(event) => setName(event.target.value)}
+                value={name}
+                id="name-field"
+                label="Название набора"
+                variant="outlined"
+              />
+              <TextField
+                onChange={(event) => setDescription(event.target.value)}
+                value={description}
+                id="description-field"
+                label="Описание набора"
+                variant="outlined"
+              />
               <TextField
                 onChange={(event) => {
                   setA(event.target.value);
@@ -392,14 +450,24 @@ const AdminParset = () => {
                 helperText="В числах допустимы и точка, и запятая как разделитель дробной части"
               />
               <TextField
-                onChange={(event) => setRulesText(event.target.value)}
-                value={rulesText}
-                id="rules-text-field"
-                label="Текст правил игры"
+                onChange={(event) => setRulesRu(event.target.value)}
+                value={rulesRu}
+                id="rules-ru-field"
+                label="Правила игры (русский, markdown)"
                 multiline
                 minRows={8}
                 variant="outlined"
-                helperText="Пустое поле — стандартные правила. Сложная таблица: HTML <table> с colspan и rowspan"
+                helperText="Пустые поля — стандартные правила. Значения подставляются из настроек: {{bonus_step}}, {{hint_cost}}, {{false_alarm_threshold}} и другие поля бонусов и штрафов"
+              />
+              <TextField
+                onChange={(event) => setRulesEn(event.target.value)}
+                value={rulesEn}
+                id="rules-en-field"
+                label="Правила игры (английский, markdown)"
+                multiline
+                minRows={8}
+                variant="outlined"
+                helperText="Сложная таблица: HTML <table> с colspan и rowspan"
               />
             </Stack>
 
@@ -437,6 +505,9 @@ const AdminParset = () => {
                 <TableRow>
                   <TableCell />
                   <TableCell>ID</TableCell>
+                  <TableCell>Название</TableCell>
+                  <TableCell>Версия</TableCell>
+                  <TableCell>Использование</TableCell>
                   <TableCell>Коэффициент a</TableCell>
                   <TableCell>Коэффициент b</TableCell>
                   <TableCell>Математическое ожидание помехи</TableCell>
@@ -456,7 +527,7 @@ const AdminParset = () => {
                     <TableRow key={parSet.id} sx={{ "&:last-child td, &:last-child th": { border: 0 } }}>
                       <TableCell sx={{ width: 75 }}>
                         <Stack direction="row" spacing={1}>
-                          <ImageButton onClick={() => {}}>
+                          <ImageButton onClick={() => deleteParSetUI(parSet.id)}>
                             <DeleteIcon />
                           </ImageButton>
                         </Stack>
@@ -464,6 +535,17 @@ const AdminParset = () => {
                       <TableCell component="th" scope="row">
                         {parSet.id}
                       </TableCell>
+                      <TableCell>
+                        {parSet.name}
+                        {parSet.description ? <Typography variant="body2">{parSet.description}</Typography> : null}
+                      </TableCell>
+                      <TableCell>
+                        {parSet.version}
+                        {parSet.lineage && parSet.lineage.length > 0 ? " (" + parSet.lineage.join(" → ") + " → " + parSet.id + ")" : ""}
+                      </TableCell>
+                      <TableCell>
+                        Графики: {parSet.chart_count}, группы: {parSet.group_count}, пользователи: {parSet.user_count}
+                      </TableCell>
                       <TableCell>{parSet.a}</TableCell>
                       <TableCell>{parSet.b}</TableCell>
                       <TableCell>{parSet.noise_mean}</TableCell>
@@ -478,7 +560,7 @@ const AdminParset = () => {
                         </Box>
                       </TableCell>
                       <TableCell sx={scrollableCellSx}>
-                        <Box sx={{ ...scrollableBoxSx, fontSize: 13 }}>{formatRulesText(parSet.rules_text)}</Box>
+                        <Box sx={{ ...scrollableBoxSx, fontSize: 13 }}>{formatRules(parSet.rules)}</Box>
                       </TableCell>
                       <TableCell>{dateFormat(parSet.created_at, "yyyy-mm-dd HH:MM:ss")}</TableCell>
                     </TableRow>
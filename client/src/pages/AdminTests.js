@@ -20,15 +20,37 @@ import {
 } from "@mui/material";
 import NavBarDrawer from "../components/NavBarDrawer";
 import { useSnackbar } from "notistack";
-import { createTest, deleteTest, fetchAllTests, updateTest } from "../http/testAPI";
-import { TEST_CONFIG_EXAMPLES, TEST_TYPE_OPTIONS } from "../features/tests/testTypes";
+import {
+  createTest,
+  createTestFromInstrument,
+  deleteTest,
+  fetchAllTests,
+  fetchInstruments,
+  updateTest,
+} from "../http/testAPI";
+import TestReport from "../features/tests/components/TestReport";
+import { RETAKE_POLICY_OPTIONS, TEST_CONFIG_EXAMPLES, TEST_TYPE_OPTIONS } from "../features/tests/testTypes";
+
+const INSTRUMENT_LOCALE_LABELS = {
+  en: "Английский",
+  ru: "Русский",
+};
+
+const emptyInstrumentForm = {
+  instrument: "",
+  locale: "ru",
+  slug: "",
+};
 
 const emptyForm = {
-  slug: TEST_TYPE_OPTIONS[0].value,
+  slug: "",
+  type: TEST_TYPE_OPTIONS[0].value,
   title: "",
   description: "",
   config: JSON.stringify(TEST_CONFIG_EXAMPLES.likert, null, 2),
   is_active: true,
+  retake_policy: RETAKE_POLICY_OPTIONS[0].value,
+  min_duration_seconds: "",
   sort_order: 0,
 };
 
@@ -37,6 +59,9 @@ export default function AdminTests() {
   const [tests, setTests] = useState([]);
   const [form, setForm] = useState(emptyForm);
   const [editingId, setEditingId] = useState(null);
+  const [reportTest, setReportTest] = useState(null);
+  const [instruments, setInstruments] = useState([]);
+  const [instrumentForm, setInstrumentForm] = useState(emptyInstrumentForm);
 
   const loadTests = () => {
     fetchAllTests()
@@ -48,18 +73,40 @@ export default function AdminTests() {
 
   useEffect(() => {
     loadTests();
+    fetchInstruments()
+      .then((data) => setInstruments(Array.isArray(data) ? data : []))
+      .catch(() => {
+        enqueueSnackbar("Не удалось загрузить библиотеку методик", { variant: "error" });
+      });
   }, []);
 
+  const selectedInstrument = instruments.find((instrument) => instrument.id === instrumentForm.instrument);
+
+  const handleAddInstrument = async () => {
+    try {
+      await createTestFromInstrument(instrumentForm.instrument, {
+        locale: instrumentForm.locale,
+        slug: instrumentForm.slug,
+        is_active: false,
+      });
+      enqueueSnackbar("Методика добавлена как неактивный тест", { variant: "success" });
+      setInstrumentForm(emptyInstrumentForm);
+      loadTests();
+    } catch (e) {
+      enqueueSnackbar(e.response?.data?.error || "Ошибка при добавлении методики", { variant: "error" });
+    }
+  };
+
   const resetForm = () => {
     setForm(emptyForm);
     setEditingId(null);
   };
 
-  const handleSlugChange = (slug) => {
+  const handleTypeChange = (type) => {
     setForm((prev) => ({
       ...prev,
-      slug,
-      config: JSON.stringify(TEST_CONFIG_EXAMPLES[slug] || {}, null, 2),
+      type,
+      config: JSON.stringify(TEST_CONFIG_EXAMPLES[type] || {}, null, 2),
     }));
   };
 
@@ -73,10 +120,13 @@ export default function AdminTests() {
 
     const payload = {
       slug: form.slug,
+      type: form.type,
       title: form.title,
       description: form.description,
       config: JSON.parse(form.config),
       is_active: form.is_active,
+      retake_policy: form.retake_policy,
+      min_duration_seconds: Number(form.min_duration_seconds || 0),
       sort_order: Number(form.sort_order),
     };
 
@@ -99,10 +149,13 @@ export default function AdminTests() {
     setEditingId(test.id);
     setForm({
       slug: test.slug,
+      type: test.type,
       title: test.title,
       description: test.description,
       config: JSON.stringify(test.config, null, 2),
       is_active: test.is_active,
+      retake_policy: test.retake_policy,
+      min_duration_seconds: test.min_duration_seconds ?? "",
       sort_order: test.sort_order,
     });
   };
@@ -143,11 +196,55 @@ export default function AdminTests() {
           </Typography>
 
           <Stack spacing={2}>
+            <Typography variant="h6">Стандартные методики</Typography>
+            <TextField
+              select
+              label="Методика"
+              value={instrumentForm.instrument}
+              onChange={(event) => setInstrumentForm((prev) => ({ ...prev, instrument: event.target.value }))}
+              helperText={selectedInstrument ? `${selectedInstrument.item_count} вопросов. ${selectedInstrument.reference}` : undefined}
+            >
+              {instruments.map((instrument) => (
+                <MenuItem key={instrument.id} value={instrument.id}>
+                  {instrument.title}
+                </MenuItem>
+              ))}
+            </TextField>
             <TextField
               select
-              label="Тип теста (slug)"
+              label="Язык"
+              value={instrumentForm.locale}
+              onChange={(event) => setInstrumentForm((prev) => ({ ...prev, locale: event.target.value }))}
+            >
+              {(selectedInstrument?.locales ?? Object.keys(INSTRUMENT_LOCALE_LABELS)).map((locale) => (
+                <MenuItem key={locale} value={locale}>
+                  {INSTRUMENT_LOCALE_LABELS[locale] ?? locale}
+                </MenuItem>
+              ))}
+            </TextField>
+            <TextField
+              label="Идентификатор (slug)"
+              value={instrumentForm.slug}
+              onChange={(event) => setInstrumentForm((prev) => ({ ...prev, slug: event.target.value }))}
+              helperText="По умолчанию — идентификатор методики и язык, например nasa_tlx_ru"
+            />
+            <Button variant="outlined" disabled={!instrumentForm.instrument} onClick={handleAddInstrument}>
+              Добавить методику
+            </Button>
+          </Stack>
+
+          <Stack spacing={2}>
+            <TextField
+              label="Идентификатор (slug)"
               value={form.slug}
-              onChange={(event) => handleSlugChange(event.target.value)}
+              onChange={(event) => setForm((prev) => ({ ...prev, slug: event.target.value }))}
+              helperText="Уникальное имя теста, например asrs или nasa_tlx"
+            />
+            <TextField
+              select
+              label="Тип теста"
+              value={form.type}
+              onChange={(event) => handleTypeChange(event.target.value)}
             >
               {TEST_TYPE_OPTIONS.map((option) => (
                 <MenuItem key={option.value} value={option.value}>
@@ -165,6 +262,26 @@ export default function AdminTests() {
               value={form.description}
               onChange={(event) => setForm((prev) => ({ ...prev, description: event.target.value }))}
             />
+            <TextField
+              select
+              label="Повторное прохождение"
+              value={form.retake_policy}
+              onChange={(event) => setForm((prev) => ({ ...prev, retake_policy: event.target.value }))}
+            >
+              {RETAKE_POLICY_OPTIONS.map((option) => (
+                <MenuItem key={option.value} value={option.value}>
+                  {option.label}
+                </MenuItem>
+              ))}
+            </TextField>
+            <TextField
+              label="Минимальное время прохождения, с"
+              type="number"
+              value={form.min_duration_seconds}
+              onChange={(event) => setForm((prev) => ({ ...prev, min_duration_seconds: event.target.value }))}
+              helperText="Более быстрые прохождения отмечаются как слишком быстрые, пусто — без порога"
+              inputProps={{ min: 0 }}
+            />
             <TextField
               label="Порядок"
               type="number"
@@ -184,6 +301,9 @@ export default function AdminTests() {
               label="Конфигурация (JSON)"
               value={form.config}
               onChange={(event) => setForm((prev) => ({ ...prev, config: event.target.value }))}
+              helperText={
+                editingId ? "Если на текущую версию уже есть ответы, изменения сохранятся как новая версия" : undefined
+              }
               multiline
               minRows={10}
             />
@@ -204,7 +324,9 @@ export default function AdminTests() {
               <TableHead>
                 <TableRow>
                   <TableCell>ID</TableCell>
+                  <TableCell>Идентификатор</TableCell>
                   <TableCell>Тип</TableCell>
+                  <TableCell>Версия</TableCell>
                   <TableCell>Название</TableCell>
                   <TableCell>Активен</TableCell>
                   <TableCell>Порядок</TableCell>
@@ -216,6 +338,8 @@ export default function AdminTests() {
                   <TableRow key={test.id}>
                     <TableCell>{test.id}</TableCell>
                     <TableCell>{test.slug}</TableCell>
+                    <TableCell>{test.type}</TableCell>
+                    <TableCell>{test.version}</TableCell>
                     <TableCell>{test.title}</TableCell>
                     <TableCell>{test.is_active ? "Да" : "Нет"}</TableCell>
                     <TableCell>{test.sort_order}</TableCell>
@@ -224,6 +348,9 @@ export default function AdminTests() {
                         <Button size="small" onClick={() => handleEdit(test)}>
                           Изменить
                         </Button>
+                        <Button size="small" onClick={() => setReportTest(test)}>
+                          Отчёт
+                        </Button>
                         <Button size="small" onClick={() => handleToggleActive(test)}>
                           {test.is_active ? "Выключить" : "Включить"}
                         </Button>
@@ -237,6 +364,9 @@ export default function AdminTests() {
               </TableBody>
             </Table>
           </TableContainer>
+          {reportTest ? (
+            <TestReport key={reportTest.id} test={reportTest} onClose={() => setReportTest(null)} />
+          ) : null}
         </Stack>
       </Box>
     </Box>
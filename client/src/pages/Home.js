@@ -21,7 +21,7 @@ import NavBarDrawer from "../components/NavBarDrawer";
 import { Context } from "../index";
 import { observer } from "mobx-react-lite";
 import { useSnackbar } from "notistack";
-import { fetchGraphs, getGraphsCount, getGraphsPageCount } from "../http/graphAPI";
+import { fetchGraphs, getCrashHint, getGraphsCount, getGraphsPageCount } from "../http/graphAPI";
 import HintModal from "../features/game/components/modals/HintModal/HintModal";
 import RulesModal from "../features/game/components/modals/RulesModal";
 import TrainingEndModal from "../features/game/components/modals/TrainingEndModal";
@@ -29,7 +29,8 @@ import DangerOverlay from "../features/game/components/DangerOverlay";
 import GameControls from "../features/game/components/GameControls";
 import ModeBanner from "../features/game/components/ModeBanner";
 import ScorePanel from "../features/game/components/ScorePanel";
-import { chartOptions } from "../features/game/constants";
+import { chartOptions, endReasons } from "../features/game/constants";
+import { useChartSession } from "../features/game/hooks/useChartSession";
 import { useGameLoop } from "../features/game/hooks/useGameLoop";
 import { useGameSession } from "../features/game/hooks/useGameSession";
 import { useUserParSet } from "../features/game/hooks/useUserParSet";
@@ -50,6 +51,13 @@ ChartJS.register(
   Filler
 );
 
+// Экраны модального окна подсказок для каждого типа подсказки
+const hintScreens = {
+  crash_probability: "CrashProbability",
+  current_session: "CurrentSession",
+  all_sessions: "AllSessions",
+};
+
 const Home = observer(() => {
   const chartRef = useRef < ChartJS > null;
   const fullChartRef = useRef < ChartJS > null;
@@ -106,13 +114,19 @@ const Home = observer(() => {
   const [curPageHintChartsNum, setCurPageHintChartsNum] = React.useState(1);
   const [curLocalHintChartNum, setCurLocalHintChartNum] = React.useState(1);
   const [hintModalDataFetched, setHintModalDataFetched] = React.useState(false);
-  const [crashProb, setCrashProb] = React.useState("");
+  const [crashHint, setCrashHint] = React.useState(null);
   const [endGameCause, setEndGameCause] = React.useState("");
 
   const [isTrainingWarnModalOpened, setIsTrainingWarnModalOpened] = React.useState(false);
   const handleOpenTrainingWarnModal = () => setIsTrainingWarnModalOpened(true);
   const handleCloseTrainingWarnModal = () => setIsTrainingWarnModalOpened(false);
 
+  const chartSession = useChartSession({
+    chartData: chart.chartData,
+    userId: user.user.user_id,
+    enqueueSnackbar,
+  });
+
   useGameLoop({
     chartData: chart.chartData,
     isChartPaused,
@@ -121,7 +135,7 @@ const Home = observer(() => {
     isDanger,
     isTimeUp,
     userParSet,
-    userId: user.user.user_id,
+    chartSession,
     totalScore,
     changeScore,
     changeTotalScore,
@@ -153,11 +167,6 @@ const Home = observer(() => {
       fetchData().then(() => {
         setHintModalDataFetched(true);
       });
-    } else if (chosenHint === "CrashProbability") {
-      setHintModalDataFetched(false);
-      let crashProb = chart.chartData.getCrashProbApprox();
-      setCrashProb(crashProb);
-      setHintModalDataFetched(true);
     }
   }, [chosenHint]);
 
@@ -202,15 +211,36 @@ const Home = observer(() => {
     setCurLocalHintChartNum(curLocalHintChartNum + 1);
   };
 
-  const handleSelectCrashProbabilityHint = () => {
-    const hintCost = chart.chartData.hintCost;
-    setChosenHint("CrashProbability");
-    chart.chartData.chartHintUsed(hintCost, chart.chartData.getCrashProbApprox());
-    changeScore(-hintCost);
+  // Подсказку выдаёт сервер: он же назначает её стоимость и сохраняет,
+  // какой уровень риска увидел игрок
+  const handleSelectHint = async (option) => {
+    const point = chart.chartData.currentPoint();
+    const isCrashProbability = option.type === "crash_probability";
+    setChosenHint(hintScreens[option.type]);
+    if (isCrashProbability) {
+      setHintModalDataFetched(false);
+    }
+    try {
+      const chartId = await chartSession.chartId();
+      if (chartId == null) {
+        throw new Error("chart session is not open");
+      }
+      const hint = await getCrashHint(chartId, point.x, point.y, option.type);
+      chart.chartData.chartHintUsed(hint.cost, hint.type, hint.risk_level ?? null, hint.crash_probability ?? null);
+      changeScore(-hint.cost);
+      if (isCrashProbability) {
+        setCrashHint(hint);
+        setHintModalDataFetched(true);
+      }
+    } catch (e) {
+      enqueueSnackbar("Не удалось получить подсказку", { variant: "error" });
+      setChosenHint("");
+    }
   };
 
   const handleConfirmEndTraining = () => {
     setIsChartPaused(true);
+    chartSession.close(endReasons.exit);
     chart.chartData.restart();
     setIsDanger(false);
     handleCloseTrainingWarnModal();
@@ -259,12 +289,14 @@ const Home = observer(() => {
   };
 
   const handleDangerContinue = () => {
+    chart.chartData.markResponse();
     setIsChartPaused(false);
     setIsDanger(false);
     handleCloseHintModal();
   };
 
   const handleDangerStop = () => {
+    chart.chartData.markResponse();
     setIsChartStopped(true);
     setIsDanger(false);
   };
@@ -295,7 +327,7 @@ const Home = observer(() => {
         <RulesModal
           open={isRuleModalOpened}
           onClose={handleCloseRuleModal}
-          rulesText={chart.chartData.parSet?.rules_text}
+          parSetId={chart.chartData.parSet?.id}
         />
         <HintModal
           open={isHintModalOpened}
@@ -303,18 +335,17 @@ const Home = observer(() => {
           chosenHint={chosenHint}
           onBack={() => setChosenHint("")}
           chartData={chart.chartData}
-          hintCost={chart.chartData.hintCost}
           chartRef={fullChartRef}
           hintCharts={hintCharts}
           hintModalDataFetched={hintModalDataFetched}
           endGameCause={endGameCause}
-          crashProb={crashProb}
+          crashHint={crashHint}
           curHintChartNum={curHintChartNum}
           countHintCharts={countHintCharts}
           curLocalHintChartNum={curLocalHintChartNum}
           onPrevHintChart={moveToPrevHintChart}
           onNextHintChart={moveToNextHintChart}
-          onSelectCrashProbability={handleSelectCrashProbabilityHint}
+          onSelectHint={handleSelectHint}
         />
         <TrainingEndModal
           open={isTrainingWarnModalOpened}
@@ -12,7 +12,12 @@ import {
   USER_ROLE_RESEARCHER,
   USER_ROLE_USER,
 } from "../utils/constants";
-import { getTestSessionStatus, submitTestResult } from "../http/testAPI";
+import {
+  fetchTestProgress,
+  getTestSessionStatus,
+  saveTestProgress,
+  submitTestResult,
+} from "../http/testAPI";
 import TestRenderer from "../features/tests/components/TestRenderer";
 
 function getDefaultRouteForRole(role) {
@@ -31,8 +36,10 @@ const OnboardingTests = observer(() => {
   const { enqueueSnackbar } = useSnackbar();
   const [loading, setLoading] = useState(true);
   const [submitting, setSubmitting] = useState(false);
+  const [questionErrors, setQuestionErrors] = useState({});
   const [pendingTests, setPendingTests] = useState([]);
   const [currentIndex, setCurrentIndex] = useState(0);
+  const [progress, setProgress] = useState({ testId: null, draft: null });
 
   const loadSession = async () => {
     setLoading(true);
@@ -62,15 +69,50 @@ const OnboardingTests = observer(() => {
     loadSession();
   }, [user.isAuth, user.user.role, navigate]);
 
-  const handleSubmit = async (answers) => {
+  const currentTestId = pendingTests[currentIndex]?.id;
+
+  // A test the player has started earlier resumes from its saved answers,
+  // the form is shown once the draft of the current test has been loaded.
+  // A test opened for the first time gets an empty draft, the server times
+  // the attempt from its start.
+  useEffect(() => {
+    if (!currentTestId) {
+      return undefined;
+    }
+    let cancelled = false;
+    fetchTestProgress(currentTestId)
+      .then((drafts) => drafts.find((item) => item.context === "onboarding") ?? null)
+      .catch(() => null)
+      .then((draft) => {
+        if (draft == null) {
+          saveTestProgress(currentTestId, {}).catch(() => {});
+        }
+        if (!cancelled) {
+          setProgress({ testId: currentTestId, draft });
+        }
+      });
+    return () => {
+      cancelled = true;
+    };
+  }, [currentTestId]);
+
+  const handleProgress = (answers, itemTimes) => {
+    if (!currentTestId) {
+      return;
+    }
+    saveTestProgress(currentTestId, answers, itemTimes).catch(() => {});
+  };
+
+  const handleSubmit = async (answers, itemTimes) => {
     const currentTest = pendingTests[currentIndex];
     if (!currentTest) {
       return;
     }
 
     setSubmitting(true);
+    setQuestionErrors({});
     try {
-      await submitTestResult(currentTest.id, answers);
+      await submitTestResult(currentTest.id, answers, "onboarding", "", itemTimes);
       const nextIndex = currentIndex + 1;
       if (nextIndex >= pendingTests.length) {
         const status = await getTestSessionStatus();
@@ -83,6 +125,12 @@ const OnboardingTests = observer(() => {
       }
       setCurrentIndex(nextIndex);
     } catch (e) {
+      const questions = e.response?.data?.questions;
+      if (questions) {
+        setQuestionErrors(questions);
+        enqueueSnackbar("Проверьте отмеченные ответы", { variant: "warning" });
+        return;
+      }
       enqueueSnackbar("Не удалось сохранить результаты теста", { variant: "error" });
     } finally {
       setSubmitting(false);
@@ -93,7 +141,7 @@ const OnboardingTests = observer(() => {
     return null;
   }
 
-  if (loading) {
+  if (loading || (currentTestId && progress.testId !== currentTestId)) {
     return (
       <Box display="flex" justifyContent="center" alignItems="center" minHeight="100vh">
         <CircularProgress />
@@ -118,7 +166,15 @@ const OnboardingTests = observer(() => {
           </Typography>
           <Typography variant="h5">{currentTest.title}</Typography>
           {currentTest.description ? <Typography>{currentTest.description}</Typography> : null}
-          <TestRenderer test={currentTest} onSubmit={handleSubmit} submitting={submitting} />
+          <TestRenderer
+            key={currentTest.id}
+            test={currentTest}
+            draft={progress.draft}
+            onSubmit={handleSubmit}
+            onProgress={handleProgress}
+            submitting={submitting}
+            errors={questionErrors}
+          />
         </Stack>
       </Card>
     </Box>
@@ -25,6 +25,7 @@ import { getParSets } from "../http/graphAPI";
 import { changeGroupParSet, getAllGroups } from "../http/userAPI";
 import ChangeIconBlack from "../components/icons/ChangeIconBlack";
 import { ModalContent } from "../components/ModalContent";
+import TestGameCorrelations from "../components/TestGameCorrelations";
 
 const ResearcherGroup = () => {
   const [updateTrigger, setUpdateTrigger] = useState(false);
@@ -33,6 +34,7 @@ const ResearcherGroup = () => {
   const [fetchedParSets, setFetchedParSets] = useState([]);
   const [selectedParSetId, setSelectedParSetId] = useState(-1);
   const [chosenGroupId, setChosenGroupId] = useState(-1);
+  const [correlationsGroup, setCorrelationsGroup] = useState(null);
 
   const [isChangeGroupModalOpened, setIsChangeGroupModalOpened] = React.useState(false);
   const handleOpenChangeGroupModal = () => setIsChangeGroupModalOpened(true);
@@ -135,7 +137,7 @@ const ResearcherGroup = () => {
                 {isDataFetched ? (
                   fetchedGroups.map((group) => (
                     <TableRow key={group.id} sx={{ "&:last-child td, &:last-child th": { border: 0 } }}>
-                      <TableCell sx={{ width: 75 }}>
+                      <TableCell sx={{ width: 150 }}>
                         <Stack direction="row" spacing={1}>
                           <ImageButton
                             onClick={() => {
@@ -145,6 +147,9 @@ const ResearcherGroup = () => {
                           >
                             <ChangeIconBlack />
                           </ImageButton>
+                          <Button size="small" onClick={() => setCorrelationsGroup(group)}>
+                            Тесты
+                          </Button>
                         </Stack>
                       </TableCell>
                       <TableCell component="th" scope="row">
@@ -161,6 +166,13 @@ const ResearcherGroup = () => {
               </TableBody>
             </Table>
           </TableContainer>
+          {correlationsGroup ? (
+            <TestGameCorrelations
+              key={correlationsGroup.id}
+              group={correlationsGroup}
+              onClose={() => setCorrelationsGroup(null)}
+            />
+          ) : null}
         </Stack>
         <Modal
           sx={{
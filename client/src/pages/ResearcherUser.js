@@ -28,9 +28,11 @@ import ResUserCharts from "../components/ResUserCharts";
 import ResUserVengerTable from "../components/ResUserVengerTable";
 import ResUserVengerCharts from "../components/ResUserVengerCharts";
 import PlayerTestResults from "../components/PlayerTestResults";
+import { useMessages } from "../features/game/hooks/useMessages";
 
 const ResearcherUser = () => {
   const { user } = useContext(Context);
+  const { eventLabel, riskLevelLabel } = useMessages();
   const location = useLocation();
   const [selectedParSetId, setSelectedParSetId] = useState(location.state.player.cur_par_set_id);
   const [isDataFetched, setIsDataFetched] = useState(false);
@@ -212,8 +214,8 @@ const ResearcherUser = () => {
                         {event.x.toFixed(2)}
                       </TableCell>
                       <TableCell>
-                        {event.name.join(" | ") +
-                          (event.check_info ? ' | Текст подсказки: "' + event.check_info + '"' : "")}
+                        {event.name.map((code) => eventLabel(code)).join(" | ") +
+                          (event.risk_level ? ' | Текст подсказки: "' + riskLevelLabel(event.risk_level) + '"' : "")}
                       </TableCell>
                     </TableRow>
                   ))
@@ -1,6 +1,5 @@
 import { COLORS } from "./constants";
 import {
-  DEFAULT_FALSE_ALARM_THRESHOLD,
   DEFAULT_HINT_COST,
   DEFAULT_SCORING_CONFIG,
 } from "../features/game/constants/parSetDefaults";
@@ -35,7 +34,8 @@ export class ChartData {
   penaltyIncorrectStopNoAdvice = DEFAULT_SCORING_CONFIG.penalty_incorrect_stop_no_advice;
   penaltyExplosionNoAdvice = DEFAULT_SCORING_CONFIG.penalty_explosion_no_advice;
   penaltyPause = DEFAULT_SCORING_CONFIG.penalty_pause;
-  hintCost = DEFAULT_HINT_COST;
+  scenario = null;
+  hintOptions = [{ type: "crash_probability", cost: DEFAULT_HINT_COST, disclosure: "risk_band" }];
 
 
   constructor(
@@ -62,7 +62,6 @@ export class ChartData {
     this.wasRealAlert = false;
     this.wasFakeAlert = false;
     this.parSet = parSet;
-    this.falseAlarmThreshold = DEFAULT_FALSE_ALARM_THRESHOLD;
     this.restart();
   }
 
@@ -103,6 +102,10 @@ export class ChartData {
     if (this.curIndex === 0 || this.parSet === null) {
       return new Point(this.curIndex, 0, this.score);
     }
+    // Сценарий с сервера проигрывается без генерации шума
+    if (this.scenario !== null && this.curIndex < this.scenario.trajectory.length) {
+      return new Point(this.curIndex, this.scenario.trajectory[this.curIndex], this.score);
+    }
     let a = this.parSet.a;
     let b = this.parSet.b;
     let noise_mean = this.parSet.noise_mean;
@@ -123,43 +126,47 @@ export class ChartData {
     return this.points[index].y >= this.criticalValue;
   }
 
-  isDanger() {
-    const randomVal = Math.random();
-    if (!this.shouldSentAlert) return false;
+  // Значение следующей точки, по которому сервер решает, есть ли опасность
+  nextPoint() {
+    return this.points[this.points.length - this.checkDangerNum];
+  }
+
+  // Совет ИИ для текущей точки выдаёт сервер. Возвращает true, если игроку
+  // нужно показать предупреждение
+  applyAdvice(advice) {
+    if (!this.shouldSentAlert || !advice.signal) {
+      return false;
+    }
+    const point = this.currentPoint();
+    point.is_ai_signal = true;
+    point.ai_confidence = advice.confidence ?? null;
     if (this.isRealDanger()) {
       this.shouldSentAlert = false;
-      this.points[this.points.length - this.checkDangerNum - 1].is_ai_signal = true;
       this.wasRealAlert = true;
-      this.points[this.points.length - this.checkDangerNum - 1].is_useful_ai_signal = true;
-      return true;
-      // Пропуск цели
-      // if (randomVal >= this.missingDangerProb) {
-      //   this.points[this.points.length - this.checkDangerNum - 1].is_ai_signal = true;
-      //   this.wasRealAlert = true;
-      //   this.points[this.points.length - this.checkDangerNum - 1].is_useful_ai_signal = true;
-      //   return true;
-      // } else {
-      //   return false;
-      // }
+      point.is_useful_ai_signal = true;
+    } else {
+      this.wasFakeAlert = true;
+      point.is_deceptive_ai_signal = true;
     }
+    this._markSignalShown();
+    return true;
+  }
 
-    if (
-      this.points.length <= this.checkDangerNum ||
-      this.points[this.points.length - this.checkDangerNum - 1].y < this.falseAlarmThreshold * this.criticalValue
-    ) {
-      return;
-    }
+  _markSignalShown() {
+    this.signalPoint = this.points[this.points.length - this.checkDangerNum - 1];
+    this.signalPoint.signal_shown_at = new Date().toISOString();
+    this.signalShownAtMs = performance.now();
+  }
 
-    if (
-      randomVal < this.falseWarningProb
-    ) {
-      this.points[this.points.length - this.checkDangerNum - 1].is_ai_signal = true;
-      this.wasFakeAlert = true;
-      this.points[this.points.length - this.checkDangerNum - 1].is_deceptive_ai_signal = true;
-      return true;
-    } else {
-      return false;
+  // Записывает время реакции участника на последний сигнал ИИ
+  markResponse() {
+    if (this.signalPoint == null) {
+      return;
     }
+    this.signalPoint.responded_at = new Date().toISOString();
+    this.signalPoint.reaction_time_ms = Math.round(performance.now() - this.signalShownAtMs);
+    this.signalPoint = null;
+    this.signalShownAtMs = null;
   }
 
   isRealDanger() {
@@ -183,6 +190,8 @@ export class ChartData {
     this.wasManualStop = false;
     this.wasRealAlert = false;
     this.wasFakeAlert = false;
+    this.signalPoint = null;
+    this.signalShownAtMs = null;
     for (let i = 0; i < this.maxPointsToShow; i++) {
       this.points.push(new Point(0, null, this.score));
     }
@@ -214,10 +223,16 @@ export class ChartData {
     this._updateMidScores();
   }
 
-  chartHintUsed(cost, hintText) {
+  currentPoint() {
+    return this.points[this.points.length - this.checkDangerNum - 1];
+  }
+
+  chartHintUsed(cost, hintType, riskLevel = null, crashProbability = null) {
     this.score -= cost;
     this.points[this.points.length - this.checkDangerNum - 1].is_check = true;
-    this.points[this.points.length - this.checkDangerNum - 1].check_info = hintText;
+    this.points[this.points.length - this.checkDangerNum - 1].hint_type = hintType;
+    this.points[this.points.length - this.checkDangerNum - 1].risk_level = riskLevel;
+    this.points[this.points.length - this.checkDangerNum - 1].crash_probability = crashProbability;
     this._updateMidScores();
   }
 
@@ -304,15 +319,16 @@ export class ChartData {
     });
   }
 
+  setScenario(scenario) {
+    this.scenario = scenario;
+  }
+
   setParSet(parSet) {
     this.parSet = parSet;
     this.missingDangerProb = parSet.missing_danger_prob;
     this.falseWarningProb = parSet.false_warning_prob;
-    if (parSet.false_alarm_threshold != null) {
-      this.falseAlarmThreshold = parSet.false_alarm_threshold;
-    }
-    if (parSet.hint_cost != null) {
-      this.hintCost = parSet.hint_cost;
+    if (Array.isArray(parSet.hint_config)) {
+      this.hintOptions = parSet.hint_config;
     }
     this.applyScoringConfig(parSet.scoring_config);
     this.restart();
@@ -332,30 +348,6 @@ export class ChartData {
     return crashProb;
   }
 
-  LOW_RISK_LEVEL = "Низкий уровень риска"
-  MODERATE_RISK_LEVEL = "Средний уровень риска"
-  HIGH_RISK_LEVEL = "Высокий уровень риска"
-  getCrashProbApprox() {
-    if (this.parSet === null) {
-      return this.LOW_RISK_LEVEL;
-    }
-    let noise_stdev = this.parSet.noise_stdev;
-    let z = this.points[this.points.length - this.checkDangerNum - 1].y
-    if (this.isRealDanger()) {
-      z = 0.5 * z + 0.5;
-    } else {
-      z = 1.5 * z - 0.5;
-    }
-    let critDiff = this.criticalValue - z
-    if (critDiff >= 2.5 * noise_stdev) {
-      return this.LOW_RISK_LEVEL
-    } else if (critDiff >= 2 * noise_stdev) {
-      return this.MODERATE_RISK_LEVEL
-    } else {
-      return this.HIGH_RISK_LEVEL
-    }
-  }
-
   formData(dataPoints) {
     return {
       labels: dataPoints.map((point) => {
@@ -429,7 +421,13 @@ class Point {
     this.is_stop = is_stop;
     this.is_pause = is_pause;
     this.is_check = is_check;
-    this.check_info = null;
+    this.hint_type = null;
+    this.risk_level = null;
+    this.crash_probability = null;
+    this.ai_confidence = null;
+    this.reaction_time_ms = null;
+    this.signal_shown_at = null;
+    this.responded_at = null;
   }
 }
 
@@ -1,23 +1,56 @@
 package gameServer
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"reflect"
 	"time"
 )
 
+const (
+	ChartStatusInProgress = "in_progress"
+	ChartStatusFinished   = "finished"
+	ChartStatusAbandoned  = "abandoned"
+)
+
+const (
+	ChartEndReasonCrash   = "crash"
+	ChartEndReasonStop    = "stop"
+	ChartEndReasonTimeUp  = "time_up"
+	ChartEndReasonExit    = "exit"
+	ChartEndReasonTimeout = "timeout"
+	// ChartEndReasonUnknown marks the games recorded before the end reason
+	// was tracked and the uploads that did not send one.
+	ChartEndReasonUnknown = "unknown"
+)
+
+var ErrChartNotInProgress = errors.New("chart is not in progress")
+
 type Chart struct {
-	Id             int    `json:"id"`
-	ParameterSetId int    `json:"parameter_set_id" binding:"required" db:"parameter_set_id"`
-	UserId         int    `json:"user_id" binding:"required" db:"user_id"`
-	CreatedAt      string `json:"created_at" binding:"required" db:"created_at"`
-	IsTraining     bool   `json:"is_training" db:"is_training"`
+	Id             int      `json:"id"`
+	ParameterSetId int      `json:"parameter_set_id" binding:"required" db:"parameter_set_id"`
+	UserId         int      `json:"user_id" binding:"required" db:"user_id"`
+	CreatedAt      string   `json:"created_at" binding:"required" db:"created_at"`
+	IsTraining     bool     `json:"is_training" db:"is_training"`
+	Status         string   `json:"status" db:"status"`
+	EndReason      *string  `json:"end_reason" db:"end_reason"`
+	PointCount     int      `json:"point_count" db:"point_count"`
+	Duration       *float64 `json:"duration" db:"duration"`
+	ScenarioId     *int     `json:"scenario_id" db:"scenario_id"`
 }
 
 type CreateChartInput struct {
 	ParameterSetId int  `json:"par_set_id" binding:"required" db:"parameter_set_id"`
 	UserId         int  `json:"user_id" binding:"required" db:"user_id"`
 	IsTraining     bool `json:"is_training" db:"is_training"`
+	// EndReason and Duration (in seconds) are sent when a finished game is
+	// uploaded at once; game sessions get them when the chart is closed.
+	EndReason *string  `json:"end_reason" db:"end_reason"`
+	Duration  *float64 `json:"duration" db:"duration"`
+	// ScenarioId is the scenario the game was played on, if any.
+	ScenarioId *int `json:"scenario_id" db:"scenario_id"`
 }
 
 func (i *CreateChartInput) Validate() error {
@@ -27,9 +60,100 @@ func (i *CreateChartInput) Validate() error {
 	if i.UserId <= 0 {
 		return errors.New("user id is equal or less than zero")
 	}
+	if i.EndReason != nil && !IsClientEndReason(*i.EndReason) {
+		return errors.New("unknown end reason")
+	}
+	if i.Duration != nil && *i.Duration < 0 {
+		return errors.New("duration is less than zero")
+	}
+	if i.ScenarioId != nil && *i.ScenarioId <= 0 {
+		return errors.New("scenario id is equal or less than zero")
+	}
+	return nil
+}
+
+// IsClientEndReason reports whether the reason can be sent by the game client.
+// ChartEndReasonTimeout and ChartEndReasonUnknown are set by the server only.
+func IsClientEndReason(endReason string) bool {
+	switch endReason {
+	case ChartEndReasonCrash, ChartEndReasonStop, ChartEndReasonTimeUp, ChartEndReasonExit:
+		return true
+	default:
+		return false
+	}
+}
+
+type AppendChartPointsInput struct {
+	Points []Point `json:"points" binding:"required"`
+}
+
+func (i *AppendChartPointsInput) Validate() error {
+	if len(i.Points) == 0 {
+		return errors.New("points are empty")
+	}
+	return nil
+}
+
+type CloseChartInput struct {
+	EndReason string `json:"end_reason" binding:"required"`
+}
+
+func (i *CloseChartInput) Validate() error {
+	if !IsClientEndReason(i.EndReason) {
+		return errors.New("unknown end reason")
+	}
+	return nil
+}
+
+const (
+	RiskLevelLow    = "low"
+	RiskLevelMedium = "medium"
+	RiskLevelHigh   = "high"
+)
+
+func IsRiskLevel(riskLevel string) bool {
+	switch riskLevel {
+	case RiskLevelLow, RiskLevelMedium, RiskLevelHigh:
+		return true
+	default:
+		return false
+	}
+}
+
+type GetHintInput struct {
+	Type string  `json:"type"`
+	X    float32 `json:"x"`
+	Y    float32 `json:"y"`
+}
+
+func (i *GetHintInput) Validate() error {
+	if i.Type == "" {
+		i.Type = HintTypeCrashProbability
+	}
+	if !IsHintType(i.Type) {
+		return errors.New("unknown hint type")
+	}
+	if i.X < 0 {
+		return errors.New("x coordinate is less than zero")
+	}
 	return nil
 }
 
+// Hint is a hint exactly as it was shown to the participant: the crash
+// probability and the risk level are empty when they were not disclosed.
+type Hint struct {
+	Id               int      `json:"id" db:"id"`
+	ChartId          int      `json:"chart_id" db:"chart_id"`
+	Type             string   `json:"type" db:"hint_type"`
+	Disclosure       string   `json:"disclosure" db:"disclosure"`
+	X                float32  `json:"x" db:"x"`
+	Y                float32  `json:"y" db:"y"`
+	CrashProbability *float64 `json:"crash_probability" db:"crash_probability"`
+	RiskLevel        *string  `json:"risk_level" db:"risk_level"`
+	Cost             float32  `json:"cost" db:"cost"`
+	CreatedAt        string   `json:"created_at" db:"created_at"`
+}
+
 type GetChartsPageCountInput struct {
 	FilterTag   string `json:"filter_tag"`
 	FilterValue string `json:"filter_value"`
@@ -65,22 +189,57 @@ func (i *GetAllParSetsInput) Validate() error {
 }
 
 type CreateParSetInput struct {
+	Name                string          `json:"name" db:"name"`
+	Description         string          `json:"description" db:"description"`
+	ParentId            *int            `json:"-" db:"parent_id"`
 	A                   float32         `json:"a" db:"a"`
 	B                   float32         `json:"b" db:"b"`
-	NoiseMean             float32         `json:"noise_mean" db:"noise_mean"`
-	NoiseStdev            float32         `json:"noise_stdev" db:"noise_stdev"`
-	FalseWarningProb      float32         `json:"false_warning_prob" db:"false_warning_prob"`
-	MissingDangerProb     float32         `json:"missing_danger_prob" db:"missing_danger_prob"`
-	ScoringConfig         json.RawMessage `json:"scoring_config" db:"scoring_config"`
-	HintCost              float32         `json:"hint_cost" db:"hint_cost"`
-	FalseAlarmThreshold   float32         `json:"false_alarm_threshold" db:"false_alarm_threshold"`
-	RulesText             string          `json:"rules_text" db:"rules_text"`
+	NoiseMean           float32         `json:"noise_mean" db:"noise_mean"`
+	NoiseStdev          float32         `json:"noise_stdev" db:"noise_stdev"`
+	FalseWarningProb    float32         `json:"false_warning_prob" db:"false_warning_prob"`
+	MissingDangerProb   float32         `json:"missing_danger_prob" db:"missing_danger_prob"`
+	ScoringConfig       json.RawMessage `json:"scoring_config" db:"scoring_config"`
+	HintCost            float32         `json:"hint_cost" db:"hint_cost"`
+	HintConfig          json.RawMessage `json:"hint_config" db:"hint_config"`
+	AdvisorConfig       json.RawMessage `json:"advisor_config" db:"advisor_config"`
+	FalseAlarmThreshold float32         `json:"false_alarm_threshold" db:"false_alarm_threshold"`
+	Rules               json.RawMessage `json:"rules" db:"rules"`
 }
 
 func DefaultScoringConfigJSON() json.RawMessage {
 	return json.RawMessage(`{"bonus_step":50,"bonus_reject_incorrect_advice_with_check":1000,"bonus_reject_incorrect_advice_no_check":2000,"bonus_accept_correct_advice_with_check":250,"bonus_accept_correct_advice_no_check":500,"penalty_reject_correct_advice_with_check":4000,"penalty_reject_correct_advice_no_check":2000,"penalty_accept_incorrect_advice_with_check":2000,"penalty_accept_incorrect_advice_no_check":1000,"penalty_incorrect_stop_no_advice":2000,"penalty_explosion_no_advice":0,"penalty_pause":50}`)
 }
 
+// ScoringConfig holds the bonuses and penalties of the game, the client
+// applies them in ChartData.
+type ScoringConfig struct {
+	BonusStep                             float64 `json:"bonus_step"`
+	BonusRejectIncorrectAdviceWithCheck   float64 `json:"bonus_reject_incorrect_advice_with_check"`
+	BonusRejectIncorrectAdviceNoCheck     float64 `json:"bonus_reject_incorrect_advice_no_check"`
+	BonusAcceptCorrectAdviceWithCheck     float64 `json:"bonus_accept_correct_advice_with_check"`
+	BonusAcceptCorrectAdviceNoCheck       float64 `json:"bonus_accept_correct_advice_no_check"`
+	PenaltyRejectCorrectAdviceWithCheck   float64 `json:"penalty_reject_correct_advice_with_check"`
+	PenaltyRejectCorrectAdviceNoCheck     float64 `json:"penalty_reject_correct_advice_no_check"`
+	PenaltyAcceptIncorrectAdviceWithCheck float64 `json:"penalty_accept_incorrect_advice_with_check"`
+	PenaltyAcceptIncorrectAdviceNoCheck   float64 `json:"penalty_accept_incorrect_advice_no_check"`
+	PenaltyIncorrectStopNoAdvice          float64 `json:"penalty_incorrect_stop_no_advice"`
+	PenaltyExplosionNoAdvice              float64 `json:"penalty_explosion_no_advice"`
+	PenaltyPause                          float64 `json:"penalty_pause"`
+}
+
+// ParseScoringConfig reads the scoring config over the default one, so the
+// fields missing in config keep their default values.
+func ParseScoringConfig(config json.RawMessage) (ScoringConfig, error) {
+	var scoring ScoringConfig
+	if err := json.Unmarshal(DefaultScoringConfigJSON(), &scoring); err != nil {
+		return ScoringConfig{}, err
+	}
+	if err := json.Unmarshal(config, &scoring); err != nil {
+		return ScoringConfig{}, errors.New("scoring config must be a json object with numeric values")
+	}
+	return scoring, nil
+}
+
 func (i *CreateParSetInput) ApplyDefaults() {
 	if len(i.ScoringConfig) == 0 || !json.Valid(i.ScoringConfig) {
 		i.ScoringConfig = DefaultScoringConfigJSON()
@@ -88,16 +247,31 @@ func (i *CreateParSetInput) ApplyDefaults() {
 	if i.HintCost <= 0 {
 		i.HintCost = 250
 	}
+	if len(i.HintConfig) == 0 {
+		i.HintConfig = DefaultHintConfigJSON(i.HintCost)
+	}
+	if len(i.AdvisorConfig) == 0 {
+		i.AdvisorConfig = DefaultAdvisorConfigJSON()
+	}
 	if i.FalseAlarmThreshold <= 0 {
 		i.FalseAlarmThreshold = 0.9
 	}
+	if len(i.Rules) == 0 {
+		i.Rules = json.RawMessage(`{}`)
+	}
 }
 
 func (i *CreateParSetInput) Validate() error {
 	i.ApplyDefaults()
+	if len(i.Name) > 255 {
+		return errors.New("name is longer than 255 characters")
+	}
 	if i.A < 0 {
 		return errors.New("coefficient a is less than zero")
 	}
+	if i.A >= 1 {
+		return errors.New("coefficient a must be less than one, otherwise the process is not stationary")
+	}
 	if i.B < 0 {
 		return errors.New("coefficient b is less than zero")
 	}
@@ -107,24 +281,235 @@ func (i *CreateParSetInput) Validate() error {
 	if i.NoiseStdev < 0 {
 		return errors.New("noise standard deviation is less than zero")
 	}
+	if i.NoiseStdev == 0 {
+		return errors.New("noise standard deviation must be greater than zero")
+	}
 	if i.FalseWarningProb < 0 {
 		return errors.New("false warning probability is less than zero")
 	}
+	if i.FalseWarningProb > 1 {
+		return errors.New("false warning probability is greater than one")
+	}
 	if i.MissingDangerProb < 0 {
 		return errors.New("missing danger probability is less than zero")
 	}
+	if i.MissingDangerProb > 1 {
+		return errors.New("missing danger probability is greater than one")
+	}
 	if len(i.ScoringConfig) > 0 && !json.Valid(i.ScoringConfig) {
 		return errors.New("scoring config must be valid json")
 	}
-	if i.HintCost < 0 {
-		return errors.New("hint cost is less than zero")
+	scoring, err := ParseScoringConfig(i.ScoringConfig)
+	if err != nil {
+		return err
+	}
+	hints, err := ParseHintConfig(i.HintConfig)
+	if err != nil {
+		return err
+	}
+	// hint_cost only mirrors the crash probability hint of the config for
+	// the {{hint_cost}} rules placeholder and the older clients.
+	i.HintCost = hintCost(hints, HintTypeCrashProbability)
+	if _, err := ParseAdvisorConfig(i.AdvisorConfig); err != nil {
+		return err
 	}
 	if i.FalseAlarmThreshold <= 0 || i.FalseAlarmThreshold > 1 {
 		return errors.New("false alarm threshold must be between 0 and 1")
 	}
+	rules, err := ParseRules(i.Rules)
+	if err != nil {
+		return err
+	}
+	values := RulesValues(scoring, i.HintCost, hints, i.FalseAlarmThreshold)
+	for locale, text := range rules {
+		if _, err := ExpandRules(text, values); err != nil {
+			return fmt.Errorf("rules for locale %s: %w", locale, err)
+		}
+	}
+	return nil
+}
+
+const (
+	HintTypeCrashProbability = "crash_probability"
+	HintTypeCurrentSession   = "current_session"
+	HintTypeAllSessions      = "all_sessions"
+)
+
+const (
+	HintDisclosureExactProbability = "exact_probability"
+	HintDisclosureRiskBand         = "risk_band"
+	HintDisclosureSessionHistory   = "session_history"
+)
+
+var ErrHintNotAvailable = errors.New("hint type is not available in the parameter set")
+
+// HintOption is a hint that a parameter set offers to participants.
+type HintOption struct {
+	Type       string  `json:"type"`
+	Cost       float32 `json:"cost"`
+	Disclosure string  `json:"disclosure"`
+}
+
+func DefaultHintConfigJSON(hintCost float32) json.RawMessage {
+	config, _ := json.Marshal([]HintOption{{
+		Type:       HintTypeCrashProbability,
+		Cost:       hintCost,
+		Disclosure: HintDisclosureRiskBand,
+	}})
+	return config
+}
+
+func hintCost(options []HintOption, hintType string) float32 {
+	for _, option := range options {
+		if option.Type == hintType {
+			return option.Cost
+		}
+	}
+	return 0
+}
+
+func IsHintType(hintType string) bool {
+	switch hintType {
+	case HintTypeCrashProbability, HintTypeCurrentSession, HintTypeAllSessions:
+		return true
+	default:
+		return false
+	}
+}
+
+func ParseHintConfig(config json.RawMessage) ([]HintOption, error) {
+	var options []HintOption
+	if err := json.Unmarshal(config, &options); err != nil {
+		return nil, errors.New("hint config must be a json array of hint options")
+	}
+
+	seen := make(map[string]bool, len(options))
+	for _, option := range options {
+		if !IsHintType(option.Type) {
+			return nil, errors.New("unknown hint type in hint config")
+		}
+		if seen[option.Type] {
+			return nil, errors.New("duplicate hint type in hint config")
+		}
+		seen[option.Type] = true
+
+		if option.Cost < 0 {
+			return nil, errors.New("hint cost is less than zero")
+		}
+
+		// Session charts can only be shown as a history, the crash
+		// probability can be disclosed exactly or as a risk band.
+		switch option.Disclosure {
+		case HintDisclosureExactProbability, HintDisclosureRiskBand:
+			if option.Type != HintTypeCrashProbability {
+				return nil, errors.New("disclosure level does not match the hint type")
+			}
+		case HintDisclosureSessionHistory:
+			if option.Type == HintTypeCrashProbability {
+				return nil, errors.New("disclosure level does not match the hint type")
+			}
+		default:
+			return nil, errors.New("unknown hint disclosure level")
+		}
+	}
+
+	return options, nil
+}
+
+const (
+	AdvisorModelBernoulli = "bernoulli"
+	AdvisorModelDrifting  = "drifting"
+	AdvisorModelProximity = "proximity"
+	AdvisorModelScripted  = "scripted"
+)
+
+// AdvisorConfig selects the model that decides when the AI advisor is right.
+// Only the fields of the selected model are used.
+type AdvisorConfig struct {
+	Model          string `json:"model"`
+	ShowConfidence bool   `json:"show_confidence"`
+	// drifting: reliability changes linearly from StartReliability to
+	// EndReliability over DriftSteps steps and stays there afterwards.
+	StartReliability float64 `json:"start_reliability,omitempty"`
+	EndReliability   float64 `json:"end_reliability,omitempty"`
+	DriftSteps       int     `json:"drift_steps,omitempty"`
+	// proximity: reliability changes from NearReliability at the critical
+	// value to FarReliability at ProximityRange below it.
+	NearReliability float64 `json:"near_reliability,omitempty"`
+	FarReliability  float64 `json:"far_reliability,omitempty"`
+	ProximityRange  float64 `json:"proximity_range,omitempty"`
+	// scripted: Schedule[x] tells whether the advice at step x is correct,
+	// the schedule is repeated when the game is longer.
+	Schedule []bool `json:"schedule,omitempty"`
+}
+
+func DefaultAdvisorConfigJSON() json.RawMessage {
+	return json.RawMessage(`{"model":"bernoulli","show_confidence":false}`)
+}
+
+func isProbability(p float64) bool {
+	return p >= 0 && p <= 1
+}
+
+func ParseAdvisorConfig(config json.RawMessage) (AdvisorConfig, error) {
+	var advisor AdvisorConfig
+	if err := json.Unmarshal(config, &advisor); err != nil {
+		return AdvisorConfig{}, errors.New("advisor config must be a json object")
+	}
+
+	switch advisor.Model {
+	case AdvisorModelBernoulli:
+	case AdvisorModelDrifting:
+		if !isProbability(advisor.StartReliability) || !isProbability(advisor.EndReliability) {
+			return AdvisorConfig{}, errors.New("advisor reliability must be between 0 and 1")
+		}
+		if advisor.DriftSteps <= 0 {
+			return AdvisorConfig{}, errors.New("advisor drift steps is equal or less than zero")
+		}
+	case AdvisorModelProximity:
+		if !isProbability(advisor.NearReliability) || !isProbability(advisor.FarReliability) {
+			return AdvisorConfig{}, errors.New("advisor reliability must be between 0 and 1")
+		}
+		if advisor.ProximityRange <= 0 {
+			return AdvisorConfig{}, errors.New("advisor proximity range is equal or less than zero")
+		}
+	case AdvisorModelScripted:
+		if len(advisor.Schedule) == 0 {
+			return AdvisorConfig{}, errors.New("advisor schedule is empty")
+		}
+	default:
+		return AdvisorConfig{}, errors.New("unknown advisor model")
+	}
+
+	return advisor, nil
+}
+
+// GetAdviceInput is the current step of the game. NextY is the value of the
+// next step that the client has already generated, the advisor uses it to
+// decide whether the process is in danger. Scenario games ignore it.
+type GetAdviceInput struct {
+	X     float32 `json:"x"`
+	Y     float32 `json:"y"`
+	NextY float32 `json:"next_y"`
+}
+
+func (i *GetAdviceInput) Validate() error {
+	if i.X < 0 {
+		return errors.New("x coordinate is less than zero")
+	}
 	return nil
 }
 
+// Advice is the AI signal for one step of the game. Confidence is only set
+// when the parameter set shows it to participants. IsDanger is kept on the
+// server to tell useful signals from deceptive ones.
+type Advice struct {
+	X          float32  `json:"x" db:"x"`
+	Signal     bool     `json:"signal" db:"signal"`
+	Confidence *float64 `json:"confidence" db:"confidence"`
+	IsDanger   bool     `json:"-" db:"is_danger"`
+}
+
 type Point struct {
 	Id                  int     `json:"id" db:"id"`
 	X                   float32 `json:"x" db:"x"`
@@ -138,7 +523,18 @@ type Point struct {
 	IsCheck             bool    `json:"is_check" db:"is_check"`
 	ChartId             int     `json:"chart_id" binding:"required" db:"chart_id"`
 	CreatedAt           string  `json:"created_at" db:"created_at"`
-	CheckInfo           *string `json:"check_info" db:"check_info"`
+	// HintType, RiskLevel and CrashProbability record the hint the participant
+	// was shown at this point.
+	HintType         *string  `json:"hint_type" db:"hint_type"`
+	RiskLevel        *string  `json:"risk_level" db:"risk_level"`
+	CrashProbability *float64 `json:"crash_probability" db:"crash_probability"`
+	// AiConfidence is the confidence the advisor showed with its signal.
+	AiConfidence *float64 `json:"ai_confidence" db:"ai_confidence"`
+	// ReactionTimeMs is measured by the client from the moment the AI signal
+	// or the danger overlay was shown until the participant responded.
+	ReactionTimeMs *float64 `json:"reaction_time_ms" db:"reaction_time_ms"`
+	SignalShownAt  *string  `json:"signal_shown_at" db:"signal_shown_at"`
+	RespondedAt    *string  `json:"responded_at" db:"responded_at"`
 }
 
 func (p *Point) Validate() error {
@@ -148,40 +544,225 @@ func (p *Point) Validate() error {
 	if p.ChartId <= 0 {
 		return errors.New("chart id is equal or less than zero")
 	}
+	if p.HintType != nil && !IsHintType(*p.HintType) {
+		return errors.New("unknown hint type")
+	}
+	if p.RiskLevel != nil && !IsRiskLevel(*p.RiskLevel) {
+		return errors.New("unknown risk level")
+	}
+	if p.CrashProbability != nil && (*p.CrashProbability < 0 || *p.CrashProbability > 1) {
+		return errors.New("crash probability must be between 0 and 1")
+	}
+	if p.AiConfidence != nil && !isProbability(*p.AiConfidence) {
+		return errors.New("ai confidence must be between 0 and 1")
+	}
+	if p.ReactionTimeMs != nil && *p.ReactionTimeMs < 0 {
+		return errors.New("reaction time is less than zero")
+	}
+
+	var signalShownAt, respondedAt time.Time
+	var err error
+	if p.SignalShownAt != nil {
+		if signalShownAt, err = time.Parse(time.RFC3339Nano, *p.SignalShownAt); err != nil {
+			return errors.New("signal shown at is not a valid timestamp")
+		}
+	}
+	if p.RespondedAt != nil {
+		if respondedAt, err = time.Parse(time.RFC3339Nano, *p.RespondedAt); err != nil {
+			return errors.New("responded at is not a valid timestamp")
+		}
+	}
+	if p.SignalShownAt != nil && p.RespondedAt != nil && respondedAt.Before(signalShownAt) {
+		return errors.New("response is earlier than the signal")
+	}
 	return nil
 }
 
 type PointForCSV struct {
-	Id                  int     `json:"id" db:"id"`
-	X                   float32 `json:"x" db:"x"`
-	Y                   float32 `json:"y" db:"y"`
-	Score               float32 `json:"score" db:"score"`
-	IsCrash             bool    `json:"is_crash" db:"is_crash"`
-	IsUsefulAiSignal    bool    `json:"is_useful_ai_signal" db:"is_useful_ai_signal"`
-	IsDeceptiveAiSignal bool    `json:"is_deceptive_ai_signal" db:"is_deceptive_ai_signal"`
-	IsStop              bool    `json:"is_stop" db:"is_stop"`
-	IsPause             bool    `json:"is_pause" db:"is_pause"`
-	IsCheck             bool    `json:"is_check" db:"is_check"`
-	ChartId             int     `json:"chart_id" db:"chart_id"`
-	CheckInfo           *string `json:"check_info" db:"check_info"`
-	UserId              int     `json:"user_id" db:"user_id"`
-	ParameterSetId      int     `json:"parameter_set_id" db:"parameter_set_id"`
-	IsTraining          bool    `json:"is_training" db:"is_training"`
+	Id                  int      `json:"id" db:"id"`
+	X                   float32  `json:"x" db:"x"`
+	Y                   float32  `json:"y" db:"y"`
+	Score               float32  `json:"score" db:"score"`
+	IsCrash             bool     `json:"is_crash" db:"is_crash"`
+	IsUsefulAiSignal    bool     `json:"is_useful_ai_signal" db:"is_useful_ai_signal"`
+	IsDeceptiveAiSignal bool     `json:"is_deceptive_ai_signal" db:"is_deceptive_ai_signal"`
+	IsStop              bool     `json:"is_stop" db:"is_stop"`
+	IsPause             bool     `json:"is_pause" db:"is_pause"`
+	IsCheck             bool     `json:"is_check" db:"is_check"`
+	ChartId             int      `json:"chart_id" db:"chart_id"`
+	HintType            *string  `json:"hint_type" db:"hint_type"`
+	RiskLevel           *string  `json:"risk_level" db:"risk_level"`
+	CrashProbability    *float64 `json:"crash_probability" db:"crash_probability"`
+	AiConfidence        *float64 `json:"ai_confidence" db:"ai_confidence"`
+	ReactionTimeMs      *float64 `json:"reaction_time_ms" db:"reaction_time_ms"`
+	UserId              int      `json:"user_id" db:"user_id"`
+	ParameterSetId      int      `json:"parameter_set_id" db:"parameter_set_id"`
+	IsTraining          bool     `json:"is_training" db:"is_training"`
+	ScenarioId          *int     `json:"scenario_id" db:"scenario_id"`
 }
 
 type ParameterSet struct {
 	Id                  int             `json:"id" db:"id"`
+	ParentId            *int            `json:"parent_id" db:"parent_id"`
+	Name                string          `json:"name" db:"name"`
+	Description         string          `json:"description" db:"description"`
 	A                   float32         `json:"a" db:"a"`
 	B                   float32         `json:"b" db:"b"`
-	NoiseMean             float32         `json:"noise_mean" db:"noise_mean"`
-	NoiseStDev            float32         `json:"noise_stdev" db:"noise_stdev"`
-	FalseWarningProb      float32         `json:"false_warning_prob" db:"false_warning_prob"`
-	MissingDangerProb     float32         `json:"missing_danger_prob" db:"missing_danger_prob"`
-	ScoringConfig         json.RawMessage `json:"scoring_config" db:"scoring_config"`
-	HintCost              float32         `json:"hint_cost" db:"hint_cost"`
-	FalseAlarmThreshold   float32         `json:"false_alarm_threshold" db:"false_alarm_threshold"`
-	RulesText             string          `json:"rules_text" db:"rules_text"`
-	CreatedAt             string          `json:"created_at" db:"created_at"`
+	NoiseMean           float32         `json:"noise_mean" db:"noise_mean"`
+	NoiseStDev          float32         `json:"noise_stdev" db:"noise_stdev"`
+	FalseWarningProb    float32         `json:"false_warning_prob" db:"false_warning_prob"`
+	MissingDangerProb   float32         `json:"missing_danger_prob" db:"missing_danger_prob"`
+	ScoringConfig       json.RawMessage `json:"scoring_config" db:"scoring_config"`
+	HintCost            float32         `json:"hint_cost" db:"hint_cost"`
+	HintConfig          json.RawMessage `json:"hint_config" db:"hint_config"`
+	AdvisorConfig       json.RawMessage `json:"advisor_config" db:"advisor_config"`
+	FalseAlarmThreshold float32         `json:"false_alarm_threshold" db:"false_alarm_threshold"`
+	Rules               json.RawMessage `json:"rules" db:"rules"`
+	CreatedAt           string          `json:"created_at" db:"created_at"`
+	ArchivedAt          *string         `json:"archived_at" db:"archived_at"`
+}
+
+var ErrParSetArchived = errors.New("parameter set is archived")
+
+// ChangesGame reports whether the input changes how the game of the parameter
+// set is played. Once games have been played with a set only its name,
+// description and rules can be edited.
+func (i CreateParSetInput) ChangesGame(parSet ParameterSet) bool {
+	return i.A != parSet.A ||
+		i.B != parSet.B ||
+		i.NoiseMean != parSet.NoiseMean ||
+		i.NoiseStdev != parSet.NoiseStDev ||
+		i.FalseWarningProb != parSet.FalseWarningProb ||
+		i.MissingDangerProb != parSet.MissingDangerProb ||
+		i.FalseAlarmThreshold != parSet.FalseAlarmThreshold ||
+		!jsonEqual(i.ScoringConfig, parSet.ScoringConfig) ||
+		!jsonEqual(i.HintConfig, parSet.HintConfig) ||
+		!jsonEqual(i.AdvisorConfig, parSet.AdvisorConfig)
+}
+
+// jsonEqual compares json values regardless of their formatting, the
+// database does not keep the formatting of the configs.
+func jsonEqual(a, b json.RawMessage) bool {
+	var valueA, valueB any
+	if json.Unmarshal(a, &valueA) != nil || json.Unmarshal(b, &valueB) != nil {
+		return bytes.Equal(a, b)
+	}
+	return reflect.DeepEqual(valueA, valueB)
+}
+
+// ToCreateInput returns the input that creates a copy of the parameter set.
+func (p ParameterSet) ToCreateInput() CreateParSetInput {
+	return CreateParSetInput{
+		Name:                p.Name,
+		Description:         p.Description,
+		A:                   p.A,
+		B:                   p.B,
+		NoiseMean:           p.NoiseMean,
+		NoiseStdev:          p.NoiseStDev,
+		FalseWarningProb:    p.FalseWarningProb,
+		MissingDangerProb:   p.MissingDangerProb,
+		ScoringConfig:       p.ScoringConfig,
+		HintCost:            p.HintCost,
+		HintConfig:          p.HintConfig,
+		AdvisorConfig:       p.AdvisorConfig,
+		FalseAlarmThreshold: p.FalseAlarmThreshold,
+		Rules:               p.Rules,
+	}
+}
+
+const (
+	DefaultPreviewGames = 1000
+	MaxPreviewGames     = 10000
+)
+
+const (
+	StrategyFollowAi        = "follow_ai"
+	StrategyNeverFollow     = "never_follow"
+	StrategyStopAtThreshold = "stop_at_threshold"
+)
+
+type PreviewParSetInput struct {
+	ParSet   CreateParSetInput `json:"par_set"`
+	Games    int               `json:"games"`
+	MaxSteps int               `json:"max_steps"`
+	// StopThreshold is the process value at which the stop_at_threshold
+	// strategy stops, by default the false alarm threshold.
+	StopThreshold *float64 `json:"stop_threshold"`
+	Seed          *int64   `json:"seed"`
+}
+
+func (i *PreviewParSetInput) Validate() error {
+	if err := i.ParSet.Validate(); err != nil {
+		return err
+	}
+	if i.Games == 0 {
+		i.Games = DefaultPreviewGames
+	}
+	if i.MaxSteps == 0 {
+		i.MaxSteps = DefaultScenarioLength
+	}
+	if i.StopThreshold == nil {
+		stopThreshold := float64(i.ParSet.FalseAlarmThreshold)
+		i.StopThreshold = &stopThreshold
+	}
+	if i.Games < 0 || i.Games > MaxPreviewGames {
+		return errors.New("games count must be between 1 and 10000")
+	}
+	if i.MaxSteps < 0 || i.MaxSteps > MaxScenarioLength {
+		return errors.New("max steps must be between 1 and 1000")
+	}
+	return nil
+}
+
+// StrategyPreview holds the mean outcome of the simulated games played with
+// one baseline strategy.
+type StrategyPreview struct {
+	Strategy        string  `json:"strategy"`
+	CrashRate       float64 `json:"crash_rate"`
+	StopRate        float64 `json:"stop_rate"`
+	MeanGameLength  float64 `json:"mean_game_length"`
+	MeanSignalCount float64 `json:"mean_signal_count"`
+	MeanScore       float64 `json:"mean_score"`
+}
+
+type ParSetPreview struct {
+	Games      int               `json:"games"`
+	MaxSteps   int               `json:"max_steps"`
+	Strategies []StrategyPreview `json:"strategies"`
+}
+
+// ParSetReferences lists the charts, groups and users that refer to a
+// parameter set.
+type ParSetReferences struct {
+	ChartCount int   `json:"chart_count"`
+	GroupIds   []int `json:"group_ids"`
+	UserIds    []int `json:"user_ids"`
+}
+
+func (r ParSetReferences) IsEmpty() bool {
+	return r.ChartCount == 0 && len(r.GroupIds) == 0 && len(r.UserIds) == 0
+}
+
+type ParSetConflictError struct {
+	Action     string
+	References ParSetReferences
+}
+
+func (e *ParSetConflictError) Error() string {
+	return fmt.Sprintf("parameter set cannot be %s: it is referenced by %d charts, groups %v and users %v",
+		e.Action, e.References.ChartCount, e.References.GroupIds, e.References.UserIds)
+}
+
+// ParameterSetWithUsage is a parameter set version together with its
+// ancestors (Lineage, from the first version) and how many charts, groups and
+// users refer to it.
+type ParameterSetWithUsage struct {
+	ParameterSet
+	Version    int             `json:"version" db:"version"`
+	Lineage    json.RawMessage `json:"lineage" db:"lineage"`
+	ChartCount int             `json:"chart_count" db:"chart_count"`
+	GroupCount int             `json:"group_count" db:"group_count"`
+	UserCount  int             `json:"user_count" db:"user_count"`
 }
 
 type UserParameterSet struct {
@@ -2,6 +2,7 @@ package main
 
 import (
 	"os"
+	"time"
 
 	gameServer "example.com/gameHoldTheProcessServer"
 	"example.com/gameHoldTheProcessServer/pkg/handler"
@@ -41,12 +42,31 @@ func main() {
 	services := service.NewService(repo)
 	handlers := handler.NewHandler(services)
 
+	go abandonStaleCharts(services.Chart, viper.GetDuration("game.session_timeout"))
+
 	srv := new(gameServer.Server)
 	if err := srv.Run(viper.GetString("port"), handlers.InitRoutes()); err != nil {
 		logrus.Fatalf("error when trying to run the server: %s", err.Error())
 	}
 }
 
+// abandonStaleCharts periodically closes games whose client stopped streaming points.
+func abandonStaleCharts(charts service.Chart, timeout time.Duration) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		count, err := charts.AbandonStaleCharts(timeout)
+		if err != nil {
+			logrus.Errorf("error when abandoning stale charts: %s", err.Error())
+			continue
+		}
+		if count > 0 {
+			logrus.Infof("charts marked as abandoned: %d", count)
+		}
+	}
+}
+
 func initConfig() error {
 	viper.AddConfigPath("configs")
 	viper.SetConfigName("config")
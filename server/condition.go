@@ -0,0 +1,524 @@
+package gameServer
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Condition is a compiled show_if expression of a questionnaire, such as
+//
+//	q4 == 'yes' && (experience_years > 2 || contains(tools, 'radar'))
+//
+// An identifier is the answer to a question, the answer to a row of a matrix
+// by MatrixKey, or a field of the UserProfile. Numbers, 'strings' or
+// "strings", true, false and null are literals. The comparisons are ==, !=,
+// <, <=, > and >=, the logic operators are && (and), || (or) and ! (not).
+// answered(id) tells whether the question is answered and contains(id, value)
+// whether a multi_select or ranking answer includes the value.
+//
+// An unanswered question is null: it only equals null, only the literal null
+// differs from an answered one and any other comparison with it is false, so
+// a condition on an unanswered question, q != 'yes' as well, hides the item
+// until the question is answered.
+type Condition struct {
+	source string
+	root   conditionNode
+	refs   []string
+}
+
+// ParseCondition compiles the expression, which must be a boolean one.
+func ParseCondition(source string) (Condition, error) {
+	tokens, err := tokenizeCondition(source)
+	if err != nil {
+		return Condition{}, err
+	}
+	p := &conditionParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return Condition{}, err
+	}
+	if p.peek().kind != tokenEnd {
+		return Condition{}, fmt.Errorf("unexpected %q at position %d", p.peek().text, p.peek().pos)
+	}
+	if !isBoolNode(root) {
+		return Condition{}, errors.New("condition must be a comparison, a function call or a logic expression")
+	}
+	return Condition{source: source, root: root, refs: p.refs}, nil
+}
+
+// Refs returns the identifiers the condition refers to.
+func (c Condition) Refs() []string {
+	return c.refs
+}
+
+// Eval evaluates the condition with the values of the identifiers, nil for
+// an unanswered question or an unset profile field.
+func (c Condition) Eval(lookup func(name string) any) bool {
+	if c.root == nil {
+		return true
+	}
+	return c.root.eval(lookup) == true
+}
+
+func (c Condition) String() string {
+	return c.source
+}
+
+// UserProfile holds the profile fields of the user that the conditions can
+// refer to by name, a field the user has not filled in is nil. A question
+// with the same id as a field takes precedence over it.
+type UserProfile map[string]any
+
+const (
+	ProfileProfession      = "profession"
+	ProfileExperienceYears = "experience_years"
+	ProfileGender          = "gender"
+	ProfileAge             = "age"
+)
+
+func isProfileField(name string) bool {
+	switch name {
+	case ProfileProfession, ProfileExperienceYears, ProfileGender, ProfileAge:
+		return true
+	default:
+		return false
+	}
+}
+
+func NewUserProfile(user User) UserProfile {
+	profile := UserProfile{
+		ProfileProfession:      nil,
+		ProfileExperienceYears: nil,
+		ProfileGender:          nil,
+		ProfileAge:             nil,
+	}
+	if user.Profession != nil {
+		profile[ProfileProfession] = *user.Profession
+	}
+	if user.ExperienceYears != nil {
+		profile[ProfileExperienceYears] = float64(*user.ExperienceYears)
+	}
+	if user.Gender != nil {
+		profile[ProfileGender] = *user.Gender
+	}
+	if user.Age != nil {
+		profile[ProfileAge] = float64(*user.Age)
+	}
+	return profile
+}
+
+const (
+	tokenEnd = iota
+	tokenNumber
+	tokenString
+	tokenIdent
+	tokenOperator
+)
+
+type conditionToken struct {
+	kind  int
+	text  string
+	value any
+	pos   int
+}
+
+var conditionOperators = []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "(", ")", ","}
+
+func tokenizeCondition(source string) ([]conditionToken, error) {
+	var tokens []conditionToken
+	runes := []rune(source)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			text := string(runes[start:i])
+			value, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q at position %d", text, start)
+			}
+			tokens = append(tokens, conditionToken{kind: tokenNumber, text: text, value: value, pos: start})
+		case r == '\'' || r == '"':
+			start := i
+			i++
+			var b strings.Builder
+			for i < len(runes) && runes[i] != r {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				b.WriteRune(runes[i])
+				i++
+			}
+			if i == len(runes) {
+				return nil, fmt.Errorf("unterminated string at position %d", start)
+			}
+			i++
+			tokens = append(tokens, conditionToken{kind: tokenString, text: string(runes[start:i]), value: b.String(), pos: start})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, conditionToken{kind: tokenIdent, text: string(runes[start:i]), pos: start})
+		default:
+			matched := false
+			for _, op := range conditionOperators {
+				if strings.HasPrefix(string(runes[i:]), op) {
+					tokens = append(tokens, conditionToken{kind: tokenOperator, text: op, pos: i})
+					i += len([]rune(op))
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected %q at position %d", string(r), i)
+			}
+		}
+	}
+	return append(tokens, conditionToken{kind: tokenEnd, text: "end of condition", pos: len(runes)}), nil
+}
+
+type conditionParser struct {
+	tokens []conditionToken
+	pos    int
+	refs   []string
+}
+
+func (p *conditionParser) peek() conditionToken {
+	return p.tokens[p.pos]
+}
+
+func (p *conditionParser) next() conditionToken {
+	token := p.tokens[p.pos]
+	if token.kind != tokenEnd {
+		p.pos++
+	}
+	return token
+}
+
+// accept consumes the next token if it is one of the operators or keywords.
+func (p *conditionParser) accept(texts ...string) (string, bool) {
+	token := p.peek()
+	if token.kind != tokenOperator && token.kind != tokenIdent {
+		return "", false
+	}
+	for _, text := range texts {
+		if token.text == text {
+			p.pos++
+			return text, true
+		}
+	}
+	return "", false
+}
+
+func (p *conditionParser) expect(text string) error {
+	if _, ok := p.accept(text); !ok {
+		return fmt.Errorf("expected %q at position %d, got %q", text, p.peek().pos, p.peek().text)
+	}
+	return nil
+}
+
+func (p *conditionParser) parseOr() (conditionNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept("||", "or"); !ok {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if !isBoolNode(left) || !isBoolNode(right) {
+			return nil, errors.New("operands of || must be conditions")
+		}
+		left = logicNode{or: true, left: left, right: right}
+	}
+}
+
+func (p *conditionParser) parseAnd() (conditionNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept("&&", "and"); !ok {
+			return left, nil
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		if !isBoolNode(left) || !isBoolNode(right) {
+			return nil, errors.New("operands of && must be conditions")
+		}
+		left = logicNode{left: left, right: right}
+	}
+}
+
+func (p *conditionParser) parseNot() (conditionNode, error) {
+	if _, ok := p.accept("!", "not"); ok {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		if !isBoolNode(operand) {
+			return nil, errors.New("operand of ! must be a condition")
+		}
+		return notNode{operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *conditionParser) parseComparison() (conditionNode, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	op, ok := p.accept("==", "!=", "<", "<=", ">", ">=")
+	if !ok {
+		return left, nil
+	}
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	return compareNode{op: op, left: left, right: right}, nil
+}
+
+func (p *conditionParser) parseOperand() (conditionNode, error) {
+	token := p.next()
+	switch token.kind {
+	case tokenNumber, tokenString:
+		return literalNode{value: token.value}, nil
+	case tokenOperator:
+		if token.text == "(" {
+			node, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			return node, p.expect(")")
+		}
+	case tokenIdent:
+		switch token.text {
+		case "true":
+			return literalNode{value: true}, nil
+		case "false":
+			return literalNode{value: false}, nil
+		case "null":
+			return literalNode{value: nil}, nil
+		case "and", "or", "not":
+		default:
+			if p.peek().text == "(" && p.peek().kind == tokenOperator {
+				return p.parseCall(token)
+			}
+			p.refs = append(p.refs, token.text)
+			return refNode{name: token.text}, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected %q at position %d", token.text, token.pos)
+}
+
+func (p *conditionParser) parseCall(name conditionToken) (conditionNode, error) {
+	p.next()
+	var args []conditionNode
+	for p.peek().text != ")" || p.peek().kind != tokenOperator {
+		if len(args) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		arg, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	p.next()
+
+	switch name.text {
+	case "answered":
+		if len(args) != 1 {
+			return nil, errors.New("answered takes a question")
+		}
+	case "contains":
+		if len(args) != 2 {
+			return nil, errors.New("contains takes a question and a value")
+		}
+	default:
+		return nil, fmt.Errorf("unknown function %q at position %d", name.text, name.pos)
+	}
+	if _, ok := args[0].(refNode); !ok {
+		return nil, fmt.Errorf("%s takes a question as its first argument", name.text)
+	}
+	return callNode{name: name.text, args: args}, nil
+}
+
+type conditionNode interface {
+	eval(lookup func(name string) any) any
+}
+
+func isBoolNode(node conditionNode) bool {
+	switch node := node.(type) {
+	case compareNode, logicNode, notNode, callNode:
+		return true
+	case literalNode:
+		_, ok := node.value.(bool)
+		return ok
+	default:
+		return false
+	}
+}
+
+type literalNode struct {
+	value any
+}
+
+func (n literalNode) eval(func(string) any) any {
+	return n.value
+}
+
+type refNode struct {
+	name string
+}
+
+func (n refNode) eval(lookup func(string) any) any {
+	return lookup(n.name)
+}
+
+type compareNode struct {
+	op          string
+	left, right conditionNode
+}
+
+func (n compareNode) eval(lookup func(string) any) any {
+	left, right := n.left.eval(lookup), n.right.eval(lookup)
+	switch n.op {
+	case "==":
+		return sameValue(left, right)
+	case "!=":
+		if left == nil || right == nil {
+			return (isNullLiteral(n.left) && right != nil) || (isNullLiteral(n.right) && left != nil)
+		}
+		return !sameValue(left, right)
+	}
+
+	var cmp int
+	switch l := left.(type) {
+	case float64:
+		r, ok := right.(float64)
+		if !ok {
+			return false
+		}
+		cmp = compareOrdered(l, r)
+	case string:
+		r, ok := right.(string)
+		if !ok {
+			return false
+		}
+		cmp = strings.Compare(l, r)
+	default:
+		return false
+	}
+	switch n.op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default:
+		return cmp >= 0
+	}
+}
+
+func isNullLiteral(node conditionNode) bool {
+	literal, ok := node.(literalNode)
+	return ok && literal.value == nil
+}
+
+func compareOrdered(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func sameValue(a, b any) bool {
+	switch a := a.(type) {
+	case nil:
+		return b == nil
+	case float64, string, bool:
+		return a == b
+	default:
+		return false
+	}
+}
+
+type logicNode struct {
+	or          bool
+	left, right conditionNode
+}
+
+func (n logicNode) eval(lookup func(string) any) any {
+	if n.or {
+		return n.left.eval(lookup) == true || n.right.eval(lookup) == true
+	}
+	return n.left.eval(lookup) == true && n.right.eval(lookup) == true
+}
+
+type notNode struct {
+	operand conditionNode
+}
+
+func (n notNode) eval(lookup func(string) any) any {
+	return n.operand.eval(lookup) != true
+}
+
+type callNode struct {
+	name string
+	args []conditionNode
+}
+
+func (n callNode) eval(lookup func(string) any) any {
+	value := n.args[0].eval(lookup)
+	switch n.name {
+	case "answered":
+		switch value := value.(type) {
+		case nil:
+			return false
+		case string:
+			return strings.TrimSpace(value) != ""
+		case []any:
+			return len(value) > 0
+		default:
+			return true
+		}
+	default:
+		values, ok := value.([]any)
+		if !ok {
+			return false
+		}
+		needle := n.args[1].eval(lookup)
+		for _, v := range values {
+			if sameValue(v, needle) {
+				return true
+			}
+		}
+		return false
+	}
+}
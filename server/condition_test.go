@@ -0,0 +1,375 @@
+package gameServer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCondition(t *testing.T) {
+	tests := []struct {
+		name         string
+		source       string
+		expectedRefs []string
+		expectedErr  string
+	}{
+		{
+			name:         "ok",
+			source:       "q4 == 'yes' && (experience_years > 2 || contains(tools, \"radar\"))",
+			expectedRefs: []string{"q4", "experience_years", "tools"},
+		},
+		{
+			name:         "matrix row and keywords",
+			source:       "not grid.r1 >= 3 or answered(q2)",
+			expectedRefs: []string{"grid.r1", "q2"},
+		},
+		{
+			name:        "empty",
+			source:      "",
+			expectedErr: `unexpected "end of condition" at position 0`,
+		},
+		{
+			name:        "missing operand",
+			source:      "q1 ==",
+			expectedErr: `unexpected "end of condition" at position 5`,
+		},
+		{
+			name:        "missing operand of a logic operator",
+			source:      "q1 == 1 &&",
+			expectedErr: `unexpected "end of condition" at position 10`,
+		},
+		{
+			name:        "unterminated string",
+			source:      "q1 == 'yes",
+			expectedErr: "unterminated string at position 6",
+		},
+		{
+			name:        "invalid number",
+			source:      "q1 == 1.2.3",
+			expectedErr: `invalid number "1.2.3" at position 6`,
+		},
+		{
+			name:        "unknown character",
+			source:      "q1 # 2",
+			expectedErr: `unexpected "#" at position 3`,
+		},
+		{
+			name:        "unclosed parenthesis",
+			source:      "(q1 == 1",
+			expectedErr: `expected ")" at position 8, got "end of condition"`,
+		},
+		{
+			name:        "trailing operand",
+			source:      "q1 == 1 q2",
+			expectedErr: `unexpected "q2" at position 8`,
+		},
+		{
+			name:        "not a condition",
+			source:      "q1",
+			expectedErr: "condition must be a comparison, a function call or a logic expression",
+		},
+		{
+			name:        "operand of && is not a condition",
+			source:      "q1 && q2 == 1",
+			expectedErr: "operands of && must be conditions",
+		},
+		{
+			name:        "operand of || is not a condition",
+			source:      "q1 == 1 || 2",
+			expectedErr: "operands of || must be conditions",
+		},
+		{
+			name:        "operand of ! is not a condition",
+			source:      "!q1",
+			expectedErr: "operand of ! must be a condition",
+		},
+		{
+			name:        "keyword as an operand",
+			source:      "and == 1",
+			expectedErr: `unexpected "and" at position 0`,
+		},
+		{
+			name:        "unknown function",
+			source:      "size(q1) > 2",
+			expectedErr: `unknown function "size" at position 0`,
+		},
+		{
+			name:        "answered without a question",
+			source:      "answered()",
+			expectedErr: "answered takes a question",
+		},
+		{
+			name:        "contains without a value",
+			source:      "contains(q1)",
+			expectedErr: "contains takes a question and a value",
+		},
+		{
+			name:        "contains of a literal",
+			source:      "contains('a', 'b')",
+			expectedErr: "contains takes a question as its first argument",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, err := ParseCondition(tt.source)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedRefs, condition.Refs())
+			assert.Equal(t, tt.source, condition.String())
+		})
+	}
+}
+
+func TestCondition_Eval(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		values   map[string]any
+		expected bool
+	}{
+		// && binds tighter than ||, ! binds to the comparison after it.
+		{
+			name:     "precedence - or of and",
+			source:   "a == 1 || b == 1 && c == 1",
+			values:   map[string]any{"a": 1.0, "b": 0.0, "c": 0.0},
+			expected: true,
+		},
+		{
+			name:     "precedence - and within or",
+			source:   "a == 1 || b == 1 && c == 1",
+			values:   map[string]any{"a": 0.0, "b": 1.0, "c": 0.0},
+			expected: false,
+		},
+		{
+			name:     "precedence - parentheses",
+			source:   "(a == 1 || b == 1) && c == 1",
+			values:   map[string]any{"a": 1.0, "b": 0.0, "c": 0.0},
+			expected: false,
+		},
+		{
+			name:     "precedence - not of a comparison",
+			source:   "!a == 1 && b == 1",
+			values:   map[string]any{"a": 2.0, "b": 0.0},
+			expected: false,
+		},
+		{
+			name:     "precedence - keywords",
+			source:   "not a == 1 and b == 1 or c == 1",
+			values:   map[string]any{"a": 2.0, "b": 0.0, "c": 1.0},
+			expected: true,
+		},
+
+		{
+			name:     "equal strings",
+			source:   "q == 'yes'",
+			values:   map[string]any{"q": "yes"},
+			expected: true,
+		},
+		{
+			name:     "number is not its string",
+			source:   "q == 3",
+			values:   map[string]any{"q": "3"},
+			expected: false,
+		},
+		{
+			name:     "numbers ordered",
+			source:   "age >= 18 && age < 65",
+			values:   map[string]any{"age": 18.0},
+			expected: true,
+		},
+		{
+			name:     "strings ordered",
+			source:   "q > 'a'",
+			values:   map[string]any{"q": "b"},
+			expected: true,
+		},
+		{
+			name:     "number ordered against a string",
+			source:   "q > 'a'",
+			values:   map[string]any{"q": 3.0},
+			expected: false,
+		},
+		{
+			name:     "boolean",
+			source:   "q == true",
+			values:   map[string]any{"q": true},
+			expected: true,
+		},
+		{
+			name:     "matrix row",
+			source:   "grid.r1 <= 2",
+			values:   map[string]any{"grid.r1": 2.0},
+			expected: true,
+		},
+
+		// An unanswered question is null.
+		{
+			name:     "null - equality",
+			source:   "q == 'yes'",
+			values:   map[string]any{},
+			expected: false,
+		},
+		{
+			name:     "null - inequality",
+			source:   "q != 'yes'",
+			values:   map[string]any{},
+			expected: false,
+		},
+		{
+			name:     "null - inequality of answered",
+			source:   "q != 'yes'",
+			values:   map[string]any{"q": "no"},
+			expected: true,
+		},
+		{
+			name:     "null - inequality of the same answer",
+			source:   "q != 'yes'",
+			values:   map[string]any{"q": "yes"},
+			expected: false,
+		},
+		{
+			name:     "null - equals null",
+			source:   "q == null",
+			values:   map[string]any{},
+			expected: true,
+		},
+		{
+			name:     "null - answered does not equal null",
+			source:   "q == null",
+			values:   map[string]any{"q": "no"},
+			expected: false,
+		},
+		{
+			name:     "null - answered differs from null",
+			source:   "null != q",
+			values:   map[string]any{"q": 0.0},
+			expected: true,
+		},
+		{
+			name:     "null - unanswered does not differ from null",
+			source:   "q != null",
+			values:   map[string]any{},
+			expected: false,
+		},
+		{
+			name:     "null - compared with an answered question",
+			source:   "q != r",
+			values:   map[string]any{"r": "yes"},
+			expected: false,
+		},
+		{
+			name:     "null - ordering",
+			source:   "q <= 2",
+			values:   map[string]any{},
+			expected: false,
+		},
+		{
+			name:     "null - not of a comparison",
+			source:   "!(q > 2)",
+			values:   map[string]any{},
+			expected: true,
+		},
+
+		{
+			name:     "answered - number",
+			source:   "answered(q)",
+			values:   map[string]any{"q": 0.0},
+			expected: true,
+		},
+		{
+			name:     "answered - false",
+			source:   "answered(q)",
+			values:   map[string]any{"q": false},
+			expected: true,
+		},
+		{
+			name:     "answered - text",
+			source:   "answered(q)",
+			values:   map[string]any{"q": "some"},
+			expected: true,
+		},
+		{
+			name:     "answered - blank text",
+			source:   "answered(q)",
+			values:   map[string]any{"q": "  "},
+			expected: false,
+		},
+		{
+			name:     "answered - selection",
+			source:   "answered(q)",
+			values:   map[string]any{"q": []any{"a"}},
+			expected: true,
+		},
+		{
+			name:     "answered - empty selection",
+			source:   "answered(q)",
+			values:   map[string]any{"q": []any{}},
+			expected: false,
+		},
+		{
+			name:     "answered - unanswered",
+			source:   "answered(q)",
+			values:   map[string]any{},
+			expected: false,
+		},
+
+		{
+			name:     "contains - selected",
+			source:   "contains(tools, 'radar')",
+			values:   map[string]any{"tools": []any{"sonar", "radar"}},
+			expected: true,
+		},
+		{
+			name:     "contains - not selected",
+			source:   "contains(tools, 'lidar')",
+			values:   map[string]any{"tools": []any{"sonar", "radar"}},
+			expected: false,
+		},
+		{
+			name:     "contains - number",
+			source:   "contains(ranks, 2)",
+			values:   map[string]any{"ranks": []any{1.0, 2.0}},
+			expected: true,
+		},
+		{
+			name:     "contains - not a list",
+			source:   "contains(tools, 'radar')",
+			values:   map[string]any{"tools": "radar"},
+			expected: false,
+		},
+		{
+			name:     "contains - unanswered",
+			source:   "contains(tools, 'radar')",
+			values:   map[string]any{},
+			expected: false,
+		},
+		{
+			name:     "contains - value of a question",
+			source:   "contains(tools, favourite)",
+			values:   map[string]any{"tools": []any{"sonar", "radar"}, "favourite": "sonar"},
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, err := ParseCondition(tt.source)
+			require.NoError(t, err)
+
+			actual := condition.Eval(func(name string) any {
+				return tt.values[name]
+			})
+
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestCondition_EvalEmpty(t *testing.T) {
+	assert.True(t, Condition{}.Eval(func(string) any { return nil }))
+}
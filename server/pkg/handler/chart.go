@@ -1,10 +1,13 @@
 package handler
 
 import (
+	"database/sql"
+	"errors"
 	"net/http"
 	"strconv"
 
 	gameServer "example.com/gameHoldTheProcessServer"
+	"example.com/gameHoldTheProcessServer/pkg/i18n"
 	"github.com/gin-gonic/gin"
 )
 
@@ -21,8 +24,16 @@ func (h *Handler) createChart(c *gin.Context) {
 		return
 	}
 
+	if !h.checkUserAccess(c, input.UserId) {
+		return
+	}
+
 	id, err := h.services.Chart.CreateChart(input)
 	if err != nil {
+		if errors.Is(err, gameServer.ErrScenarioMismatch) {
+			newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeScenarioMismatch)
+			return
+		}
 		newErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -32,6 +43,205 @@ func (h *Handler) createChart(c *gin.Context) {
 	})
 }
 
+func (h *Handler) openChart(c *gin.Context) {
+	var input gameServer.CreateChartInput
+
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !h.checkUserAccess(c, input.UserId) {
+		return
+	}
+
+	id, err := h.services.Chart.OpenChart(input)
+	if err != nil {
+		if errors.Is(err, gameServer.ErrScenarioMismatch) {
+			newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeScenarioMismatch)
+			return
+		}
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]any{
+		"id": id,
+	})
+}
+
+type appendChartPointsResponse struct {
+	Ids []int `json:"ids"`
+}
+
+func (h *Handler) appendChartPoints(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "id")
+		return
+	}
+
+	var input gameServer.AppendChartPointsInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	for i := range input.Points {
+		input.Points[i].ChartId = id
+		if err := input.Points[i].Validate(); err != nil {
+			newErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	if !h.checkChartAccess(c, id) {
+		return
+	}
+
+	ids, err := h.services.Chart.AppendPoints(id, input)
+	if err != nil {
+		if errors.Is(err, gameServer.ErrChartNotInProgress) {
+			newCodedErrorResponse(c, http.StatusConflict, i18n.CodeChartNotInProgress)
+			return
+		}
+		if errors.Is(err, gameServer.ErrHintNotAvailable) {
+			newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeHintNotAvailable)
+			return
+		}
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, appendChartPointsResponse{
+		Ids: ids,
+	})
+}
+
+func (h *Handler) closeChart(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "id")
+		return
+	}
+
+	var input gameServer.CloseChartInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !h.checkChartAccess(c, id) {
+		return
+	}
+
+	if err := h.services.Chart.CloseChart(id, input); err != nil {
+		if errors.Is(err, gameServer.ErrChartNotInProgress) {
+			newCodedErrorResponse(c, http.StatusConflict, i18n.CodeChartNotInProgress)
+			return
+		}
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+type getCrashHintResponse struct {
+	Data gameServer.Hint `json:"data"`
+}
+
+func (h *Handler) getCrashHint(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "id")
+		return
+	}
+
+	var input gameServer.GetHintInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !h.checkChartAccess(c, id) {
+		return
+	}
+
+	hint, err := h.services.Hint.GetCrashHint(id, input)
+	if err != nil {
+		if errors.Is(err, gameServer.ErrHintNotAvailable) {
+			newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeHintNotAvailable)
+			return
+		}
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, getCrashHintResponse{
+		Data: hint,
+	})
+}
+
+type getAdviceResponse struct {
+	Data gameServer.Advice `json:"data"`
+}
+
+func (h *Handler) getAdvice(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "id")
+		return
+	}
+
+	var input gameServer.GetAdviceInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !h.checkChartAccess(c, id) {
+		return
+	}
+
+	advice, err := h.services.Advisor.GetAdvice(id, input)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, getAdviceResponse{
+		Data: advice,
+	})
+}
+
 type getOneChartResponse struct {
 	Data gameServer.Chart `json:"data"`
 }
@@ -39,16 +249,24 @@ type getOneChartResponse struct {
 func (h *Handler) getOneChart(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil || id <= 0 {
-		newErrorResponse(c, http.StatusBadRequest, "invalid parameter id")
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "id")
 		return
 	}
 
 	chart, err := h.services.Chart.GetOneChart(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		newCodedErrorResponse(c, http.StatusNotFound, i18n.CodeNotFound)
+		return
+	}
 	if err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	if !h.checkUserAccess(c, chart.UserId) {
+		return
+	}
+
 	c.JSON(http.StatusOK, getOneChartResponse{
 		Data: chart,
 	})
@@ -65,9 +283,14 @@ func (h *Handler) getChartsPageCount(c *gin.Context) {
 		return
 	}
 
+	if !h.checkChartsFilterAccess(c, input.FilterTag, input.FilterValue) {
+		return
+	}
+
 	pageCount, err := h.services.Chart.GetChartsPageCount(input)
 	if err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
 	}
 
 	c.JSON(http.StatusOK, getChartsPageCountResponse{
@@ -86,6 +309,10 @@ func (h *Handler) getChartsCount(c *gin.Context) {
 		return
 	}
 
+	if !h.checkChartsFilterAccess(c, input.FilterTag, input.FilterValue) {
+		return
+	}
+
 	chartsCount, err := h.services.Chart.GetChartsCount(input)
 	if err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, err.Error())
@@ -113,6 +340,10 @@ func (h *Handler) getAllCharts(c *gin.Context) {
 		return
 	}
 
+	if !h.checkChartsFilterAccess(c, input.FilterTag, input.FilterValue) {
+		return
+	}
+
 	charts, err := h.services.Chart.GetAllCharts(input)
 	if err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, err.Error())
@@ -127,7 +358,7 @@ func (h *Handler) getAllCharts(c *gin.Context) {
 func (h *Handler) deleteChart(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil || id <= 0 {
-		newErrorResponse(c, http.StatusBadRequest, "invalid parameter id")
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "id")
 		return
 	}
 
@@ -143,7 +374,7 @@ func (h *Handler) deleteChart(c *gin.Context) {
 }
 
 type getAllParSetsResponse struct {
-	Data []gameServer.ParameterSet `json:"data"`
+	Data []gameServer.ParameterSetWithUsage `json:"data"`
 }
 
 func (h *Handler) getAllParSets(c *gin.Context) {
@@ -208,3 +439,207 @@ func (h *Handler) createParSet(c *gin.Context) {
 		"id": id,
 	})
 }
+
+func (h *Handler) createParSetVersion(c *gin.Context) {
+	parentId, err := strconv.Atoi(c.Param("id"))
+	if err != nil || parentId <= 0 {
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "id")
+		return
+	}
+
+	var input gameServer.CreateParSetInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id, err := h.services.Chart.CreateParSetVersion(parentId, input)
+	if err != nil {
+		newParSetErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]any{
+		"id": id,
+	})
+}
+
+type getOneParSetResponse struct {
+	Data gameServer.ParameterSet `json:"data"`
+}
+
+func (h *Handler) getOneParSet(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "id")
+		return
+	}
+
+	parSet, err := h.services.Chart.GetOneParSet(id)
+	if err != nil {
+		newParSetErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, getOneParSetResponse{
+		Data: parSet,
+	})
+}
+
+type getParSetRulesResponse struct {
+	Data gameServer.ParSetRules `json:"data"`
+}
+
+// getParSetRules returns the rules of the parameter set rendered to HTML in
+// the locale that matches the Accept-Language header.
+func (h *Handler) getParSetRules(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "id")
+		return
+	}
+
+	rules, err := h.services.Chart.GetParSetRules(id, c.GetHeader("Accept-Language"))
+	if err != nil {
+		newParSetErrorResponse(c, err)
+		return
+	}
+
+	if rules.Locale != "" {
+		c.Header("Content-Language", rules.Locale)
+	}
+	c.JSON(http.StatusOK, getParSetRulesResponse{
+		Data: rules,
+	})
+}
+
+// cloneParSet creates a new parameter set from an existing one, the request
+// body holds only the fields to override.
+func (h *Handler) cloneParSet(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "id")
+		return
+	}
+
+	parSet, err := h.services.Chart.GetOneParSet(id)
+	if err != nil {
+		newParSetErrorResponse(c, err)
+		return
+	}
+
+	input := parSet.ToCreateInput()
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	newId, err := h.services.Chart.CreateParSet(input)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]any{
+		"id": newId,
+	})
+}
+
+func (h *Handler) updateParSet(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "id")
+		return
+	}
+
+	var input gameServer.CreateParSetInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.services.Chart.UpdateParSet(id, input); err != nil {
+		newParSetErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+func (h *Handler) archiveParSet(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "id")
+		return
+	}
+
+	if err := h.services.Chart.ArchiveParSet(id); err != nil {
+		newParSetErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+func (h *Handler) deleteParSet(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "id")
+		return
+	}
+
+	if err := h.services.Chart.DeleteParSet(id); err != nil {
+		newParSetErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+type previewParSetResponse struct {
+	Data gameServer.ParSetPreview `json:"data"`
+}
+
+func (h *Handler) previewParSet(c *gin.Context) {
+	var input gameServer.PreviewParSetInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	preview, err := h.services.Chart.PreviewParSet(input)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, previewParSetResponse{
+		Data: preview,
+	})
+}
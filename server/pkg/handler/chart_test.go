@@ -2,6 +2,8 @@ package handler
 
 import (
 	"bytes"
+	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http/httptest"
@@ -11,10 +13,13 @@ import (
 	gameServer "example.com/gameHoldTheProcessServer"
 	"example.com/gameHoldTheProcessServer/pkg/service"
 	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestHandler_createChart(t *testing.T) {
+	scenarioId := 7
+
 	type mockBehavior func(r *service.MockChart, createChartInput gameServer.CreateChartInput)
 
 	tests := []struct {
@@ -39,6 +44,20 @@ func TestHandler_createChart(t *testing.T) {
 			expectedStatusCode:  200,
 			expectedRequestBody: `{"id":1}`,
 		},
+		{
+			name:      "scenario of another parameter set",
+			inputBody: `{"par_set_id": 1, "user_id": 1, "scenario_id": 7}`,
+			createChartInput: gameServer.CreateChartInput{
+				ParameterSetId: 1,
+				UserId:         1,
+				ScenarioId:     &scenarioId,
+			},
+			mockBehavior: func(r *service.MockChart, createChartInput gameServer.CreateChartInput) {
+				r.EXPECT().CreateChart(createChartInput).Return(0, gameServer.ErrScenarioMismatch)
+			},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"the scenario does not belong to the parameter set","code":"scenario_mismatch"}`,
+		},
 		{
 			name:               "incorrect user id - negative value",
 			inputBody:          `{"par_set_id": 1, "user_id": -1}`,
@@ -94,6 +113,20 @@ func TestHandler_createChart(t *testing.T) {
 			expectedStatusCode: 500,
 			isError:            true,
 		},
+		{
+			name:               "access denied - chart for another user",
+			inputBody:          `{"par_set_id": 1, "user_id": 2}`,
+			mockBehavior:       func(r *service.MockChart, createChartInput gameServer.CreateChartInput) {},
+			expectedStatusCode: 403,
+			isError:            true,
+		},
+		{
+			name:               "unknown end reason",
+			inputBody:          `{"par_set_id": 1, "user_id": 1, "end_reason": "timeout"}`,
+			mockBehavior:       func(r *service.MockChart, createChartInput gameServer.CreateChartInput) {},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -106,7 +139,7 @@ func TestHandler_createChart(t *testing.T) {
 
 			gin.SetMode(gin.TestMode)
 			r := gin.New()
-			r.POST("/", handler.createChart)
+			r.POST("/", setUserCtx(1, gameServer.RoleUser), handler.createChart)
 
 			w := httptest.NewRecorder()
 			req := httptest.NewRequest("POST", "/", bytes.NewBufferString(tt.inputBody))
@@ -148,7 +181,7 @@ func TestHandler_getOneChart(t *testing.T) {
 					nil)
 			},
 			expectedStatusCode:  200,
-			expectedRequestBody: `{"data":{"id":1,"parameter_set_id":1,"user_id":1,"created_at":"2023-10-01T00:00:00Z"}}`,
+			expectedRequestBody: `{"data":{"id":1,"parameter_set_id":1,"user_id":1,"created_at":"2023-10-01T00:00:00Z","is_training":false,"status":"","end_reason":null,"point_count":0,"duration":null,"scenario_id":null}}`,
 		},
 		{
 			name:               "incorrect parameter id - negative value",
@@ -171,6 +204,16 @@ func TestHandler_getOneChart(t *testing.T) {
 			expectedStatusCode: 400,
 			isError:            true,
 		},
+		{
+			name:    "chart not found",
+			paramId: "1",
+			mockBehavior: func(r *service.MockChart, id string) {
+				idInt, _ := strconv.Atoi(id)
+				r.EXPECT().GetOneChart(idInt).Return(gameServer.Chart{}, sql.ErrNoRows)
+			},
+			expectedStatusCode: 404,
+			isError:            true,
+		},
 		{
 			name:    "internal server error",
 			paramId: "1",
@@ -181,6 +224,22 @@ func TestHandler_getOneChart(t *testing.T) {
 			expectedStatusCode: 500,
 			isError:            true,
 		},
+		{
+			name:    "access denied - chart of another user",
+			paramId: "1",
+			mockBehavior: func(r *service.MockChart, id string) {
+				idInt, _ := strconv.Atoi(id)
+				r.EXPECT().GetOneChart(idInt).Return(gameServer.Chart{
+					Id:             1,
+					ParameterSetId: 1,
+					UserId:         2,
+					CreatedAt:      "2023-10-01T00:00:00Z",
+				},
+					nil)
+			},
+			expectedStatusCode: 403,
+			isError:            true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -193,7 +252,7 @@ func TestHandler_getOneChart(t *testing.T) {
 
 			gin.SetMode(gin.TestMode)
 			r := gin.New()
-			r.GET("/:id", handler.getOneChart)
+			r.GET("/:id", setUserCtx(1, gameServer.RoleUser), handler.getOneChart)
 
 			w := httptest.NewRecorder()
 			req := httptest.NewRequest("GET", fmt.Sprintf("/%s", tt.paramId), nil)
@@ -215,6 +274,7 @@ func TestHandler_getChartsPageCount(t *testing.T) {
 
 	tests := []struct {
 		name                    string
+		role                    string
 		inputBody               string
 		getChartsPageCountInput gameServer.GetChartsPageCountInput
 		mockBehavior            mockBehavior
@@ -224,6 +284,7 @@ func TestHandler_getChartsPageCount(t *testing.T) {
 	}{
 		{
 			name:      "ok",
+			role:      gameServer.RoleResearcher,
 			inputBody: `{"filter_tag": "f", "filter_value": "f"}`,
 			getChartsPageCountInput: gameServer.GetChartsPageCountInput{
 				FilterTag:   "f",
@@ -237,6 +298,7 @@ func TestHandler_getChartsPageCount(t *testing.T) {
 		},
 		{
 			name:      "internal server error",
+			role:      gameServer.RoleResearcher,
 			inputBody: `{"filter_tag": "f", "filter_value": "f"}`,
 			getChartsPageCountInput: gameServer.GetChartsPageCountInput{
 				FilterTag:   "f",
@@ -250,6 +312,7 @@ func TestHandler_getChartsPageCount(t *testing.T) {
 		},
 		{
 			name:      "empty filter tag",
+			role:      gameServer.RoleResearcher,
 			inputBody: `{"filter_tag": "", "filter_value": "f"}`,
 			getChartsPageCountInput: gameServer.GetChartsPageCountInput{
 				FilterTag:   "",
@@ -263,6 +326,7 @@ func TestHandler_getChartsPageCount(t *testing.T) {
 		},
 		{
 			name:      "empty filter value",
+			role:      gameServer.RoleResearcher,
 			inputBody: `{"filter_tag": "f", "filter_value": ""}`,
 			getChartsPageCountInput: gameServer.GetChartsPageCountInput{
 				FilterTag:   "f",
@@ -276,6 +340,7 @@ func TestHandler_getChartsPageCount(t *testing.T) {
 		},
 		{
 			name:      "empty filter tag and value",
+			role:      gameServer.RoleResearcher,
 			inputBody: `{"filter_tag": "", "filter_value": ""}`,
 			getChartsPageCountInput: gameServer.GetChartsPageCountInput{
 				FilterTag:   "",
@@ -289,6 +354,7 @@ func TestHandler_getChartsPageCount(t *testing.T) {
 		},
 		{
 			name:               "incorrect filter tag - wrong type",
+			role:               gameServer.RoleResearcher,
 			inputBody:          `{"filter_tag": 1, "filter_value": "f"}`,
 			mockBehavior:       func(r *service.MockChart, getChartsPageCountInput gameServer.GetChartsPageCountInput) {},
 			expectedStatusCode: 400,
@@ -296,11 +362,50 @@ func TestHandler_getChartsPageCount(t *testing.T) {
 		},
 		{
 			name:               "incorrect filter value - wrong type",
+			role:               gameServer.RoleResearcher,
 			inputBody:          `{"filter_tag": "f", "filter_value": 1}`,
 			mockBehavior:       func(r *service.MockChart, getChartsPageCountInput gameServer.GetChartsPageCountInput) {},
 			expectedStatusCode: 400,
 			isError:            true,
 		},
+		{
+			name:      "participant - own charts",
+			role:      gameServer.RoleUser,
+			inputBody: `{"filter_tag": "user_id", "filter_value": "1"}`,
+			getChartsPageCountInput: gameServer.GetChartsPageCountInput{
+				FilterTag:   "user_id",
+				FilterValue: "1",
+			},
+			mockBehavior: func(r *service.MockChart, getChartsPageCountInput gameServer.GetChartsPageCountInput) {
+				r.EXPECT().GetChartsPageCount(getChartsPageCountInput).Return(1, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"pageCount":1}`,
+		},
+		{
+			name:                "participant - charts of another user",
+			role:                gameServer.RoleUser,
+			inputBody:           `{"filter_tag": "user_id", "filter_value": "2"}`,
+			mockBehavior:        func(r *service.MockChart, getChartsPageCountInput gameServer.GetChartsPageCountInput) {},
+			expectedStatusCode:  403,
+			expectedRequestBody: `{"error":"access to the resource is denied","code":"access_denied"}`,
+		},
+		{
+			name:                "participant - all charts",
+			role:                gameServer.RoleUser,
+			inputBody:           `{"filter_tag": "", "filter_value": ""}`,
+			mockBehavior:        func(r *service.MockChart, getChartsPageCountInput gameServer.GetChartsPageCountInput) {},
+			expectedStatusCode:  403,
+			expectedRequestBody: `{"error":"not enough rights","code":"not_enough_rights"}`,
+		},
+		{
+			name:                "incorrect user id filter",
+			role:                gameServer.RoleUser,
+			inputBody:           `{"filter_tag": "user_id", "filter_value": "1 OR 1=1"}`,
+			mockBehavior:        func(r *service.MockChart, getChartsPageCountInput gameServer.GetChartsPageCountInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"invalid parameter filter_value","code":"invalid_parameter"}`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -313,7 +418,7 @@ func TestHandler_getChartsPageCount(t *testing.T) {
 
 			gin.SetMode(gin.TestMode)
 			r := gin.New()
-			r.POST("/pageCount", handler.getChartsPageCount)
+			r.POST("/pageCount", setUserCtx(1, tt.role), handler.getChartsPageCount)
 
 			w := httptest.NewRecorder()
 			req := httptest.NewRequest("POST", "/pageCount", bytes.NewBufferString(tt.inputBody))
@@ -335,6 +440,7 @@ func TestHandler_getChartsCount(t *testing.T) {
 
 	tests := []struct {
 		name                string
+		role                string
 		inputBody           string
 		getChartsCountInput gameServer.GetChartsCountInput
 		mockBehavior        mockBehavior
@@ -344,6 +450,7 @@ func TestHandler_getChartsCount(t *testing.T) {
 	}{
 		{
 			name:      "ok",
+			role:      gameServer.RoleResearcher,
 			inputBody: `{"filter_tag": "f", "filter_value": "f"}`,
 			getChartsCountInput: gameServer.GetChartsCountInput{
 				FilterTag:   "f",
@@ -357,6 +464,7 @@ func TestHandler_getChartsCount(t *testing.T) {
 		},
 		{
 			name:      "internal server error",
+			role:      gameServer.RoleResearcher,
 			inputBody: `{"filter_tag": "f", "filter_value": "f"}`,
 			getChartsCountInput: gameServer.GetChartsCountInput{
 				FilterTag:   "f",
@@ -370,6 +478,7 @@ func TestHandler_getChartsCount(t *testing.T) {
 		},
 		{
 			name:      "empty filter tag",
+			role:      gameServer.RoleResearcher,
 			inputBody: `{"filter_tag": "", "filter_value": "f"}`,
 			getChartsCountInput: gameServer.GetChartsCountInput{
 				FilterTag:   "",
@@ -383,6 +492,7 @@ func TestHandler_getChartsCount(t *testing.T) {
 		},
 		{
 			name:      "empty filter value",
+			role:      gameServer.RoleResearcher,
 			inputBody: `{"filter_tag": "f", "filter_value": ""}`,
 			getChartsCountInput: gameServer.GetChartsCountInput{
 				FilterTag:   "f",
@@ -396,6 +506,7 @@ func TestHandler_getChartsCount(t *testing.T) {
 		},
 		{
 			name:      "empty filter tag and value",
+			role:      gameServer.RoleResearcher,
 			inputBody: `{"filter_tag": "", "filter_value": ""}`,
 			getChartsCountInput: gameServer.GetChartsCountInput{
 				FilterTag:   "",
@@ -409,6 +520,7 @@ func TestHandler_getChartsCount(t *testing.T) {
 		},
 		{
 			name:               "incorrect filter tag - wrong type",
+			role:               gameServer.RoleResearcher,
 			inputBody:          `{"filter_tag": 1, "filter_value": "f"}`,
 			mockBehavior:       func(r *service.MockChart, getChartsCountInput gameServer.GetChartsCountInput) {},
 			expectedStatusCode: 400,
@@ -416,11 +528,42 @@ func TestHandler_getChartsCount(t *testing.T) {
 		},
 		{
 			name:               "incorrect filter value - wrong type",
+			role:               gameServer.RoleResearcher,
 			inputBody:          `{"filter_tag": "f", "filter_value": 1}`,
 			mockBehavior:       func(r *service.MockChart, getChartsCountInput gameServer.GetChartsCountInput) {},
 			expectedStatusCode: 400,
 			isError:            true,
 		},
+		{
+			name:      "participant - own charts",
+			role:      gameServer.RoleUser,
+			inputBody: `{"filter_tag": "user_id", "filter_value": "1"}`,
+			getChartsCountInput: gameServer.GetChartsCountInput{
+				FilterTag:   "user_id",
+				FilterValue: "1",
+			},
+			mockBehavior: func(r *service.MockChart, getChartsCountInput gameServer.GetChartsCountInput) {
+				r.EXPECT().GetChartsCount(getChartsCountInput).Return(1, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"count":1}`,
+		},
+		{
+			name:                "participant - charts of another user",
+			role:                gameServer.RoleUser,
+			inputBody:           `{"filter_tag": "user_id", "filter_value": "2"}`,
+			mockBehavior:        func(r *service.MockChart, getChartsCountInput gameServer.GetChartsCountInput) {},
+			expectedStatusCode:  403,
+			expectedRequestBody: `{"error":"access to the resource is denied","code":"access_denied"}`,
+		},
+		{
+			name:                "participant - charts by login",
+			role:                gameServer.RoleUser,
+			inputBody:           `{"filter_tag": "user_login", "filter_value": "admin"}`,
+			mockBehavior:        func(r *service.MockChart, getChartsCountInput gameServer.GetChartsCountInput) {},
+			expectedStatusCode:  403,
+			expectedRequestBody: `{"error":"not enough rights","code":"not_enough_rights"}`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -433,7 +576,7 @@ func TestHandler_getChartsCount(t *testing.T) {
 
 			gin.SetMode(gin.TestMode)
 			r := gin.New()
-			r.POST("/count", handler.getChartsCount)
+			r.POST("/count", setUserCtx(1, tt.role), handler.getChartsCount)
 
 			w := httptest.NewRecorder()
 			req := httptest.NewRequest("POST", "/count", bytes.NewBufferString(tt.inputBody))
@@ -455,6 +598,7 @@ func TestHandler_getAllCharts(t *testing.T) {
 
 	tests := []struct {
 		name                string
+		role                string
 		inputBody           string
 		getAllChartsInput   gameServer.GetAllChartsInput
 		mockBehavior        mockBehavior
@@ -464,6 +608,7 @@ func TestHandler_getAllCharts(t *testing.T) {
 	}{
 		{
 			name:      "ok",
+			role:      gameServer.RoleResearcher,
 			inputBody: `{"filter_tag": "f", "filter_value": "f", "current_page": 1}`,
 			getAllChartsInput: gameServer.GetAllChartsInput{
 				FilterTag:   "f",
@@ -481,10 +626,11 @@ func TestHandler_getAllCharts(t *testing.T) {
 				}, nil)
 			},
 			expectedStatusCode:  200,
-			expectedRequestBody: `{"data":[{"id":1,"parameter_set_id":1,"user_id":1,"created_at":"2023-10-01T00:00:00Z"}]}`,
+			expectedRequestBody: `{"data":[{"id":1,"parameter_set_id":1,"user_id":1,"created_at":"2023-10-01T00:00:00Z","is_training":false,"status":"","end_reason":null,"point_count":0,"duration":null,"scenario_id":null}]}`,
 		},
 		{
 			name:      "internal server error",
+			role:      gameServer.RoleResearcher,
 			inputBody: `{"filter_tag": "f", "filter_value": "f", "current_page": 1}`,
 			getAllChartsInput: gameServer.GetAllChartsInput{
 				FilterTag:   "f",
@@ -499,6 +645,7 @@ func TestHandler_getAllCharts(t *testing.T) {
 		},
 		{
 			name:      "empty filter tag",
+			role:      gameServer.RoleResearcher,
 			inputBody: `{"filter_tag": "", "filter_value": "f", "current_page": 1}`,
 			getAllChartsInput: gameServer.GetAllChartsInput{
 				FilterTag:   "",
@@ -516,10 +663,11 @@ func TestHandler_getAllCharts(t *testing.T) {
 				}, nil)
 			},
 			expectedStatusCode:  200,
-			expectedRequestBody: `{"data":[{"id":1,"parameter_set_id":1,"user_id":1,"created_at":"2023-10-01T00:00:00Z"}]}`,
+			expectedRequestBody: `{"data":[{"id":1,"parameter_set_id":1,"user_id":1,"created_at":"2023-10-01T00:00:00Z","is_training":false,"status":"","end_reason":null,"point_count":0,"duration":null,"scenario_id":null}]}`,
 		},
 		{
 			name:      "empty filter value",
+			role:      gameServer.RoleResearcher,
 			inputBody: `{"filter_tag": "f", "filter_value": "", "current_page": 1}`,
 			getAllChartsInput: gameServer.GetAllChartsInput{
 				FilterTag:   "f",
@@ -537,10 +685,11 @@ func TestHandler_getAllCharts(t *testing.T) {
 				}, nil)
 			},
 			expectedStatusCode:  200,
-			expectedRequestBody: `{"data":[{"id":1,"parameter_set_id":1,"user_id":1,"created_at":"2023-10-01T00:00:00Z"}]}`,
+			expectedRequestBody: `{"data":[{"id":1,"parameter_set_id":1,"user_id":1,"created_at":"2023-10-01T00:00:00Z","is_training":false,"status":"","end_reason":null,"point_count":0,"duration":null,"scenario_id":null}]}`,
 		},
 		{
 			name:      "empty filter tag and value",
+			role:      gameServer.RoleResearcher,
 			inputBody: `{"filter_tag": "", "filter_value": "", "current_page": 1}`,
 			getAllChartsInput: gameServer.GetAllChartsInput{
 				FilterTag:   "",
@@ -558,10 +707,11 @@ func TestHandler_getAllCharts(t *testing.T) {
 				}, nil)
 			},
 			expectedStatusCode:  200,
-			expectedRequestBody: `{"data":[{"id":1,"parameter_set_id":1,"user_id":1,"created_at":"2023-10-01T00:00:00Z"}]}`,
+			expectedRequestBody: `{"data":[{"id":1,"parameter_set_id":1,"user_id":1,"created_at":"2023-10-01T00:00:00Z","is_training":false,"status":"","end_reason":null,"point_count":0,"duration":null,"scenario_id":null}]}`,
 		},
 		{
 			name:               "incorrect filter tag - wrong type",
+			role:               gameServer.RoleResearcher,
 			inputBody:          `{"filter_tag": 1, "filter_value": "f", "current_page": 1}`,
 			mockBehavior:       func(r *service.MockChart, getAllChartsInput gameServer.GetAllChartsInput) {},
 			expectedStatusCode: 400,
@@ -569,6 +719,7 @@ func TestHandler_getAllCharts(t *testing.T) {
 		},
 		{
 			name:               "incorrect filter value - wrong type",
+			role:               gameServer.RoleResearcher,
 			inputBody:          `{"filter_tag": "f", "filter_value": 1, "current_page": 1}`,
 			mockBehavior:       func(r *service.MockChart, getAllChartsInput gameServer.GetAllChartsInput) {},
 			expectedStatusCode: 400,
@@ -576,6 +727,7 @@ func TestHandler_getAllCharts(t *testing.T) {
 		},
 		{
 			name:               "incorrect current page value - wrong type",
+			role:               gameServer.RoleResearcher,
 			inputBody:          `{"filter_tag": "f", "filter_value": "f", "current_page": "1"}`,
 			mockBehavior:       func(r *service.MockChart, getAllChartsInput gameServer.GetAllChartsInput) {},
 			expectedStatusCode: 400,
@@ -583,6 +735,7 @@ func TestHandler_getAllCharts(t *testing.T) {
 		},
 		{
 			name:               "incorrect current page value - zero value",
+			role:               gameServer.RoleResearcher,
 			inputBody:          `{"filter_tag": "f", "filter_value": "f", "current_page": 0}`,
 			mockBehavior:       func(r *service.MockChart, getAllChartsInput gameServer.GetAllChartsInput) {},
 			expectedStatusCode: 400,
@@ -590,11 +743,43 @@ func TestHandler_getAllCharts(t *testing.T) {
 		},
 		{
 			name:               "incorrect current page value - negative value",
+			role:               gameServer.RoleResearcher,
 			inputBody:          `{"filter_tag": "f", "filter_value": "f", "current_page": -1}`,
 			mockBehavior:       func(r *service.MockChart, getAllChartsInput gameServer.GetAllChartsInput) {},
 			expectedStatusCode: 400,
 			isError:            true,
 		},
+		{
+			name:      "participant - own charts",
+			role:      gameServer.RoleUser,
+			inputBody: `{"filter_tag": "user_id", "filter_value": "1", "current_page": 1}`,
+			getAllChartsInput: gameServer.GetAllChartsInput{
+				FilterTag:   "user_id",
+				FilterValue: "1",
+				CurrentPage: 1,
+			},
+			mockBehavior: func(r *service.MockChart, getAllChartsInput gameServer.GetAllChartsInput) {
+				r.EXPECT().GetAllCharts(getAllChartsInput).Return([]gameServer.Chart{}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"data":[]}`,
+		},
+		{
+			name:                "participant - charts of another user",
+			role:                gameServer.RoleUser,
+			inputBody:           `{"filter_tag": "user_id", "filter_value": "2", "current_page": 1}`,
+			mockBehavior:        func(r *service.MockChart, getAllChartsInput gameServer.GetAllChartsInput) {},
+			expectedStatusCode:  403,
+			expectedRequestBody: `{"error":"access to the resource is denied","code":"access_denied"}`,
+		},
+		{
+			name:                "participant - chart by id",
+			role:                gameServer.RoleUser,
+			inputBody:           `{"filter_tag": "chart_id", "filter_value": "5", "current_page": 1}`,
+			mockBehavior:        func(r *service.MockChart, getAllChartsInput gameServer.GetAllChartsInput) {},
+			expectedStatusCode:  403,
+			expectedRequestBody: `{"error":"not enough rights","code":"not_enough_rights"}`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -607,7 +792,7 @@ func TestHandler_getAllCharts(t *testing.T) {
 
 			gin.SetMode(gin.TestMode)
 			r := gin.New()
-			r.POST("/charts", handler.getAllCharts)
+			r.POST("/charts", setUserCtx(1, tt.role), handler.getAllCharts)
 
 			w := httptest.NewRecorder()
 			req := httptest.NewRequest("POST", "/charts", bytes.NewBufferString(tt.inputBody))
@@ -704,3 +889,1263 @@ func TestHandler_deleteChart(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_appendChartPoints(t *testing.T) {
+	type mockBehavior func(r *service.MockChart, id int, input gameServer.AppendChartPointsInput)
+
+	points := gameServer.AppendChartPointsInput{
+		Points: []gameServer.Point{
+			{X: 1, Y: 0.5, Score: 50, ChartId: 1},
+			{X: 2, Y: 0.6, Score: 100, IsStop: true, ChartId: 1},
+		},
+	}
+
+	tests := []struct {
+		name                string
+		paramId             string
+		inputBody           string
+		input               gameServer.AppendChartPointsInput
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+		isError             bool
+	}{
+		{
+			name:      "ok",
+			paramId:   "1",
+			inputBody: `{"points": [{"x": 1, "y": 0.5, "score": 50}, {"x": 2, "y": 0.6, "score": 100, "is_stop": true}]}`,
+			input:     points,
+			mockBehavior: func(r *service.MockChart, id int, input gameServer.AppendChartPointsInput) {
+				r.EXPECT().GetOneChart(id).Return(gameServer.Chart{Id: id, UserId: 1}, nil)
+				r.EXPECT().AppendPoints(id, input).Return([]int{10, 11}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"ids":[10,11]}`,
+		},
+		{
+			name:               "empty points",
+			paramId:            "1",
+			inputBody:          `{"points": []}`,
+			mockBehavior:       func(r *service.MockChart, id int, input gameServer.AppendChartPointsInput) {},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
+		{
+			name:               "incorrect point",
+			paramId:            "1",
+			inputBody:          `{"points": [{"x": -1, "y": 0.5, "score": 50}]}`,
+			mockBehavior:       func(r *service.MockChart, id int, input gameServer.AppendChartPointsInput) {},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
+		{
+			name:               "incorrect id",
+			paramId:            "0",
+			inputBody:          `{"points": [{"x": 1, "y": 0.5, "score": 50}]}`,
+			mockBehavior:       func(r *service.MockChart, id int, input gameServer.AppendChartPointsInput) {},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
+		{
+			name:      "chart is already closed",
+			paramId:   "1",
+			inputBody: `{"points": [{"x": 1, "y": 0.5, "score": 50}, {"x": 2, "y": 0.6, "score": 100, "is_stop": true}]}`,
+			input:     points,
+			mockBehavior: func(r *service.MockChart, id int, input gameServer.AppendChartPointsInput) {
+				r.EXPECT().GetOneChart(id).Return(gameServer.Chart{Id: id, UserId: 1}, nil)
+				r.EXPECT().AppendPoints(id, input).Return(nil, gameServer.ErrChartNotInProgress)
+			},
+			expectedStatusCode: 409,
+			isError:            true,
+		},
+		{
+			name:      "access denied - chart of another user",
+			paramId:   "1",
+			inputBody: `{"points": [{"x": 1, "y": 0.5, "score": 50}]}`,
+			mockBehavior: func(r *service.MockChart, id int, input gameServer.AppendChartPointsInput) {
+				r.EXPECT().GetOneChart(id).Return(gameServer.Chart{Id: id, UserId: 2}, nil)
+			},
+			expectedStatusCode: 403,
+			isError:            true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chartMock := service.NewMockChart(t)
+			id, _ := strconv.Atoi(tt.paramId)
+			tt.mockBehavior(chartMock, id, tt.input)
+
+			services := &service.Service{Chart: chartMock}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/session/:id/points", setUserCtx(1, gameServer.RoleUser), handler.appendChartPoints)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", fmt.Sprintf("/session/%s/points", tt.paramId), bytes.NewBufferString(tt.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			if tt.isError {
+				assert.Contains(t, w.Body.String(), "error")
+			} else {
+				assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_closeChart(t *testing.T) {
+	type mockBehavior func(r *service.MockChart, id int, input gameServer.CloseChartInput)
+
+	tests := []struct {
+		name                string
+		paramId             string
+		inputBody           string
+		input               gameServer.CloseChartInput
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+		isError             bool
+	}{
+		{
+			name:      "ok",
+			paramId:   "1",
+			inputBody: `{"end_reason": "crash"}`,
+			input:     gameServer.CloseChartInput{EndReason: gameServer.ChartEndReasonCrash},
+			mockBehavior: func(r *service.MockChart, id int, input gameServer.CloseChartInput) {
+				r.EXPECT().GetOneChart(id).Return(gameServer.Chart{Id: id, UserId: 1}, nil)
+				r.EXPECT().CloseChart(id, input).Return(nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"status":"ok"}`,
+		},
+		{
+			name:               "unknown end reason",
+			paramId:            "1",
+			inputBody:          `{"end_reason": "timeout"}`,
+			mockBehavior:       func(r *service.MockChart, id int, input gameServer.CloseChartInput) {},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
+		{
+			name:               "empty body",
+			paramId:            "1",
+			inputBody:          ``,
+			mockBehavior:       func(r *service.MockChart, id int, input gameServer.CloseChartInput) {},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
+		{
+			name:      "chart is already closed",
+			paramId:   "1",
+			inputBody: `{"end_reason": "stop"}`,
+			input:     gameServer.CloseChartInput{EndReason: gameServer.ChartEndReasonStop},
+			mockBehavior: func(r *service.MockChart, id int, input gameServer.CloseChartInput) {
+				r.EXPECT().GetOneChart(id).Return(gameServer.Chart{Id: id, UserId: 1}, nil)
+				r.EXPECT().CloseChart(id, input).Return(gameServer.ErrChartNotInProgress)
+			},
+			expectedStatusCode: 409,
+			isError:            true,
+		},
+		{
+			name:      "internal server error",
+			paramId:   "1",
+			inputBody: `{"end_reason": "stop"}`,
+			input:     gameServer.CloseChartInput{EndReason: gameServer.ChartEndReasonStop},
+			mockBehavior: func(r *service.MockChart, id int, input gameServer.CloseChartInput) {
+				r.EXPECT().GetOneChart(id).Return(gameServer.Chart{Id: id, UserId: 1}, nil)
+				r.EXPECT().CloseChart(id, input).Return(errors.New(""))
+			},
+			expectedStatusCode: 500,
+			isError:            true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chartMock := service.NewMockChart(t)
+			id, _ := strconv.Atoi(tt.paramId)
+			tt.mockBehavior(chartMock, id, tt.input)
+
+			services := &service.Service{Chart: chartMock}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/session/:id/close", setUserCtx(1, gameServer.RoleUser), handler.closeChart)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", fmt.Sprintf("/session/%s/close", tt.paramId), bytes.NewBufferString(tt.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			if tt.isError {
+				assert.Contains(t, w.Body.String(), "error")
+			} else {
+				assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_getCrashHint(t *testing.T) {
+	crashProbability := 0.25
+
+	type mockBehavior func(r *service.MockHint, rc *service.MockChart, id int, input gameServer.GetHintInput)
+
+	tests := []struct {
+		name                string
+		paramId             string
+		inputBody           string
+		input               gameServer.GetHintInput
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+		isError             bool
+	}{
+		{
+			name:      "ok",
+			paramId:   "1",
+			inputBody: `{"x": 10, "y": 0.8}`,
+			input:     gameServer.GetHintInput{Type: gameServer.HintTypeCrashProbability, X: 10, Y: 0.8},
+			mockBehavior: func(r *service.MockHint, rc *service.MockChart, id int, input gameServer.GetHintInput) {
+				rc.EXPECT().GetOneChart(id).Return(gameServer.Chart{Id: id, UserId: 1}, nil)
+				r.EXPECT().GetCrashHint(id, input).Return(gameServer.Hint{
+					Id:               5,
+					ChartId:          id,
+					Type:             gameServer.HintTypeCrashProbability,
+					Disclosure:       gameServer.HintDisclosureExactProbability,
+					X:                10,
+					Y:                0.8,
+					CrashProbability: &crashProbability,
+					Cost:             250,
+					CreatedAt:        "2023-10-01T00:00:00Z",
+				}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"data":{"id":5,"chart_id":1,"type":"crash_probability","disclosure":"exact_probability","x":10,"y":0.8,"crash_probability":0.25,"risk_level":null,"cost":250,"created_at":"2023-10-01T00:00:00Z"}}`,
+		},
+		{
+			name:               "incorrect x - negative value",
+			paramId:            "1",
+			inputBody:          `{"x": -1, "y": 0.8}`,
+			mockBehavior:       func(r *service.MockHint, rc *service.MockChart, id int, input gameServer.GetHintInput) {},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
+		{
+			name:               "incorrect id",
+			paramId:            "abc",
+			inputBody:          `{"x": 10, "y": 0.8}`,
+			mockBehavior:       func(r *service.MockHint, rc *service.MockChart, id int, input gameServer.GetHintInput) {},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
+		{
+			name:      "access denied - chart of another user",
+			paramId:   "1",
+			inputBody: `{"x": 10, "y": 0.8}`,
+			mockBehavior: func(r *service.MockHint, rc *service.MockChart, id int, input gameServer.GetHintInput) {
+				rc.EXPECT().GetOneChart(id).Return(gameServer.Chart{Id: id, UserId: 2}, nil)
+			},
+			expectedStatusCode: 403,
+			isError:            true,
+		},
+		{
+			name:      "internal server error",
+			paramId:   "1",
+			inputBody: `{"x": 10, "y": 0.8}`,
+			input:     gameServer.GetHintInput{Type: gameServer.HintTypeCrashProbability, X: 10, Y: 0.8},
+			mockBehavior: func(r *service.MockHint, rc *service.MockChart, id int, input gameServer.GetHintInput) {
+				rc.EXPECT().GetOneChart(id).Return(gameServer.Chart{Id: id, UserId: 1}, nil)
+				r.EXPECT().GetCrashHint(id, input).Return(gameServer.Hint{}, errors.New(""))
+			},
+			expectedStatusCode: 500,
+			isError:            true,
+		},
+		{
+			name:      "hint type not available in parameter set",
+			paramId:   "1",
+			inputBody: `{"type": "all_sessions", "x": 10, "y": 0.8}`,
+			input:     gameServer.GetHintInput{Type: gameServer.HintTypeAllSessions, X: 10, Y: 0.8},
+			mockBehavior: func(r *service.MockHint, rc *service.MockChart, id int, input gameServer.GetHintInput) {
+				rc.EXPECT().GetOneChart(id).Return(gameServer.Chart{Id: id, UserId: 1}, nil)
+				r.EXPECT().GetCrashHint(id, input).Return(gameServer.Hint{}, gameServer.ErrHintNotAvailable)
+			},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
+		{
+			name:               "unknown hint type",
+			paramId:            "1",
+			inputBody:          `{"type": "oracle", "x": 10, "y": 0.8}`,
+			mockBehavior:       func(r *service.MockHint, rc *service.MockChart, id int, input gameServer.GetHintInput) {},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hintMock := service.NewMockHint(t)
+			chartMock := service.NewMockChart(t)
+			id, _ := strconv.Atoi(tt.paramId)
+			tt.mockBehavior(hintMock, chartMock, id, tt.input)
+
+			services := &service.Service{Hint: hintMock, Chart: chartMock}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/:id/hint", setUserCtx(1, gameServer.RoleUser), handler.getCrashHint)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", fmt.Sprintf("/%s/hint", tt.paramId), bytes.NewBufferString(tt.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			if tt.isError {
+				assert.Contains(t, w.Body.String(), "error")
+			} else {
+				assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_getAdvice(t *testing.T) {
+	confidence := 0.7
+
+	type mockBehavior func(r *service.MockAdvisor, rc *service.MockChart, id int, input gameServer.GetAdviceInput)
+
+	tests := []struct {
+		name                string
+		paramId             string
+		inputBody           string
+		input               gameServer.GetAdviceInput
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+		isError             bool
+	}{
+		{
+			name:      "ok",
+			paramId:   "1",
+			inputBody: `{"x": 10, "y": 0.8, "next_y": 1.2}`,
+			input:     gameServer.GetAdviceInput{X: 10, Y: 0.8, NextY: 1.2},
+			mockBehavior: func(r *service.MockAdvisor, rc *service.MockChart, id int, input gameServer.GetAdviceInput) {
+				rc.EXPECT().GetOneChart(id).Return(gameServer.Chart{Id: id, UserId: 1}, nil)
+				r.EXPECT().GetAdvice(id, input).Return(gameServer.Advice{X: 10, Signal: true, Confidence: &confidence, IsDanger: true}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"data":{"x":10,"signal":true,"confidence":0.7}}`,
+		},
+		{
+			name:      "ok - confidence hidden",
+			paramId:   "1",
+			inputBody: `{"x": 10, "y": 0.8}`,
+			input:     gameServer.GetAdviceInput{X: 10, Y: 0.8},
+			mockBehavior: func(r *service.MockAdvisor, rc *service.MockChart, id int, input gameServer.GetAdviceInput) {
+				rc.EXPECT().GetOneChart(id).Return(gameServer.Chart{Id: id, UserId: 1}, nil)
+				r.EXPECT().GetAdvice(id, input).Return(gameServer.Advice{X: 10}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"data":{"x":10,"signal":false,"confidence":null}}`,
+		},
+		{
+			name:               "incorrect x - negative value",
+			paramId:            "1",
+			inputBody:          `{"x": -1, "y": 0.8}`,
+			mockBehavior:       func(r *service.MockAdvisor, rc *service.MockChart, id int, input gameServer.GetAdviceInput) {},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
+		{
+			name:               "incorrect id",
+			paramId:            "abc",
+			inputBody:          `{"x": 10, "y": 0.8}`,
+			mockBehavior:       func(r *service.MockAdvisor, rc *service.MockChart, id int, input gameServer.GetAdviceInput) {},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
+		{
+			name:      "access denied - chart of another user",
+			paramId:   "1",
+			inputBody: `{"x": 10, "y": 0.8}`,
+			mockBehavior: func(r *service.MockAdvisor, rc *service.MockChart, id int, input gameServer.GetAdviceInput) {
+				rc.EXPECT().GetOneChart(id).Return(gameServer.Chart{Id: id, UserId: 2}, nil)
+			},
+			expectedStatusCode: 403,
+			isError:            true,
+		},
+		{
+			name:      "internal server error",
+			paramId:   "1",
+			inputBody: `{"x": 10, "y": 0.8}`,
+			input:     gameServer.GetAdviceInput{X: 10, Y: 0.8},
+			mockBehavior: func(r *service.MockAdvisor, rc *service.MockChart, id int, input gameServer.GetAdviceInput) {
+				rc.EXPECT().GetOneChart(id).Return(gameServer.Chart{Id: id, UserId: 1}, nil)
+				r.EXPECT().GetAdvice(id, input).Return(gameServer.Advice{}, errors.New(""))
+			},
+			expectedStatusCode: 500,
+			isError:            true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			advisorMock := service.NewMockAdvisor(t)
+			chartMock := service.NewMockChart(t)
+			id, _ := strconv.Atoi(tt.paramId)
+			tt.mockBehavior(advisorMock, chartMock, id, tt.input)
+
+			services := &service.Service{Advisor: advisorMock, Chart: chartMock}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/:id/advice", setUserCtx(1, gameServer.RoleUser), handler.getAdvice)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", fmt.Sprintf("/%s/advice", tt.paramId), bytes.NewBufferString(tt.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			if tt.isError {
+				assert.Contains(t, w.Body.String(), "error")
+			} else {
+				assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_getAllParSets(t *testing.T) {
+	type mockBehavior func(r *service.MockChart, input gameServer.GetAllParSetsInput)
+
+	parentId := 1
+
+	tests := []struct {
+		name                string
+		inputBody           string
+		input               gameServer.GetAllParSetsInput
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+		isError             bool
+	}{
+		{
+			name:      "ok",
+			inputBody: `{"current_page": 1}`,
+			input:     gameServer.GetAllParSetsInput{CurrentPage: 1},
+			mockBehavior: func(r *service.MockChart, input gameServer.GetAllParSetsInput) {
+				r.EXPECT().GetAllParSets(input).Return([]gameServer.ParameterSetWithUsage{
+					{
+						ParameterSet: gameServer.ParameterSet{
+							Id:        2,
+							ParentId:  &parentId,
+							Name:      "v2",
+							CreatedAt: "2023-10-01T00:00:00Z",
+						},
+						Version:    2,
+						Lineage:    []byte(`[1]`),
+						ChartCount: 10,
+						GroupCount: 1,
+						UserCount:  5,
+					},
+				}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"data":[{"id":2,"parent_id":1,"name":"v2","description":"","a":0,"b":0,"noise_mean":0,"noise_stdev":0,"false_warning_prob":0,"missing_danger_prob":0,"scoring_config":null,"hint_cost":0,"hint_config":null,"advisor_config":null,"false_alarm_threshold":0,"rules":null,"created_at":"2023-10-01T00:00:00Z","archived_at":null,"version":2,"lineage":[1],"chart_count":10,"group_count":1,"user_count":5}]}`,
+		},
+		{
+			name:               "incorrect current page",
+			inputBody:          `{"current_page": 0}`,
+			mockBehavior:       func(r *service.MockChart, input gameServer.GetAllParSetsInput) {},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
+		{
+			name:      "internal server error",
+			inputBody: `{"current_page": -1}`,
+			input:     gameServer.GetAllParSetsInput{CurrentPage: -1},
+			mockBehavior: func(r *service.MockChart, input gameServer.GetAllParSetsInput) {
+				r.EXPECT().GetAllParSets(input).Return(nil, errors.New(""))
+			},
+			expectedStatusCode: 500,
+			isError:            true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chartMock := service.NewMockChart(t)
+			tt.mockBehavior(chartMock, tt.input)
+
+			services := &service.Service{Chart: chartMock}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/parSets", handler.getAllParSets)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/parSets", bytes.NewBufferString(tt.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			if tt.isError {
+				assert.Contains(t, w.Body.String(), "error")
+			} else {
+				assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_createParSetVersion(t *testing.T) {
+	type mockBehavior func(r *service.MockChart, parentId int, input gameServer.CreateParSetInput)
+
+	withDefaults := func(input gameServer.CreateParSetInput) gameServer.CreateParSetInput {
+		input.ApplyDefaults()
+		return input
+	}
+
+	tests := []struct {
+		name                string
+		paramId             string
+		inputBody           string
+		input               gameServer.CreateParSetInput
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+		isError             bool
+	}{
+		{
+			name:      "ok",
+			paramId:   "1",
+			inputBody: `{"name": "v2", "description": "fixed rules typo", "a": 0.6, "b": 0.2, "noise_mean": 0.18, "noise_stdev": 0.03}`,
+			input: withDefaults(gameServer.CreateParSetInput{
+				Name:        "v2",
+				Description: "fixed rules typo",
+				A:           0.6,
+				B:           0.2,
+				NoiseMean:   0.18,
+				NoiseStdev:  0.03,
+			}),
+			mockBehavior: func(r *service.MockChart, parentId int, input gameServer.CreateParSetInput) {
+				r.EXPECT().CreateParSetVersion(parentId, input).Return(2, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"id":2}`,
+		},
+		{
+			name:               "incorrect a - negative value",
+			paramId:            "1",
+			inputBody:          `{"a": -0.6, "b": 0.2, "noise_mean": 0.18, "noise_stdev": 0.03}`,
+			mockBehavior:       func(r *service.MockChart, parentId int, input gameServer.CreateParSetInput) {},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
+		{
+			name:               "incorrect id",
+			paramId:            "abc",
+			inputBody:          `{"a": 0.6, "b": 0.2, "noise_mean": 0.18, "noise_stdev": 0.03}`,
+			mockBehavior:       func(r *service.MockChart, parentId int, input gameServer.CreateParSetInput) {},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
+		{
+			name:      "internal server error",
+			paramId:   "1",
+			inputBody: `{"a": 0.6, "b": 0.2, "noise_mean": 0.18, "noise_stdev": 0.03}`,
+			input: withDefaults(gameServer.CreateParSetInput{
+				A:          0.6,
+				B:          0.2,
+				NoiseMean:  0.18,
+				NoiseStdev: 0.03,
+			}),
+			mockBehavior: func(r *service.MockChart, parentId int, input gameServer.CreateParSetInput) {
+				r.EXPECT().CreateParSetVersion(parentId, input).Return(0, errors.New(""))
+			},
+			expectedStatusCode: 500,
+			isError:            true,
+		},
+		{
+			name:               "incorrect a - explosive process",
+			paramId:            "1",
+			inputBody:          `{"a": 1, "b": 0.2, "noise_mean": 0.18, "noise_stdev": 0.03}`,
+			mockBehavior:       func(r *service.MockChart, parentId int, input gameServer.CreateParSetInput) {},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
+		{
+			name:               "incorrect noise stdev - zero value",
+			paramId:            "1",
+			inputBody:          `{"a": 0.6, "b": 0.2, "noise_mean": 0.18, "noise_stdev": 0}`,
+			mockBehavior:       func(r *service.MockChart, parentId int, input gameServer.CreateParSetInput) {},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
+		{
+			name:               "incorrect false warning prob - greater than one",
+			paramId:            "1",
+			inputBody:          `{"a": 0.6, "b": 0.2, "noise_mean": 0.18, "noise_stdev": 0.03, "false_warning_prob": 1.5}`,
+			mockBehavior:       func(r *service.MockChart, parentId int, input gameServer.CreateParSetInput) {},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
+		{
+			name:               "incorrect scoring config - not numeric",
+			paramId:            "1",
+			inputBody:          `{"a": 0.6, "b": 0.2, "noise_mean": 0.18, "noise_stdev": 0.03, "scoring_config": {"bonus_step": "fifty"}}`,
+			mockBehavior:       func(r *service.MockChart, parentId int, input gameServer.CreateParSetInput) {},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chartMock := service.NewMockChart(t)
+			parentId, _ := strconv.Atoi(tt.paramId)
+			tt.mockBehavior(chartMock, parentId, tt.input)
+
+			services := &service.Service{Chart: chartMock}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/parSet/:id/version", handler.createParSetVersion)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", fmt.Sprintf("/parSet/%s/version", tt.paramId), bytes.NewBufferString(tt.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			if tt.isError {
+				assert.Contains(t, w.Body.String(), "error")
+			} else {
+				assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_getOneParSet(t *testing.T) {
+	type mockBehavior func(r *service.MockChart, id int)
+
+	tests := []struct {
+		name                string
+		paramId             string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+		isError             bool
+	}{
+		{
+			name:    "ok",
+			paramId: "1",
+			mockBehavior: func(r *service.MockChart, id int) {
+				r.EXPECT().GetOneParSet(id).Return(gameServer.ParameterSet{Id: id, Name: "v1", A: 0.5, CreatedAt: "2023-10-01T00:00:00Z"}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"data":{"id":1,"parent_id":null,"name":"v1","description":"","a":0.5,"b":0,"noise_mean":0,"noise_stdev":0,"false_warning_prob":0,"missing_danger_prob":0,"scoring_config":null,"hint_cost":0,"hint_config":null,"advisor_config":null,"false_alarm_threshold":0,"rules":null,"created_at":"2023-10-01T00:00:00Z","archived_at":null}}`,
+		},
+		{
+			name:               "incorrect id",
+			paramId:            "0",
+			mockBehavior:       func(r *service.MockChart, id int) {},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
+		{
+			name:    "parameter set not found",
+			paramId: "1",
+			mockBehavior: func(r *service.MockChart, id int) {
+				r.EXPECT().GetOneParSet(id).Return(gameServer.ParameterSet{}, sql.ErrNoRows)
+			},
+			expectedStatusCode:  404,
+			expectedRequestBody: `{"error":"not found","code":"not_found"}`,
+		},
+		{
+			name:    "internal server error",
+			paramId: "1",
+			mockBehavior: func(r *service.MockChart, id int) {
+				r.EXPECT().GetOneParSet(id).Return(gameServer.ParameterSet{}, errors.New(""))
+			},
+			expectedStatusCode: 500,
+			isError:            true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chartMock := service.NewMockChart(t)
+			id, _ := strconv.Atoi(tt.paramId)
+			tt.mockBehavior(chartMock, id)
+
+			services := &service.Service{Chart: chartMock}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/parSet/:id", handler.getOneParSet)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", fmt.Sprintf("/parSet/%s", tt.paramId), nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			if tt.isError {
+				assert.Contains(t, w.Body.String(), "error")
+			} else {
+				assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_cloneParSet(t *testing.T) {
+	type mockBehavior func(r *service.MockChart, id int)
+
+	source := gameServer.ParameterSet{
+		Id:                  1,
+		Name:                "v1",
+		A:                   0.6,
+		B:                   0.2,
+		NoiseMean:           0.18,
+		NoiseStDev:          0.03,
+		ScoringConfig:       gameServer.DefaultScoringConfigJSON(),
+		HintCost:            250,
+		HintConfig:          gameServer.DefaultHintConfigJSON(250),
+		AdvisorConfig:       gameServer.DefaultAdvisorConfigJSON(),
+		FalseAlarmThreshold: 0.9,
+		Rules:               json.RawMessage(`{"ru": "rules"}`),
+	}
+
+	tests := []struct {
+		name                string
+		paramId             string
+		inputBody           string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+		isError             bool
+	}{
+		{
+			name:      "ok - overrides applied",
+			paramId:   "1",
+			inputBody: `{"name": "v1 copy", "false_warning_prob": 0.1}`,
+			mockBehavior: func(r *service.MockChart, id int) {
+				r.EXPECT().GetOneParSet(id).Return(source, nil)
+				input := source.ToCreateInput()
+				input.Name = "v1 copy"
+				input.FalseWarningProb = 0.1
+				r.EXPECT().CreateParSet(input).Return(2, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"id":2}`,
+		},
+		{
+			name:      "override is not valid",
+			paramId:   "1",
+			inputBody: `{"a": -1}`,
+			mockBehavior: func(r *service.MockChart, id int) {
+				r.EXPECT().GetOneParSet(id).Return(source, nil)
+			},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
+		{
+			name:               "incorrect id",
+			paramId:            "abc",
+			inputBody:          `{}`,
+			mockBehavior:       func(r *service.MockChart, id int) {},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
+		{
+			name:      "internal server error",
+			paramId:   "1",
+			inputBody: `{}`,
+			mockBehavior: func(r *service.MockChart, id int) {
+				r.EXPECT().GetOneParSet(id).Return(gameServer.ParameterSet{}, errors.New(""))
+			},
+			expectedStatusCode: 500,
+			isError:            true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chartMock := service.NewMockChart(t)
+			id, _ := strconv.Atoi(tt.paramId)
+			tt.mockBehavior(chartMock, id)
+
+			services := &service.Service{Chart: chartMock}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/parSet/:id/clone", handler.cloneParSet)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", fmt.Sprintf("/parSet/%s/clone", tt.paramId), bytes.NewBufferString(tt.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			if tt.isError {
+				assert.Contains(t, w.Body.String(), "error")
+			} else {
+				assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_updateParSet(t *testing.T) {
+	type mockBehavior func(r *service.MockChart, id int, input gameServer.CreateParSetInput)
+
+	input := gameServer.CreateParSetInput{A: 0.6, B: 0.2, NoiseMean: 0.18, NoiseStdev: 0.03, Rules: json.RawMessage(`{"en": "fixed"}`)}
+	input.ApplyDefaults()
+
+	tests := []struct {
+		name                string
+		paramId             string
+		inputBody           string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "ok",
+			paramId:   "1",
+			inputBody: `{"a": 0.6, "b": 0.2, "noise_mean": 0.18, "noise_stdev": 0.03, "rules": {"en": "fixed"}}`,
+			mockBehavior: func(r *service.MockChart, id int, input gameServer.CreateParSetInput) {
+				r.EXPECT().UpdateParSet(id, input).Return(nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"status":"ok"}`,
+		},
+		{
+			name:      "parameter set already played",
+			paramId:   "1",
+			inputBody: `{"a": 0.6, "b": 0.2, "noise_mean": 0.18, "noise_stdev": 0.03, "rules": {"en": "fixed"}}`,
+			mockBehavior: func(r *service.MockChart, id int, input gameServer.CreateParSetInput) {
+				r.EXPECT().UpdateParSet(id, input).Return(&gameServer.ParSetConflictError{
+					Action:     "updated",
+					References: gameServer.ParSetReferences{ChartCount: 3, GroupIds: []int{2}, UserIds: []int{5, 6}},
+				})
+			},
+			expectedStatusCode:  409,
+			expectedRequestBody: `{"error":"parameter set cannot be updated: it is referenced by 3 charts, groups [2] and users [5 6]","code":"par_set_in_use","references":{"chart_count":3,"group_ids":[2],"user_ids":[5,6]}}`,
+		},
+		{
+			name:      "parameter set played - rejected by the database",
+			paramId:   "1",
+			inputBody: `{"a": 0.6, "b": 0.2, "noise_mean": 0.18, "noise_stdev": 0.03, "rules": {"en": "fixed"}}`,
+			mockBehavior: func(r *service.MockChart, id int, input gameServer.CreateParSetInput) {
+				r.EXPECT().UpdateParSet(id, input).Return(&pq.Error{Code: "23001"})
+			},
+			expectedStatusCode:  409,
+			expectedRequestBody: `{"error":"games have been played with the parameter set, it cannot be changed","code":"par_set_played"}`,
+		},
+		{
+			name:                "incorrect noise stdev - negative value",
+			paramId:             "1",
+			inputBody:           `{"a": 0.6, "b": 0.2, "noise_mean": 0.18, "noise_stdev": -0.03}`,
+			mockBehavior:        func(r *service.MockChart, id int, input gameServer.CreateParSetInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"noise standard deviation is less than zero","code":"bad_request"}`,
+		},
+		{
+			name:      "internal server error",
+			paramId:   "1",
+			inputBody: `{"a": 0.6, "b": 0.2, "noise_mean": 0.18, "noise_stdev": 0.03, "rules": {"en": "fixed"}}`,
+			mockBehavior: func(r *service.MockChart, id int, input gameServer.CreateParSetInput) {
+				r.EXPECT().UpdateParSet(id, input).Return(errors.New("db is down"))
+			},
+			expectedStatusCode:  500,
+			expectedRequestBody: `{"error":"db is down","code":"internal_error"}`,
+		},
+		{
+			name:                "incorrect rules - unknown placeholder",
+			paramId:             "1",
+			inputBody:           `{"a": 0.6, "b": 0.2, "noise_mean": 0.18, "noise_stdev": 0.03, "rules": {"en": "Each step gives {{bonus_step}} points, a crash costs {{crash_penalty}}"}}`,
+			mockBehavior:        func(r *service.MockChart, id int, input gameServer.CreateParSetInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"rules for locale en: unknown rules placeholder {{crash_penalty}}","code":"bad_request"}`,
+		},
+		{
+			name:                "incorrect rules - not a locale",
+			paramId:             "1",
+			inputBody:           `{"a": 0.6, "b": 0.2, "noise_mean": 0.18, "noise_stdev": 0.03, "rules": {"English": "fixed"}}`,
+			mockBehavior:        func(r *service.MockChart, id int, input gameServer.CreateParSetInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"rules locale \"English\" is not a language tag","code":"bad_request"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chartMock := service.NewMockChart(t)
+			id, _ := strconv.Atoi(tt.paramId)
+			tt.mockBehavior(chartMock, id, input)
+
+			services := &service.Service{Chart: chartMock}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.PUT("/parSet/:id", handler.updateParSet)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", fmt.Sprintf("/parSet/%s", tt.paramId), bytes.NewBufferString(tt.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_deleteParSet(t *testing.T) {
+	type mockBehavior func(r *service.MockChart, id int)
+
+	tests := []struct {
+		name                string
+		paramId             string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:    "ok",
+			paramId: "1",
+			mockBehavior: func(r *service.MockChart, id int) {
+				r.EXPECT().DeleteParSet(id).Return(nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"status":"ok"}`,
+		},
+		{
+			name:    "parameter set is referenced",
+			paramId: "1",
+			mockBehavior: func(r *service.MockChart, id int) {
+				r.EXPECT().DeleteParSet(id).Return(&gameServer.ParSetConflictError{
+					Action:     "deleted",
+					References: gameServer.ParSetReferences{GroupIds: []int{1}, UserIds: []int{}},
+				})
+			},
+			expectedStatusCode:  409,
+			expectedRequestBody: `{"error":"parameter set cannot be deleted: it is referenced by 0 charts, groups [1] and users []","code":"par_set_in_use","references":{"chart_count":0,"group_ids":[1],"user_ids":[]}}`,
+		},
+		{
+			name:                "incorrect id",
+			paramId:             "-1",
+			mockBehavior:        func(r *service.MockChart, id int) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"invalid parameter id","code":"invalid_parameter"}`,
+		},
+		{
+			name:    "internal server error",
+			paramId: "1",
+			mockBehavior: func(r *service.MockChart, id int) {
+				r.EXPECT().DeleteParSet(id).Return(errors.New("db is down"))
+			},
+			expectedStatusCode:  500,
+			expectedRequestBody: `{"error":"db is down","code":"internal_error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chartMock := service.NewMockChart(t)
+			id, _ := strconv.Atoi(tt.paramId)
+			tt.mockBehavior(chartMock, id)
+
+			services := &service.Service{Chart: chartMock}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.DELETE("/parSet/:id", handler.deleteParSet)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", fmt.Sprintf("/parSet/%s", tt.paramId), nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_archiveParSet(t *testing.T) {
+	type mockBehavior func(r *service.MockChart, id int)
+
+	tests := []struct {
+		name                string
+		paramId             string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:    "ok",
+			paramId: "1",
+			mockBehavior: func(r *service.MockChart, id int) {
+				r.EXPECT().ArchiveParSet(id).Return(nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"status":"ok"}`,
+		},
+		{
+			name:    "parameter set is referenced",
+			paramId: "1",
+			mockBehavior: func(r *service.MockChart, id int) {
+				r.EXPECT().ArchiveParSet(id).Return(&gameServer.ParSetConflictError{
+					Action:     "archived",
+					References: gameServer.ParSetReferences{ChartCount: 1, GroupIds: []int{}, UserIds: []int{4}},
+				})
+			},
+			expectedStatusCode:  409,
+			expectedRequestBody: `{"error":"parameter set cannot be archived: it is referenced by 1 charts, groups [] and users [4]","code":"par_set_in_use","references":{"chart_count":1,"group_ids":[],"user_ids":[4]}}`,
+		},
+		{
+			name:                "incorrect id",
+			paramId:             "abc",
+			mockBehavior:        func(r *service.MockChart, id int) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"invalid parameter id","code":"invalid_parameter"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chartMock := service.NewMockChart(t)
+			id, _ := strconv.Atoi(tt.paramId)
+			tt.mockBehavior(chartMock, id)
+
+			services := &service.Service{Chart: chartMock}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/parSet/:id/archive", handler.archiveParSet)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", fmt.Sprintf("/parSet/%s/archive", tt.paramId), nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_previewParSet(t *testing.T) {
+	type mockBehavior func(r *service.MockChart, input gameServer.PreviewParSetInput)
+
+	seed := int64(7)
+	stopThreshold := float64(float32(0.9))
+
+	parSet := gameServer.CreateParSetInput{A: 0.6, B: 0.2, NoiseMean: 0.18, NoiseStdev: 0.03, FalseWarningProb: 0.02}
+	parSet.ApplyDefaults()
+
+	tests := []struct {
+		name                string
+		inputBody           string
+		input               gameServer.PreviewParSetInput
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+		isError             bool
+	}{
+		{
+			name:      "ok - defaults applied",
+			inputBody: `{"par_set": {"a": 0.6, "b": 0.2, "noise_mean": 0.18, "noise_stdev": 0.03, "false_warning_prob": 0.02}, "seed": 7}`,
+			input: gameServer.PreviewParSetInput{
+				ParSet:        parSet,
+				Games:         gameServer.DefaultPreviewGames,
+				MaxSteps:      gameServer.DefaultScenarioLength,
+				StopThreshold: &stopThreshold,
+				Seed:          &seed,
+			},
+			mockBehavior: func(r *service.MockChart, input gameServer.PreviewParSetInput) {
+				r.EXPECT().PreviewParSet(input).Return(gameServer.ParSetPreview{
+					Games:    1000,
+					MaxSteps: 200,
+					Strategies: []gameServer.StrategyPreview{
+						{Strategy: gameServer.StrategyFollowAi, CrashRate: 0.01, StopRate: 0.99, MeanGameLength: 20, MeanSignalCount: 1, MeanScore: 1000},
+					},
+				}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"data":{"games":1000,"max_steps":200,"strategies":[{"strategy":"follow_ai","crash_rate":0.01,"stop_rate":0.99,"mean_game_length":20,"mean_signal_count":1,"mean_score":1000}]}}`,
+		},
+		{
+			name:               "incorrect parameter set - explosive process",
+			inputBody:          `{"par_set": {"a": 1.2, "b": 0.2, "noise_mean": 0.18, "noise_stdev": 0.03}}`,
+			mockBehavior:       func(r *service.MockChart, input gameServer.PreviewParSetInput) {},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
+		{
+			name:               "incorrect games count - too many",
+			inputBody:          `{"par_set": {"a": 0.6, "b": 0.2, "noise_mean": 0.18, "noise_stdev": 0.03}, "games": 100000}`,
+			mockBehavior:       func(r *service.MockChart, input gameServer.PreviewParSetInput) {},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
+		{
+			name:      "internal server error",
+			inputBody: `{"par_set": {"a": 0.6, "b": 0.2, "noise_mean": 0.18, "noise_stdev": 0.03, "false_warning_prob": 0.02}, "seed": 7}`,
+			input: gameServer.PreviewParSetInput{
+				ParSet:        parSet,
+				Games:         gameServer.DefaultPreviewGames,
+				MaxSteps:      gameServer.DefaultScenarioLength,
+				StopThreshold: &stopThreshold,
+				Seed:          &seed,
+			},
+			mockBehavior: func(r *service.MockChart, input gameServer.PreviewParSetInput) {
+				r.EXPECT().PreviewParSet(input).Return(gameServer.ParSetPreview{}, errors.New(""))
+			},
+			expectedStatusCode: 500,
+			isError:            true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chartMock := service.NewMockChart(t)
+			tt.mockBehavior(chartMock, tt.input)
+
+			services := &service.Service{Chart: chartMock}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/parSet/preview", handler.previewParSet)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/parSet/preview", bytes.NewBufferString(tt.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			if tt.isError {
+				assert.Contains(t, w.Body.String(), "error")
+			} else {
+				assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_getParSetRules(t *testing.T) {
+	type mockBehavior func(r *service.MockChart, id int, acceptLanguage string)
+
+	tests := []struct {
+		name                    string
+		paramId                 string
+		acceptLanguage          string
+		mockBehavior            mockBehavior
+		expectedStatusCode      int
+		expectedContentLanguage string
+		expectedRequestBody     string
+	}{
+		{
+			name:           "ok",
+			paramId:        "1",
+			acceptLanguage: "en-US,en;q=0.9",
+			mockBehavior: func(r *service.MockChart, id int, acceptLanguage string) {
+				r.EXPECT().GetParSetRules(id, acceptLanguage).Return(gameServer.ParSetRules{
+					Locale:   "en",
+					Locales:  []string{"ru", "en"},
+					Markdown: "Each step gives **50** points",
+					Html:     "<p>Each step gives <strong>50</strong> points</p>\n",
+				}, nil)
+			},
+			expectedStatusCode:      200,
+			expectedContentLanguage: "en",
+			expectedRequestBody:     `{"data":{"locale":"en","locales":["ru","en"],"markdown":"Each step gives **50** points","html":"\u003cp\u003eEach step gives \u003cstrong\u003e50\u003c/strong\u003e points\u003c/p\u003e\n"}}`,
+		},
+		{
+			name:    "ok - no rules",
+			paramId: "1",
+			mockBehavior: func(r *service.MockChart, id int, acceptLanguage string) {
+				r.EXPECT().GetParSetRules(id, acceptLanguage).Return(gameServer.ParSetRules{Locales: []string{}}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"data":{"locale":"","locales":[],"markdown":"","html":""}}`,
+		},
+		{
+			name:                "incorrect id",
+			paramId:             "abc",
+			mockBehavior:        func(r *service.MockChart, id int, acceptLanguage string) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"invalid parameter id","code":"invalid_parameter"}`,
+		},
+		{
+			name:    "parameter set not found",
+			paramId: "1",
+			mockBehavior: func(r *service.MockChart, id int, acceptLanguage string) {
+				r.EXPECT().GetParSetRules(id, acceptLanguage).Return(gameServer.ParSetRules{}, sql.ErrNoRows)
+			},
+			expectedStatusCode:  404,
+			expectedRequestBody: `{"error":"not found","code":"not_found"}`,
+		},
+		{
+			name:    "internal server error",
+			paramId: "1",
+			mockBehavior: func(r *service.MockChart, id int, acceptLanguage string) {
+				r.EXPECT().GetParSetRules(id, acceptLanguage).Return(gameServer.ParSetRules{}, errors.New("db is down"))
+			},
+			expectedStatusCode:  500,
+			expectedRequestBody: `{"error":"db is down","code":"internal_error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chartMock := service.NewMockChart(t)
+			id, _ := strconv.Atoi(tt.paramId)
+			tt.mockBehavior(chartMock, id, tt.acceptLanguage)
+
+			services := &service.Service{Chart: chartMock}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/parSet/:id/rules", handler.getParSetRules)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", fmt.Sprintf("/parSet/%s/rules", tt.paramId), nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedContentLanguage, w.Header().Get("Content-Language"))
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
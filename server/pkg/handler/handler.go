@@ -23,6 +23,8 @@ func (h *Handler) InitRoutes() *gin.Engine {
 
 	api := router.Group("/api")
 	{
+		api.GET("/i18n/messages", h.getMessages)
+
 		user := api.Group("/user")
 		{
 			user.POST("/registration", h.registration)
@@ -58,11 +60,27 @@ func (h *Handler) InitRoutes() *gin.Engine {
 			chart.POST("/pageCount", h.getChartsPageCount)
 			chart.POST("/count", h.getChartsCount)
 			chart.POST("/", h.createChart)
+			chart.POST("/session", h.openChart)
+			chart.POST("/session/:id/points", h.appendChartPoints)
+			chart.POST("/session/:id/close", h.closeChart)
+			chart.POST("/:id/hint", h.getCrashHint)
+			chart.POST("/:id/advice", h.getAdvice)
+			chart.GET("/:id/scenario", h.getChartScenario)
 			chart.GET("/:id", h.getOneChart)
 			chart.DELETE("/:id", h.checkAdminRole, h.deleteChart)
 			chart.POST("/parSets", h.checkResearcherRole, h.getAllParSets)
 			chart.GET("/parSetsPageCount", h.checkResearcherRole, h.getParSetsPageCount)
 			chart.POST("/parSet", h.checkAdminRole, h.createParSet)
+			chart.POST("/parSet/preview", h.checkResearcherRole, h.previewParSet)
+			chart.GET("/parSet/:id", h.checkResearcherRole, h.getOneParSet)
+			chart.GET("/parSet/:id/rules", h.getParSetRules)
+			chart.PUT("/parSet/:id", h.checkAdminRole, h.updateParSet)
+			chart.DELETE("/parSet/:id", h.checkAdminRole, h.deleteParSet)
+			chart.POST("/parSet/:id/archive", h.checkAdminRole, h.archiveParSet)
+			chart.POST("/parSet/:id/clone", h.checkAdminRole, h.cloneParSet)
+			chart.POST("/parSet/:id/version", h.checkAdminRole, h.createParSetVersion)
+			chart.POST("/parSet/:id/scenarios", h.checkAdminRole, h.createScenarios)
+			chart.GET("/parSet/:id/scenarios", h.checkResearcherRole, h.getAllScenarios)
 		}
 
 		point := api.Group("/point", h.checkUserAuth)
@@ -78,16 +96,24 @@ func (h *Handler) InitRoutes() *gin.Engine {
 		{
 			statistics.POST("/", h.computeStatistics)
 			statistics.GET("user_id/:userId/par_set_id/:parSetId", h.getStatistics)
+			statistics.GET("/live/group_id/:groupId", h.streamLiveEvents)
+			statistics.GET("/tests/group_id/:groupId/par_set_id/:parSetId", h.getTestGameCorrelations)
 		}
 
 		test := api.Group("/test", h.checkUserAuth)
 		{
 			test.GET("/session", h.getTestSessionStatus)
 			test.POST("/results", h.submitTestResult)
+			test.POST("/:id/visibility", h.getTestVisibility)
+			test.GET("/:id/progress", h.getTestProgress)
+			test.PUT("/:id/progress", h.saveTestProgress)
+			test.GET("/:id/report", h.checkResearcherRole, h.getTestReport)
 			test.GET("/results/user/:userId", h.checkResearcherRole, h.getPlayerTestResults)
 			test.GET("/results", h.getUserTestResults)
 			test.GET("/", h.checkAdminRole, h.getAllTests)
 			test.POST("/", h.checkAdminRole, h.createTest)
+			test.GET("/instruments", h.checkAdminRole, h.getInstruments)
+			test.POST("/instruments/:id", h.checkAdminRole, h.createTestFromInstrument)
 			test.PUT("/:id", h.checkAdminRole, h.updateTest)
 			test.DELETE("/:id", h.checkAdminRole, h.deleteTest)
 		}
@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"net/http"
+
+	"example.com/gameHoldTheProcessServer/pkg/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+type getMessagesResponse struct {
+	Locale string            `json:"locale"`
+	Data   map[string]string `json:"data"`
+}
+
+// getMessages returns the message catalog in the locale of the request, the
+// client translates the event, risk level and error codes with it.
+func (h *Handler) getMessages(c *gin.Context) {
+	locale := requestLocale(c)
+	c.Header("Content-Language", locale)
+	c.JSON(http.StatusOK, getMessagesResponse{
+		Locale: locale,
+		Data:   i18n.Messages(locale),
+	})
+}
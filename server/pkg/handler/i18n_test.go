@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"example.com/gameHoldTheProcessServer/pkg/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_getMessages(t *testing.T) {
+	tests := []struct {
+		name                    string
+		acceptLanguage          string
+		expectedLocale          string
+		expectedCrashLabel      string
+		expectedAccessDenied    string
+		expectedContentLanguage string
+	}{
+		{
+			name:                    "ok - default locale",
+			expectedLocale:          "en",
+			expectedCrashLabel:      "Crash",
+			expectedAccessDenied:    "access to the resource is denied",
+			expectedContentLanguage: "en",
+		},
+		{
+			name:                    "ok - russian",
+			acceptLanguage:          "ru-RU,ru;q=0.9,en;q=0.8",
+			expectedLocale:          "ru",
+			expectedCrashLabel:      "Взрыв",
+			expectedAccessDenied:    "доступ к ресурсу запрещён",
+			expectedContentLanguage: "ru",
+		},
+		{
+			name:                    "ok - unknown locale",
+			acceptLanguage:          "de-DE",
+			expectedLocale:          "en",
+			expectedCrashLabel:      "Crash",
+			expectedAccessDenied:    "access to the resource is denied",
+			expectedContentLanguage: "en",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHandler(&service.Service{})
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/i18n/messages", handler.getMessages)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/i18n/messages", nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+
+			r.ServeHTTP(w, req)
+
+			var response getMessagesResponse
+			assert.Equal(t, 200, w.Code)
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedLocale, response.Locale)
+			assert.Equal(t, tt.expectedCrashLabel, response.Data["event.crash"])
+			assert.Equal(t, tt.expectedAccessDenied, response.Data["error.access_denied"])
+			assert.Equal(t, tt.expectedContentLanguage, w.Header().Get("Content-Language"))
+		})
+	}
+}
+
+func TestHandler_localizedErrors(t *testing.T) {
+	tests := []struct {
+		name                string
+		acceptLanguage      string
+		expectedRequestBody string
+	}{
+		{
+			name:                "default locale",
+			expectedRequestBody: `{"error":"empty authorization header","code":"empty_auth_header"}`,
+		},
+		{
+			name:                "english",
+			acceptLanguage:      "en-GB",
+			expectedRequestBody: `{"error":"empty authorization header","code":"empty_auth_header"}`,
+		},
+		{
+			name:                "russian",
+			acceptLanguage:      "ru",
+			expectedRequestBody: `{"error":"пустой заголовок авторизации","code":"empty_auth_header"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHandler(&service.Service{})
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/protected", handler.checkUserAuth)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/protected", nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, 401, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
@@ -1,10 +1,14 @@
 package handler
 
 import (
+	"database/sql"
+	"errors"
 	"net/http"
+	"strconv"
 	"strings"
 
 	gameServer "example.com/gameHoldTheProcessServer"
+	"example.com/gameHoldTheProcessServer/pkg/i18n"
 	"github.com/gin-gonic/gin"
 )
 
@@ -17,13 +21,13 @@ const (
 func (h *Handler) checkUserAuth(c *gin.Context) {
 	header := c.GetHeader(authorizationHeader)
 	if header == "" {
-		newErrorResponse(c, http.StatusUnauthorized, "empty authorization header")
+		newCodedErrorResponse(c, http.StatusUnauthorized, i18n.CodeEmptyAuthHeader)
 		return
 	}
 
 	headerParts := strings.Split(header, " ")
 	if len(headerParts) != 2 || headerParts[0] != "Bearer" || len(headerParts[1]) == 0 {
-		newErrorResponse(c, http.StatusUnauthorized, "invalid authorization header")
+		newCodedErrorResponse(c, http.StatusUnauthorized, i18n.CodeInvalidAuthHeader)
 		return
 	}
 
@@ -40,7 +44,7 @@ func (h *Handler) checkUserAuth(c *gin.Context) {
 func (h *Handler) checkAdminRole(c *gin.Context) {
 	role, exists := c.Get(userCtxRole)
 	if !exists || role != gameServer.RoleAdmin {
-		newErrorResponse(c, http.StatusForbidden, "not enough rights")
+		newCodedErrorResponse(c, http.StatusForbidden, i18n.CodeNotEnoughRights)
 		return
 	}
 }
@@ -48,7 +52,97 @@ func (h *Handler) checkAdminRole(c *gin.Context) {
 func (h *Handler) checkResearcherRole(c *gin.Context) {
 	role, exists := c.Get(userCtxRole)
 	if !exists || (role != gameServer.RoleAdmin && role != gameServer.RoleResearcher) {
-		newErrorResponse(c, http.StatusForbidden, "not enough rights")
+		newCodedErrorResponse(c, http.StatusForbidden, i18n.CodeNotEnoughRights)
 		return
 	}
 }
+
+// checkUserAccess allows the request when the authenticated user is the owner
+// of the data, an admin, or a researcher whose group contains the owner.
+func (h *Handler) checkUserAccess(c *gin.Context, userId int) bool {
+	requesterId, ok := c.Get(userCtx)
+	if !ok {
+		newCodedErrorResponse(c, http.StatusForbidden, i18n.CodeAccessDenied)
+		return false
+	}
+	role, _ := c.Get(userCtxRole)
+
+	if requesterId == userId || role == gameServer.RoleAdmin {
+		return true
+	}
+
+	if role == gameServer.RoleResearcher {
+		hasAccess, err := h.services.User.HasResearcherAccess(requesterId.(int), userId)
+		if err != nil {
+			newErrorResponse(c, http.StatusInternalServerError, err.Error())
+			return false
+		}
+		if hasAccess {
+			return true
+		}
+	}
+
+	newCodedErrorResponse(c, http.StatusForbidden, i18n.CodeAccessDenied)
+	return false
+}
+
+func (h *Handler) checkChartAccess(c *gin.Context, chartId int) bool {
+	chart, err := h.services.Chart.GetOneChart(chartId)
+	if errors.Is(err, sql.ErrNoRows) {
+		newCodedErrorResponse(c, http.StatusNotFound, i18n.CodeNotFound)
+		return false
+	}
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return false
+	}
+
+	return h.checkUserAccess(c, chart.UserId)
+}
+
+// checkChartsFilterAccess lets a participant list only their own charts by
+// the user_id filter, the charts of the others and the other filters are
+// open to the researchers that checkUserAccess allows.
+func (h *Handler) checkChartsFilterAccess(c *gin.Context, filterTag, filterValue string) bool {
+	if filterTag == "user_id" {
+		userId, err := strconv.Atoi(filterValue)
+		if err != nil || userId <= 0 {
+			newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "filter_value")
+			return false
+		}
+		return h.checkUserAccess(c, userId)
+	}
+
+	role, _ := c.Get(userCtxRole)
+	if role != gameServer.RoleAdmin && role != gameServer.RoleResearcher {
+		newCodedErrorResponse(c, http.StatusForbidden, i18n.CodeNotEnoughRights)
+		return false
+	}
+	return true
+}
+
+// checkGroupAccess allows admins and the researcher who created the group.
+func (h *Handler) checkGroupAccess(c *gin.Context, groupId int) bool {
+	role, _ := c.Get(userCtxRole)
+	if role == gameServer.RoleAdmin {
+		return true
+	}
+
+	group, err := h.services.User.GetOneGroup(groupId)
+	if errors.Is(err, sql.ErrNoRows) {
+		newCodedErrorResponse(c, http.StatusNotFound, i18n.CodeNotFound)
+		return false
+	}
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return false
+	}
+
+	requesterId, _ := c.Get(userCtx)
+	if requesterId == group.CreatorId {
+		return true
+	}
+
+	newCodedErrorResponse(c, http.StatusForbidden, i18n.CodeAccessDenied)
+	return false
+}
@@ -1,11 +1,13 @@
 package handler
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 	"net/http/httptest"
 	"testing"
 
+	gameServer "example.com/gameHoldTheProcessServer"
 	"example.com/gameHoldTheProcessServer/pkg/service"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -109,3 +111,203 @@ func TestHandler_checkUserAuth(t *testing.T) {
 		})
 	}
 }
+
+func setUserCtx(userId int, role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(userCtx, userId)
+		c.Set(userCtxRole, role)
+	}
+}
+
+func TestHandler_checkUserAccess(t *testing.T) {
+	type mockBehavior func(r *service.MockUser, requesterId, userId int)
+
+	tests := []struct {
+		name                string
+		requesterId         int
+		requesterRole       string
+		userId              int
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+		isError             bool
+	}{
+		{
+			name:                "owner",
+			requesterId:         1,
+			requesterRole:       gameServer.RoleUser,
+			userId:              1,
+			mockBehavior:        func(r *service.MockUser, requesterId, userId int) {},
+			expectedStatusCode:  200,
+			expectedRequestBody: `ok`,
+		},
+		{
+			name:                "admin",
+			requesterId:         1,
+			requesterRole:       gameServer.RoleAdmin,
+			userId:              2,
+			mockBehavior:        func(r *service.MockUser, requesterId, userId int) {},
+			expectedStatusCode:  200,
+			expectedRequestBody: `ok`,
+		},
+		{
+			name:          "researcher with access",
+			requesterId:   1,
+			requesterRole: gameServer.RoleResearcher,
+			userId:        2,
+			mockBehavior: func(r *service.MockUser, requesterId, userId int) {
+				r.EXPECT().HasResearcherAccess(requesterId, userId).Return(true, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `ok`,
+		},
+		{
+			name:          "researcher without access",
+			requesterId:   1,
+			requesterRole: gameServer.RoleResearcher,
+			userId:        2,
+			mockBehavior: func(r *service.MockUser, requesterId, userId int) {
+				r.EXPECT().HasResearcherAccess(requesterId, userId).Return(false, nil)
+			},
+			expectedStatusCode: 403,
+			isError:            true,
+		},
+		{
+			name:               "another user",
+			requesterId:        1,
+			requesterRole:      gameServer.RoleUser,
+			userId:             2,
+			mockBehavior:       func(r *service.MockUser, requesterId, userId int) {},
+			expectedStatusCode: 403,
+			isError:            true,
+		},
+		{
+			name:          "internal error",
+			requesterId:   1,
+			requesterRole: gameServer.RoleResearcher,
+			userId:        2,
+			mockBehavior: func(r *service.MockUser, requesterId, userId int) {
+				r.EXPECT().HasResearcherAccess(requesterId, userId).Return(false, errors.New(""))
+			},
+			expectedStatusCode: 500,
+			isError:            true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userMock := service.NewMockUser(t)
+			tt.mockBehavior(userMock, tt.requesterId, tt.userId)
+
+			services := &service.Service{User: userMock}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/access", setUserCtx(tt.requesterId, tt.requesterRole), func(c *gin.Context) {
+				if handler.checkUserAccess(c, tt.userId) {
+					c.String(200, "ok")
+				}
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/access", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			if tt.isError {
+				assert.Contains(t, w.Body.String(), "error")
+			} else {
+				assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_checkGroupAccess(t *testing.T) {
+	type mockBehavior func(r *service.MockUser, groupId int)
+
+	tests := []struct {
+		name               string
+		requesterId        int
+		requesterRole      string
+		groupId            int
+		mockBehavior       mockBehavior
+		expectedStatusCode int
+	}{
+		{
+			name:               "admin",
+			requesterId:        1,
+			requesterRole:      gameServer.RoleAdmin,
+			groupId:            3,
+			mockBehavior:       func(r *service.MockUser, groupId int) {},
+			expectedStatusCode: 200,
+		},
+		{
+			name:          "group creator",
+			requesterId:   1,
+			requesterRole: gameServer.RoleResearcher,
+			groupId:       3,
+			mockBehavior: func(r *service.MockUser, groupId int) {
+				r.EXPECT().GetOneGroup(groupId).Return(gameServer.Group{Id: groupId, CreatorId: 1}, nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:          "another researcher",
+			requesterId:   1,
+			requesterRole: gameServer.RoleResearcher,
+			groupId:       3,
+			mockBehavior: func(r *service.MockUser, groupId int) {
+				r.EXPECT().GetOneGroup(groupId).Return(gameServer.Group{Id: groupId, CreatorId: 2}, nil)
+			},
+			expectedStatusCode: 403,
+		},
+		{
+			name:          "group not found",
+			requesterId:   1,
+			requesterRole: gameServer.RoleResearcher,
+			groupId:       3,
+			mockBehavior: func(r *service.MockUser, groupId int) {
+				r.EXPECT().GetOneGroup(groupId).Return(gameServer.Group{}, sql.ErrNoRows)
+			},
+			expectedStatusCode: 404,
+		},
+		{
+			name:          "internal error",
+			requesterId:   1,
+			requesterRole: gameServer.RoleResearcher,
+			groupId:       3,
+			mockBehavior: func(r *service.MockUser, groupId int) {
+				r.EXPECT().GetOneGroup(groupId).Return(gameServer.Group{}, errors.New(""))
+			},
+			expectedStatusCode: 500,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userMock := service.NewMockUser(t)
+			tt.mockBehavior(userMock, tt.groupId)
+
+			services := &service.Service{User: userMock}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/access", setUserCtx(tt.requesterId, tt.requesterRole), func(c *gin.Context) {
+				if handler.checkGroupAccess(c, tt.groupId) {
+					c.String(200, "ok")
+				}
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/access", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+		})
+	}
+}
@@ -1,10 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	gameServer "example.com/gameHoldTheProcessServer"
+	"example.com/gameHoldTheProcessServer/pkg/i18n"
 	"github.com/gin-gonic/gin"
 )
 
@@ -20,8 +22,16 @@ func (h *Handler) createPoint(c *gin.Context) {
 		return
 	}
 
+	if !h.checkChartAccess(c, input.ChartId) {
+		return
+	}
+
 	id, err := h.services.Point.CreatePoint(input)
 	if err != nil {
+		if errors.Is(err, gameServer.ErrHintNotAvailable) {
+			newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeHintNotAvailable)
+			return
+		}
 		newErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -38,7 +48,7 @@ type getOnePointResponse struct {
 func (h *Handler) getOnePoint(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil || id <= 0 {
-		newErrorResponse(c, http.StatusBadRequest, "invalid parameter id")
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "id")
 		return
 	}
 
@@ -48,6 +58,10 @@ func (h *Handler) getOnePoint(c *gin.Context) {
 		return
 	}
 
+	if !h.checkChartAccess(c, point.ChartId) {
+		return
+	}
+
 	c.JSON(http.StatusOK, getOnePointResponse{
 		Data: point,
 	})
@@ -60,7 +74,11 @@ type getAllPointsByIdResponse struct {
 func (h *Handler) getAllPointsById(c *gin.Context) {
 	chartId, err := strconv.Atoi(c.Param("chart_id"))
 	if err != nil || chartId <= 0 {
-		newErrorResponse(c, http.StatusBadRequest, "invalid parameter chart_id")
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "chart_id")
+		return
+	}
+
+	if !h.checkChartAccess(c, chartId) {
 		return
 	}
 
@@ -90,7 +108,7 @@ func (h *Handler) getAllPointsInCsv(c *gin.Context) {
 func (h *Handler) deletePoint(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil || id <= 0 {
-		newErrorResponse(c, http.StatusBadRequest, "invalid parameter id")
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "id")
 		return
 	}
 
@@ -15,7 +15,9 @@ import (
 )
 
 func TestHandler_createPoint(t *testing.T) {
-	type mockBehavior func(r *service.MockPoint, point gameServer.Point)
+	hintType := gameServer.HintTypeAllSessions
+
+	type mockBehavior func(r *service.MockPoint, rc *service.MockChart, point gameServer.Point)
 
 	tests := []struct {
 		name                string
@@ -41,37 +43,56 @@ func TestHandler_createPoint(t *testing.T) {
 				IsCheck:             false,
 				ChartId:             1,
 			},
-			mockBehavior: func(r *service.MockPoint, point gameServer.Point) {
+			mockBehavior: func(r *service.MockPoint, rc *service.MockChart, point gameServer.Point) {
+				rc.EXPECT().GetOneChart(1).Return(gameServer.Chart{Id: 1, UserId: 1}, nil)
 				r.EXPECT().CreatePoint(point).Return(1, nil)
 			},
 			expectedStatusCode:  200,
 			expectedRequestBody: `{"id":1}`,
 		},
+		{
+			name:      "hint type is not enabled in the parameter set",
+			inputBody: `{"x": 1, "y": 1, "score": 1, "is_check": true, "chart_id": 1, "hint_type": "all_sessions"}`,
+			point: gameServer.Point{
+				X:        1,
+				Y:        1,
+				Score:    1,
+				IsCheck:  true,
+				ChartId:  1,
+				HintType: &hintType,
+			},
+			mockBehavior: func(r *service.MockPoint, rc *service.MockChart, point gameServer.Point) {
+				rc.EXPECT().GetOneChart(1).Return(gameServer.Chart{Id: 1, UserId: 1}, nil)
+				r.EXPECT().CreatePoint(point).Return(0, gameServer.ErrHintNotAvailable)
+			},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"hint type is not available in the parameter set","code":"hint_not_available"}`,
+		},
 		{
 			name:               "incorrect chart id - negative value",
 			inputBody:          `{"x": 1, "y": 1, "score": 1, "is_crash": false, "is_useful_ai_signal": false, "is_deceptive_ai_signal": false, "is_stop": false, "is_pause": false, "is_check": false, "chart_id": -1}`,
-			mockBehavior:       func(r *service.MockPoint, point gameServer.Point) {},
+			mockBehavior:       func(r *service.MockPoint, rc *service.MockChart, point gameServer.Point) {},
 			expectedStatusCode: 400,
 			isError:            true,
 		},
 		{
 			name:               "incorrect chart id - zero value",
 			inputBody:          `{"x": 1, "y": 1, "score": 1, "is_crash": false, "is_useful_ai_signal": false, "is_deceptive_ai_signal": false, "is_stop": false, "is_pause": false, "is_check": false, "chart_id": 0}`,
-			mockBehavior:       func(r *service.MockPoint, point gameServer.Point) {},
+			mockBehavior:       func(r *service.MockPoint, rc *service.MockChart, point gameServer.Point) {},
 			expectedStatusCode: 400,
 			isError:            true,
 		},
 		{
 			name:               "incorrect chart id - wrong type",
 			inputBody:          `{"x": 1, "y": 1, "score": 1, "is_crash": false, "is_useful_ai_signal": false, "is_deceptive_ai_signal": false, "is_stop": false, "is_pause": false, "is_check": false, "chart_id": "1"}`,
-			mockBehavior:       func(r *service.MockPoint, point gameServer.Point) {},
+			mockBehavior:       func(r *service.MockPoint, rc *service.MockChart, point gameServer.Point) {},
 			expectedStatusCode: 400,
 			isError:            true,
 		},
 		{
 			name:               "incorrect chart id - negative value",
 			inputBody:          `{"x": -1, "y": 1, "score": 1, "is_crash": false, "is_useful_ai_signal": false, "is_deceptive_ai_signal": false, "is_stop": false, "is_pause": false, "is_check": false, "chart_id": -1}`,
-			mockBehavior:       func(r *service.MockPoint, point gameServer.Point) {},
+			mockBehavior:       func(r *service.MockPoint, rc *service.MockChart, point gameServer.Point) {},
 			expectedStatusCode: 400,
 			isError:            true,
 		},
@@ -90,25 +111,64 @@ func TestHandler_createPoint(t *testing.T) {
 				IsCheck:             false,
 				ChartId:             1,
 			},
-			mockBehavior: func(r *service.MockPoint, point gameServer.Point) {
+			mockBehavior: func(r *service.MockPoint, rc *service.MockChart, point gameServer.Point) {
+				rc.EXPECT().GetOneChart(1).Return(gameServer.Chart{Id: 1, UserId: 1}, nil)
 				r.EXPECT().CreatePoint(point).Return(0, errors.New(""))
 			},
 			expectedStatusCode: 500,
 			isError:            true,
 		},
+		{
+			name:      "access denied - chart of another user",
+			inputBody: `{"x": 1, "y": 1, "score": 1, "is_crash": false, "is_useful_ai_signal": false, "is_deceptive_ai_signal": false, "is_stop": false, "is_pause": false, "is_check": false, "chart_id": 2}`,
+			mockBehavior: func(r *service.MockPoint, rc *service.MockChart, point gameServer.Point) {
+				rc.EXPECT().GetOneChart(2).Return(gameServer.Chart{Id: 2, UserId: 2}, nil)
+			},
+			expectedStatusCode: 403,
+			isError:            true,
+		},
+		{
+			name:               "incorrect reaction time - negative value",
+			inputBody:          `{"x": 1, "y": 1, "score": 1, "is_useful_ai_signal": true, "is_stop": true, "chart_id": 1, "reaction_time_ms": -5}`,
+			mockBehavior:       func(r *service.MockPoint, rc *service.MockChart, point gameServer.Point) {},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
+		{
+			name:               "response earlier than signal",
+			inputBody:          `{"x": 1, "y": 1, "score": 1, "is_useful_ai_signal": true, "is_stop": true, "chart_id": 1, "signal_shown_at": "2025-01-01T10:00:02Z", "responded_at": "2025-01-01T10:00:01Z"}`,
+			mockBehavior:       func(r *service.MockPoint, rc *service.MockChart, point gameServer.Point) {},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
+		{
+			name:               "unknown risk level",
+			inputBody:          `{"x": 1, "y": 1, "score": 1, "is_check": true, "chart_id": 1, "risk_level": "Высокий уровень риска"}`,
+			mockBehavior:       func(r *service.MockPoint, rc *service.MockChart, point gameServer.Point) {},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
+		{
+			name:               "incorrect ai confidence - greater than one",
+			inputBody:          `{"x": 1, "y": 1, "score": 1, "is_useful_ai_signal": true, "chart_id": 1, "ai_confidence": 1.5}`,
+			mockBehavior:       func(r *service.MockPoint, rc *service.MockChart, point gameServer.Point) {},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			pointMock := service.NewMockPoint(t)
-			tt.mockBehavior(pointMock, tt.point)
+			chartMock := service.NewMockChart(t)
+			tt.mockBehavior(pointMock, chartMock, tt.point)
 
-			services := &service.Service{Point: pointMock}
+			services := &service.Service{Point: pointMock, Chart: chartMock}
 			handler := NewHandler(services)
 
 			gin.SetMode(gin.TestMode)
 			r := gin.New()
-			r.POST("/", handler.createPoint)
+			r.POST("/", setUserCtx(1, gameServer.RoleUser), handler.createPoint)
 
 			w := httptest.NewRecorder()
 			req := httptest.NewRequest("POST", "/", bytes.NewBufferString(tt.inputBody))
@@ -126,7 +186,7 @@ func TestHandler_createPoint(t *testing.T) {
 }
 
 func TestHandler_getOnePoint(t *testing.T) {
-	type mockBehavior func(r *service.MockPoint, id string)
+	type mockBehavior func(r *service.MockPoint, rc *service.MockChart, id string)
 
 	tests := []struct {
 		name                string
@@ -139,7 +199,7 @@ func TestHandler_getOnePoint(t *testing.T) {
 		{
 			name:    "ok",
 			paramId: "1",
-			mockBehavior: func(r *service.MockPoint, id string) {
+			mockBehavior: func(r *service.MockPoint, rc *service.MockChart, id string) {
 				idInt, _ := strconv.Atoi(id)
 				r.EXPECT().GetOnePoint(idInt).Return(gameServer.Point{
 					Id:                  1,
@@ -156,54 +216,67 @@ func TestHandler_getOnePoint(t *testing.T) {
 					CreatedAt:           "2023-10-01T00:00:00Z",
 				},
 					nil)
+				rc.EXPECT().GetOneChart(1).Return(gameServer.Chart{Id: 1, UserId: 1}, nil)
 			},
 			expectedStatusCode:  200,
-			expectedRequestBody: `{"data":{"id":1,"x":1,"y":1,"score":1,"is_crash":false,"is_useful_ai_signal":false,"is_deceptive_ai_signal":false,"is_stop":false,"is_pause":false,"is_check":false,"chart_id":1,"created_at":"2023-10-01T00:00:00Z"}}`,
+			expectedRequestBody: `{"data":{"id":1,"x":1,"y":1,"score":1,"is_crash":false,"is_useful_ai_signal":false,"is_deceptive_ai_signal":false,"is_stop":false,"is_pause":false,"is_check":false,"chart_id":1,"created_at":"2023-10-01T00:00:00Z","hint_type":null,"risk_level":null,"crash_probability":null,"ai_confidence":null,"reaction_time_ms":null,"signal_shown_at":null,"responded_at":null}}`,
 		},
 		{
 			name:               "incorrect parameter id - negative value",
 			paramId:            "-1",
-			mockBehavior:       func(r *service.MockPoint, id string) {},
+			mockBehavior:       func(r *service.MockPoint, rc *service.MockChart, id string) {},
 			expectedStatusCode: 400,
 			isError:            true,
 		},
 		{
 			name:               "incorrect parameter id - zero value",
 			paramId:            "0",
-			mockBehavior:       func(r *service.MockPoint, id string) {},
+			mockBehavior:       func(r *service.MockPoint, rc *service.MockChart, id string) {},
 			expectedStatusCode: 400,
 			isError:            true,
 		},
 		{
 			name:               "incorrect parameter id - not a number",
 			paramId:            "abc",
-			mockBehavior:       func(r *service.MockPoint, id string) {},
+			mockBehavior:       func(r *service.MockPoint, rc *service.MockChart, id string) {},
 			expectedStatusCode: 400,
 			isError:            true,
 		},
 		{
 			name:    "internal server error",
 			paramId: "1",
-			mockBehavior: func(r *service.MockPoint, id string) {
+			mockBehavior: func(r *service.MockPoint, rc *service.MockChart, id string) {
 				idInt, _ := strconv.Atoi(id)
 				r.EXPECT().GetOnePoint(idInt).Return(gameServer.Point{}, errors.New(""))
 			},
 			expectedStatusCode: 500,
 			isError:            true,
 		},
+		{
+			name:    "access denied - point of another user",
+			paramId: "2",
+			mockBehavior: func(r *service.MockPoint, rc *service.MockChart, id string) {
+				idInt, _ := strconv.Atoi(id)
+				r.EXPECT().GetOnePoint(idInt).Return(gameServer.Point{Id: 2, ChartId: 2}, nil)
+				rc.EXPECT().GetOneChart(2).Return(gameServer.Chart{Id: 2, UserId: 2}, nil)
+			},
+			expectedStatusCode: 403,
+			isError:            true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			pointMock := service.NewMockPoint(t)
-			tt.mockBehavior(pointMock, tt.paramId)
+			chartMock := service.NewMockChart(t)
+			tt.mockBehavior(pointMock, chartMock, tt.paramId)
 
-			services := &service.Service{Point: pointMock}
+			services := &service.Service{Point: pointMock, Chart: chartMock}
 			handler := NewHandler(services)
 
 			gin.SetMode(gin.TestMode)
 			r := gin.New()
-			r.GET("/:id", handler.getOnePoint)
+			r.GET("/:id", setUserCtx(1, gameServer.RoleUser), handler.getOnePoint)
 
 			w := httptest.NewRecorder()
 			req := httptest.NewRequest("GET", fmt.Sprintf("/%s", tt.paramId), nil)
@@ -221,7 +294,7 @@ func TestHandler_getOnePoint(t *testing.T) {
 }
 
 func TestHandler_getAllPointsById(t *testing.T) {
-	type mockBehavior func(r *service.MockPoint, id string)
+	type mockBehavior func(r *service.MockPoint, rc *service.MockChart, id string)
 
 	tests := []struct {
 		name                string
@@ -234,8 +307,9 @@ func TestHandler_getAllPointsById(t *testing.T) {
 		{
 			name:         "ok",
 			paramChartId: "1",
-			mockBehavior: func(r *service.MockPoint, id string) {
+			mockBehavior: func(r *service.MockPoint, rc *service.MockChart, id string) {
 				idInt, _ := strconv.Atoi(id)
+				rc.EXPECT().GetOneChart(1).Return(gameServer.Chart{Id: 1, UserId: 1}, nil)
 				r.EXPECT().GetAllPointsById(idInt).Return([]gameServer.Point{
 					{
 						Id:                  1,
@@ -255,52 +329,63 @@ func TestHandler_getAllPointsById(t *testing.T) {
 					nil)
 			},
 			expectedStatusCode:  200,
-			expectedRequestBody: `{"data":[{"id":1,"x":1,"y":1,"score":1,"is_crash":false,"is_useful_ai_signal":false,"is_deceptive_ai_signal":false,"is_stop":false,"is_pause":false,"is_check":false,"chart_id":1,"created_at":"2023-10-01T00:00:00Z"}]}`,
+			expectedRequestBody: `{"data":[{"id":1,"x":1,"y":1,"score":1,"is_crash":false,"is_useful_ai_signal":false,"is_deceptive_ai_signal":false,"is_stop":false,"is_pause":false,"is_check":false,"chart_id":1,"created_at":"2023-10-01T00:00:00Z","hint_type":null,"risk_level":null,"crash_probability":null,"ai_confidence":null,"reaction_time_ms":null,"signal_shown_at":null,"responded_at":null}]}`,
 		},
 		{
 			name:               "incorrect parameter chart id - negative value",
 			paramChartId:       "-1",
-			mockBehavior:       func(r *service.MockPoint, id string) {},
+			mockBehavior:       func(r *service.MockPoint, rc *service.MockChart, id string) {},
 			expectedStatusCode: 400,
 			isError:            true,
 		},
 		{
 			name:               "incorrect parameter chart id - zero value",
 			paramChartId:       "0",
-			mockBehavior:       func(r *service.MockPoint, id string) {},
+			mockBehavior:       func(r *service.MockPoint, rc *service.MockChart, id string) {},
 			expectedStatusCode: 400,
 			isError:            true,
 		},
 		{
 			name:               "incorrect parameter chart id - not a number",
 			paramChartId:       "abc",
-			mockBehavior:       func(r *service.MockPoint, id string) {},
+			mockBehavior:       func(r *service.MockPoint, rc *service.MockChart, id string) {},
 			expectedStatusCode: 400,
 			isError:            true,
 		},
 		{
 			name:         "internal server error",
 			paramChartId: "1",
-			mockBehavior: func(r *service.MockPoint, id string) {
+			mockBehavior: func(r *service.MockPoint, rc *service.MockChart, id string) {
 				idInt, _ := strconv.Atoi(id)
+				rc.EXPECT().GetOneChart(1).Return(gameServer.Chart{Id: 1, UserId: 1}, nil)
 				r.EXPECT().GetAllPointsById(idInt).Return(nil, errors.New(""))
 			},
 			expectedStatusCode: 500,
 			isError:            true,
 		},
+		{
+			name:         "access denied - chart of another user",
+			paramChartId: "2",
+			mockBehavior: func(r *service.MockPoint, rc *service.MockChart, id string) {
+				rc.EXPECT().GetOneChart(2).Return(gameServer.Chart{Id: 2, UserId: 2}, nil)
+			},
+			expectedStatusCode: 403,
+			isError:            true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			pointMock := service.NewMockPoint(t)
-			tt.mockBehavior(pointMock, tt.paramChartId)
+			chartMock := service.NewMockChart(t)
+			tt.mockBehavior(pointMock, chartMock, tt.paramChartId)
 
-			services := &service.Service{Point: pointMock}
+			services := &service.Service{Point: pointMock, Chart: chartMock}
 			handler := NewHandler(services)
 
 			gin.SetMode(gin.TestMode)
 			r := gin.New()
-			r.GET("/chart_id/:chart_id", handler.getAllPointsById)
+			r.GET("/chart_id/:chart_id", setUserCtx(1, gameServer.RoleUser), handler.getAllPointsById)
 
 			w := httptest.NewRecorder()
 			req := httptest.NewRequest("GET", fmt.Sprintf("/chart_id/%s", tt.paramChartId), nil)
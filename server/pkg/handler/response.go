@@ -1,19 +1,138 @@
 package handler
 
 import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	gameServer "example.com/gameHoldTheProcessServer"
+	"example.com/gameHoldTheProcessServer/pkg/i18n"
 	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
 	"github.com/sirupsen/logrus"
 )
 
 type errorResponse struct {
 	Message string `json:"error"`
+	Code    string `json:"code"`
 }
 
 type statusResponse struct {
 	Status string `json:"status"`
 }
 
+// newErrorResponse reports an error whose message is not in the catalog,
+// e.g. a validation error, with the generic code of the status.
 func newErrorResponse(c *gin.Context, statusCode int, message string) {
 	logrus.Error(message)
-	c.AbortWithStatusJSON(statusCode, errorResponse{message})
+	c.AbortWithStatusJSON(statusCode, errorResponse{Message: message, Code: statusErrorCode(statusCode)})
+}
+
+// newCodedErrorResponse reports an error of the catalog translated to the
+// locale of the request.
+func newCodedErrorResponse(c *gin.Context, statusCode int, code string, args ...any) {
+	logrus.Error(i18n.T(i18n.DefaultLocale, i18n.ErrorKey(code), args...))
+	c.AbortWithStatusJSON(statusCode, errorResponse{
+		Message: i18n.T(requestLocale(c), i18n.ErrorKey(code), args...),
+		Code:    code,
+	})
+}
+
+func requestLocale(c *gin.Context) string {
+	return i18n.MatchLocale(c.GetHeader("Accept-Language"))
+}
+
+func statusErrorCode(statusCode int) string {
+	switch statusCode {
+	case http.StatusBadRequest:
+		return i18n.CodeBadRequest
+	case http.StatusUnauthorized:
+		return i18n.CodeUnauthorized
+	case http.StatusForbidden:
+		return i18n.CodeForbidden
+	case http.StatusNotFound:
+		return i18n.CodeNotFound
+	case http.StatusConflict:
+		return i18n.CodeConflict
+	default:
+		return i18n.CodeInternalError
+	}
+}
+
+type parSetConflictResponse struct {
+	Message    string                      `json:"error"`
+	Code       string                      `json:"code"`
+	References gameServer.ParSetReferences `json:"references"`
+}
+
+// restrictViolation is the code of the error the database raises for a change
+// of a parameter set that has been played.
+const restrictViolation = "23001"
+
+// newParSetErrorResponse reports a missing parameter set as 404, an archived
+// or played one as a conflict, a parameter set that is still referenced as a
+// conflict together with the references and other errors as 500.
+func newParSetErrorResponse(c *gin.Context, err error) {
+	if errors.Is(err, sql.ErrNoRows) {
+		newCodedErrorResponse(c, http.StatusNotFound, i18n.CodeNotFound)
+		return
+	}
+	if errors.Is(err, gameServer.ErrParSetArchived) {
+		newCodedErrorResponse(c, http.StatusConflict, i18n.CodeParSetArchived)
+		return
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == restrictViolation {
+		newCodedErrorResponse(c, http.StatusConflict, i18n.CodeParSetPlayed)
+		return
+	}
+
+	var conflictErr *gameServer.ParSetConflictError
+	if !errors.As(err, &conflictErr) {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	locale := requestLocale(c)
+	references := conflictErr.References
+	logrus.Error(conflictErr.Error())
+	c.AbortWithStatusJSON(http.StatusConflict, parSetConflictResponse{
+		Message: i18n.T(locale, i18n.ErrorKey(i18n.CodeParSetInUse),
+			i18n.T(locale, "par_set_action."+conflictErr.Action), references.ChartCount, references.GroupIds, references.UserIds),
+		Code:       i18n.CodeParSetInUse,
+		References: references,
+	})
+}
+
+type questionErrorResponse struct {
+	Message string `json:"error"`
+	Code    string `json:"code"`
+}
+
+type answersErrorResponse struct {
+	Message   string                           `json:"error"`
+	Code      string                           `json:"code"`
+	Questions map[string]questionErrorResponse `json:"questions"`
+}
+
+// newAnswersErrorResponse reports invalid answers of a test as 400 with the
+// error of every invalid question, other errors as 500.
+func newAnswersErrorResponse(c *gin.Context, err error) {
+	var answersErr *gameServer.AnswersError
+	if !errors.As(err, &answersErr) {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	locale := requestLocale(c)
+	questions := make(map[string]questionErrorResponse, len(answersErr.Questions))
+	for id, code := range answersErr.Questions {
+		questions[id] = questionErrorResponse{Message: i18n.T(locale, i18n.AnswerErrorKey(code)), Code: code}
+	}
+	logrus.Error(answersErr.Error())
+	c.AbortWithStatusJSON(http.StatusBadRequest, answersErrorResponse{
+		Message:   i18n.T(locale, i18n.ErrorKey(i18n.CodeInvalidAnswers)),
+		Code:      i18n.CodeInvalidAnswers,
+		Questions: questions,
+	})
 }
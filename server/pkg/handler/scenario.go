@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	gameServer "example.com/gameHoldTheProcessServer"
+	"example.com/gameHoldTheProcessServer/pkg/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) createScenarios(c *gin.Context) {
+	parSetId, err := strconv.Atoi(c.Param("id"))
+	if err != nil || parSetId <= 0 {
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "id")
+		return
+	}
+
+	var input gameServer.CreateScenariosInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := input.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ids, err := h.services.Scenario.CreateScenarios(parSetId, input)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]any{
+		"ids": ids,
+	})
+}
+
+type getAllScenariosResponse struct {
+	Data []gameServer.Scenario `json:"data"`
+}
+
+func (h *Handler) getAllScenarios(c *gin.Context) {
+	parSetId, err := strconv.Atoi(c.Param("id"))
+	if err != nil || parSetId <= 0 {
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "id")
+		return
+	}
+
+	scenarios, err := h.services.Scenario.GetAllScenarios(parSetId)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllScenariosResponse{
+		Data: scenarios,
+	})
+}
+
+type getChartScenarioResponse struct {
+	Data gameServer.Scenario `json:"data"`
+}
+
+func (h *Handler) getChartScenario(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "id")
+		return
+	}
+
+	if !h.checkChartAccess(c, id) {
+		return
+	}
+
+	scenario, err := h.services.Scenario.GetChartScenario(id)
+	if err != nil {
+		if errors.Is(err, gameServer.ErrNoScenarios) {
+			newCodedErrorResponse(c, http.StatusNotFound, i18n.CodeNoScenarios)
+			return
+		}
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, getChartScenarioResponse{
+		Data: scenario,
+	})
+}
@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	gameServer "example.com/gameHoldTheProcessServer"
+	"example.com/gameHoldTheProcessServer/pkg/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_createScenarios(t *testing.T) {
+	type mockBehavior func(r *service.MockScenario, parSetId int, input gameServer.CreateScenariosInput)
+
+	seed := int64(42)
+
+	tests := []struct {
+		name                string
+		paramId             string
+		inputBody           string
+		input               gameServer.CreateScenariosInput
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+		isError             bool
+	}{
+		{
+			name:      "ok",
+			paramId:   "1",
+			inputBody: `{"count": 2, "length": 100, "seed": 42}`,
+			input:     gameServer.CreateScenariosInput{Count: 2, Length: 100, Seed: &seed},
+			mockBehavior: func(r *service.MockScenario, parSetId int, input gameServer.CreateScenariosInput) {
+				r.EXPECT().CreateScenarios(parSetId, input).Return([]int{1, 2}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"ids":[1,2]}`,
+		},
+		{
+			name:      "ok - default length",
+			paramId:   "1",
+			inputBody: `{"count": 1}`,
+			input:     gameServer.CreateScenariosInput{Count: 1, Length: gameServer.DefaultScenarioLength},
+			mockBehavior: func(r *service.MockScenario, parSetId int, input gameServer.CreateScenariosInput) {
+				r.EXPECT().CreateScenarios(parSetId, input).Return([]int{1}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"ids":[1]}`,
+		},
+		{
+			name:               "incorrect count - zero",
+			paramId:            "1",
+			inputBody:          `{"count": 0}`,
+			mockBehavior:       func(r *service.MockScenario, parSetId int, input gameServer.CreateScenariosInput) {},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
+		{
+			name:               "incorrect length - too long",
+			paramId:            "1",
+			inputBody:          `{"count": 1, "length": 100000}`,
+			mockBehavior:       func(r *service.MockScenario, parSetId int, input gameServer.CreateScenariosInput) {},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
+		{
+			name:               "incorrect id",
+			paramId:            "abc",
+			inputBody:          `{"count": 1}`,
+			mockBehavior:       func(r *service.MockScenario, parSetId int, input gameServer.CreateScenariosInput) {},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
+		{
+			name:      "internal server error",
+			paramId:   "1",
+			inputBody: `{"count": 1}`,
+			input:     gameServer.CreateScenariosInput{Count: 1, Length: gameServer.DefaultScenarioLength},
+			mockBehavior: func(r *service.MockScenario, parSetId int, input gameServer.CreateScenariosInput) {
+				r.EXPECT().CreateScenarios(parSetId, input).Return(nil, errors.New(""))
+			},
+			expectedStatusCode: 500,
+			isError:            true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenarioMock := service.NewMockScenario(t)
+			parSetId, _ := strconv.Atoi(tt.paramId)
+			tt.mockBehavior(scenarioMock, parSetId, tt.input)
+
+			services := &service.Service{Scenario: scenarioMock}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/parSet/:id/scenarios", handler.createScenarios)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", fmt.Sprintf("/parSet/%s/scenarios", tt.paramId), bytes.NewBufferString(tt.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			if tt.isError {
+				assert.Contains(t, w.Body.String(), "error")
+			} else {
+				assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_getChartScenario(t *testing.T) {
+	type mockBehavior func(r *service.MockScenario, rc *service.MockChart, id int)
+
+	scenario := gameServer.Scenario{
+		Id:             3,
+		ParameterSetId: 1,
+		Seed:           42,
+		Trajectory:     json.RawMessage(`[0,0.4,1.2]`),
+		DangerPoints:   json.RawMessage(`[1]`),
+		AiSignals:      json.RawMessage(`[1]`),
+		CreatedAt:      "2023-10-01T00:00:00Z",
+	}
+
+	tests := []struct {
+		name                string
+		paramId             string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+		isError             bool
+	}{
+		{
+			name:    "ok",
+			paramId: "1",
+			mockBehavior: func(r *service.MockScenario, rc *service.MockChart, id int) {
+				rc.EXPECT().GetOneChart(id).Return(gameServer.Chart{Id: id, UserId: 1}, nil)
+				r.EXPECT().GetChartScenario(id).Return(scenario, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"data":{"id":3,"parameter_set_id":1,"seed":42,"trajectory":[0,0.4,1.2],"danger_points":[1],"ai_signals":[1],"created_at":"2023-10-01T00:00:00Z"}}`,
+		},
+		{
+			name:    "no scenarios in parameter set",
+			paramId: "1",
+			mockBehavior: func(r *service.MockScenario, rc *service.MockChart, id int) {
+				rc.EXPECT().GetOneChart(id).Return(gameServer.Chart{Id: id, UserId: 1}, nil)
+				r.EXPECT().GetChartScenario(id).Return(gameServer.Scenario{}, gameServer.ErrNoScenarios)
+			},
+			expectedStatusCode: 404,
+			isError:            true,
+		},
+		{
+			name:               "incorrect id",
+			paramId:            "abc",
+			mockBehavior:       func(r *service.MockScenario, rc *service.MockChart, id int) {},
+			expectedStatusCode: 400,
+			isError:            true,
+		},
+		{
+			name:    "access denied - chart of another user",
+			paramId: "1",
+			mockBehavior: func(r *service.MockScenario, rc *service.MockChart, id int) {
+				rc.EXPECT().GetOneChart(id).Return(gameServer.Chart{Id: id, UserId: 2}, nil)
+			},
+			expectedStatusCode: 403,
+			isError:            true,
+		},
+		{
+			name:    "internal server error",
+			paramId: "1",
+			mockBehavior: func(r *service.MockScenario, rc *service.MockChart, id int) {
+				rc.EXPECT().GetOneChart(id).Return(gameServer.Chart{Id: id, UserId: 1}, nil)
+				r.EXPECT().GetChartScenario(id).Return(gameServer.Scenario{}, errors.New(""))
+			},
+			expectedStatusCode: 500,
+			isError:            true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scenarioMock := service.NewMockScenario(t)
+			chartMock := service.NewMockChart(t)
+			id, _ := strconv.Atoi(tt.paramId)
+			tt.mockBehavior(scenarioMock, chartMock, id)
+
+			services := &service.Service{Scenario: scenarioMock, Chart: chartMock}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/:id/scenario", setUserCtx(1, gameServer.RoleUser), handler.getChartScenario)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", fmt.Sprintf("/%s/scenario", tt.paramId), nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			if tt.isError {
+				assert.Contains(t, w.Body.String(), "error")
+			} else {
+				assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+			}
+		})
+	}
+}
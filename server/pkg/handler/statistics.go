@@ -1,10 +1,13 @@
 package handler
 
 import (
+	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	gameServer "example.com/gameHoldTheProcessServer"
+	"example.com/gameHoldTheProcessServer/pkg/i18n"
 	"github.com/gin-gonic/gin"
 )
 
@@ -24,6 +27,7 @@ func (h *Handler) computeStatistics(c *gin.Context) {
 		return
 	}
 
+	input.Locale = requestLocale(c)
 	stats, err := h.services.Statistics.ComputeStatistics(input)
 	if err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, err.Error())
@@ -42,13 +46,13 @@ type getStatisticsResponse struct {
 func (h *Handler) getStatistics(c *gin.Context) {
 	userId, err := strconv.Atoi(c.Param("userId"))
 	if err != nil || userId <= 0 {
-		newErrorResponse(c, http.StatusBadRequest, "invalid parameter userId")
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "userId")
 		return
 	}
 
 	parSetId, err := strconv.Atoi(c.Param("parSetId"))
 	if err != nil || parSetId <= 0 {
-		newErrorResponse(c, http.StatusBadRequest, "invalid parameter parSetId")
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "parSetId")
 		return
 	}
 
@@ -62,3 +66,82 @@ func (h *Handler) getStatistics(c *gin.Context) {
 		Data: stats,
 	})
 }
+
+func (h *Handler) getTestGameCorrelations(c *gin.Context) {
+	groupId, err := strconv.Atoi(c.Param("groupId"))
+	if err != nil || groupId <= 0 {
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "groupId")
+		return
+	}
+
+	parSetId, err := strconv.Atoi(c.Param("parSetId"))
+	if err != nil || parSetId <= 0 {
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "parSetId")
+		return
+	}
+
+	var input gameServer.TestGameCorrelationsInput
+	if err := c.ShouldBindQuery(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := input.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !h.checkGroupAccess(c, groupId) {
+		return
+	}
+
+	input.GroupId = groupId
+	input.ParSetId = parSetId
+	input.Locale = requestLocale(c)
+	correlations, err := h.services.Statistics.GetTestGameCorrelations(input)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, correlations)
+}
+
+const liveHeartbeatInterval = 15 * time.Second
+
+func (h *Handler) streamLiveEvents(c *gin.Context) {
+	groupId, err := strconv.Atoi(c.Param("groupId"))
+	if err != nil || groupId <= 0 {
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "groupId")
+		return
+	}
+
+	if !h.checkGroupAccess(c, groupId) {
+		return
+	}
+
+	events, unsubscribe := h.services.Live.Subscribe(groupId)
+	defer unsubscribe()
+
+	// The server write timeout is meant for regular requests, not for a stream
+	// that stays open for the whole session.
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	heartbeat := time.NewTicker(liveHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.SSEvent(event.Type, event)
+			return true
+		case <-heartbeat.C:
+			c.SSEvent("heartbeat", time.Now().UTC().Format(time.RFC3339))
+			return true
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	gameServer "example.com/gameHoldTheProcessServer"
+	"example.com/gameHoldTheProcessServer/pkg/i18n"
+	"example.com/gameHoldTheProcessServer/pkg/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler_getTestGameCorrelations(t *testing.T) {
+	type mockBehavior func(s *service.MockStatistics, u *service.MockUser, input gameServer.TestGameCorrelationsInput)
+
+	correlation, pValue := -0.62, 0.04
+
+	tests := []struct {
+		name                string
+		path                string
+		acceptLanguage      string
+		role                string
+		input               gameServer.TestGameCorrelationsInput
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:           "ok",
+			path:           "/statistics/tests/group_id/3/par_set_id/2",
+			acceptLanguage: "ru-RU,ru;q=0.9",
+			role:           gameServer.RoleAdmin,
+			input:          gameServer.TestGameCorrelationsInput{GroupId: 3, ParSetId: 2, Locale: i18n.LocaleRu},
+			mockBehavior: func(s *service.MockStatistics, u *service.MockUser, input gameServer.TestGameCorrelationsInput) {
+				s.EXPECT().GetTestGameCorrelations(input).Return(gameServer.TestGameCorrelations{
+					GroupId:  3,
+					ParSetId: 2,
+					Variables: []gameServer.CorrelationVariable{
+						{Id: "test.asrs", Title: "ASRS", Source: gameServer.VariableSourceTest},
+						{Id: gameServer.MetricMeanStopY, Title: "Средний Y остановки", Source: gameServer.VariableSourceGame},
+					},
+					Participants: []gameServer.ParticipantMeasures{
+						{UserId: 5, Name: "Anna", Values: map[string]float64{"test.asrs": 4, gameServer.MetricMeanStopY: 1.2}},
+						{UserId: 6, Name: "Boris", Values: map[string]float64{}},
+					},
+					R: [][]*float64{{nil, &correlation}, {&correlation, nil}},
+					P: [][]*float64{{nil, &pValue}, {&pValue, nil}},
+					N: [][]int{{1, 1}, {1, 1}},
+				}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"group_id":3,"par_set_id":2,"variables":[{"id":"test.asrs","title":"ASRS","source":"test"},{"id":"mean_stop_y","title":"Средний Y остановки","source":"game"}],"participants":[{"user_id":5,"name":"Anna","values":{"mean_stop_y":1.2,"test.asrs":4}},{"user_id":6,"name":"Boris","values":{}}],"r":[[null,-0.62],[-0.62,null]],"p":[[null,0.04],[0.04,null]],"n":[[1,1],[1,1]]}`,
+		},
+		{
+			name: "ok - group creator, study phase",
+			path: "/statistics/tests/group_id/3/par_set_id/2?context=study_phase&phase=after",
+			role: gameServer.RoleResearcher,
+			input: gameServer.TestGameCorrelationsInput{
+				GroupId:  3,
+				ParSetId: 2,
+				Context:  gameServer.TestContextStudyPhase,
+				Phase:    "after",
+				Locale:   i18n.LocaleEn,
+			},
+			mockBehavior: func(s *service.MockStatistics, u *service.MockUser, input gameServer.TestGameCorrelationsInput) {
+				u.EXPECT().GetOneGroup(3).Return(gameServer.Group{Id: 3, CreatorId: 1}, nil)
+				s.EXPECT().GetTestGameCorrelations(input).Return(gameServer.TestGameCorrelations{
+					GroupId:      3,
+					ParSetId:     2,
+					Variables:    []gameServer.CorrelationVariable{},
+					Participants: []gameServer.ParticipantMeasures{},
+					R:            [][]*float64{},
+					P:            [][]*float64{},
+					N:            [][]int{},
+				}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"group_id":3,"par_set_id":2,"variables":[],"participants":[],"r":[],"p":[],"n":[]}`,
+		},
+		{
+			name: "another researcher's group",
+			path: "/statistics/tests/group_id/3/par_set_id/2",
+			role: gameServer.RoleResearcher,
+			mockBehavior: func(s *service.MockStatistics, u *service.MockUser, input gameServer.TestGameCorrelationsInput) {
+				u.EXPECT().GetOneGroup(3).Return(gameServer.Group{Id: 3, CreatorId: 2}, nil)
+			},
+			expectedStatusCode:  403,
+			expectedRequestBody: `{"error":"access to the resource is denied","code":"access_denied"}`,
+		},
+		{
+			name:                "invalid group id",
+			path:                "/statistics/tests/group_id/0/par_set_id/2",
+			role:                gameServer.RoleAdmin,
+			mockBehavior:        func(s *service.MockStatistics, u *service.MockUser, input gameServer.TestGameCorrelationsInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"invalid parameter groupId","code":"invalid_parameter"}`,
+		},
+		{
+			name:                "invalid parameter set id",
+			path:                "/statistics/tests/group_id/3/par_set_id/abc",
+			role:                gameServer.RoleAdmin,
+			mockBehavior:        func(s *service.MockStatistics, u *service.MockUser, input gameServer.TestGameCorrelationsInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"invalid parameter parSetId","code":"invalid_parameter"}`,
+		},
+		{
+			name:                "unknown context",
+			path:                "/statistics/tests/group_id/3/par_set_id/2?context=lunch",
+			role:                gameServer.RoleAdmin,
+			mockBehavior:        func(s *service.MockStatistics, u *service.MockUser, input gameServer.TestGameCorrelationsInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"unknown test context \"lunch\"","code":"bad_request"}`,
+		},
+		{
+			name:  "service error",
+			path:  "/statistics/tests/group_id/3/par_set_id/2",
+			role:  gameServer.RoleAdmin,
+			input: gameServer.TestGameCorrelationsInput{GroupId: 3, ParSetId: 2, Locale: i18n.LocaleEn},
+			mockBehavior: func(s *service.MockStatistics, u *service.MockUser, input gameServer.TestGameCorrelationsInput) {
+				s.EXPECT().GetTestGameCorrelations(input).Return(gameServer.TestGameCorrelations{}, errors.New("db is down"))
+			},
+			expectedStatusCode:  500,
+			expectedRequestBody: `{"error":"db is down","code":"internal_error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statisticsMock := service.NewMockStatistics(t)
+			userMock := service.NewMockUser(t)
+			tt.mockBehavior(statisticsMock, userMock, tt.input)
+
+			services := &service.Service{Statistics: statisticsMock, User: userMock}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/statistics/tests/group_id/:groupId/par_set_id/:parSetId", setUserCtx(1, tt.role), handler.getTestGameCorrelations)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", tt.path, nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
@@ -1,10 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	gameServer "example.com/gameHoldTheProcessServer"
+	"example.com/gameHoldTheProcessServer/pkg/i18n"
 	"github.com/gin-gonic/gin"
 )
 
@@ -40,7 +42,7 @@ func (h *Handler) getTestSessionStatus(c *gin.Context) {
 
 func (h *Handler) submitTestResult(c *gin.Context) {
 	if !h.isRegularUser(c) {
-		newErrorResponse(c, http.StatusForbidden, "tests are available only for users")
+		newCodedErrorResponse(c, http.StatusForbidden, i18n.CodeTestsOnlyForUsers)
 		return
 	}
 
@@ -57,7 +59,11 @@ func (h *Handler) submitTestResult(c *gin.Context) {
 	userId, _ := c.Get(userCtx)
 	id, err := h.services.Test.SubmitResult(userId.(int), input)
 	if err != nil {
-		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		if errors.Is(err, gameServer.ErrRetakeNotAllowed) {
+			newCodedErrorResponse(c, http.StatusConflict, i18n.CodeRetakeNotAllowed)
+			return
+		}
+		newAnswersErrorResponse(c, err)
 		return
 	}
 
@@ -66,6 +72,85 @@ func (h *Handler) submitTestResult(c *gin.Context) {
 	})
 }
 
+func (h *Handler) saveTestProgress(c *gin.Context) {
+	if !h.isRegularUser(c) {
+		newCodedErrorResponse(c, http.StatusForbidden, i18n.CodeTestsOnlyForUsers)
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "id")
+		return
+	}
+
+	var input gameServer.SaveTestProgressInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := input.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, _ := c.Get(userCtx)
+	if err := h.services.Test.SaveProgress(userId.(int), id, input); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{Status: "ok"})
+}
+
+func (h *Handler) getTestProgress(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "id")
+		return
+	}
+	if !h.isRegularUser(c) {
+		c.JSON(http.StatusOK, map[string]any{"data": []gameServer.TestDraft{}})
+		return
+	}
+
+	userId, _ := c.Get(userCtx)
+	drafts, err := h.services.Test.GetProgress(userId.(int), id)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]any{"data": drafts})
+}
+
+func (h *Handler) getTestVisibility(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "id")
+		return
+	}
+
+	var input gameServer.TestVisibilityInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := input.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userId, _ := c.Get(userCtx)
+	visibility, err := h.services.Test.GetVisibility(userId.(int), id, input)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, visibility)
+}
+
 type getAllTestsResponse struct {
 	Data []gameServer.Test `json:"data"`
 }
@@ -103,10 +188,41 @@ func (h *Handler) createTest(c *gin.Context) {
 	c.JSON(http.StatusOK, map[string]any{"id": id})
 }
 
+func (h *Handler) getInstruments(c *gin.Context) {
+	c.JSON(http.StatusOK, map[string]any{"data": h.services.Test.GetInstruments(requestLocale(c))})
+}
+
+func (h *Handler) createTestFromInstrument(c *gin.Context) {
+	var input gameServer.CreateInstrumentTestInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := input.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id, err := h.services.Test.CreateTestFromInstrument(c.Param("id"), input)
+	if err != nil {
+		switch {
+		case errors.Is(err, gameServer.ErrUnknownInstrument):
+			newCodedErrorResponse(c, http.StatusNotFound, i18n.CodeUnknownInstrument, c.Param("id"))
+		case errors.Is(err, gameServer.ErrInstrumentLocale):
+			newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInstrumentLocale, input.Locale)
+		default:
+			newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]any{"id": id})
+}
+
 func (h *Handler) updateTest(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil || id <= 0 {
-		newErrorResponse(c, http.StatusBadRequest, "invalid parameter id")
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "id")
 		return
 	}
 
@@ -121,6 +237,10 @@ func (h *Handler) updateTest(c *gin.Context) {
 	}
 
 	if err := h.services.Test.UpdateTest(id, input); err != nil {
+		if errors.Is(err, gameServer.ErrInvalidTestConfig) {
+			newErrorResponse(c, http.StatusBadRequest, err.Error())
+			return
+		}
 		newErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -131,7 +251,7 @@ func (h *Handler) updateTest(c *gin.Context) {
 func (h *Handler) deleteTest(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil || id <= 0 {
-		newErrorResponse(c, http.StatusBadRequest, "invalid parameter id")
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "id")
 		return
 	}
 
@@ -165,7 +285,11 @@ func (h *Handler) getUserTestResults(c *gin.Context) {
 func (h *Handler) getPlayerTestResults(c *gin.Context) {
 	userId, err := strconv.Atoi(c.Param("userId"))
 	if err != nil || userId <= 0 {
-		newErrorResponse(c, http.StatusBadRequest, "invalid parameter userId")
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "userId")
+		return
+	}
+
+	if !h.checkUserAccess(c, userId) {
 		return
 	}
 
@@ -180,3 +304,45 @@ func (h *Handler) getPlayerTestResults(c *gin.Context) {
 
 	c.JSON(http.StatusOK, map[string]any{"data": results})
 }
+
+func (h *Handler) getTestReport(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "id")
+		return
+	}
+
+	var input gameServer.TestReportInput
+	if err := c.ShouldBindQuery(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := input.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if input.GroupId != nil {
+		if !h.checkGroupAccess(c, *input.GroupId) {
+			return
+		}
+	} else if role, _ := c.Get(userCtxRole); role != gameServer.RoleAdmin {
+		// A researcher only sees the results of the users of their groups.
+		requesterId := c.GetInt(userCtx)
+		input.CreatorId = &requesterId
+	}
+
+	report, err := h.services.Test.GetReport(id, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, gameServer.ErrTestNotFound):
+			newCodedErrorResponse(c, http.StatusNotFound, i18n.CodeNotFound)
+		case errors.Is(err, gameServer.ErrTestNotScaled):
+			newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeTestNotScaled)
+		default:
+			newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,1175 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	gameServer "example.com/gameHoldTheProcessServer"
+	"example.com/gameHoldTheProcessServer/pkg/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+const asrsConfig = `{"scale": {"min": 0, "max": 4}, "scoring": "sum", "questions": [{"id": "q1", "text": "a", "threshold": 2}, {"id": "q2", "text": "b", "threshold": 3}, {"id": "q3", "text": "c", "reverse": true, "weight": 2}], "subscales": [{"id": "part_a", "title": "Part A", "items": ["q1", "q2"], "scoring": "threshold_count", "interpretations": [{"min": 2, "label": "positive"}]}], "interpretations": [{"max": 5, "label": "low"}, {"min": 5, "label": "high"}]}`
+
+const mixedConfig = `{"scale": {"min": 1, "max": 5}, "items": [{"id": "demand", "type": "slider", "text": "a", "scale": {"min": 0, "max": 100}, "step": 5}, {"id": "trust", "type": "matrix", "text": "b", "rows": [{"id": "r1", "text": "c"}, {"id": "r2", "text": "d", "reverse": true}]}, {"id": "tools", "type": "multi_select", "text": "e", "options": ["x", "y"], "option_scores": {"x": 1}}, {"id": "order", "type": "ranking", "text": "f", "options": ["x", "y"]}], "subscales": [{"id": "trust", "title": "Trust", "items": ["trust"]}]}`
+
+func TestHandler_createTest(t *testing.T) {
+	type mockBehavior func(r *service.MockTest, input gameServer.CreateTestInput)
+
+	tests := []struct {
+		name                string
+		inputBody           string
+		input               gameServer.CreateTestInput
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "ok - likert with subscales",
+			inputBody: fmt.Sprintf(`{"slug": "asrs", "type": "likert", "title": "ASRS", "config": %s, "is_active": true}`, asrsConfig),
+			input: gameServer.CreateTestInput{
+				Slug:         "asrs",
+				Type:         gameServer.TestTypeLikert,
+				Title:        "ASRS",
+				Config:       json.RawMessage(asrsConfig),
+				IsActive:     true,
+				RetakePolicy: gameServer.RetakeNever,
+			},
+			mockBehavior: func(r *service.MockTest, input gameServer.CreateTestInput) {
+				r.EXPECT().CreateTest(input).Return(1, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"id":1}`,
+		},
+		{
+			name:      "ok - second likert test with another slug",
+			inputBody: `{"slug": "tias", "type": "likert", "title": "Trust", "config": {"questions": [{"id": "q1", "text": "a"}]}}`,
+			input: gameServer.CreateTestInput{
+				Slug:         "tias",
+				Type:         gameServer.TestTypeLikert,
+				Title:        "Trust",
+				Config:       json.RawMessage(`{"questions": [{"id": "q1", "text": "a"}]}`),
+				RetakePolicy: gameServer.RetakeNever,
+			},
+			mockBehavior: func(r *service.MockTest, input gameServer.CreateTestInput) {
+				r.EXPECT().CreateTest(input).Return(2, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"id":2}`,
+		},
+		{
+			name:                "unknown type",
+			inputBody:           `{"slug": "asrs", "type": "matrix", "title": "ASRS", "config": {}}`,
+			mockBehavior:        func(r *service.MockTest, input gameServer.CreateTestInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"invalid test config: unknown test type \"matrix\"","code":"bad_request"}`,
+		},
+		{
+			name:                "missing type",
+			inputBody:           `{"slug": "asrs", "title": "ASRS", "config": {}}`,
+			mockBehavior:        func(r *service.MockTest, input gameServer.CreateTestInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"Key: 'CreateTestInput.Type' Error:Field validation for 'Type' failed on the 'required' tag","code":"bad_request"}`,
+		},
+		{
+			name:                "subscale with unknown question",
+			inputBody:           `{"slug": "asrs", "type": "likert", "title": "ASRS", "config": {"questions": [{"id": "q1", "text": "a"}], "subscales": [{"id": "a", "items": ["q7"]}]}}`,
+			mockBehavior:        func(r *service.MockTest, input gameServer.CreateTestInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"invalid test config: subscale \"a\" refers to unknown question \"q7\"","code":"bad_request"}`,
+		},
+		{
+			name:                "scale with min not less than max",
+			inputBody:           `{"slug": "asrs", "type": "likert", "title": "ASRS", "config": {"scale": {"min": 5, "max": 1}, "questions": [{"id": "q1", "text": "a", "reverse": true}]}}`,
+			mockBehavior:        func(r *service.MockTest, input gameServer.CreateTestInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"invalid test config: likert scale min must be less than max","code":"bad_request"}`,
+		},
+		{
+			name:                "condition in a likert test",
+			inputBody:           `{"slug": "asrs", "type": "likert", "title": "ASRS", "config": {"questions": [{"id": "q1", "text": "a"}, {"id": "q2", "text": "b", "show_if": "q1 > 2"}]}}`,
+			mockBehavior:        func(r *service.MockTest, input gameServer.CreateTestInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"invalid test config: show_if is only supported in mixed tests","code":"bad_request"}`,
+		},
+		{
+			name:                "non-positive weight",
+			inputBody:           `{"slug": "asrs", "type": "likert", "title": "ASRS", "config": {"questions": [{"id": "q1", "text": "a", "weight": 0}]}}`,
+			mockBehavior:        func(r *service.MockTest, input gameServer.CreateTestInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"invalid test config: weight of question \"q1\" must be greater than zero","code":"bad_request"}`,
+		},
+		{
+			name:      "internal server error",
+			inputBody: `{"slug": "notes", "type": "text", "title": "Notes", "config": {"questions": []}}`,
+			input: gameServer.CreateTestInput{
+				Slug:         "notes",
+				Type:         gameServer.TestTypeText,
+				Title:        "Notes",
+				Config:       json.RawMessage(`{"questions": []}`),
+				RetakePolicy: gameServer.RetakeNever,
+			},
+			mockBehavior: func(r *service.MockTest, input gameServer.CreateTestInput) {
+				r.EXPECT().CreateTest(input).Return(0, errors.New("db is down"))
+			},
+			expectedStatusCode:  500,
+			expectedRequestBody: `{"error":"db is down","code":"internal_error"}`,
+		},
+		{
+			name:                "single choice question without options",
+			inputBody:           `{"slug": "experience", "type": "single_choice", "title": "Experience", "config": {"questions": [{"id": "q1", "text": "a", "options": []}]}}`,
+			mockBehavior:        func(r *service.MockTest, input gameServer.CreateTestInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"invalid test config: question \"q1\" has no options","code":"bad_request"}`,
+		},
+		{
+			name:                "text question with duplicate ids",
+			inputBody:           `{"slug": "notes", "type": "text", "title": "Notes", "config": {"questions": [{"id": "q1", "text": "a"}, {"id": "q1", "text": "b"}]}}`,
+			mockBehavior:        func(r *service.MockTest, input gameServer.CreateTestInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"invalid test config: question id \"q1\" is not unique","code":"bad_request"}`,
+		},
+		{
+			name:                "unknown retake policy",
+			inputBody:           `{"slug": "notes", "type": "text", "title": "Notes", "config": {"questions": []}, "retake_policy": "twice"}`,
+			mockBehavior:        func(r *service.MockTest, input gameServer.CreateTestInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"unknown retake policy \"twice\"","code":"bad_request"}`,
+		},
+		{
+			name:      "ok - mixed items",
+			inputBody: fmt.Sprintf(`{"slug": "tlx", "type": "mixed", "title": "Workload", "config": %s}`, mixedConfig),
+			input: gameServer.CreateTestInput{
+				Slug:         "tlx",
+				Type:         gameServer.TestTypeMixed,
+				Title:        "Workload",
+				Config:       json.RawMessage(mixedConfig),
+				RetakePolicy: gameServer.RetakeNever,
+			},
+			mockBehavior: func(r *service.MockTest, input gameServer.CreateTestInput) {
+				r.EXPECT().CreateTest(input).Return(4, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"id":4}`,
+		},
+		{
+			name:                "mixed item of unknown type",
+			inputBody:           `{"slug": "tlx", "type": "mixed", "title": "Workload", "config": {"items": [{"id": "q1", "type": "grid", "text": "a"}]}}`,
+			mockBehavior:        func(r *service.MockTest, input gameServer.CreateTestInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"invalid test config: unknown type \"grid\" of question \"q1\"","code":"bad_request"}`,
+		},
+		{
+			name:                "ranking with one option",
+			inputBody:           `{"slug": "tlx", "type": "mixed", "title": "Workload", "config": {"items": [{"id": "q1", "type": "ranking", "text": "a", "options": ["x"]}]}}`,
+			mockBehavior:        func(r *service.MockTest, input gameServer.CreateTestInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"invalid test config: ranking question \"q1\" needs at least two options","code":"bad_request"}`,
+		},
+		{
+			name:                "ranking key that leaves out an option",
+			inputBody:           `{"slug": "tlx", "type": "mixed", "title": "Workload", "config": {"items": [{"id": "q1", "type": "ranking", "text": "a", "options": ["x", "y", "z"], "ranking_key": ["x", "y"]}]}}`,
+			mockBehavior:        func(r *service.MockTest, input gameServer.CreateTestInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"invalid test config: ranking key of question \"q1\" must order all of its options","code":"bad_request"}`,
+		},
+		{
+			name:                "slider without a scale",
+			inputBody:           `{"slug": "tlx", "type": "mixed", "title": "Workload", "config": {"items": [{"id": "q1", "type": "slider", "text": "a"}]}}`,
+			mockBehavior:        func(r *service.MockTest, input gameServer.CreateTestInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"invalid test config: scale of question \"q1\" must have min less than max","code":"bad_request"}`,
+		},
+		{
+			name:                "condition refers to a later question",
+			inputBody:           `{"slug": "exp", "type": "mixed", "title": "Experience", "config": {"items": [{"id": "q1", "type": "text", "text": "a", "show_if": "q2 == 'yes'"}, {"id": "q2", "type": "single_choice", "text": "b", "options": ["yes", "no"]}]}}`,
+			mockBehavior:        func(r *service.MockTest, input gameServer.CreateTestInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"invalid test config: condition of question \"q1\" refers to \"q2\", which is neither an earlier question nor a profile field","code":"bad_request"}`,
+		},
+		{
+			name:                "condition with a syntax error",
+			inputBody:           `{"slug": "exp", "type": "mixed", "title": "Experience", "config": {"sections": [{"id": "pilots", "show_if": "experience_years >"}], "items": [{"id": "q1", "type": "text", "text": "a", "section": "pilots"}]}}`,
+			mockBehavior:        func(r *service.MockTest, input gameServer.CreateTestInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"invalid test config: condition of section \"pilots\": unexpected \"end of condition\" at position 18","code":"bad_request"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testMock := service.NewMockTest(t)
+			tt.mockBehavior(testMock, tt.input)
+
+			services := &service.Service{Test: testMock}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/test", handler.createTest)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/test", bytes.NewBufferString(tt.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_updateTest(t *testing.T) {
+	type mockBehavior func(r *service.MockTest, id int, input gameServer.UpdateTestInput)
+
+	likert := gameServer.TestTypeLikert
+
+	tests := []struct {
+		name                string
+		paramId             string
+		inputBody           string
+		input               gameServer.UpdateTestInput
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "ok",
+			paramId:   "1",
+			inputBody: `{"type": "likert"}`,
+			input:     gameServer.UpdateTestInput{Type: &likert},
+			mockBehavior: func(r *service.MockTest, id int, input gameServer.UpdateTestInput) {
+				r.EXPECT().UpdateTest(id, input).Return(nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"status":"ok"}`,
+		},
+		{
+			name:      "config does not fit the stored type",
+			paramId:   "1",
+			inputBody: `{"type": "likert"}`,
+			input:     gameServer.UpdateTestInput{Type: &likert},
+			mockBehavior: func(r *service.MockTest, id int, input gameServer.UpdateTestInput) {
+				r.EXPECT().UpdateTest(id, input).Return(fmt.Errorf("%w: question id is empty", gameServer.ErrInvalidTestConfig))
+			},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"invalid test config: question id is empty","code":"bad_request"}`,
+		},
+		{
+			name:                "unknown type",
+			paramId:             "1",
+			inputBody:           `{"type": "matrix"}`,
+			mockBehavior:        func(r *service.MockTest, id int, input gameServer.UpdateTestInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"unknown test type \"matrix\"","code":"bad_request"}`,
+		},
+		{
+			name:                "no values to update",
+			paramId:             "1",
+			inputBody:           `{}`,
+			mockBehavior:        func(r *service.MockTest, id int, input gameServer.UpdateTestInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"no values to update","code":"bad_request"}`,
+		},
+		{
+			name:      "internal server error",
+			paramId:   "1",
+			inputBody: `{"type": "likert"}`,
+			input:     gameServer.UpdateTestInput{Type: &likert},
+			mockBehavior: func(r *service.MockTest, id int, input gameServer.UpdateTestInput) {
+				r.EXPECT().UpdateTest(id, input).Return(errors.New("db is down"))
+			},
+			expectedStatusCode:  500,
+			expectedRequestBody: `{"error":"db is down","code":"internal_error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testMock := service.NewMockTest(t)
+			id, _ := strconv.Atoi(tt.paramId)
+			tt.mockBehavior(testMock, id, tt.input)
+
+			services := &service.Service{Test: testMock}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.PUT("/test/:id", handler.updateTest)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", fmt.Sprintf("/test/%s", tt.paramId), bytes.NewBufferString(tt.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_createTestFromInstrument(t *testing.T) {
+	type mockBehavior func(r *service.MockTest, instrumentId string, input gameServer.CreateInstrumentTestInput)
+
+	tests := []struct {
+		name                string
+		paramId             string
+		acceptLanguage      string
+		inputBody           string
+		input               gameServer.CreateInstrumentTestInput
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "ok",
+			paramId:   "nasa_tlx",
+			inputBody: `{"locale": "ru", "is_active": true}`,
+			input:     gameServer.CreateInstrumentTestInput{Locale: "ru", IsActive: true},
+			mockBehavior: func(r *service.MockTest, instrumentId string, input gameServer.CreateInstrumentTestInput) {
+				r.EXPECT().CreateTestFromInstrument(instrumentId, input).Return(5, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"id":5}`,
+		},
+		{
+			name:                "no locale",
+			paramId:             "nasa_tlx",
+			inputBody:           `{"is_active": true}`,
+			mockBehavior:        func(r *service.MockTest, instrumentId string, input gameServer.CreateInstrumentTestInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"Key: 'CreateInstrumentTestInput.Locale' Error:Field validation for 'Locale' failed on the 'required' tag","code":"bad_request"}`,
+		},
+		{
+			name:                "unknown retake policy",
+			paramId:             "nasa_tlx",
+			inputBody:           `{"locale": "en", "retake_policy": "twice"}`,
+			mockBehavior:        func(r *service.MockTest, instrumentId string, input gameServer.CreateInstrumentTestInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"unknown retake policy \"twice\"","code":"bad_request"}`,
+		},
+		{
+			name:           "unknown instrument",
+			paramId:        "sus",
+			acceptLanguage: "ru",
+			inputBody:      `{"locale": "en"}`,
+			input:          gameServer.CreateInstrumentTestInput{Locale: "en"},
+			mockBehavior: func(r *service.MockTest, instrumentId string, input gameServer.CreateInstrumentTestInput) {
+				r.EXPECT().CreateTestFromInstrument(instrumentId, input).Return(0, fmt.Errorf("%w: %q", gameServer.ErrUnknownInstrument, instrumentId))
+			},
+			expectedStatusCode:  404,
+			expectedRequestBody: `{"error":"неизвестная методика sus","code":"unknown_instrument"}`,
+		},
+		{
+			name:      "locale the instrument is not translated to",
+			paramId:   "bfi10",
+			inputBody: `{"locale": "de"}`,
+			input:     gameServer.CreateInstrumentTestInput{Locale: "de"},
+			mockBehavior: func(r *service.MockTest, instrumentId string, input gameServer.CreateInstrumentTestInput) {
+				r.EXPECT().CreateTestFromInstrument(instrumentId, input).Return(0, fmt.Errorf("%w: %q", gameServer.ErrInstrumentLocale, input.Locale))
+			},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"the instrument is not available in the de locale","code":"instrument_locale"}`,
+		},
+		{
+			name:      "slug is taken",
+			paramId:   "bfi10",
+			inputBody: `{"locale": "en", "slug": "bfi"}`,
+			input:     gameServer.CreateInstrumentTestInput{Locale: "en", Slug: "bfi"},
+			mockBehavior: func(r *service.MockTest, instrumentId string, input gameServer.CreateInstrumentTestInput) {
+				r.EXPECT().CreateTestFromInstrument(instrumentId, input).Return(0, errors.New("duplicate key value violates unique constraint"))
+			},
+			expectedStatusCode:  500,
+			expectedRequestBody: `{"error":"duplicate key value violates unique constraint","code":"internal_error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testMock := service.NewMockTest(t)
+			tt.mockBehavior(testMock, tt.paramId, tt.input)
+
+			services := &service.Service{Test: testMock}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/test/instruments/:id", handler.createTestFromInstrument)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", fmt.Sprintf("/test/instruments/%s", tt.paramId), bytes.NewBufferString(tt.inputBody))
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getPlayerTestResults(t *testing.T) {
+	type mockBehavior func(r *service.MockTest, u *service.MockUser, userId int)
+
+	score := 5.0
+	durationMs := int64(1200)
+
+	tests := []struct {
+		name                string
+		paramId             string
+		role                string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:    "ok - structured score and config of the answered version",
+			paramId: "3",
+			role:    gameServer.RoleAdmin,
+			mockBehavior: func(r *service.MockTest, u *service.MockUser, userId int) {
+				r.EXPECT().GetUserResultsWithTests(userId).Return([]gameServer.TestResultWithTest{
+					{
+						TestResult: gameServer.TestResult{
+							Id:           1,
+							UserId:       3,
+							TestId:       2,
+							TestVersion:  2,
+							Attempt:      2,
+							Context:      gameServer.TestContextAfterGame,
+							Answers:      json.RawMessage(`{"q1":2,"q2":3,"q3":4}`),
+							Score:        &score,
+							ScoreDetails: json.RawMessage(`{"total":5,"answered":3,"interpretation":"high","subscales":[{"id":"part_a","title":"Part A","score":2,"answered":2,"interpretation":"positive"}]}`),
+							CompletedAt:  "2024-01-01T00:00:00Z",
+						},
+						Slug:   "asrs",
+						Type:   gameServer.TestTypeLikert,
+						Title:  "ASRS",
+						Config: json.RawMessage(`{"questions":[{"id":"q1","text":"a"}]}`),
+					},
+				}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"data":[{"id":1,"user_id":3,"test_id":2,"test_version":2,"attempt":2,"context":"after_game","answers":{"q1":2,"q2":3,"q3":4},"score":5,"score_details":{"total":5,"answered":3,"interpretation":"high","subscales":[{"id":"part_a","title":"Part A","score":2,"answered":2,"interpretation":"positive"}]},"completed_at":"2024-01-01T00:00:00Z","slug":"asrs","type":"likert","title":"ASRS","description":"","config":{"questions":[{"id":"q1","text":"a"}]},"speeder":false}]}`,
+		},
+		{
+			name:    "ok - timed attempt faster than the minimum duration",
+			paramId: "3",
+			role:    gameServer.RoleAdmin,
+			mockBehavior: func(r *service.MockTest, u *service.MockUser, userId int) {
+				r.EXPECT().GetUserResultsWithTests(userId).Return([]gameServer.TestResultWithTest{
+					{
+						TestResult: gameServer.TestResult{
+							Id:          4,
+							UserId:      3,
+							TestId:      2,
+							TestVersion: 1,
+							Attempt:     1,
+							Context:     gameServer.TestContextOnboarding,
+							Answers:     json.RawMessage(`{"q1":"a"}`),
+							ItemTimes:   json.RawMessage(`{"q1":1200}`),
+							DurationMs:  &durationMs,
+							CompletedAt: "2024-01-01T00:00:00Z",
+						},
+						Slug:    "lab",
+						Type:    gameServer.TestTypeText,
+						Title:   "Lab",
+						Config:  json.RawMessage(`{"questions":[{"id":"q1","text":"a"}]}`),
+						Speeder: true,
+					},
+				}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"data":[{"id":4,"user_id":3,"test_id":2,"test_version":1,"attempt":1,"context":"onboarding","answers":{"q1":"a"},"item_times":{"q1":1200},"duration_ms":1200,"completed_at":"2024-01-01T00:00:00Z","slug":"lab","type":"text","title":"Lab","description":"","config":{"questions":[{"id":"q1","text":"a"}]},"speeder":true}]}`,
+		},
+		{
+			name:                "incorrect user id",
+			paramId:             "abc",
+			role:                gameServer.RoleAdmin,
+			mockBehavior:        func(r *service.MockTest, u *service.MockUser, userId int) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"invalid parameter userId","code":"invalid_parameter"}`,
+		},
+		{
+			name:    "researcher without access to the user",
+			paramId: "3",
+			role:    gameServer.RoleResearcher,
+			mockBehavior: func(r *service.MockTest, u *service.MockUser, userId int) {
+				u.EXPECT().HasResearcherAccess(1, userId).Return(false, nil)
+			},
+			expectedStatusCode:  403,
+			expectedRequestBody: `{"error":"access to the resource is denied","code":"access_denied"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testMock := service.NewMockTest(t)
+			userMock := service.NewMockUser(t)
+			userId, _ := strconv.Atoi(tt.paramId)
+			tt.mockBehavior(testMock, userMock, userId)
+
+			services := &service.Service{Test: testMock, User: userMock}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/test/results/user/:userId", setUserCtx(1, tt.role), handler.getPlayerTestResults)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", fmt.Sprintf("/test/results/user/%s", tt.paramId), nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_submitTestResult(t *testing.T) {
+	type mockBehavior func(r *service.MockTest, userId int, input gameServer.SubmitTestResultInput)
+
+	tests := []struct {
+		name                string
+		inputBody           string
+		acceptLanguage      string
+		input               gameServer.SubmitTestResultInput
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "ok",
+			inputBody: `{"test_id": 2, "answers": {"q1": 2}}`,
+			input: gameServer.SubmitTestResultInput{
+				TestId:  2,
+				Answers: json.RawMessage(`{"q1": 2}`),
+				Context: gameServer.TestContextOnboarding,
+			},
+			mockBehavior: func(r *service.MockTest, userId int, input gameServer.SubmitTestResultInput) {
+				r.EXPECT().SubmitResult(userId, input).Return(7, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"id":7}`,
+		},
+		{
+			name:                "answers are not an object",
+			inputBody:           `{"test_id": 2, "answers": [2, 3]}`,
+			mockBehavior:        func(r *service.MockTest, userId int, input gameServer.SubmitTestResultInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"answers must be an object keyed by question id","code":"bad_request"}`,
+		},
+		{
+			name:           "invalid answers",
+			inputBody:      `{"test_id": 2, "answers": {"q1": 7, "q9": 1}}`,
+			acceptLanguage: "ru",
+			input: gameServer.SubmitTestResultInput{
+				TestId:  2,
+				Answers: json.RawMessage(`{"q1": 7, "q9": 1}`),
+				Context: gameServer.TestContextOnboarding,
+			},
+			mockBehavior: func(r *service.MockTest, userId int, input gameServer.SubmitTestResultInput) {
+				r.EXPECT().SubmitResult(userId, input).Return(0, &gameServer.AnswersError{Questions: map[string]string{
+					"q1": gameServer.AnswerOutOfRange,
+					"q2": gameServer.AnswerMissing,
+					"q9": gameServer.AnswerUnknownQuestion,
+				}})
+			},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"некоторые ответы отсутствуют или некорректны","code":"invalid_answers","questions":{"q1":{"error":"ответ вне диапазона шкалы","code":"out_of_range"},"q2":{"error":"необходимо ответить на вопрос","code":"missing"},"q9":{"error":"в тесте нет такого вопроса","code":"unknown_question"}}}`,
+		},
+		{
+			name:      "service error",
+			inputBody: `{"test_id": 2, "answers": {"q1": 2}}`,
+			input: gameServer.SubmitTestResultInput{
+				TestId:  2,
+				Answers: json.RawMessage(`{"q1": 2}`),
+				Context: gameServer.TestContextOnboarding,
+			},
+			mockBehavior: func(r *service.MockTest, userId int, input gameServer.SubmitTestResultInput) {
+				r.EXPECT().SubmitResult(userId, input).Return(0, errors.New("test is not active"))
+			},
+			expectedStatusCode:  500,
+			expectedRequestBody: `{"error":"test is not active","code":"internal_error"}`,
+		},
+		{
+			name:      "retake not allowed",
+			inputBody: `{"test_id": 2, "answers": {"q1": 2}, "context": "study_phase", "phase": "post"}`,
+			input: gameServer.SubmitTestResultInput{
+				TestId:  2,
+				Answers: json.RawMessage(`{"q1": 2}`),
+				Context: gameServer.TestContextStudyPhase,
+				Phase:   "post",
+			},
+			mockBehavior: func(r *service.MockTest, userId int, input gameServer.SubmitTestResultInput) {
+				r.EXPECT().SubmitResult(userId, input).Return(0, gameServer.ErrRetakeNotAllowed)
+			},
+			expectedStatusCode:  409,
+			expectedRequestBody: `{"error":"the test has already been taken","code":"retake_not_allowed"}`,
+		},
+		{
+			name:                "study phase without a phase",
+			inputBody:           `{"test_id": 2, "answers": {"q1": 2}, "context": "study_phase"}`,
+			mockBehavior:        func(r *service.MockTest, userId int, input gameServer.SubmitTestResultInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"phase is required for a study phase","code":"bad_request"}`,
+		},
+		{
+			name:                "unknown context",
+			inputBody:           `{"test_id": 2, "answers": {"q1": 2}, "context": "lunch"}`,
+			mockBehavior:        func(r *service.MockTest, userId int, input gameServer.SubmitTestResultInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"unknown test context \"lunch\"","code":"bad_request"}`,
+		},
+		{
+			name:      "hidden question answered",
+			inputBody: `{"test_id": 2, "answers": {"q4": "no", "q5": "radar"}}`,
+			input: gameServer.SubmitTestResultInput{
+				TestId:  2,
+				Answers: json.RawMessage(`{"q4": "no", "q5": "radar"}`),
+				Context: gameServer.TestContextOnboarding,
+			},
+			mockBehavior: func(r *service.MockTest, userId int, input gameServer.SubmitTestResultInput) {
+				r.EXPECT().SubmitResult(userId, input).Return(0, &gameServer.AnswersError{Questions: map[string]string{
+					"q5": gameServer.AnswerHidden,
+				}})
+			},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"some answers are missing or invalid","code":"invalid_answers","questions":{"q5":{"error":"the question is not shown for the earlier answers","code":"hidden_question"}}}`,
+		},
+		{
+			name:      "ok - with item times",
+			inputBody: `{"test_id": 2, "answers": {"q1": 2}, "item_times": {"q1": 3500.5}}`,
+			input: gameServer.SubmitTestResultInput{
+				TestId:    2,
+				Answers:   json.RawMessage(`{"q1": 2}`),
+				ItemTimes: map[string]float64{"q1": 3500.5},
+				Context:   gameServer.TestContextOnboarding,
+			},
+			mockBehavior: func(r *service.MockTest, userId int, input gameServer.SubmitTestResultInput) {
+				r.EXPECT().SubmitResult(userId, input).Return(8, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"id":8}`,
+		},
+		{
+			name:                "negative item time",
+			inputBody:           `{"test_id": 2, "answers": {"q1": 2}, "item_times": {"q1": -1}}`,
+			mockBehavior:        func(r *service.MockTest, userId int, input gameServer.SubmitTestResultInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"time on question \"q1\" must be a non-negative number of milliseconds","code":"bad_request"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userId := 3
+			testMock := service.NewMockTest(t)
+			tt.mockBehavior(testMock, userId, tt.input)
+
+			services := &service.Service{Test: testMock}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/test/results", func(c *gin.Context) {
+				c.Set(userCtx, userId)
+				c.Set(userCtxRole, gameServer.RoleUser)
+			}, handler.submitTestResult)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/test/results", bytes.NewBufferString(tt.inputBody))
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getTestVisibility(t *testing.T) {
+	type mockBehavior func(r *service.MockTest, userId, testId int, input gameServer.TestVisibilityInput)
+
+	tests := []struct {
+		name                string
+		paramId             string
+		inputBody           string
+		input               gameServer.TestVisibilityInput
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "ok",
+			paramId:   "2",
+			inputBody: `{"answers": {"q4": "yes"}}`,
+			input:     gameServer.TestVisibilityInput{Answers: json.RawMessage(`{"q4": "yes"}`)},
+			mockBehavior: func(r *service.MockTest, userId, testId int, input gameServer.TestVisibilityInput) {
+				r.EXPECT().GetVisibility(userId, testId, input).Return(gameServer.TestVisibility{Visible: []string{"q4", "q5"}, Next: "q5"}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"visible":["q4","q5"],"next":"q5"}`,
+		},
+		{
+			name:      "all visible questions answered",
+			paramId:   "2",
+			inputBody: `{"answers": {"q4": "no"}}`,
+			input:     gameServer.TestVisibilityInput{Answers: json.RawMessage(`{"q4": "no"}`)},
+			mockBehavior: func(r *service.MockTest, userId, testId int, input gameServer.TestVisibilityInput) {
+				r.EXPECT().GetVisibility(userId, testId, input).Return(gameServer.TestVisibility{Visible: []string{"q4"}}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"visible":["q4"]}`,
+		},
+		{
+			name:                "invalid id",
+			paramId:             "abc",
+			inputBody:           `{"answers": {}}`,
+			mockBehavior:        func(r *service.MockTest, userId, testId int, input gameServer.TestVisibilityInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"invalid parameter id","code":"invalid_parameter"}`,
+		},
+		{
+			name:                "answers are not an object",
+			paramId:             "2",
+			inputBody:           `{"answers": "yes"}`,
+			mockBehavior:        func(r *service.MockTest, userId, testId int, input gameServer.TestVisibilityInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"answers must be an object keyed by question id","code":"bad_request"}`,
+		},
+		{
+			name:      "service error",
+			paramId:   "2",
+			inputBody: `{"answers": {}}`,
+			input:     gameServer.TestVisibilityInput{Answers: json.RawMessage(`{}`)},
+			mockBehavior: func(r *service.MockTest, userId, testId int, input gameServer.TestVisibilityInput) {
+				r.EXPECT().GetVisibility(userId, testId, input).Return(gameServer.TestVisibility{}, errors.New("db is down"))
+			},
+			expectedStatusCode:  500,
+			expectedRequestBody: `{"error":"db is down","code":"internal_error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userId := 3
+			testMock := service.NewMockTest(t)
+			testId, _ := strconv.Atoi(tt.paramId)
+			tt.mockBehavior(testMock, userId, testId, tt.input)
+
+			services := &service.Service{Test: testMock}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.POST("/test/:id/visibility", func(c *gin.Context) {
+				c.Set(userCtx, userId)
+			}, handler.getTestVisibility)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", fmt.Sprintf("/test/%s/visibility", tt.paramId), bytes.NewBufferString(tt.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_saveTestProgress(t *testing.T) {
+	type mockBehavior func(r *service.MockTest, userId, testId int, input gameServer.SaveTestProgressInput)
+
+	tests := []struct {
+		name                string
+		paramId             string
+		role                string
+		inputBody           string
+		input               gameServer.SaveTestProgressInput
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "ok",
+			paramId:   "2",
+			role:      gameServer.RoleUser,
+			inputBody: `{"answers": {"q1": 2}, "item_times": {"q1": 4000, "q2": 1500}}`,
+			input: gameServer.SaveTestProgressInput{
+				Answers:   json.RawMessage(`{"q1": 2}`),
+				ItemTimes: map[string]float64{"q1": 4000, "q2": 1500},
+				Context:   gameServer.TestContextOnboarding,
+			},
+			mockBehavior: func(r *service.MockTest, userId, testId int, input gameServer.SaveTestProgressInput) {
+				r.EXPECT().SaveProgress(userId, testId, input).Return(nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"status":"ok"}`,
+		},
+		{
+			name:      "ok - study phase",
+			paramId:   "2",
+			role:      gameServer.RoleUser,
+			inputBody: `{"answers": {}, "context": "study_phase", "phase": "block_2"}`,
+			input: gameServer.SaveTestProgressInput{
+				Answers: json.RawMessage(`{}`),
+				Context: gameServer.TestContextStudyPhase,
+				Phase:   "block_2",
+			},
+			mockBehavior: func(r *service.MockTest, userId, testId int, input gameServer.SaveTestProgressInput) {
+				r.EXPECT().SaveProgress(userId, testId, input).Return(nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"status":"ok"}`,
+		},
+		{
+			name:                "not a regular user",
+			paramId:             "2",
+			role:                gameServer.RoleResearcher,
+			inputBody:           `{"answers": {"q1": 2}}`,
+			mockBehavior:        func(r *service.MockTest, userId, testId int, input gameServer.SaveTestProgressInput) {},
+			expectedStatusCode:  403,
+			expectedRequestBody: `{"error":"tests are available only for users","code":"tests_only_for_users"}`,
+		},
+		{
+			name:                "invalid id",
+			paramId:             "0",
+			role:                gameServer.RoleUser,
+			inputBody:           `{"answers": {"q1": 2}}`,
+			mockBehavior:        func(r *service.MockTest, userId, testId int, input gameServer.SaveTestProgressInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"invalid parameter id","code":"invalid_parameter"}`,
+		},
+		{
+			name:                "phase outside a study phase",
+			paramId:             "2",
+			role:                gameServer.RoleUser,
+			inputBody:           `{"answers": {"q1": 2}, "phase": "block_2"}`,
+			mockBehavior:        func(r *service.MockTest, userId, testId int, input gameServer.SaveTestProgressInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"phase is only set for a study phase","code":"bad_request"}`,
+		},
+		{
+			name:      "service error",
+			paramId:   "2",
+			role:      gameServer.RoleUser,
+			inputBody: `{"answers": {"q1": 2}}`,
+			input: gameServer.SaveTestProgressInput{
+				Answers: json.RawMessage(`{"q1": 2}`),
+				Context: gameServer.TestContextOnboarding,
+			},
+			mockBehavior: func(r *service.MockTest, userId, testId int, input gameServer.SaveTestProgressInput) {
+				r.EXPECT().SaveProgress(userId, testId, input).Return(errors.New("test is not active"))
+			},
+			expectedStatusCode:  500,
+			expectedRequestBody: `{"error":"test is not active","code":"internal_error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userId := 3
+			testMock := service.NewMockTest(t)
+			testId, _ := strconv.Atoi(tt.paramId)
+			tt.mockBehavior(testMock, userId, testId, tt.input)
+
+			services := &service.Service{Test: testMock}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.PUT("/test/:id/progress", func(c *gin.Context) {
+				c.Set(userCtx, userId)
+				c.Set(userCtxRole, tt.role)
+			}, handler.saveTestProgress)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", fmt.Sprintf("/test/%s/progress", tt.paramId), bytes.NewBufferString(tt.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getTestProgress(t *testing.T) {
+	type mockBehavior func(r *service.MockTest, userId, testId int)
+
+	tests := []struct {
+		name                string
+		paramId             string
+		role                string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:    "ok",
+			paramId: "2",
+			role:    gameServer.RoleUser,
+			mockBehavior: func(r *service.MockTest, userId, testId int) {
+				r.EXPECT().GetProgress(userId, testId).Return([]gameServer.TestDraft{
+					{
+						TestId:      2,
+						TestVersion: 1,
+						Context:     gameServer.TestContextOnboarding,
+						Answers:     json.RawMessage(`{"q1":2}`),
+						ItemTimes:   json.RawMessage(`{"q1":4000}`),
+						StartedAt:   "2024-01-01T00:00:00Z",
+						UpdatedAt:   "2024-01-01T00:05:00Z",
+					},
+				}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"data":[{"test_id":2,"test_version":1,"context":"onboarding","answers":{"q1":2},"item_times":{"q1":4000},"started_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:05:00Z"}]}`,
+		},
+		{
+			name:    "ok - nothing saved",
+			paramId: "2",
+			role:    gameServer.RoleUser,
+			mockBehavior: func(r *service.MockTest, userId, testId int) {
+				r.EXPECT().GetProgress(userId, testId).Return([]gameServer.TestDraft{}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"data":[]}`,
+		},
+		{
+			name:                "not a regular user",
+			paramId:             "2",
+			role:                gameServer.RoleAdmin,
+			mockBehavior:        func(r *service.MockTest, userId, testId int) {},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"data":[]}`,
+		},
+		{
+			name:    "service error",
+			paramId: "2",
+			role:    gameServer.RoleUser,
+			mockBehavior: func(r *service.MockTest, userId, testId int) {
+				r.EXPECT().GetProgress(userId, testId).Return(nil, errors.New("db is down"))
+			},
+			expectedStatusCode:  500,
+			expectedRequestBody: `{"error":"db is down","code":"internal_error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userId := 3
+			testMock := service.NewMockTest(t)
+			testId, _ := strconv.Atoi(tt.paramId)
+			tt.mockBehavior(testMock, userId, testId)
+
+			services := &service.Service{Test: testMock}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/test/:id/progress", func(c *gin.Context) {
+				c.Set(userCtx, userId)
+				c.Set(userCtxRole, tt.role)
+			}, handler.getTestProgress)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", fmt.Sprintf("/test/%s/progress", tt.paramId), nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_getTestReport(t *testing.T) {
+	type mockBehavior func(r *service.MockTest, u *service.MockUser, testId int, input gameServer.TestReportInput)
+
+	alpha, omega, mean := 0.82, 0.85, 3.5
+	groupId, version, creatorId := 4, 2, 1
+
+	tests := []struct {
+		name                string
+		paramId             string
+		query               string
+		role                string
+		input               gameServer.TestReportInput
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:    "ok",
+			paramId: "2",
+			role:    gameServer.RoleAdmin,
+			mockBehavior: func(r *service.MockTest, u *service.MockUser, testId int, input gameServer.TestReportInput) {
+				r.EXPECT().GetReport(testId, input).Return(gameServer.TestReport{
+					TestId:  2,
+					Version: 1,
+					Results: 12,
+					Scale: gameServer.ScaleReliability{
+						Items:         []string{"q1", "q2"},
+						CompleteCases: 11,
+						Alpha:         &alpha,
+						Omega:         &omega,
+					},
+					Subscales: []gameServer.ScaleReliability{},
+					Items: []gameServer.TestItemReport{
+						{
+							Id:           "q1",
+							Text:         "a",
+							Answered:     12,
+							Mean:         &mean,
+							Distribution: []gameServer.ResponseCount{{Value: 1, Count: 0}, {Value: 2, Count: 12}},
+						},
+					},
+				}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"test_id":2,"version":1,"results":12,"scale":{"items":["q1","q2"],"complete_cases":11,"alpha":0.82,"omega":0.85},"subscales":[],"items":[{"id":"q1","text":"a","answered":12,"mean":3.5,"sd":null,"item_total":null,"alpha_if_deleted":null,"distribution":[{"value":1,"count":0},{"value":2,"count":12}]}]}`,
+		},
+		{
+			name:    "ok - group creator, filtered by study phase and version",
+			paramId: "2",
+			role:    gameServer.RoleResearcher,
+			query:   "?group_id=4&context=study_phase&phase=block_2&version=2",
+			input: gameServer.TestReportInput{
+				GroupId: &groupId,
+				Context: gameServer.TestContextStudyPhase,
+				Phase:   "block_2",
+				Version: &version,
+			},
+			mockBehavior: func(r *service.MockTest, u *service.MockUser, testId int, input gameServer.TestReportInput) {
+				u.EXPECT().GetOneGroup(groupId).Return(gameServer.Group{Id: groupId, CreatorId: 1}, nil)
+				r.EXPECT().GetReport(testId, input).Return(gameServer.TestReport{
+					TestId:    2,
+					Version:   2,
+					Scale:     gameServer.ScaleReliability{Items: []string{"q1"}},
+					Subscales: []gameServer.ScaleReliability{},
+				}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"test_id":2,"version":2,"results":0,"scale":{"items":["q1"],"complete_cases":0,"alpha":null,"omega":null},"subscales":[],"items":null}`,
+		},
+		{
+			name:    "ok - researcher without a group",
+			paramId: "2",
+			role:    gameServer.RoleResearcher,
+			input:   gameServer.TestReportInput{CreatorId: &creatorId},
+			mockBehavior: func(r *service.MockTest, u *service.MockUser, testId int, input gameServer.TestReportInput) {
+				r.EXPECT().GetReport(testId, input).Return(gameServer.TestReport{
+					TestId:    2,
+					Version:   1,
+					Scale:     gameServer.ScaleReliability{Items: []string{"q1"}},
+					Subscales: []gameServer.ScaleReliability{},
+				}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"test_id":2,"version":1,"results":0,"scale":{"items":["q1"],"complete_cases":0,"alpha":null,"omega":null},"subscales":[],"items":null}`,
+		},
+		{
+			name:    "another researcher's group",
+			paramId: "2",
+			role:    gameServer.RoleResearcher,
+			query:   "?group_id=4",
+			mockBehavior: func(r *service.MockTest, u *service.MockUser, testId int, input gameServer.TestReportInput) {
+				u.EXPECT().GetOneGroup(groupId).Return(gameServer.Group{Id: groupId, CreatorId: 2}, nil)
+			},
+			expectedStatusCode:  403,
+			expectedRequestBody: `{"error":"access to the resource is denied","code":"access_denied"}`,
+		},
+		{
+			name:                "invalid id",
+			paramId:             "abc",
+			role:                gameServer.RoleAdmin,
+			mockBehavior:        func(r *service.MockTest, u *service.MockUser, testId int, input gameServer.TestReportInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"invalid parameter id","code":"invalid_parameter"}`,
+		},
+		{
+			name:                "non-positive group id",
+			paramId:             "2",
+			role:                gameServer.RoleAdmin,
+			query:               "?group_id=0",
+			mockBehavior:        func(r *service.MockTest, u *service.MockUser, testId int, input gameServer.TestReportInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"group id is non-positive","code":"bad_request"}`,
+		},
+		{
+			name:                "phase outside a study phase",
+			paramId:             "2",
+			role:                gameServer.RoleAdmin,
+			query:               "?context=after_game&phase=block_2",
+			mockBehavior:        func(r *service.MockTest, u *service.MockUser, testId int, input gameServer.TestReportInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"phase is only set for a study phase","code":"bad_request"}`,
+		},
+		{
+			name:    "test not found",
+			paramId: "2",
+			role:    gameServer.RoleAdmin,
+			mockBehavior: func(r *service.MockTest, u *service.MockUser, testId int, input gameServer.TestReportInput) {
+				r.EXPECT().GetReport(testId, input).Return(gameServer.TestReport{}, gameServer.ErrTestNotFound)
+			},
+			expectedStatusCode:  404,
+			expectedRequestBody: `{"error":"not found","code":"not_found"}`,
+		},
+		{
+			name:    "test without a scale",
+			paramId: "2",
+			role:    gameServer.RoleAdmin,
+			mockBehavior: func(r *service.MockTest, u *service.MockUser, testId int, input gameServer.TestReportInput) {
+				r.EXPECT().GetReport(testId, input).Return(gameServer.TestReport{}, gameServer.ErrTestNotScaled)
+			},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"error":"the test has no questions answered on a scale","code":"test_not_scaled"}`,
+		},
+		{
+			name:    "service error",
+			paramId: "2",
+			role:    gameServer.RoleAdmin,
+			mockBehavior: func(r *service.MockTest, u *service.MockUser, testId int, input gameServer.TestReportInput) {
+				r.EXPECT().GetReport(testId, input).Return(gameServer.TestReport{}, errors.New("db is down"))
+			},
+			expectedStatusCode:  500,
+			expectedRequestBody: `{"error":"db is down","code":"internal_error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testMock := service.NewMockTest(t)
+			userMock := service.NewMockUser(t)
+			testId, _ := strconv.Atoi(tt.paramId)
+			tt.mockBehavior(testMock, userMock, testId, tt.input)
+
+			services := &service.Service{Test: testMock, User: userMock}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/test/:id/report", setUserCtx(1, tt.role), handler.getTestReport)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", fmt.Sprintf("/test/%s/report%s", tt.paramId, tt.query), nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRequestBody, w.Body.String())
+		})
+	}
+}
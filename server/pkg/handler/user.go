@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	gameServer "example.com/gameHoldTheProcessServer"
+	"example.com/gameHoldTheProcessServer/pkg/i18n"
 	"github.com/gin-gonic/gin"
 )
 
@@ -66,6 +67,10 @@ func (h *Handler) updateScore(c *gin.Context) {
 		return
 	}
 
+	if !h.checkUserAccess(c, input.UserId) {
+		return
+	}
+
 	err := h.services.User.UpdateScore(input)
 	if err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, err.Error())
@@ -80,19 +85,19 @@ func (h *Handler) updateScore(c *gin.Context) {
 func (h *Handler) check(c *gin.Context) {
 	header := c.GetHeader(authorizationHeader)
 	if header == "" {
-		newErrorResponse(c, http.StatusUnauthorized, "empty authorization header")
+		newCodedErrorResponse(c, http.StatusUnauthorized, i18n.CodeEmptyAuthHeader)
 		return
 	}
 
 	headerParts := strings.Split(header, " ")
 	if len(headerParts) != 2 || headerParts[0] != "Bearer" || len(headerParts[1]) == 0 {
-		newErrorResponse(c, http.StatusUnauthorized, "invalid authorization header")
+		newCodedErrorResponse(c, http.StatusUnauthorized, i18n.CodeInvalidAuthHeader)
 		return
 	}
 
 	token, err := h.services.User.RefreshToken(headerParts[1])
 	if err != nil {
-		newErrorResponse(c, http.StatusUnauthorized, "invalid authorization header")
+		newCodedErrorResponse(c, http.StatusUnauthorized, i18n.CodeInvalidAuthHeader)
 		return
 	}
 
@@ -108,7 +113,11 @@ type getOneUserResponse struct {
 func (h *Handler) getOneUser(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil || id <= 0 {
-		newErrorResponse(c, http.StatusBadRequest, "invalid parameter id")
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "id")
+		return
+	}
+
+	if !h.checkUserAccess(c, id) {
 		return
 	}
 
@@ -152,7 +161,11 @@ type getParSetResponse struct {
 func (h *Handler) getParSet(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil || id <= 0 {
-		newErrorResponse(c, http.StatusBadRequest, "invalid parameter id")
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "id")
+		return
+	}
+
+	if !h.checkUserAccess(c, id) {
 		return
 	}
 
@@ -174,13 +187,17 @@ type getScoreResponse struct {
 func (h *Handler) getScore(c *gin.Context) {
 	userId, err := strconv.Atoi(c.Param("userId"))
 	if err != nil || userId <= 0 {
-		newErrorResponse(c, http.StatusBadRequest, "invalid parameter userId")
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "userId")
 		return
 	}
 
 	parSetId, err := strconv.Atoi(c.Param("parSetId"))
 	if err != nil || parSetId <= 0 {
-		newErrorResponse(c, http.StatusBadRequest, "invalid parameter parSetId")
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "parSetId")
+		return
+	}
+
+	if !h.checkUserAccess(c, userId) {
 		return
 	}
 
@@ -202,13 +219,17 @@ type getUserParSetResponse struct {
 func (h *Handler) getUserParSet(c *gin.Context) {
 	userId, err := strconv.Atoi(c.Param("userId"))
 	if err != nil || userId <= 0 {
-		newErrorResponse(c, http.StatusBadRequest, "invalid parameter userId")
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "userId")
 		return
 	}
 
 	parSetId, err := strconv.Atoi(c.Param("parSetId"))
 	if err != nil || parSetId <= 0 {
-		newErrorResponse(c, http.StatusBadRequest, "invalid parameter parSetId")
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "parSetId")
+		return
+	}
+
+	if !h.checkUserAccess(c, userId) {
 		return
 	}
 
@@ -253,7 +274,7 @@ func (h *Handler) getAllUsers(c *gin.Context) {
 func (h *Handler) deleteUser(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil || id <= 0 {
-		newErrorResponse(c, http.StatusBadRequest, "invalid parameter id")
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "id")
 		return
 	}
 
@@ -271,7 +292,7 @@ func (h *Handler) deleteUser(c *gin.Context) {
 func (h *Handler) updateUser(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil || id <= 0 {
-		newErrorResponse(c, http.StatusBadRequest, "invalid parameter id")
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "id")
 		return
 	}
 
@@ -326,7 +347,7 @@ func (h *Handler) createGroup(c *gin.Context) {
 
 	id, err := h.services.User.CreateGroup(input)
 	if err != nil {
-		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		newParSetErrorResponse(c, err)
 		return
 	}
 
@@ -414,7 +435,7 @@ func (h *Handler) getPlayersEvents(c *gin.Context) {
 func (h *Handler) updateUserParSet(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil || id <= 0 {
-		newErrorResponse(c, http.StatusBadRequest, "invalid parameter id")
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "id")
 		return
 	}
 
@@ -430,7 +451,7 @@ func (h *Handler) updateUserParSet(c *gin.Context) {
 	}
 
 	if err := h.services.User.UpdateUserParSet(id, input); err != nil {
-		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		newParSetErrorResponse(c, err)
 		return
 	}
 
@@ -442,7 +463,7 @@ func (h *Handler) updateUserParSet(c *gin.Context) {
 func (h *Handler) updateUserUserParSet(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil || id <= 0 {
-		newErrorResponse(c, http.StatusBadRequest, "invalid parameter id")
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "id")
 		return
 	}
 
@@ -457,6 +478,10 @@ func (h *Handler) updateUserUserParSet(c *gin.Context) {
 		return
 	}
 
+	if !h.checkUserAccess(c, id) {
+		return
+	}
+
 	if err := h.services.User.UpdateUserUserParSet(id, input); err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, err.Error())
 		return
@@ -502,7 +527,7 @@ func (h *Handler) changeGroupParSet(c *gin.Context) {
 	}
 
 	if err := h.services.User.ChangeGroupParSet(input); err != nil {
-		newErrorResponse(c, http.StatusInternalServerError, err.Error())
+		newParSetErrorResponse(c, err)
 		return
 	}
 
@@ -514,12 +539,12 @@ func (h *Handler) changeGroupParSet(c *gin.Context) {
 func (h *Handler) fixBugStat(c *gin.Context) {
 	start, err := strconv.Atoi(c.Param("start"))
 	if err != nil {
-		newErrorResponse(c, http.StatusBadRequest, "invalid parameter start")
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "start")
 		return
 	}
 	end, err := strconv.Atoi(c.Param("end"))
 	if err != nil {
-		newErrorResponse(c, http.StatusBadRequest, "invalid parameter end")
+		newCodedErrorResponse(c, http.StatusBadRequest, i18n.CodeInvalidParameter, "end")
 		return
 	}
 
@@ -479,6 +479,13 @@ func TestHandler_updateScore(t *testing.T) {
 			expectedStatusCode: 400,
 			isError:            true,
 		},
+		{
+			name:               "access denied - another user",
+			inputBody:          `{"userId": 2, "parSetId": 1, "score": 100}`,
+			mockBehavior:       func(r *service.MockUser, updateScoreInput gameServer.UpdateScoreInput) {},
+			expectedStatusCode: 403,
+			isError:            true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -491,7 +498,7 @@ func TestHandler_updateScore(t *testing.T) {
 
 			gin.SetMode(gin.TestMode)
 			r := gin.New()
-			r.POST("/score", handler.updateScore)
+			r.POST("/score", setUserCtx(1, gameServer.RoleUser), handler.updateScore)
 
 			w := httptest.NewRecorder()
 			req := httptest.NewRequest("POST", "/score", bytes.NewBufferString(tt.inputBody))
@@ -661,6 +668,13 @@ func TestHandler_getOneUser(t *testing.T) {
 			expectedStatusCode: 500,
 			isError:            true,
 		},
+		{
+			name:               "access denied - another user",
+			paramId:            "2",
+			mockBehavior:       func(r *service.MockUser, id string) {},
+			expectedStatusCode: 403,
+			isError:            true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -673,7 +687,7 @@ func TestHandler_getOneUser(t *testing.T) {
 
 			gin.SetMode(gin.TestMode)
 			r := gin.New()
-			r.GET("/:id", handler.getOneUser)
+			r.GET("/:id", setUserCtx(1, gameServer.RoleUser), handler.getOneUser)
 
 			w := httptest.NewRecorder()
 			req := httptest.NewRequest("GET", fmt.Sprintf("/%s", tt.paramId), nil)
@@ -830,20 +844,19 @@ func TestHandler_getParSet(t *testing.T) {
 					Id:                  1,
 					A:                   1.1,
 					B:                   1.1,
-					NoiseMean:             1.1,
-					NoiseStDev:            1.1,
-					FalseWarningProb:      0.1,
-					MissingDangerProb:     0.1,
-					ScoringConfig:         gameServer.DefaultScoringConfigJSON(),
-					HintCost:              250,
-					FalseAlarmThreshold:   0.9,
-					RulesText:             "",
-					CreatedAt:             "2023-10-01T00:00:00Z",
+					NoiseMean:           1.1,
+					NoiseStDev:          1.1,
+					FalseWarningProb:    0.1,
+					MissingDangerProb:   0.1,
+					ScoringConfig:       gameServer.DefaultScoringConfigJSON(),
+					HintCost:            250,
+					FalseAlarmThreshold: 0.9,
+					CreatedAt:           "2023-10-01T00:00:00Z",
 				},
 					nil)
 			},
 			expectedStatusCode:  200,
-			expectedRequestBody: `{"data":{"id":1,"a":1.1,"b":1.1,"noise_mean":1.1,"noise_stdev":1.1,"false_warning_prob":0.1,"missing_danger_prob":0.1,"scoring_config":{"bonus_step":50,"bonus_reject_incorrect_advice_with_check":1000,"bonus_reject_incorrect_advice_no_check":2000,"bonus_accept_correct_advice_with_check":250,"bonus_accept_correct_advice_no_check":500,"penalty_reject_correct_advice_with_check":4000,"penalty_reject_correct_advice_no_check":2000,"penalty_accept_incorrect_advice_with_check":2000,"penalty_accept_incorrect_advice_no_check":1000,"penalty_incorrect_stop_no_advice":2000,"penalty_explosion_no_advice":0,"penalty_pause":50},"hint_cost":250,"false_alarm_threshold":0.9,"rules_text":"","created_at":"2023-10-01T00:00:00Z"}}`,
+			expectedRequestBody: `{"data":{"id":1,"parent_id":null,"name":"","description":"","a":1.1,"b":1.1,"noise_mean":1.1,"noise_stdev":1.1,"false_warning_prob":0.1,"missing_danger_prob":0.1,"scoring_config":{"bonus_step":50,"bonus_reject_incorrect_advice_with_check":1000,"bonus_reject_incorrect_advice_no_check":2000,"bonus_accept_correct_advice_with_check":250,"bonus_accept_correct_advice_no_check":500,"penalty_reject_correct_advice_with_check":4000,"penalty_reject_correct_advice_no_check":2000,"penalty_accept_incorrect_advice_with_check":2000,"penalty_accept_incorrect_advice_no_check":1000,"penalty_incorrect_stop_no_advice":2000,"penalty_explosion_no_advice":0,"penalty_pause":50},"hint_cost":250,"hint_config":null,"advisor_config":null,"false_alarm_threshold":0.9,"rules":null,"created_at":"2023-10-01T00:00:00Z","archived_at":null}}`,
 		},
 		{
 			name:               "incorrect parameter id - negative value",
@@ -876,6 +889,13 @@ func TestHandler_getParSet(t *testing.T) {
 			expectedStatusCode: 500,
 			isError:            true,
 		},
+		{
+			name:               "access denied - another user",
+			paramId:            "2",
+			mockBehavior:       func(r *service.MockUser, id string) {},
+			expectedStatusCode: 403,
+			isError:            true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -888,7 +908,7 @@ func TestHandler_getParSet(t *testing.T) {
 
 			gin.SetMode(gin.TestMode)
 			r := gin.New()
-			r.GET("/parSet/:id", handler.getParSet)
+			r.GET("/parSet/:id", setUserCtx(1, gameServer.RoleUser), handler.getParSet)
 
 			w := httptest.NewRecorder()
 			req := httptest.NewRequest("GET", fmt.Sprintf("/parSet/%s", tt.paramId), nil)
@@ -989,6 +1009,14 @@ func TestHandler_getScore(t *testing.T) {
 			expectedStatusCode: 500,
 			isError:            true,
 		},
+		{
+			name:               "access denied - another user",
+			paramUserId:        "2",
+			paramParSetId:      "1",
+			mockBehavior:       func(r *service.MockUser, userId, parSetId string) {},
+			expectedStatusCode: 403,
+			isError:            true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1001,7 +1029,7 @@ func TestHandler_getScore(t *testing.T) {
 
 			gin.SetMode(gin.TestMode)
 			r := gin.New()
-			r.GET("/score/:userId/:parSetId", handler.getScore)
+			r.GET("/score/:userId/:parSetId", setUserCtx(1, gameServer.RoleUser), handler.getScore)
 
 			w := httptest.NewRecorder()
 			req := httptest.NewRequest("GET", fmt.Sprintf("/score/%s/%s", tt.paramUserId, tt.paramParSetId), nil)
@@ -1458,7 +1486,7 @@ func TestHandler_getAllGroups(t *testing.T) {
 					nil)
 			},
 			expectedStatusCode:  200,
-			expectedRequestBody: `{"data":[{"id":1,"name":"n","created_at":"2023-10-01T00:00:00Z","creator_id":1}]}`,
+			expectedRequestBody: `{"data":[{"id":1,"name":"n","created_at":"2023-10-01T00:00:00Z","creator_id":1,"parameter_set_id":0}]}`,
 		},
 		{
 			name: "internal server error",
@@ -1511,10 +1539,11 @@ func TestHandler_createGroup(t *testing.T) {
 	}{
 		{
 			name:      "ok",
-			inputBody: `{"creator_id": 1, "name": "n"}`,
+			inputBody: `{"creator_id": 1, "name": "n", "par_set_id": 1}`,
 			createGroupInput: gameServer.CreateGroupInput{
 				CreatorId: 1,
 				Name:      "n",
+				ParSetId:  1,
 			},
 			mockBehavior: func(r *service.MockUser, createGroupInput gameServer.CreateGroupInput) {
 				r.EXPECT().CreateGroup(createGroupInput).Return(1, nil)
@@ -1524,10 +1553,11 @@ func TestHandler_createGroup(t *testing.T) {
 		},
 		{
 			name:      "internal server error",
-			inputBody: `{"creator_id": 1, "name": "n"}`,
+			inputBody: `{"creator_id": 1, "name": "n", "par_set_id": 1}`,
 			createGroupInput: gameServer.CreateGroupInput{
 				CreatorId: 1,
 				Name:      "n",
+				ParSetId:  1,
 			},
 			mockBehavior: func(r *service.MockUser, createGroupInput gameServer.CreateGroupInput) {
 				r.EXPECT().CreateGroup(createGroupInput).Return(0, errors.New(""))
@@ -1537,35 +1567,35 @@ func TestHandler_createGroup(t *testing.T) {
 		},
 		{
 			name:               "incorrect creator id - wrong type",
-			inputBody:          `{"creator_id": "1", "name": "n"}`,
+			inputBody:          `{"creator_id": "1", "name": "n", "par_set_id": 1}`,
 			mockBehavior:       func(r *service.MockUser, createGroupInput gameServer.CreateGroupInput) {},
 			expectedStatusCode: 400,
 			isError:            true,
 		},
 		{
 			name:               "incorrect creator id - zero value",
-			inputBody:          `{"creator_id": 0, "name": "n"}`,
+			inputBody:          `{"creator_id": 0, "name": "n", "par_set_id": 1}`,
 			mockBehavior:       func(r *service.MockUser, createGroupInput gameServer.CreateGroupInput) {},
 			expectedStatusCode: 400,
 			isError:            true,
 		},
 		{
 			name:               "incorrect creator id - negative value",
-			inputBody:          `{"creator_id": -1, "name": "n"}`,
+			inputBody:          `{"creator_id": -1, "name": "n", "par_set_id": 1}`,
 			mockBehavior:       func(r *service.MockUser, createGroupInput gameServer.CreateGroupInput) {},
 			expectedStatusCode: 400,
 			isError:            true,
 		},
 		{
 			name:               "incorrect name - wrong type",
-			inputBody:          `{"creator_id": 1, "name": 1}`,
+			inputBody:          `{"creator_id": 1, "name": 1, "par_set_id": 1}`,
 			mockBehavior:       func(r *service.MockUser, createGroupInput gameServer.CreateGroupInput) {},
 			expectedStatusCode: 400,
 			isError:            true,
 		},
 		{
 			name:               "incorrect name - empty value",
-			inputBody:          `{"creator_id": 1, "name": ""}`,
+			inputBody:          `{"creator_id": 1, "name": "", "par_set_id": 1}`,
 			mockBehavior:       func(r *service.MockUser, createGroupInput gameServer.CreateGroupInput) {},
 			expectedStatusCode: 400,
 			isError:            true,
@@ -0,0 +1,162 @@
+package i18n
+
+// Error codes of the API, sent in the "code" field of the error responses.
+// The generic codes are used for errors whose message comes from validation
+// and is not translated.
+const (
+	CodeBadRequest    = "bad_request"
+	CodeUnauthorized  = "unauthorized"
+	CodeForbidden     = "forbidden"
+	CodeNotFound      = "not_found"
+	CodeConflict      = "conflict"
+	CodeInternalError = "internal_error"
+
+	CodeInvalidParameter   = "invalid_parameter"
+	CodeEmptyAuthHeader    = "empty_auth_header"
+	CodeInvalidAuthHeader  = "invalid_auth_header"
+	CodeNotEnoughRights    = "not_enough_rights"
+	CodeAccessDenied       = "access_denied"
+	CodeTestsOnlyForUsers  = "tests_only_for_users"
+	CodeChartNotInProgress = "chart_not_in_progress"
+	CodeHintNotAvailable   = "hint_not_available"
+	CodeNoScenarios        = "no_scenarios"
+	CodeScenarioMismatch   = "scenario_mismatch"
+	CodeParSetInUse        = "par_set_in_use"
+	CodeParSetArchived     = "par_set_archived"
+	CodeParSetPlayed       = "par_set_played"
+	CodeInvalidAnswers     = "invalid_answers"
+	CodeRetakeNotAllowed   = "retake_not_allowed"
+	CodeUnknownInstrument  = "unknown_instrument"
+	CodeInstrumentLocale   = "instrument_locale"
+	CodeTestNotScaled      = "test_not_scaled"
+)
+
+const (
+	KeyDecisionPointsTitle    = "statistics.decision_points"
+	KeyAllDecisionPointsTitle = "statistics.all_decision_points"
+)
+
+var catalog = map[string]map[string]string{
+	LocaleEn: {
+		"event.crash":               "Crash",
+		"event.useful_ai_signal":    "Correct AI advice",
+		"event.deceptive_ai_signal": "Wrong AI advice",
+		"event.stop":                "Manual stop",
+		"event.pause":               "Pause",
+		"event.check":               "Hint used",
+		"event.reject_advice":       "AI advice rejected",
+
+		"risk_level.low":    "Low risk",
+		"risk_level.medium": "Medium risk",
+		"risk_level.high":   "High risk",
+
+		"metric.total_score":            "Total score",
+		"metric.advice_acceptance_rate": "AI advice acceptance rate",
+		"metric.hints_per_game":         "Hints per game",
+		"metric.mean_stop_y":            "Mean stop Y",
+
+		KeyDecisionPointsTitle:    "Decision points %d-%d",
+		KeyAllDecisionPointsTitle: "All decision points %d-%d",
+
+		"par_set_action.updated":  "updated",
+		"par_set_action.archived": "archived",
+		"par_set_action.deleted":  "deleted",
+
+		"error.bad_request":           "bad request",
+		"error.unauthorized":          "unauthorized",
+		"error.forbidden":             "forbidden",
+		"error.not_found":             "not found",
+		"error.conflict":              "conflict",
+		"error.internal_error":        "internal server error",
+		"error.invalid_parameter":     "invalid parameter %s",
+		"error.empty_auth_header":     "empty authorization header",
+		"error.invalid_auth_header":   "invalid authorization header",
+		"error.not_enough_rights":     "not enough rights",
+		"error.access_denied":         "access to the resource is denied",
+		"error.tests_only_for_users":  "tests are available only for users",
+		"error.chart_not_in_progress": "chart is not in progress",
+		"error.hint_not_available":    "hint type is not available in the parameter set",
+		"error.no_scenarios":          "parameter set has no scenarios",
+		"error.scenario_mismatch":     "the scenario does not belong to the parameter set",
+		"error.par_set_in_use":        "parameter set cannot be %s: it is referenced by %d charts, groups %v and users %v",
+		"error.par_set_archived":      "parameter set is archived",
+		"error.par_set_played":        "games have been played with the parameter set, it cannot be changed",
+		"error.invalid_answers":       "some answers are missing or invalid",
+		"error.retake_not_allowed":    "the test has already been taken",
+		"error.unknown_instrument":    "unknown instrument %s",
+		"error.instrument_locale":     "the instrument is not available in the %s locale",
+		"error.test_not_scaled":       "the test has no questions answered on a scale",
+
+		"answer_error.missing":           "the question must be answered",
+		"answer_error.unknown_question":  "the test has no such question",
+		"answer_error.invalid_type":      "the answer has a wrong type",
+		"answer_error.out_of_range":      "the answer is outside the scale",
+		"answer_error.off_step":          "the answer is not a step of the scale",
+		"answer_error.invalid_selection": "the number of selected options is not allowed",
+		"answer_error.invalid_ranking":   "all options must be ranked",
+		"answer_error.invalid_option":    "the answer is not one of the options",
+		"answer_error.too_long":          "the answer is too long",
+		"answer_error.hidden_question":   "the question is not shown for the earlier answers",
+	},
+	LocaleRu: {
+		"event.crash":               "Взрыв",
+		"event.useful_ai_signal":    "Верный совет ИИ",
+		"event.deceptive_ai_signal": "Ложный совет ИИ",
+		"event.stop":                "Ручная остановка",
+		"event.pause":               "Пауза",
+		"event.check":               "Использована подсказка",
+		"event.reject_advice":       "Отклонение совета ИИ",
+
+		"risk_level.low":    "Низкий риск",
+		"risk_level.medium": "Средний риск",
+		"risk_level.high":   "Высокий риск",
+
+		"metric.total_score":            "Итоговый счёт",
+		"metric.advice_acceptance_rate": "Доля принятых советов ИИ",
+		"metric.hints_per_game":         "Подсказок за игру",
+		"metric.mean_stop_y":            "Средний Y остановки",
+
+		KeyDecisionPointsTitle:    "Точки принятия решений %d-%d",
+		KeyAllDecisionPointsTitle: "Все точки принятия решений %d-%d",
+
+		"par_set_action.updated":  "изменить",
+		"par_set_action.archived": "архивировать",
+		"par_set_action.deleted":  "удалить",
+
+		"error.bad_request":           "некорректный запрос",
+		"error.unauthorized":          "требуется авторизация",
+		"error.forbidden":             "доступ запрещён",
+		"error.not_found":             "не найдено",
+		"error.conflict":              "конфликт",
+		"error.internal_error":        "внутренняя ошибка сервера",
+		"error.invalid_parameter":     "некорректный параметр %s",
+		"error.empty_auth_header":     "пустой заголовок авторизации",
+		"error.invalid_auth_header":   "некорректный заголовок авторизации",
+		"error.not_enough_rights":     "недостаточно прав",
+		"error.access_denied":         "доступ к ресурсу запрещён",
+		"error.tests_only_for_users":  "тесты доступны только игрокам",
+		"error.chart_not_in_progress": "игра уже завершена",
+		"error.hint_not_available":    "этот тип подсказки недоступен в наборе параметров",
+		"error.no_scenarios":          "у набора параметров нет сценариев",
+		"error.scenario_mismatch":     "сценарий не относится к набору параметров",
+		"error.par_set_in_use":        "набор параметров нельзя %s: на него ссылаются графики (%d), группы %v и пользователи %v",
+		"error.par_set_archived":      "набор параметров перенесён в архив",
+		"error.par_set_played":        "с набором параметров уже сыграны игры, его нельзя изменить",
+		"error.invalid_answers":       "некоторые ответы отсутствуют или некорректны",
+		"error.retake_not_allowed":    "тест уже пройден",
+		"error.unknown_instrument":    "неизвестная методика %s",
+		"error.instrument_locale":     "методика недоступна на языке %s",
+		"error.test_not_scaled":       "в тесте нет вопросов со шкалой ответов",
+
+		"answer_error.missing":           "необходимо ответить на вопрос",
+		"answer_error.unknown_question":  "в тесте нет такого вопроса",
+		"answer_error.invalid_type":      "ответ имеет неверный тип",
+		"answer_error.out_of_range":      "ответ вне диапазона шкалы",
+		"answer_error.off_step":          "ответ не соответствует шагу шкалы",
+		"answer_error.invalid_selection": "выбрано недопустимое число вариантов",
+		"answer_error.invalid_ranking":   "необходимо упорядочить все варианты",
+		"answer_error.invalid_option":    "ответа нет среди вариантов",
+		"answer_error.too_long":          "ответ слишком длинный",
+		"answer_error.hidden_question":   "вопрос не показывается при данных ответах",
+	},
+}
@@ -0,0 +1,86 @@
+// Package i18n holds the message catalog of the server: the translations of
+// the event codes, risk levels, statistics titles and error codes.
+package i18n
+
+import (
+	"fmt"
+
+	"golang.org/x/text/language"
+)
+
+const (
+	LocaleEn = "en"
+	LocaleRu = "ru"
+	// DefaultLocale is used when the request has no Accept-Language header
+	// or asks for a locale that the catalog does not have.
+	DefaultLocale = LocaleEn
+)
+
+// Locales are the locales of the catalog, the default locale first.
+var Locales = []string{LocaleEn, LocaleRu}
+
+var matcher = language.NewMatcher([]language.Tag{language.English, language.Russian})
+
+// MatchLocale returns the locale of the catalog that best matches the
+// Accept-Language header.
+func MatchLocale(acceptLanguage string) string {
+	preferred, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(preferred) == 0 {
+		return DefaultLocale
+	}
+	_, index, confidence := matcher.Match(preferred...)
+	if confidence == language.No {
+		return DefaultLocale
+	}
+	return Locales[index]
+}
+
+// T translates the key to the locale and formats it with the args. Keys
+// missing from the locale fall back to the default locale, keys missing
+// from the catalog are returned as is.
+func T(locale, key string, args ...any) string {
+	message, ok := catalog[locale][key]
+	if !ok {
+		message, ok = catalog[DefaultLocale][key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// Messages returns the whole catalog of the locale, the client uses it to
+// translate the codes it receives.
+func Messages(locale string) map[string]string {
+	messages := make(map[string]string, len(catalog[DefaultLocale]))
+	for key, message := range catalog[DefaultLocale] {
+		messages[key] = message
+	}
+	for key, message := range catalog[locale] {
+		messages[key] = message
+	}
+	return messages
+}
+
+func EventKey(code string) string {
+	return "event." + code
+}
+
+func MetricKey(code string) string {
+	return "metric." + code
+}
+
+func RiskLevelKey(code string) string {
+	return "risk_level." + code
+}
+
+func ErrorKey(code string) string {
+	return "error." + code
+}
+
+func AnswerErrorKey(code string) string {
+	return "answer_error." + code
+}
@@ -0,0 +1,70 @@
+package instrument
+
+import gameServer "example.com/gameHoldTheProcessServer"
+
+// asrsItems are the six screener items of Part A of the ASRS v1.1 with the
+// answer from which each of them counts towards the screening.
+var asrsItems = []struct {
+	text      text
+	threshold float64
+}{
+	{text{"en": "How often do you have trouble wrapping up the final details of a project, once the challenging parts have been done?", "ru": "Как часто вам трудно завершить последние детали проекта, когда самые сложные его части уже выполнены?"}, 2},
+	{text{"en": "How often do you have difficulty getting things in order when you have to do a task that requires organization?", "ru": "Как часто вам трудно навести порядок, когда нужно выполнить задачу, требующую организованности?"}, 2},
+	{text{"en": "How often do you have problems remembering appointments or obligations?", "ru": "Как часто у вас возникают проблемы с тем, чтобы помнить о встречах или обязательствах?"}, 2},
+	{text{"en": "When you have a task that requires a lot of thought, how often do you avoid or delay getting started?", "ru": "Когда вам предстоит задача, требующая серьёзного обдумывания, как часто вы избегаете или откладываете начало работы?"}, 3},
+	{text{"en": "How often do you fidget or squirm with your hands or feet when you have to sit down for a long time?", "ru": "Как часто вы ёрзаете или двигаете руками и ногами, когда вам приходится долго сидеть?"}, 3},
+	{text{"en": "How often do you feel overly active and compelled to do things, like you were driven by a motor?", "ru": "Как часто вы чувствуете себя чрезмерно активным(ой) и вынужденным(ой) что-то делать, как будто вами движет мотор?"}, 3},
+}
+
+// asrsCutoff is the number of items in the shaded boxes from which the
+// symptoms are highly consistent with adult ADHD.
+const asrsCutoff = 4
+
+var asrsScreener = definition{
+	id:    "asrs_screener",
+	title: text{"en": "Adult ADHD Self-Report Scale (ASRS v1.1) Screener", "ru": "Шкала самооценки СДВГ у взрослых (ASRS v1.1), скрининг"},
+	description: text{
+		"en": "Answer how you have felt and conducted yourself over the past 6 months.",
+		"ru": "Ответьте, как вы себя чувствовали и вели в течение последних 6 месяцев.",
+	},
+	reference: "Kessler, R. C., et al. (2005). The World Health Organization Adult ADHD Self-Report Scale (ASRS): A short screening scale for use in the general population. Psychological Medicine, 35(2), 245–256.",
+	config:    asrsConfig,
+}
+
+func asrsConfig(locale string) gameServer.MixedConfig {
+	config := gameServer.MixedConfig{
+		Scale: gameServer.LikertScale{
+			Min: 0,
+			Max: 4,
+			Labels: map[string]string{
+				"0": text{"en": "Never", "ru": "Никогда"}.in(locale),
+				"1": text{"en": "Rarely", "ru": "Редко"}.in(locale),
+				"2": text{"en": "Sometimes", "ru": "Иногда"}.in(locale),
+				"3": text{"en": "Often", "ru": "Часто"}.in(locale),
+				"4": text{"en": "Very often", "ru": "Очень часто"}.in(locale),
+			},
+		},
+		ScoringRules: gameServer.ScoringRules{
+			Scoring: gameServer.ScoringThresholdCount,
+			Interpretations: []gameServer.ScoreInterpretation{
+				{
+					Max:   float(asrsCutoff),
+					Label: text{"en": "Below the screening threshold", "ru": "Ниже порога скрининга"}.in(locale),
+				},
+				{
+					Min:   float(asrsCutoff),
+					Label: text{"en": "Highly consistent with adult ADHD, further investigation is warranted", "ru": "Высокая согласованность с СДВГ у взрослых, рекомендуется дальнейшее обследование"}.in(locale),
+				},
+			},
+		},
+	}
+	for i, item := range asrsItems {
+		config.Items = append(config.Items, gameServer.TestItem{
+			Id:        itemId(i),
+			Type:      gameServer.ItemTypeLikert,
+			Text:      item.text.in(locale),
+			Threshold: float(item.threshold),
+		})
+	}
+	return config
+}
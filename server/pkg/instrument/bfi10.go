@@ -0,0 +1,82 @@
+package instrument
+
+import gameServer "example.com/gameHoldTheProcessServer"
+
+type bfiTrait struct {
+	id    string
+	title text
+}
+
+var bfiTraits = []bfiTrait{
+	{"extraversion", text{"en": "Extraversion", "ru": "Экстраверсия"}},
+	{"agreeableness", text{"en": "Agreeableness", "ru": "Доброжелательность"}},
+	{"conscientiousness", text{"en": "Conscientiousness", "ru": "Добросовестность"}},
+	{"neuroticism", text{"en": "Neuroticism", "ru": "Нейротизм"}},
+	{"openness", text{"en": "Openness", "ru": "Открытость опыту"}},
+}
+
+// bfiItems follow the traits in the order of bfiTraits twice, the reverse
+// keys are those of the BFI-10 scoring key.
+var bfiItems = []struct {
+	text    text
+	reverse bool
+}{
+	{text{"en": "... is reserved", "ru": "... сдержанный(ая)"}, true},
+	{text{"en": "... is generally trusting", "ru": "... обычно доверяет людям"}, false},
+	{text{"en": "... tends to be lazy", "ru": "... склонен(на) к лени"}, true},
+	{text{"en": "... is relaxed, handles stress well", "ru": "... расслабленный(ая), хорошо справляется со стрессом"}, true},
+	{text{"en": "... has few artistic interests", "ru": "... мало интересуется искусством"}, true},
+	{text{"en": "... is outgoing, sociable", "ru": "... общительный(ая), компанейский(ая)"}, false},
+	{text{"en": "... tends to find fault with others", "ru": "... склонен(на) придираться к другим"}, true},
+	{text{"en": "... does a thorough job", "ru": "... работает основательно"}, false},
+	{text{"en": "... gets nervous easily", "ru": "... легко начинает нервничать"}, false},
+	{text{"en": "... has an active imagination", "ru": "... обладает живым воображением"}, false},
+}
+
+var bfi10 = definition{
+	id:    "bfi10",
+	title: text{"en": "Big Five Inventory, short form (BFI-10)", "ru": "Большая пятёрка, краткая форма (BFI-10)"},
+	description: text{
+		"en": "How well do the following statements describe your personality? I see myself as someone who...",
+		"ru": "Насколько хорошо следующие утверждения описывают вашу личность? Я считаю себя человеком, который...",
+	},
+	reference: "Rammstedt, B., & John, O. P. (2007). Measuring personality in one minute or less: A 10-item short version of the Big Five Inventory in English and German. Journal of Research in Personality, 41(1), 203–212.",
+	config:    bfi10Config,
+}
+
+// bfi10Config scores each trait as the mean of its two items, the inventory
+// has no total score.
+func bfi10Config(locale string) gameServer.MixedConfig {
+	config := gameServer.MixedConfig{
+		Scale: gameServer.LikertScale{
+			Min: 1,
+			Max: 5,
+			Labels: map[string]string{
+				"1": text{"en": "Disagree strongly", "ru": "Совершенно не согласен(на)"}.in(locale),
+				"2": text{"en": "Disagree a little", "ru": "Скорее не согласен(на)"}.in(locale),
+				"3": text{"en": "Neither agree nor disagree", "ru": "Ни то ни другое"}.in(locale),
+				"4": text{"en": "Agree a little", "ru": "Скорее согласен(на)"}.in(locale),
+				"5": text{"en": "Agree strongly", "ru": "Полностью согласен(на)"}.in(locale),
+			},
+		},
+		ScoringRules: gameServer.ScoringRules{Scoring: gameServer.ScoringNone},
+	}
+	for _, trait := range bfiTraits {
+		config.Subscales = append(config.Subscales, gameServer.LikertSubscale{
+			Id:      trait.id,
+			Title:   trait.title.in(locale),
+			Scoring: gameServer.ScoringMean,
+		})
+	}
+	for i, item := range bfiItems {
+		config.Items = append(config.Items, gameServer.TestItem{
+			Id:      itemId(i),
+			Type:    gameServer.ItemTypeLikert,
+			Text:    item.text.in(locale),
+			Reverse: item.reverse,
+		})
+		trait := &config.Subscales[i%len(bfiTraits)]
+		trait.Items = append(trait.Items, itemId(i))
+	}
+	return config
+}
@@ -0,0 +1,126 @@
+// Package instrument is the library of standardized questionnaires that can
+// be added as tests: their items in every supported language and the
+// official scoring where the generic scoring of a test does not express it.
+package instrument
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	gameServer "example.com/gameHoldTheProcessServer"
+	"example.com/gameHoldTheProcessServer/pkg/i18n"
+)
+
+// ScoreFunc scores the validated answers to a test created from an
+// instrument with its own scoring.
+type ScoreFunc func(config gameServer.MixedConfig, answers map[string]json.RawMessage) (*gameServer.TestScore, error)
+
+// text is a text in every locale of the library.
+type text map[string]string
+
+func (t text) in(locale string) string {
+	if value, ok := t[locale]; ok {
+		return value
+	}
+	return t[i18n.DefaultLocale]
+}
+
+type definition struct {
+	id          string
+	title       text
+	description text
+	reference   string
+	// config builds the config of a test in the locale.
+	config func(locale string) gameServer.MixedConfig
+	// score is nil for the instruments scored by the scoring rules of the
+	// config.
+	score ScoreFunc
+}
+
+var library = []definition{nasaTLX, jianTrust, asrsScreener, bfi10}
+
+func find(id string) (definition, bool) {
+	for _, d := range library {
+		if d.id == id {
+			return d, true
+		}
+	}
+	return definition{}, false
+}
+
+// List returns the instruments of the library described in the locale.
+func List(locale string) []gameServer.Instrument {
+	instruments := make([]gameServer.Instrument, 0, len(library))
+	for _, d := range library {
+		instruments = append(instruments, gameServer.Instrument{
+			Id:          d.id,
+			Title:       d.title.in(locale),
+			Description: d.description.in(locale),
+			Reference:   d.reference,
+			Locales:     i18n.Locales,
+			ItemCount:   len(d.config(locale).Items),
+		})
+	}
+	return instruments
+}
+
+// Build returns the test of the instrument in the locale.
+func Build(id string, input gameServer.CreateInstrumentTestInput) (gameServer.CreateTestInput, error) {
+	d, ok := find(id)
+	if !ok {
+		return gameServer.CreateTestInput{}, fmt.Errorf("%w: %q", gameServer.ErrUnknownInstrument, id)
+	}
+	if !supportsLocale(input.Locale) {
+		return gameServer.CreateTestInput{}, fmt.Errorf("%w: %q", gameServer.ErrInstrumentLocale, input.Locale)
+	}
+
+	config := d.config(input.Locale)
+	config.Instrument = d.id
+	rawConfig, err := json.Marshal(config)
+	if err != nil {
+		return gameServer.CreateTestInput{}, err
+	}
+
+	slug := input.Slug
+	if slug == "" {
+		slug = d.id + "_" + input.Locale
+	}
+	return gameServer.CreateTestInput{
+		Slug:         slug,
+		Type:         gameServer.TestTypeMixed,
+		Title:        d.title.in(input.Locale),
+		Description:  d.description.in(input.Locale),
+		Config:       rawConfig,
+		IsActive:     input.IsActive,
+		RetakePolicy: input.RetakePolicy,
+		SortOrder:    input.SortOrder,
+	}, nil
+}
+
+// Scorer returns the scoring of the instrument, nil when the instrument is
+// scored by the scoring rules of its config.
+func Scorer(id string) ScoreFunc {
+	d, ok := find(id)
+	if !ok {
+		return nil
+	}
+	return d.score
+}
+
+func supportsLocale(locale string) bool {
+	for _, l := range i18n.Locales {
+		if l == locale {
+			return true
+		}
+	}
+	return false
+}
+
+func float(value float64) *float64 {
+	return &value
+}
+
+func itemId(i int) string {
+	return "q" + strconv.Itoa(i+1)
+}
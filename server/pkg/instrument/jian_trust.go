@@ -0,0 +1,66 @@
+package instrument
+
+import gameServer "example.com/gameHoldTheProcessServer"
+
+// jianItems are the items of the checklist for trust between people and
+// automation, the first five measure distrust and are reverse-keyed.
+var jianItems = []text{
+	{"en": "The system is deceptive.", "ru": "Система вводит в заблуждение."},
+	{"en": "The system behaves in an underhanded manner.", "ru": "Система действует скрытно и нечестно."},
+	{"en": "I am suspicious of the system's intent, action, or outputs.", "ru": "Я с подозрением отношусь к намерениям, действиям или результатам системы."},
+	{"en": "I am wary of the system.", "ru": "Я настороженно отношусь к системе."},
+	{"en": "The system's actions will have a harmful or injurious outcome.", "ru": "Действия системы приведут к вредным или опасным последствиям."},
+	{"en": "I am confident in the system.", "ru": "Я уверен(а) в системе."},
+	{"en": "The system provides security.", "ru": "Система обеспечивает безопасность."},
+	{"en": "The system has integrity.", "ru": "Система честна и последовательна."},
+	{"en": "The system is dependable.", "ru": "На систему можно положиться."},
+	{"en": "The system is reliable.", "ru": "Система надёжна."},
+	{"en": "I can trust the system.", "ru": "Я могу доверять системе."},
+	{"en": "I am familiar with the system.", "ru": "Я хорошо знаком(а) с системой."},
+}
+
+const jianDistrustItems = 5
+
+var jianTrust = definition{
+	id:    "jian_trust",
+	title: text{"en": "Trust in Automation", "ru": "Доверие к автоматизации"},
+	description: text{
+		"en": "Rate how much each statement describes your impression of the system you have worked with.",
+		"ru": "Оцените, насколько каждое утверждение описывает ваше впечатление от системы, с которой вы работали.",
+	},
+	reference: "Jian, J.-Y., Bisantz, A. M., & Drury, C. G. (2000). Foundations for an empirically determined scale of trust in automated systems. International Journal of Cognitive Ergonomics, 4(1), 53–71.",
+	config:    jianTrustConfig,
+}
+
+func jianTrustConfig(locale string) gameServer.MixedConfig {
+	config := gameServer.MixedConfig{
+		Scale: gameServer.LikertScale{
+			Min: 1,
+			Max: 7,
+			Labels: map[string]string{
+				"1": text{"en": "Not at all", "ru": "Совсем нет"}.in(locale),
+				"7": text{"en": "Extremely", "ru": "Полностью"}.in(locale),
+			},
+		},
+		ScoringRules: gameServer.ScoringRules{Scoring: gameServer.ScoringMean},
+	}
+	distrust := gameServer.LikertSubscale{Id: "distrust", Title: text{"en": "Distrust (reversed)", "ru": "Недоверие (обращённое)"}.in(locale)}
+	trust := gameServer.LikertSubscale{Id: "trust", Title: text{"en": "Trust", "ru": "Доверие"}.in(locale)}
+	for i, item := range jianItems {
+		id := itemId(i)
+		reverse := i < jianDistrustItems
+		config.Items = append(config.Items, gameServer.TestItem{
+			Id:      id,
+			Type:    gameServer.ItemTypeLikert,
+			Text:    item.in(locale),
+			Reverse: reverse,
+		})
+		if reverse {
+			distrust.Items = append(distrust.Items, id)
+		} else {
+			trust.Items = append(trust.Items, id)
+		}
+	}
+	config.Subscales = []gameServer.LikertSubscale{trust, distrust}
+	return config
+}
@@ -0,0 +1,263 @@
+package instrument
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	gameServer "example.com/gameHoldTheProcessServer"
+)
+
+type tlxDimension struct {
+	id       string
+	title    text
+	question text
+	// low and high label the ends of the rating scale, only performance
+	// goes from perfect to failure.
+	low, high text
+}
+
+var (
+	tlxLow  = text{"en": "Very low", "ru": "Очень низкая"}
+	tlxHigh = text{"en": "Very high", "ru": "Очень высокая"}
+)
+
+var tlxDimensions = []tlxDimension{
+	{
+		id:       "mental_demand",
+		title:    text{"en": "Mental Demand", "ru": "Умственная нагрузка"},
+		question: text{"en": "How mentally demanding was the task?", "ru": "Насколько задание было умственно напряжённым?"},
+		low:      tlxLow, high: tlxHigh,
+	},
+	{
+		id:       "physical_demand",
+		title:    text{"en": "Physical Demand", "ru": "Физическая нагрузка"},
+		question: text{"en": "How physically demanding was the task?", "ru": "Насколько задание было физически напряжённым?"},
+		low:      tlxLow, high: tlxHigh,
+	},
+	{
+		id:       "temporal_demand",
+		title:    text{"en": "Temporal Demand", "ru": "Временная нагрузка"},
+		question: text{"en": "How hurried or rushed was the pace of the task?", "ru": "Насколько торопливым или спешным был темп выполнения задания?"},
+		low:      tlxLow, high: tlxHigh,
+	},
+	{
+		id:       "performance",
+		title:    text{"en": "Performance", "ru": "Успешность"},
+		question: text{"en": "How successful were you in accomplishing what you were asked to do?", "ru": "Насколько успешно вы выполнили то, что от вас требовалось?"},
+		low:      text{"en": "Perfect", "ru": "Идеально"},
+		high:     text{"en": "Failure", "ru": "Провал"},
+	},
+	{
+		id:       "effort",
+		title:    text{"en": "Effort", "ru": "Усилие"},
+		question: text{"en": "How hard did you have to work to accomplish your level of performance?", "ru": "Насколько усердно вам пришлось работать, чтобы достичь своего результата?"},
+		low:      tlxLow, high: tlxHigh,
+	},
+	{
+		id:       "frustration",
+		title:    text{"en": "Frustration", "ru": "Раздражение"},
+		question: text{"en": "How insecure, discouraged, irritated, stressed, and annoyed were you?", "ru": "Насколько вы чувствовали неуверенность, уныние, раздражение, стресс и досаду?"},
+		low:      tlxLow, high: tlxHigh,
+	},
+}
+
+// tlxPairs are the 15 pairwise comparisons of the dimensions, by index in
+// tlxDimensions. Each pair is an item pair_<n> with the two dimensions as its
+// options in this order.
+var tlxPairs = func() [][2]int {
+	var pairs [][2]int
+	for i := range tlxDimensions {
+		for j := i + 1; j < len(tlxDimensions); j++ {
+			pairs = append(pairs, [2]int{i, j})
+		}
+	}
+	return pairs
+}()
+
+const tlxRatingStep = 5
+
+var nasaTLX = definition{
+	id:    "nasa_tlx",
+	title: text{"en": "NASA Task Load Index", "ru": "Индекс нагрузки NASA-TLX"},
+	description: text{
+		"en": "Rate the task you have just completed on six dimensions of workload, then choose in each pair the dimension that contributed more to it.",
+		"ru": "Оцените только что выполненное задание по шести составляющим нагрузки, затем в каждой паре выберите составляющую, которая внесла в неё больший вклад.",
+	},
+	reference: "Hart, S. G., & Staveland, L. E. (1988). Development of NASA-TLX (Task Load Index): Results of empirical and theoretical research. Advances in Psychology, 52, 139–183.",
+	config:    nasaTLXConfig,
+	score:     scoreNASATLX,
+}
+
+func nasaTLXConfig(locale string) gameServer.MixedConfig {
+	config := gameServer.MixedConfig{
+		Scale:        gameServer.LikertScale{Min: 0, Max: 100},
+		ScoringRules: gameServer.ScoringRules{Scoring: gameServer.ScoringNone},
+	}
+	// The subscales carry the titles of the dimensions for the scoring and
+	// score the ratings without their weights when the config is scored as
+	// a plain mixed test.
+	raw := gameServer.LikertSubscale{Id: "raw_tlx", Title: text{"en": "Raw TLX", "ru": "Невзвешенный TLX"}.in(locale)}
+	for _, dimension := range tlxDimensions {
+		config.Items = append(config.Items, gameServer.TestItem{
+			Id:   dimension.id,
+			Type: gameServer.ItemTypeSlider,
+			Text: dimension.title.in(locale) + ": " + dimension.question.in(locale),
+			Scale: &gameServer.LikertScale{
+				Min: 0,
+				Max: 100,
+				Labels: map[string]string{
+					"0":   dimension.low.in(locale),
+					"100": dimension.high.in(locale),
+				},
+			},
+			Step: tlxRatingStep,
+		})
+		config.Subscales = append(config.Subscales, gameServer.LikertSubscale{
+			Id:    dimension.id,
+			Title: dimension.title.in(locale),
+			Items: []string{dimension.id},
+		})
+		raw.Items = append(raw.Items, dimension.id)
+	}
+	config.Subscales = append(config.Subscales, raw)
+
+	question := text{
+		"en": "Which contributed more to the workload of the task?",
+		"ru": "Что внесло больший вклад в нагрузку при выполнении задания?",
+	}
+	for n, pair := range tlxPairs {
+		config.Items = append(config.Items, gameServer.TestItem{
+			Id:   tlxPairId(n),
+			Type: gameServer.ItemTypeSingleChoice,
+			Text: question.in(locale),
+			Options: []string{
+				tlxDimensions[pair[0]].title.in(locale),
+				tlxDimensions[pair[1]].title.in(locale),
+			},
+		})
+	}
+	return config
+}
+
+func tlxPairId(n int) string {
+	return "pair_" + strconv.Itoa(n+1)
+}
+
+// scoreNASATLX weights each rating by the number of pairs in which its
+// dimension was chosen and the total is the weighted workload from 0 to 100.
+// The dimensions and pairs are read from the config the test was created
+// with, not from the library, so that an edited test is scored as it is:
+// the sliders are the ratings and a single choice item is a pair when both
+// of its options are titles of the rated dimensions. The weights are kept by
+// the item ids of the sliders, a title that is empty or shared by several
+// sliders does not tell them apart, so the pairs that name it are not scored.
+// Unanswered pairs and ratings are left out, without any weight the total is
+// the unweighted mean of the ratings, which is also reported as the raw_tlx
+// subscale.
+func scoreNASATLX(config gameServer.MixedConfig, answers map[string]json.RawMessage) (*gameServer.TestScore, error) {
+	titles := make(map[string]string, len(config.Subscales))
+	for _, subscale := range config.Subscales {
+		titles[subscale.Id] = subscale.Title
+	}
+	var dimensions []string
+	byTitle := map[string]string{}
+	ambiguous := map[string]bool{}
+	for _, item := range config.Items {
+		if item.Type != gameServer.ItemTypeSlider {
+			continue
+		}
+		dimensions = append(dimensions, item.Id)
+		title := titles[item.Id]
+		if _, ok := byTitle[title]; ok || title == "" {
+			ambiguous[title] = true
+		}
+		byTitle[title] = item.Id
+	}
+	for title := range ambiguous {
+		delete(byTitle, title)
+	}
+
+	answersErr := &gameServer.AnswersError{Questions: map[string]string{}}
+	weights := make(map[string]float64, len(dimensions))
+	var pairs float64
+	for _, item := range config.Items {
+		if item.Type != gameServer.ItemTypeSingleChoice || len(item.Options) != 2 {
+			continue
+		}
+		first, isFirst := byTitle[item.Options[0]]
+		second, isSecond := byTitle[item.Options[1]]
+		if !isFirst || !isSecond || first == second {
+			continue
+		}
+		var choice string
+		if !decodeTLXAnswer(answers, item.Id, &choice, answersErr) {
+			continue
+		}
+		switch choice {
+		case item.Options[0]:
+			weights[first]++
+		case item.Options[1]:
+			weights[second]++
+		default:
+			answersErr.Questions[item.Id] = gameServer.AnswerInvalidOption
+			continue
+		}
+		pairs++
+	}
+
+	score := &gameServer.TestScore{}
+	var weighted, weightSum, raw float64
+	rated := 0
+	for _, id := range dimensions {
+		var rating float64
+		if !decodeTLXAnswer(answers, id, &rating, answersErr) {
+			continue
+		}
+		weighted += rating * weights[id]
+		weightSum += weights[id]
+		raw += rating
+		rated++
+		score.Subscales = append(score.Subscales, gameServer.SubscaleScore{
+			Id:       id,
+			Title:    titles[id],
+			Score:    rating,
+			Answered: 1,
+			Weight:   float(weights[id]),
+		})
+	}
+	if len(answersErr.Questions) > 0 {
+		return nil, answersErr
+	}
+	if rated == 0 {
+		return nil, nil
+	}
+
+	score.Answered = rated + int(pairs)
+	score.Subscales = append(score.Subscales, gameServer.SubscaleScore{
+		Id:       "raw_tlx",
+		Title:    titles["raw_tlx"],
+		Score:    raw / float64(rated),
+		Answered: rated,
+	})
+	if weightSum > 0 {
+		score.Total = float(weighted / weightSum)
+	} else {
+		score.Total = float(raw / float64(rated))
+	}
+	return score, nil
+}
+
+// decodeTLXAnswer decodes the answer to the item into value, false when the
+// answer is missing or null. A malformed answer is added to answersErr.
+func decodeTLXAnswer(answers map[string]json.RawMessage, id string, value any, answersErr *gameServer.AnswersError) bool {
+	raw, ok := answers[id]
+	if !ok || strings.TrimSpace(string(raw)) == "null" {
+		return false
+	}
+	if err := json.Unmarshal(raw, value); err != nil {
+		answersErr.Questions[id] = gameServer.AnswerInvalidType
+		return false
+	}
+	return true
+}
@@ -0,0 +1,212 @@
+package instrument
+
+import (
+	"encoding/json"
+	"testing"
+
+	gameServer "example.com/gameHoldTheProcessServer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tlxPairAnswers are the answers to the 15 pairs of the English config with
+// the weights mental demand 5, effort 4, temporal demand 3, frustration 2,
+// performance 1 and physical demand 0.
+var tlxPairAnswers = map[string]string{
+	"pair_1":  "Mental Demand",
+	"pair_2":  "Mental Demand",
+	"pair_3":  "Mental Demand",
+	"pair_4":  "Mental Demand",
+	"pair_5":  "Mental Demand",
+	"pair_6":  "Temporal Demand",
+	"pair_7":  "Performance",
+	"pair_8":  "Effort",
+	"pair_9":  "Frustration",
+	"pair_10": "Temporal Demand",
+	"pair_11": "Effort",
+	"pair_12": "Temporal Demand",
+	"pair_13": "Effort",
+	"pair_14": "Frustration",
+	"pair_15": "Effort",
+}
+
+var tlxRatings = map[string]float64{
+	"mental_demand":   80,
+	"physical_demand": 20,
+	"temporal_demand": 60,
+	"performance":     40,
+	"effort":          70,
+	"frustration":     30,
+}
+
+func tlxAnswers(t *testing.T, ratings map[string]float64, pairs map[string]string) map[string]json.RawMessage {
+	t.Helper()
+	answers := map[string]json.RawMessage{}
+	for id, rating := range ratings {
+		raw, err := json.Marshal(rating)
+		require.NoError(t, err)
+		answers[id] = raw
+	}
+	for id, choice := range pairs {
+		raw, err := json.Marshal(choice)
+		require.NoError(t, err)
+		answers[id] = raw
+	}
+	return answers
+}
+
+func TestScoreNASATLX(t *testing.T) {
+	type subscale struct {
+		score  float64
+		weight float64
+	}
+
+	tests := []struct {
+		name string
+		// editConfig changes the config of the library before scoring.
+		editConfig        func(config *gameServer.MixedConfig)
+		answers           map[string]json.RawMessage
+		expectedTotal     float64
+		expectedRaw       float64
+		expectedAnswered  int
+		expectedSubscales map[string]subscale
+		expectedNil       bool
+		expectedErr       map[string]string
+	}{
+		{
+			name:    "all pairs answered",
+			answers: tlxAnswers(t, tlxRatings, tlxPairAnswers),
+			// (80*5 + 20*0 + 60*3 + 40*1 + 70*4 + 30*2) / 15
+			expectedTotal:    64,
+			expectedRaw:      50,
+			expectedAnswered: 21,
+			expectedSubscales: map[string]subscale{
+				"mental_demand":   {score: 80, weight: 5},
+				"physical_demand": {score: 20, weight: 0},
+				"temporal_demand": {score: 60, weight: 3},
+				"performance":     {score: 40, weight: 1},
+				"effort":          {score: 70, weight: 4},
+				"frustration":     {score: 30, weight: 2},
+			},
+		},
+		{
+			name:    "missing ratings",
+			answers: tlxAnswers(t, map[string]float64{"mental_demand": 80, "effort": 70}, tlxPairAnswers),
+			// (80*5 + 70*4) / (5 + 4)
+			expectedTotal:    680.0 / 9,
+			expectedRaw:      75,
+			expectedAnswered: 17,
+			expectedSubscales: map[string]subscale{
+				"mental_demand": {score: 80, weight: 5},
+				"effort":        {score: 70, weight: 4},
+			},
+		},
+		{
+			name:             "no pairs answered",
+			answers:          tlxAnswers(t, tlxRatings, nil),
+			expectedTotal:    50,
+			expectedRaw:      50,
+			expectedAnswered: 6,
+			expectedSubscales: map[string]subscale{
+				"mental_demand":   {score: 80},
+				"physical_demand": {score: 20},
+				"temporal_demand": {score: 60},
+				"performance":     {score: 40},
+				"effort":          {score: 70},
+				"frustration":     {score: 30},
+			},
+		},
+		{
+			name: "null answers",
+			answers: map[string]json.RawMessage{
+				"mental_demand": json.RawMessage(`null`),
+				"pair_1":        json.RawMessage(`null`),
+			},
+			expectedNil: true,
+		},
+		{
+			name:        "no answers",
+			answers:     map[string]json.RawMessage{},
+			expectedNil: true,
+		},
+		{
+			name: "invalid option",
+			answers: tlxAnswers(t, tlxRatings, map[string]string{
+				"pair_1": "Effort",
+				"pair_2": "Mental Demand",
+			}),
+			expectedErr: map[string]string{"pair_1": gameServer.AnswerInvalidOption},
+		},
+		{
+			name: "rating of a wrong type",
+			answers: map[string]json.RawMessage{
+				"mental_demand": json.RawMessage(`"high"`),
+				"effort":        json.RawMessage(`70`),
+			},
+			expectedErr: map[string]string{"mental_demand": gameServer.AnswerInvalidType},
+		},
+		{
+			name: "shared title",
+			editConfig: func(config *gameServer.MixedConfig) {
+				for i := range config.Subscales {
+					if config.Subscales[i].Id == "physical_demand" {
+						config.Subscales[i].Title = "Mental Demand"
+					}
+				}
+			},
+			// The pairs with mental or physical demand are not scored:
+			// (60*2 + 40*0 + 70*3 + 30*1) / 6
+			answers:          tlxAnswers(t, tlxRatings, tlxPairAnswers),
+			expectedTotal:    60,
+			expectedRaw:      50,
+			expectedAnswered: 12,
+			expectedSubscales: map[string]subscale{
+				"mental_demand":   {score: 80, weight: 0},
+				"physical_demand": {score: 20, weight: 0},
+				"temporal_demand": {score: 60, weight: 2},
+				"performance":     {score: 40, weight: 0},
+				"effort":          {score: 70, weight: 3},
+				"frustration":     {score: 30, weight: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := nasaTLXConfig("en")
+			if tt.editConfig != nil {
+				tt.editConfig(&config)
+			}
+
+			score, err := scoreNASATLX(config, tt.answers)
+
+			if tt.expectedErr != nil {
+				var answersErr *gameServer.AnswersError
+				require.ErrorAs(t, err, &answersErr)
+				assert.Equal(t, tt.expectedErr, answersErr.Questions)
+				return
+			}
+			require.NoError(t, err)
+			if tt.expectedNil {
+				assert.Nil(t, score)
+				return
+			}
+
+			require.NotNil(t, score)
+			require.NotNil(t, score.Total)
+			assert.InDelta(t, tt.expectedTotal, *score.Total, 1e-9)
+			assert.Equal(t, tt.expectedAnswered, score.Answered)
+
+			subscales := map[string]subscale{}
+			for _, s := range score.Subscales {
+				if s.Id == "raw_tlx" {
+					assert.InDelta(t, tt.expectedRaw, s.Score, 1e-9)
+					continue
+				}
+				require.NotNil(t, s.Weight)
+				subscales[s.Id] = subscale{score: s.Score, weight: *s.Weight}
+			}
+			assert.Equal(t, tt.expectedSubscales, subscales)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package lib
+
+import "math/rand/v2"
+
+// AdvisorState is what the AI advisor knows about the game at one step.
+type AdvisorState struct {
+	Step          int
+	Y             float64
+	CriticalValue float64
+	IsDanger      bool
+}
+
+type Advisor interface {
+	// Reliability is the probability that the advice at the state is correct.
+	Reliability(state AdvisorState) float64
+	// Confidence is the confidence the advisor reports with its advice.
+	Confidence(state AdvisorState) float64
+}
+
+// Advise draws the advice for the state and reports whether the advisor
+// warns about danger.
+func Advise(advisor Advisor, state AdvisorState, rng *rand.Rand) bool {
+	isCorrect := rng.Float64() < advisor.Reliability(state)
+	return isCorrect == state.IsDanger
+}
+
+// BernoulliAdvisor misses danger and raises false warnings with fixed
+// probabilities.
+type BernoulliAdvisor struct {
+	FalseWarningProb  float64
+	MissingDangerProb float64
+}
+
+func (a BernoulliAdvisor) Reliability(state AdvisorState) float64 {
+	if state.IsDanger {
+		return 1 - a.MissingDangerProb
+	}
+	return 1 - a.FalseWarningProb
+}
+
+func (a BernoulliAdvisor) Confidence(state AdvisorState) float64 {
+	return a.Reliability(state)
+}
+
+// DriftingAdvisor changes its reliability linearly from Start to End over
+// the first Steps steps of the game.
+type DriftingAdvisor struct {
+	Start float64
+	End   float64
+	Steps int
+}
+
+func (a DriftingAdvisor) Reliability(state AdvisorState) float64 {
+	progress := min(float64(state.Step)/float64(a.Steps), 1)
+	return a.Start + (a.End-a.Start)*progress
+}
+
+func (a DriftingAdvisor) Confidence(state AdvisorState) float64 {
+	return a.Reliability(state)
+}
+
+// ProximityAdvisor is Near reliable when the process is at the critical
+// value and Far reliable when it is Range or more below it.
+type ProximityAdvisor struct {
+	Near  float64
+	Far   float64
+	Range float64
+}
+
+func (a ProximityAdvisor) Reliability(state AdvisorState) float64 {
+	distance := max(state.CriticalValue-state.Y, 0)
+	return a.Near + (a.Far-a.Near)*min(distance/a.Range, 1)
+}
+
+func (a ProximityAdvisor) Confidence(state AdvisorState) float64 {
+	return a.Reliability(state)
+}
+
+// ScriptedAdvisor replays a fixed schedule of correct and incorrect advice,
+// so every participant sees the same AI behaviour.
+type ScriptedAdvisor struct {
+	Schedule []bool
+}
+
+func (a ScriptedAdvisor) Reliability(state AdvisorState) float64 {
+	if a.Schedule[state.Step%len(a.Schedule)] {
+		return 1
+	}
+	return 0
+}
+
+// Confidence of a scripted advisor is the share of correct advice in the
+// schedule, reporting the scripted outcome itself would give it away.
+func (a ScriptedAdvisor) Confidence(state AdvisorState) float64 {
+	var correct int
+	for _, isCorrect := range a.Schedule {
+		if isCorrect {
+			correct++
+		}
+	}
+	return float64(correct) / float64(len(a.Schedule))
+}
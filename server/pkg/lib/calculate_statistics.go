@@ -1,6 +1,9 @@
 package lib
 
-import "math"
+import (
+	"math"
+	"sort"
+)
 
 func MeanAndStdev(values []float64) (mean float64, stdev float64) {
 	if len(values) == 0 {
@@ -22,3 +25,122 @@ func MeanAndStdev(values []float64) (mean float64, stdev float64) {
 
 	return
 }
+
+// Quantile returns the q-th quantile of values using linear interpolation
+// between the closest ranks.
+func Quantile(values []float64, q float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	pos := q * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(pos-float64(lower))
+}
+
+// NormalCDF returns P(X <= x) for X ~ N(mean, stdev^2).
+func NormalCDF(x, mean, stdev float64) float64 {
+	if stdev <= 0 {
+		if x < mean {
+			return 0
+		}
+		return 1
+	}
+	return 0.5 * math.Erfc(-(x-mean)/(stdev*math.Sqrt2))
+}
+
+// CorrelationPValue returns the two-sided p-value of the Pearson correlation
+// r of n pairs against no correlation, from the t distribution with n-2
+// degrees of freedom. It is NaN for fewer than three pairs.
+func CorrelationPValue(r float64, n int) float64 {
+	if n < 3 || math.IsNaN(r) {
+		return math.NaN()
+	}
+	if math.Abs(r) >= 1 {
+		return 0
+	}
+	// P(|T| >= t) = I_x(df/2, 1/2) with x = df/(df+t^2), which is 1-r^2.
+	df := float64(n - 2)
+	return regularizedIncompleteBeta(df/2, 0.5, 1-r*r)
+}
+
+// regularizedIncompleteBeta returns I_x(a, b) from its continued fraction,
+// which converges fast for x < (a+1)/(a+b+2) and is used through the
+// symmetry I_x(a, b) = 1 - I_(1-x)(b, a) otherwise.
+func regularizedIncompleteBeta(a, b, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+
+	lgammaA, _ := math.Lgamma(a)
+	lgammaB, _ := math.Lgamma(b)
+	lgammaAB, _ := math.Lgamma(a + b)
+	front := math.Exp(lgammaAB - lgammaA - lgammaB + a*math.Log(x) + b*math.Log(1-x))
+
+	if x < (a+1)/(a+b+2) {
+		return front * betaContinuedFraction(a, b, x) / a
+	}
+	return 1 - front*betaContinuedFraction(b, a, 1-x)/b
+}
+
+func betaContinuedFraction(a, b, x float64) float64 {
+	const (
+		maxIterations = 300
+		epsilon       = 1e-14
+		tiny          = 1e-300
+	)
+
+	c := 1.0
+	d := 1 - (a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	result := d
+
+	for m := 1; m <= maxIterations; m++ {
+		m2 := float64(2 * m)
+		fm := float64(m)
+
+		// Even step of the fraction.
+		numerator := fm * (b - fm) * x / ((a + m2 - 1) * (a + m2))
+		d = 1 + numerator*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + numerator/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		result *= d * c
+
+		// Odd step of the fraction.
+		numerator = -(a + fm) * (a + b + fm) * x / ((a + m2) * (a + m2 + 1))
+		d = 1 + numerator*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + numerator/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		result *= delta
+
+		if math.Abs(delta-1) < epsilon {
+			break
+		}
+	}
+	return result
+}
@@ -0,0 +1,238 @@
+package lib
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	xhtml "golang.org/x/net/html"
+)
+
+var (
+	mdHeadingRe     = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	mdBulletRe      = regexp.MustCompile(`^\s*[-*+]\s+(.*)$`)
+	mdOrderedRe     = regexp.MustCompile(`^\s*\d+[.)]\s+(.*)$`)
+	mdTableSepRe    = regexp.MustCompile(`^\s*\|?\s*:?-{3,}:?\s*(\|\s*:?-{3,}:?\s*)*\|?\s*$`)
+	mdRuleRe        = regexp.MustCompile(`^\s*([-*_])(\s*([-*_])){2,}\s*$`)
+	mdImageRe       = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)\)`)
+	mdLinkRe        = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	mdBoldRe        = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	mdItalicRe      = regexp.MustCompile(`\*([^*]+)\*`)
+	mdCodeRe        = regexp.MustCompile("`([^`]+)`")
+	mdAllowedScheme = map[string]bool{"": true, "http": true, "https": true, "mailto": true}
+)
+
+// Tags and attributes that survive SanitizeHTML. The classes are the ones
+// the rules modal of the client styles.
+var (
+	htmlAllowedTags = map[string]bool{
+		"p": true, "br": true, "hr": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+		"strong": true, "em": true, "b": true, "i": true, "u": true, "code": true, "pre": true, "span": true,
+		"blockquote": true, "ul": true, "ol": true, "li": true, "a": true, "img": true,
+		"table": true, "thead": true, "tbody": true, "tfoot": true, "tr": true, "th": true, "td": true, "caption": true,
+	}
+	htmlDroppedContentTags = map[string]bool{
+		"script": true, "style": true, "iframe": true, "object": true, "noscript": true,
+		"template": true, "textarea": true, "title": true, "svg": true, "math": true,
+	}
+	// htmlSelfClosingTags are the dropped tags that a trailing slash closes,
+	// the others still start their content as in <script/>.
+	htmlSelfClosingTags = map[string]bool{"svg": true, "math": true}
+	htmlAllowedClasses  = map[string]bool{
+		"score-positive": true, "score-negative": true, "rules-table-title": true, "rules-row-label": true,
+	}
+)
+
+// RenderMarkdown renders the markdown subset used by the game rules to
+// sanitized HTML: headings, paragraphs, lists, pipe tables, horizontal
+// rules, emphasis, inline code, links, images and raw HTML blocks.
+func RenderMarkdown(src string) string {
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+
+	var b strings.Builder
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			i++
+		case strings.HasPrefix(trimmed, "<"):
+			start := i
+			for i < len(lines) && strings.TrimSpace(lines[i]) != "" {
+				i++
+			}
+			b.WriteString(strings.Join(lines[start:i], "\n"))
+			b.WriteString("\n")
+		case mdHeadingRe.MatchString(trimmed):
+			m := mdHeadingRe.FindStringSubmatch(trimmed)
+			level := string(rune('0' + len(m[1])))
+			b.WriteString("<h" + level + ">" + renderInline(m[2]) + "</h" + level + ">\n")
+			i++
+		case mdRuleRe.MatchString(trimmed):
+			b.WriteString("<hr>\n")
+			i++
+		case mdBulletRe.MatchString(line) || mdOrderedRe.MatchString(line):
+			re, tag := mdBulletRe, "ul"
+			if !mdBulletRe.MatchString(line) {
+				re, tag = mdOrderedRe, "ol"
+			}
+			b.WriteString("<" + tag + ">\n")
+			for i < len(lines) && re.MatchString(lines[i]) {
+				b.WriteString("<li>" + renderInline(re.FindStringSubmatch(lines[i])[1]) + "</li>\n")
+				i++
+			}
+			b.WriteString("</" + tag + ">\n")
+		case strings.Contains(trimmed, "|") && i+1 < len(lines) && mdTableSepRe.MatchString(lines[i+1]):
+			b.WriteString("<table>\n<thead>\n")
+			writeTableRow(&b, "th", trimmed)
+			b.WriteString("</thead>\n<tbody>\n")
+			i += 2
+			for i < len(lines) && strings.Contains(lines[i], "|") && strings.TrimSpace(lines[i]) != "" {
+				writeTableRow(&b, "td", strings.TrimSpace(lines[i]))
+				i++
+			}
+			b.WriteString("</tbody>\n</table>\n")
+		default:
+			var paragraph []string
+			for i < len(lines) && strings.TrimSpace(lines[i]) != "" && !startsBlock(lines, i) {
+				paragraph = append(paragraph, renderInline(strings.TrimSpace(lines[i])))
+				i++
+			}
+			if len(paragraph) == 0 {
+				paragraph = append(paragraph, renderInline(trimmed))
+				i++
+			}
+			b.WriteString("<p>" + strings.Join(paragraph, "<br>\n") + "</p>\n")
+		}
+	}
+
+	return SanitizeHTML(b.String())
+}
+
+func startsBlock(lines []string, i int) bool {
+	trimmed := strings.TrimSpace(lines[i])
+	return strings.HasPrefix(trimmed, "<") ||
+		mdHeadingRe.MatchString(trimmed) ||
+		mdRuleRe.MatchString(trimmed) ||
+		mdBulletRe.MatchString(lines[i]) ||
+		mdOrderedRe.MatchString(lines[i]) ||
+		(strings.Contains(trimmed, "|") && i+1 < len(lines) && mdTableSepRe.MatchString(lines[i+1]))
+}
+
+func writeTableRow(b *strings.Builder, cellTag string, line string) {
+	line = strings.TrimSuffix(strings.TrimPrefix(line, "|"), "|")
+	b.WriteString("<tr>")
+	for _, cell := range strings.Split(line, "|") {
+		b.WriteString("<" + cellTag + ">" + renderInline(strings.TrimSpace(cell)) + "</" + cellTag + ">")
+	}
+	b.WriteString("</tr>\n")
+}
+
+// renderInline escapes the text and renders the inline markdown of a line.
+// Code spans are rendered first so that their content is left as is.
+func renderInline(text string) string {
+	var codes []string
+	text = mdCodeRe.ReplaceAllStringFunc(text, func(code string) string {
+		codes = append(codes, "<code>"+html.EscapeString(mdCodeRe.FindStringSubmatch(code)[1])+"</code>")
+		return "\x00" + strconv.Itoa(len(codes)-1) + "\x00"
+	})
+
+	text = html.EscapeString(text)
+	text = mdImageRe.ReplaceAllString(text, `<img src="$2" alt="$1">`)
+	text = mdLinkRe.ReplaceAllString(text, `<a href="$2">$1</a>`)
+	text = mdBoldRe.ReplaceAllString(text, "<strong>$1</strong>")
+	text = mdItalicRe.ReplaceAllString(text, "<em>$1</em>")
+
+	for i, code := range codes {
+		text = strings.Replace(text, "\x00"+strconv.Itoa(i)+"\x00", code, 1)
+	}
+	return text
+}
+
+// SanitizeHTML keeps the allowed tags and attributes of the HTML and drops
+// everything else. The text of dropped tags is kept, except for tags such as
+// script and style whose content is dropped as well.
+func SanitizeHTML(src string) string {
+	var b strings.Builder
+	tokenizer := xhtml.NewTokenizer(strings.NewReader(src))
+	dropDepth := 0
+
+	for {
+		tt := tokenizer.Next()
+		if tt == xhtml.ErrorToken {
+			return b.String()
+		}
+		token := tokenizer.Token()
+
+		switch tt {
+		case xhtml.StartTagToken, xhtml.SelfClosingTagToken:
+			if htmlDroppedContentTags[token.Data] {
+				if tt == xhtml.StartTagToken || !htmlSelfClosingTags[token.Data] {
+					dropDepth++
+				}
+				continue
+			}
+			if dropDepth > 0 || !htmlAllowedTags[token.Data] {
+				continue
+			}
+			b.WriteString("<" + token.Data)
+			for _, attr := range token.Attr {
+				if value, ok := sanitizeAttr(token.Data, attr); ok {
+					b.WriteString(" " + attr.Key + `="` + html.EscapeString(value) + `"`)
+				}
+			}
+			b.WriteString(">")
+		case xhtml.EndTagToken:
+			if htmlDroppedContentTags[token.Data] {
+				if dropDepth > 0 {
+					dropDepth--
+				}
+				continue
+			}
+			if dropDepth > 0 || !htmlAllowedTags[token.Data] {
+				continue
+			}
+			b.WriteString("</" + token.Data + ">")
+		case xhtml.TextToken:
+			if dropDepth == 0 {
+				b.WriteString(html.EscapeString(token.Data))
+			}
+		}
+	}
+}
+
+func sanitizeAttr(tag string, attr xhtml.Attribute) (string, bool) {
+	if attr.Namespace != "" {
+		return "", false
+	}
+	switch attr.Key {
+	case "class":
+		var classes []string
+		for _, class := range strings.Fields(attr.Val) {
+			if htmlAllowedClasses[class] {
+				classes = append(classes, class)
+			}
+		}
+		return strings.Join(classes, " "), len(classes) > 0
+	case "colspan", "rowspan":
+		return attr.Val, tag == "th" || tag == "td"
+	case "href":
+		return attr.Val, tag == "a" && isSafeURL(attr.Val)
+	case "src":
+		return attr.Val, tag == "img" && isSafeURL(attr.Val)
+	case "alt", "title":
+		return attr.Val, true
+	}
+	return "", false
+}
+
+func isSafeURL(raw string) bool {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return false
+	}
+	return mdAllowedScheme[strings.ToLower(u.Scheme)]
+}
@@ -0,0 +1,281 @@
+package lib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{
+			name:     "link",
+			source:   "[rules](https://example.com/rules)",
+			expected: "<p><a href=\"https://example.com/rules\">rules</a></p>\n",
+		},
+		{
+			name:     "javascript link",
+			source:   "[x](javascript:alert(1))",
+			expected: "<p><a>x</a>)</p>\n",
+		},
+		{
+			name:     "javascript link in capitals",
+			source:   "[x](JaVaScRiPt:alert(1))",
+			expected: "<p><a>x</a>)</p>\n",
+		},
+		{
+			name:     "javascript image",
+			source:   "![x](javascript:alert(1))",
+			expected: "<p><img alt=\"x\">)</p>\n",
+		},
+		{
+			name:     "data link",
+			source:   "[x](data:text/html,x)",
+			expected: "<p><a>x</a></p>\n",
+		},
+		{
+			// The entity is escaped, the browser gets a relative URL.
+			name:     "entity-encoded scheme of a link",
+			source:   "[x](&#106;avascript:alert(1))",
+			expected: "<p><a href=\"&amp;#106;avascript:alert(1\">x</a>)</p>\n",
+		},
+		{
+			name:     "quotes in the alt text",
+			source:   `![a" onerror="x](y.png)`,
+			expected: "<p><img src=\"y.png\" alt=\"a&#34; onerror=&#34;x\"></p>\n",
+		},
+		{
+			// Inline tags are not raw HTML blocks, they are escaped.
+			name:     "inline tag",
+			source:   "text <b onmouseover=alert(1)>bold</b>",
+			expected: "<p>text &lt;b onmouseover=alert(1)&gt;bold&lt;/b&gt;</p>\n",
+		},
+		{
+			name:     "raw javascript link",
+			source:   `<a href="javascript:alert(1)">x</a>`,
+			expected: "<a>x</a>\n",
+		},
+		{
+			name:     "raw script",
+			source:   "<p>a<script>alert(1)</script>b</p>",
+			expected: "<p>ab</p>\n",
+		},
+		{
+			name:     "raw unclosed script",
+			source:   "<p>a</p><script>alert(1)",
+			expected: "<p>a</p>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, RenderMarkdown(tt.source))
+		})
+	}
+}
+
+func TestSanitizeHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{
+			name:     "allowed tags and classes",
+			source:   `<table><tr><td class="score-positive" colspan="2">1</td></tr></table>`,
+			expected: `<table><tr><td class="score-positive" colspan="2">1</td></tr></table>`,
+		},
+		{
+			name:     "unknown tag keeps its text",
+			source:   "<div><font>text</font></div>",
+			expected: "text",
+		},
+		{
+			name:     "comment",
+			source:   "<p>a<!-- <script>alert(1)</script> -->b</p>",
+			expected: "<p>ab</p>",
+		},
+
+		{
+			name:     "javascript link",
+			source:   `<a href="javascript:alert(1)">x</a>`,
+			expected: "<a>x</a>",
+		},
+		{
+			name:     "javascript link with spaces and capitals",
+			source:   `<a href=" JAVASCRIPT:alert(1)">x</a>`,
+			expected: "<a>x</a>",
+		},
+		{
+			name:     "javascript image",
+			source:   `<img src="javascript:alert(1)" alt="x">`,
+			expected: `<img alt="x">`,
+		},
+		{
+			name:     "data link",
+			source:   `<a href="data:text/html,x">x</a>`,
+			expected: "<a>x</a>",
+		},
+		{
+			name:     "vbscript link",
+			source:   `<a href="vbscript:msgbox(1)">x</a>`,
+			expected: "<a>x</a>",
+		},
+		{
+			name:     "href of another tag",
+			source:   `<p href="https://example.com">x</p>`,
+			expected: "<p>x</p>",
+		},
+
+		{
+			name:     "decimal entity in the scheme",
+			source:   `<a href="&#106;avascript:alert(1)">x</a>`,
+			expected: "<a>x</a>",
+		},
+		{
+			name:     "hex entity in the scheme",
+			source:   `<a href="&#x6A;avascript:alert(1)">x</a>`,
+			expected: "<a>x</a>",
+		},
+		{
+			name:     "tab entity in the scheme",
+			source:   `<a href="java&#x09;script:alert(1)">x</a>`,
+			expected: "<a>x</a>",
+		},
+		{
+			name:     "colon entity",
+			source:   `<a href="javascript&colon;alert(1)">x</a>`,
+			expected: "<a>x</a>",
+		},
+		{
+			name:     "entities in a safe link",
+			source:   `<a href="https://example.com/?a=1&amp;b=2">x</a>`,
+			expected: `<a href="https://example.com/?a=1&amp;b=2">x</a>`,
+		},
+
+		{
+			name:     "script",
+			source:   "<p>a<script>alert(1)</script>b</p>",
+			expected: "<p>ab</p>",
+		},
+		{
+			name:     "script in capitals",
+			source:   "<p>a<SCRIPT>alert(1)</SCRIPT>b</p>",
+			expected: "<p>ab</p>",
+		},
+		{
+			name:     "tags in a script",
+			source:   `<script>document.write("<p>x</p>")</script><p>y</p>`,
+			expected: "<p>y</p>",
+		},
+		{
+			name:     "style",
+			source:   "<style>p { color: red }</style><p>x</p>",
+			expected: "<p>x</p>",
+		},
+		{
+			name:     "svg",
+			source:   "<svg><script>alert(1)</script><text>t</text></svg><p>after</p>",
+			expected: "<p>after</p>",
+		},
+		{
+			name:     "nested dropped tags",
+			source:   "<svg><style><p>x</p></style></svg><p>after</p>",
+			expected: "<p>after</p>",
+		},
+		{
+			// The slash does not close a script, it still has content.
+			name:     "self-closing script",
+			source:   "<script/>alert(1)</script><p>x</p>",
+			expected: "<p>x</p>",
+		},
+		{
+			name:     "self-closing svg",
+			source:   "<svg/><p>x</p>",
+			expected: "<p>x</p>",
+		},
+		{
+			name:     "embed",
+			source:   "<embed src=\"x.swf\"><p>x</p>",
+			expected: "<p>x</p>",
+		},
+		{
+			name:     "stray end tag",
+			source:   "</script><p>x</p>",
+			expected: "<p>x</p>",
+		},
+
+		{
+			name:     "event handler",
+			source:   `<img src="x.png" onerror="alert(1)">`,
+			expected: `<img src="x.png">`,
+		},
+		{
+			name:     "event handler in capitals",
+			source:   `<p OnClick="alert(1)">x</p>`,
+			expected: "<p>x</p>",
+		},
+		{
+			name:     "event handler without quotes",
+			source:   "<b onmouseover=alert(1)>bold</b>",
+			expected: "<b>bold</b>",
+		},
+		{
+			name:     "event handler of a dropped tag",
+			source:   "<svg/onload=alert(1)>",
+			expected: "",
+		},
+		{
+			name:     "style attribute and unknown classes",
+			source:   `<p style="background:url(javascript:alert(1))" class="score-positive evil">x</p>`,
+			expected: `<p class="score-positive">x</p>`,
+		},
+		{
+			name:     "quotes in an attribute",
+			source:   `<img alt='a" onerror="alert(1)'>`,
+			expected: `<img alt="a&#34; onerror=&#34;alert(1)">`,
+		},
+
+		// An unclosed script, style or svg drops the rest of the document.
+		{
+			name:     "unclosed script",
+			source:   "<p>a</p><script>alert(1)",
+			expected: "<p>a</p>",
+		},
+		{
+			name:     "unclosed style",
+			source:   "<p>a</p><style>p { color: red }",
+			expected: "<p>a</p>",
+		},
+		{
+			name:     "unclosed svg",
+			source:   "<p>a</p><svg><p>b</p>",
+			expected: "<p>a</p>",
+		},
+		{
+			name:     "unclosed self-closing script",
+			source:   "<p>a</p><script/>alert(1)",
+			expected: "<p>a</p>",
+		},
+		{
+			name:     "unclosed title",
+			source:   "<p>a</p><title><script>alert(1)</script>",
+			expected: "<p>a</p>",
+		},
+		{
+			name:     "unclosed comment",
+			source:   "<p>a</p><!-- <script>alert(1)</script>",
+			expected: "<p>a</p>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SanitizeHTML(tt.source))
+		})
+	}
+}
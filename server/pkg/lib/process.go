@@ -0,0 +1,33 @@
+package lib
+
+import "math/rand/v2"
+
+// ProcessParams describe the first order process y' = A*y + B*U + noise.
+type ProcessParams struct {
+	A             float64
+	B             float64
+	Control       float64
+	NoiseMean     float64
+	NoiseStdev    float64
+	CriticalValue float64
+}
+
+// SimulateProcess generates the process from y = 0 until it reaches the
+// critical value or length values are generated.
+func SimulateProcess(params ProcessParams, length int, rng *rand.Rand) []float64 {
+	trajectory := make([]float64, 0, length)
+	y := 0.0
+	for len(trajectory) < length {
+		trajectory = append(trajectory, y)
+		if y >= params.CriticalValue {
+			break
+		}
+		y = NextProcessValue(params, y, rng)
+	}
+	return trajectory
+}
+
+func NextProcessValue(params ProcessParams, y float64, rng *rand.Rand) float64 {
+	noise := params.NoiseMean + params.NoiseStdev*rng.NormFloat64()
+	return params.A*y + params.B*params.Control + noise
+}
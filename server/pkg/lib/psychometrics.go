@@ -0,0 +1,287 @@
+package lib
+
+import "math"
+
+// The reliability estimates take the responses as rows of respondents with a
+// value for every item of the scale, the respondents who skipped an item are
+// left out before. An estimate that is not defined for the responses, such as
+// alpha of a scale with no variance, is NaN.
+
+const (
+	omegaMaxIterations = 500
+	omegaTolerance     = 1e-8
+	// omegaMaxCommunality keeps the communalities of the factoring below 1,
+	// a Heywood case would leave an item without unique variance.
+	omegaMaxCommunality = 0.995
+)
+
+// SampleVariance returns the unbiased variance of values, NaN for fewer than
+// two values.
+func SampleVariance(values []float64) float64 {
+	if len(values) < 2 {
+		return math.NaN()
+	}
+
+	var sum float64
+	for _, val := range values {
+		sum += val
+	}
+	mean := sum / float64(len(values))
+
+	var sumDiffs float64
+	for _, val := range values {
+		sumDiffs += (val - mean) * (val - mean)
+	}
+	return sumDiffs / float64(len(values)-1)
+}
+
+// Correlation returns the Pearson correlation of x and y, NaN when either of
+// them is constant or they are shorter than two values.
+func Correlation(x, y []float64) float64 {
+	n := len(x)
+	if n != len(y) || n < 2 {
+		return math.NaN()
+	}
+
+	var meanX, meanY float64
+	for i := range x {
+		meanX += x[i]
+		meanY += y[i]
+	}
+	meanX /= float64(n)
+	meanY /= float64(n)
+
+	var cov, varX, varY float64
+	for i := range x {
+		dx, dy := x[i]-meanX, y[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return math.NaN()
+	}
+	// Rounding can take a perfect correlation just past ±1.
+	return math.Max(-1, math.Min(1, cov/math.Sqrt(varX*varY)))
+}
+
+func column(responses [][]float64, item int) []float64 {
+	values := make([]float64, len(responses))
+	for i, row := range responses {
+		values[i] = row[item]
+	}
+	return values
+}
+
+// totals sums the items of every respondent, leaving out the item skip (-1
+// keeps them all).
+func totals(responses [][]float64, skip int) []float64 {
+	values := make([]float64, len(responses))
+	for i, row := range responses {
+		for j, val := range row {
+			if j != skip {
+				values[i] += val
+			}
+		}
+	}
+	return values
+}
+
+func itemCount(responses [][]float64) int {
+	if len(responses) == 0 {
+		return 0
+	}
+	return len(responses[0])
+}
+
+// CronbachAlpha returns coefficient alpha of the items,
+// k/(k-1) * (1 - sum of the item variances / variance of the total).
+func CronbachAlpha(responses [][]float64) float64 {
+	return alphaWithout(responses, -1)
+}
+
+func alphaWithout(responses [][]float64, skip int) float64 {
+	k := itemCount(responses)
+	if skip >= 0 {
+		k--
+	}
+	if k < 2 || len(responses) < 2 {
+		return math.NaN()
+	}
+
+	var itemVariances float64
+	for j := 0; j < itemCount(responses); j++ {
+		if j != skip {
+			itemVariances += SampleVariance(column(responses, j))
+		}
+	}
+	totalVariance := SampleVariance(totals(responses, skip))
+	if totalVariance == 0 {
+		return math.NaN()
+	}
+	return float64(k) / float64(k-1) * (1 - itemVariances/totalVariance)
+}
+
+// AlphaIfDeleted returns for every item the alpha of the other items.
+func AlphaIfDeleted(responses [][]float64) []float64 {
+	values := make([]float64, itemCount(responses))
+	for j := range values {
+		values[j] = alphaWithout(responses, j)
+	}
+	return values
+}
+
+// CorrectedItemTotal returns for every item its correlation with the total
+// of the other items, so that the item does not correlate with itself.
+func CorrectedItemTotal(responses [][]float64) []float64 {
+	values := make([]float64, itemCount(responses))
+	for j := range values {
+		if len(values) < 2 {
+			values[j] = math.NaN()
+			continue
+		}
+		values[j] = Correlation(column(responses, j), totals(responses, j))
+	}
+	return values
+}
+
+// McDonaldOmega returns omega total of the items from the standardized
+// loadings of a single factor, (sum of loadings)^2 / ((sum of loadings)^2 +
+// sum of unique variances). The factor is extracted by iterated principal
+// axis factoring of the correlation matrix, which needs at least three items.
+func McDonaldOmega(responses [][]float64) float64 {
+	loadings := factorLoadings(responses)
+	if loadings == nil {
+		return math.NaN()
+	}
+
+	var sum, unique float64
+	for _, loading := range loadings {
+		sum += loading
+		unique += 1 - loading*loading
+	}
+	return sum * sum / (sum*sum + unique)
+}
+
+// factorLoadings returns the loadings of the items on a single factor, nil
+// when they cannot be estimated.
+func factorLoadings(responses [][]float64) []float64 {
+	k := itemCount(responses)
+	if k < 3 || len(responses) < 3 {
+		return nil
+	}
+
+	columns := make([][]float64, k)
+	for j := range columns {
+		columns[j] = column(responses, j)
+	}
+	corr := make([][]float64, k)
+	for i := range corr {
+		corr[i] = make([]float64, k)
+		for j := range corr[i] {
+			if i == j {
+				continue
+			}
+			r := Correlation(columns[i], columns[j])
+			if math.IsNaN(r) {
+				return nil
+			}
+			corr[i][j] = r
+		}
+	}
+
+	// The communalities start at the largest correlation of each item.
+	communalities := make([]float64, k)
+	for i := range corr {
+		for j := range corr[i] {
+			if i != j {
+				communalities[i] = math.Max(communalities[i], math.Abs(corr[i][j]))
+			}
+		}
+	}
+
+	loadings := make([]float64, k)
+	for iteration := 0; iteration < omegaMaxIterations; iteration++ {
+		for i := range corr {
+			corr[i][i] = communalities[i]
+		}
+		value, vector := dominantEigen(corr)
+		if value <= 0 {
+			return nil
+		}
+
+		change := 0.0
+		for i := range loadings {
+			loadings[i] = vector[i] * math.Sqrt(value)
+			communality := math.Min(loadings[i]*loadings[i], omegaMaxCommunality)
+			change = math.Max(change, math.Abs(communality-communalities[i]))
+			communalities[i] = communality
+		}
+		if change < omegaTolerance {
+			break
+		}
+	}
+
+	// The sign of a factor is arbitrary, it is turned so that the items load
+	// positively on the whole.
+	var sum float64
+	for i := range loadings {
+		loadings[i] = math.Copysign(math.Min(math.Abs(loadings[i]), math.Sqrt(omegaMaxCommunality)), loadings[i])
+		sum += loadings[i]
+	}
+	if sum < 0 {
+		for i := range loadings {
+			loadings[i] = -loadings[i]
+		}
+	}
+	return loadings
+}
+
+// dominantEigen returns the largest eigenvalue of the symmetric matrix and
+// its unit eigenvector by power iteration. The matrix is shifted by the bound
+// of its spectrum so that the largest eigenvalue, not the one of the largest
+// magnitude, is found.
+func dominantEigen(matrix [][]float64) (float64, []float64) {
+	k := len(matrix)
+	var shift float64
+	for i := range matrix {
+		var rowSum float64
+		for j := range matrix[i] {
+			rowSum += math.Abs(matrix[i][j])
+		}
+		shift = math.Max(shift, rowSum)
+	}
+
+	vector := make([]float64, k)
+	for i := range vector {
+		vector[i] = 1 / math.Sqrt(float64(k))
+	}
+	next := make([]float64, k)
+	var value float64
+	for iteration := 0; iteration < omegaMaxIterations; iteration++ {
+		var norm float64
+		for i := range matrix {
+			next[i] = shift * vector[i]
+			for j := range matrix[i] {
+				next[i] += matrix[i][j] * vector[j]
+			}
+			norm += next[i] * next[i]
+		}
+		norm = math.Sqrt(norm)
+		if norm == 0 {
+			return 0, vector
+		}
+
+		change := 0.0
+		for i := range vector {
+			next[i] /= norm
+			change = math.Max(change, math.Abs(next[i]-vector[i]))
+		}
+		vector, next = next, vector
+		value = norm - shift
+		if change < omegaTolerance {
+			break
+		}
+	}
+	return value, vector
+}
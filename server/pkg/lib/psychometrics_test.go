@@ -0,0 +1,229 @@
+package lib
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// congeneric has three items with equal variances that all correlate at 0.5,
+// so the loadings on a single factor are all √0.5 and alpha equals omega,
+// 3·0.5 / (1 + 2·0.5) = 0.75.
+var congeneric = [][]float64{
+	{1, 1, 2},
+	{1, 2, 1},
+	{2, 1, 1},
+	{3, 3, 2},
+	{3, 2, 3},
+	{2, 3, 3},
+}
+
+// consistent has the item variances 5/3, 5/3 and 4/3 and the total variance
+// 40/3, so alpha is 3/2 · (1 - (14/3) / (40/3)) = 0.975.
+var consistent = [][]float64{
+	{1, 2, 3},
+	{2, 3, 3},
+	{3, 4, 5},
+	{4, 5, 5},
+}
+
+func assertEstimate(t *testing.T, expected, actual float64) {
+	t.Helper()
+	if math.IsNaN(expected) {
+		assert.True(t, math.IsNaN(actual), "expected NaN, got %v", actual)
+		return
+	}
+	assert.InDelta(t, expected, actual, 1e-6)
+}
+
+func TestCronbachAlpha(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses [][]float64
+		expected  float64
+	}{
+		{
+			name:      "equal correlations",
+			responses: congeneric,
+			expected:  0.75,
+		},
+		{
+			name:      "unequal variances",
+			responses: consistent,
+			expected:  0.975,
+		},
+		{
+			// The item variances are 1/2 each and the total variance 8.
+			name:      "fewer respondents than items",
+			responses: [][]float64{{1, 2, 3, 4}, {2, 3, 4, 5}},
+			expected:  4.0 / 3 * (1 - 2.0/8),
+		},
+		{
+			name:      "zero variance",
+			responses: [][]float64{{3, 3, 3}, {3, 3, 3}, {3, 3, 3}},
+			expected:  math.NaN(),
+		},
+		{
+			name:      "single item",
+			responses: [][]float64{{1}, {2}, {3}},
+			expected:  math.NaN(),
+		},
+		{
+			name:      "single respondent",
+			responses: [][]float64{{1, 2, 3}},
+			expected:  math.NaN(),
+		},
+		{
+			name:      "no responses",
+			responses: nil,
+			expected:  math.NaN(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertEstimate(t, tt.expected, CronbachAlpha(tt.responses))
+		})
+	}
+}
+
+func TestMcDonaldOmega(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses [][]float64
+		expected  float64
+	}{
+		{
+			name:      "equal correlations",
+			responses: congeneric,
+			expected:  0.75,
+		},
+		{
+			// The third item is reversed and loads -√0.5, the loadings sum
+			// up to √0.5: 0.5 / (0.5 + 3·0.5).
+			name: "negative loading",
+			responses: [][]float64{
+				{1, 1, 2},
+				{1, 2, 3},
+				{2, 1, 3},
+				{3, 3, 2},
+				{3, 2, 1},
+				{2, 3, 1},
+			},
+			expected: 0.25,
+		},
+		{
+			name:      "fewer respondents than items",
+			responses: [][]float64{{1, 2, 3, 4}, {2, 3, 4, 5}},
+			expected:  math.NaN(),
+		},
+		{
+			name:      "zero variance",
+			responses: [][]float64{{3, 3, 3}, {3, 3, 3}, {3, 3, 3}},
+			expected:  math.NaN(),
+		},
+		{
+			name:      "constant item",
+			responses: [][]float64{{1, 2, 3}, {2, 3, 3}, {3, 4, 3}},
+			expected:  math.NaN(),
+		},
+		{
+			name:      "two items",
+			responses: [][]float64{{1, 2}, {2, 3}, {3, 5}},
+			expected:  math.NaN(),
+		},
+		{
+			name:      "single item",
+			responses: [][]float64{{1}, {2}, {3}},
+			expected:  math.NaN(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertEstimate(t, tt.expected, McDonaldOmega(tt.responses))
+		})
+	}
+}
+
+func TestAlphaIfDeleted(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses [][]float64
+		expected  []float64
+	}{
+		{
+			// Any two of the items correlate at 0.5: 2·0.5 / (1 + 0.5).
+			name:      "equal correlations",
+			responses: congeneric,
+			expected:  []float64{2.0 / 3, 2.0 / 3, 2.0 / 3},
+		},
+		{
+			// Without the first or second item the total variance is 17/3
+			// and the item variances sum up to 3, the first two items are
+			// parallel.
+			name:      "unequal variances",
+			responses: consistent,
+			expected:  []float64{16.0 / 17, 16.0 / 17, 1},
+		},
+		{
+			name:      "two items",
+			responses: [][]float64{{1, 2}, {2, 3}, {3, 5}},
+			expected:  []float64{math.NaN(), math.NaN()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := AlphaIfDeleted(tt.responses)
+			if assert.Len(t, actual, len(tt.expected)) {
+				for i := range tt.expected {
+					assertEstimate(t, tt.expected[i], actual[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCorrectedItemTotal(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses [][]float64
+		expected  []float64
+	}{
+		{
+			// Every item has the covariance 4/5 with the total of the others,
+			// whose variance is 12/5: (4/5) / √((4/5)(12/5)) = 1/√3.
+			name:      "equal correlations",
+			responses: congeneric,
+			expected:  []float64{1 / math.Sqrt(3), 1 / math.Sqrt(3), 1 / math.Sqrt(3)},
+		},
+		{
+			name:      "unequal variances",
+			responses: consistent,
+			expected:  []float64{9 / math.Sqrt(85), 9 / math.Sqrt(85), 8 / math.Sqrt(80)},
+		},
+		{
+			name:      "single item",
+			responses: [][]float64{{1}, {2}, {3}},
+			expected:  []float64{math.NaN()},
+		},
+		{
+			name:      "constant item",
+			responses: [][]float64{{1, 3}, {2, 3}, {3, 3}},
+			expected:  []float64{math.NaN(), math.NaN()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := CorrectedItemTotal(tt.responses)
+			if assert.Len(t, actual, len(tt.expected)) {
+				for i := range tt.expected {
+					assertEstimate(t, tt.expected[i], actual[i])
+				}
+			}
+		})
+	}
+}
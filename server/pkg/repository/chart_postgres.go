@@ -18,10 +18,28 @@ func NewChartPostgres(db *sqlx.DB) *ChartPostgres {
 
 func (p *ChartPostgres) CreateChart(chart gameServer.CreateChartInput) (int, error) {
 	var id int
-	query := fmt.Sprintf("INSERT INTO %s (parameter_set_id, user_id, is_training, created_at) VALUES ($1, $2, $3, $4) RETURNING id", chartsTable)
+	query := fmt.Sprintf("INSERT INTO %s (parameter_set_id, user_id, is_training, created_at, end_reason, duration, scenario_id) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id", chartsTable)
+
+	endReason := gameServer.ChartEndReasonUnknown
+	if chart.EndReason != nil {
+		endReason = *chart.EndReason
+	}
+
+	timeNow := time.Now().UTC().Add(3 * time.Hour)
+	row := p.db.QueryRow(query, chart.ParameterSetId, chart.UserId, chart.IsTraining, timeNow, endReason, chart.Duration, chart.ScenarioId)
+	if err := row.Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (p *ChartPostgres) OpenChart(chart gameServer.CreateChartInput) (int, error) {
+	var id int
+	query := fmt.Sprintf("INSERT INTO %s (parameter_set_id, user_id, is_training, created_at, status, last_activity_at, scenario_id) VALUES ($1, $2, $3, $4, $5, $4, $6) RETURNING id", chartsTable)
 
 	timeNow := time.Now().UTC().Add(3 * time.Hour)
-	row := p.db.QueryRow(query, chart.ParameterSetId, chart.UserId, chart.IsTraining, timeNow)
+	row := p.db.QueryRow(query, chart.ParameterSetId, chart.UserId, chart.IsTraining, timeNow, gameServer.ChartStatusInProgress, chart.ScenarioId)
 	if err := row.Scan(&id); err != nil {
 		return 0, err
 	}
@@ -29,9 +47,70 @@ func (p *ChartPostgres) CreateChart(chart gameServer.CreateChartInput) (int, err
 	return id, nil
 }
 
+func (p *ChartPostgres) AppendPoints(chartId int, points []gameServer.Point) ([]int, error) {
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+
+	timeNow := time.Now().UTC().Add(3 * time.Hour)
+	query := fmt.Sprintf("UPDATE %s SET last_activity_at=$1, point_count=point_count+$2 WHERE id=$3 AND status=$4", chartsTable)
+	res, err := tx.Exec(query, timeNow, len(points), chartId, gameServer.ChartStatusInProgress)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if affected, err := res.RowsAffected(); err != nil || affected == 0 {
+		tx.Rollback()
+		return nil, gameServer.ErrChartNotInProgress
+	}
+
+	ids := make([]int, 0, len(points))
+	for _, point := range points {
+		point.ChartId = chartId
+		id, err := insertPoint(tx, point, timeNow)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, tx.Commit()
+}
+
+func (p *ChartPostgres) CloseChart(chartId int, endReason string) error {
+	query := fmt.Sprintf(`UPDATE %s SET status=$1, end_reason=$2, ended_at=$3, last_activity_at=$3,
+						 duration=EXTRACT(EPOCH FROM $3 - created_at) WHERE id=$4 AND status=$5`, chartsTable)
+
+	timeNow := time.Now().UTC().Add(3 * time.Hour)
+	res, err := p.db.Exec(query, gameServer.ChartStatusFinished, endReason, timeNow, chartId, gameServer.ChartStatusInProgress)
+	if err != nil {
+		return err
+	}
+	if affected, err := res.RowsAffected(); err != nil || affected == 0 {
+		return gameServer.ErrChartNotInProgress
+	}
+
+	return nil
+}
+
+func (p *ChartPostgres) AbandonStaleCharts(inactiveSince time.Time) (int, error) {
+	query := fmt.Sprintf(`UPDATE %s SET status=$1, end_reason=$2, ended_at=last_activity_at,
+						 duration=EXTRACT(EPOCH FROM last_activity_at - created_at) WHERE status=$3 AND last_activity_at < $4`, chartsTable)
+
+	res, err := p.db.Exec(query, gameServer.ChartStatusAbandoned, gameServer.ChartEndReasonTimeout, gameServer.ChartStatusInProgress, inactiveSince)
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := res.RowsAffected()
+	return int(affected), err
+}
+
 func (p *ChartPostgres) GetOneChart(id int) (gameServer.Chart, error) {
 	var chart gameServer.Chart
-	query := fmt.Sprintf("SELECT id, parameter_set_id, user_id, is_training, created_at FROM %s WHERE id=$1", chartsTable)
+	query := fmt.Sprintf("SELECT id, parameter_set_id, user_id, is_training, created_at, status, end_reason, point_count, duration, scenario_id FROM %s WHERE id=$1", chartsTable)
 
 	err := p.db.Get(&chart, query, id)
 	return chart, err
@@ -75,19 +154,19 @@ func (p *ChartPostgres) GetAllCharts(input gameServer.GetAllChartsInput) ([]game
 	switch input.FilterTag {
 	case "chart_id":
 		{
-			query = fmt.Sprintf("SELECT id, created_at, parameter_set_id, user_id, is_training FROM %s WHERE id=%s OFFSET %v LIMIT 9", chartsTable, input.FilterValue, (input.CurrentPage-1)*9)
+			query = fmt.Sprintf("SELECT id, created_at, parameter_set_id, user_id, is_training, status, end_reason, point_count, duration, scenario_id FROM %s WHERE id=%s OFFSET %v LIMIT 9", chartsTable, input.FilterValue, (input.CurrentPage-1)*9)
 		}
 	case "user_login":
 		{
-			query = fmt.Sprintf("SELECT id, created_at, parameter_set_id, user_id, is_training FROM %s AS ut JOIN %s AS ct ON ut.user_id=ct.user_id WHERE ut.login LIKE '%%%s%%' OFFSET %v LIMIT 9", usersTable, chartsTable, input.FilterValue, (input.CurrentPage-1)*9)
+			query = fmt.Sprintf("SELECT id, created_at, parameter_set_id, user_id, is_training, status, end_reason, point_count, duration, scenario_id FROM %s AS ut JOIN %s AS ct ON ut.user_id=ct.user_id WHERE ut.login LIKE '%%%s%%' OFFSET %v LIMIT 9", usersTable, chartsTable, input.FilterValue, (input.CurrentPage-1)*9)
 		}
 	case "user_id":
 		{
-			query = fmt.Sprintf("SELECT id, created_at, parameter_set_id, user_id, is_training FROM %s WHERE user_id=%s OFFSET %v LIMIT 9", chartsTable, input.FilterValue, (input.CurrentPage-1)*9)
+			query = fmt.Sprintf("SELECT id, created_at, parameter_set_id, user_id, is_training, status, end_reason, point_count, duration, scenario_id FROM %s WHERE user_id=%s OFFSET %v LIMIT 9", chartsTable, input.FilterValue, (input.CurrentPage-1)*9)
 		}
 	default:
 		{
-			query = fmt.Sprintf("SELECT id, created_at, parameter_set_id, user_id, is_training FROM %s OFFSET %v LIMIT 9", chartsTable, (input.CurrentPage-1)*9)
+			query = fmt.Sprintf("SELECT id, created_at, parameter_set_id, user_id, is_training, status, end_reason, point_count, duration, scenario_id FROM %s OFFSET %v LIMIT 9", chartsTable, (input.CurrentPage-1)*9)
 		}
 	}
 
@@ -103,24 +182,50 @@ func (p *ChartPostgres) DeleteChart(id int) error {
 	return err
 }
 
-func (p *ChartPostgres) GetAllParSets(input gameServer.GetAllParSetsInput) ([]gameServer.ParameterSet, error) {
-	var parSets []gameServer.ParameterSet
-	query := ""
-	switch input.CurrentPage {
-	case -1:
-		query = fmt.Sprintf("SELECT %s FROM %s", parSetColumns, parameterSetsTable)
-	default:
-		query = fmt.Sprintf("SELECT %s FROM %s OFFSET %v LIMIT 9", parSetColumns, parameterSetsTable, (input.CurrentPage-1)*9)
+func (p *ChartPostgres) GetAllParSets(input gameServer.GetAllParSetsInput) ([]gameServer.ParameterSetWithUsage, error) {
+	var parSets []gameServer.ParameterSetWithUsage
+	pagination := ""
+	if input.CurrentPage != -1 {
+		pagination = fmt.Sprintf("OFFSET %v LIMIT 9", (input.CurrentPage-1)*9)
 	}
 
+	query := fmt.Sprintf(`
+				WITH RECURSIVE lineage AS (
+					SELECT id, parent_id, id AS ancestor_id, 0 AS depth
+					FROM %[2]s
+					UNION ALL
+					SELECT ln.id, pst.parent_id, pst.id, ln.depth + 1
+					FROM lineage AS ln
+					JOIN %[2]s AS pst ON pst.id = ln.parent_id
+				)
+				SELECT %[1]s,
+					(SELECT MAX(depth) + 1 FROM lineage WHERE lineage.id = pst.id) AS version,
+					COALESCE((SELECT json_agg(ancestor_id ORDER BY depth DESC) FROM lineage WHERE lineage.id = pst.id AND depth > 0), '[]') AS lineage,
+					(SELECT COUNT(*) FROM %[3]s WHERE parameter_set_id = pst.id) AS chart_count,
+					(SELECT COUNT(*) FROM %[4]s WHERE parameter_set_id = pst.id) AS group_count,
+					(SELECT COUNT(*) FROM %[5]s WHERE parameter_set_id = pst.id) AS user_count
+				FROM %[2]s AS pst
+				WHERE pst.archived_at IS NULL
+				ORDER BY pst.id
+				%[6]s
+			`, parSetAliasedColumns, parameterSetsTable, chartsTable, groupsTable, userParameterSetsTable, pagination)
+
 	err := p.db.Select(&parSets, query)
 
 	return parSets, err
 }
 
+func (p *ChartPostgres) GetOneParSet(id int) (gameServer.ParameterSet, error) {
+	var parSet gameServer.ParameterSet
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE id=$1", parSetColumns, parameterSetsTable)
+
+	err := p.db.Get(&parSet, query, id)
+	return parSet, err
+}
+
 func (p *ChartPostgres) GetParSetsCount() (int, error) {
 	var parSetsCount int
-	query := fmt.Sprintf("SELECT COUNT(*) FROM %s", parameterSetsTable)
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE archived_at IS NULL", parameterSetsTable)
 
 	row := p.db.QueryRow(query)
 	if err := row.Scan(&parSetsCount); err != nil {
@@ -133,13 +238,16 @@ func (p *ChartPostgres) GetParSetsCount() (int, error) {
 func (p *ChartPostgres) CreateParSet(input gameServer.CreateParSetInput) (int, error) {
 	var id int
 	query := fmt.Sprintf(
-		"INSERT INTO %s (a, b, noise_mean, noise_stdev, false_warning_prob, missing_danger_prob, scoring_config, hint_cost, false_alarm_threshold, rules_text, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id",
+		"INSERT INTO %s (parent_id, name, description, a, b, noise_mean, noise_stdev, false_warning_prob, missing_danger_prob, scoring_config, hint_cost, hint_config, advisor_config, false_alarm_threshold, rules, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16) RETURNING id",
 		parameterSetsTable,
 	)
 
 	timeNow := time.Now().UTC().Add(3 * time.Hour)
 	row := p.db.QueryRow(
 		query,
+		input.ParentId,
+		input.Name,
+		input.Description,
 		input.A,
 		input.B,
 		input.NoiseMean,
@@ -148,8 +256,10 @@ func (p *ChartPostgres) CreateParSet(input gameServer.CreateParSetInput) (int, e
 		input.MissingDangerProb,
 		input.ScoringConfig,
 		input.HintCost,
+		input.HintConfig,
+		input.AdvisorConfig,
 		input.FalseAlarmThreshold,
-		input.RulesText,
+		input.Rules,
 		timeNow,
 	)
 	if err := row.Scan(&id); err != nil {
@@ -158,3 +268,220 @@ func (p *ChartPostgres) CreateParSet(input gameServer.CreateParSetInput) (int, e
 
 	return id, nil
 }
+
+// UpdateParSet changes the parameter set in place. Once games have been
+// played with the set only its name, description and rules can change, and
+// only these columns are written, so the stored parameters are left exactly
+// as they were played. The set stays locked from the check until the update,
+// so no game can start in between.
+func (p *ChartPostgres) UpdateParSet(id int, input gameServer.CreateParSetInput) error {
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	parSet, err := lockParSet(tx, id)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	references, err := parSetReferences(tx, id)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if references.ChartCount > 0 {
+		if input.ChangesGame(parSet) {
+			tx.Rollback()
+			return &gameServer.ParSetConflictError{Action: "updated", References: references}
+		}
+
+		query := fmt.Sprintf("UPDATE %s SET name=$1, description=$2, rules=$3 WHERE id=$4", parameterSetsTable)
+		if _, err := tx.Exec(query, input.Name, input.Description, input.Rules, id); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		return tx.Commit()
+	}
+
+	query := fmt.Sprintf(`UPDATE %s SET name=$1, description=$2, a=$3, b=$4, noise_mean=$5, noise_stdev=$6, false_warning_prob=$7,
+						 missing_danger_prob=$8, scoring_config=$9, hint_cost=$10, hint_config=$11, advisor_config=$12,
+						 false_alarm_threshold=$13, rules=$14 WHERE id=$15`, parameterSetsTable)
+
+	_, err = tx.Exec(
+		query,
+		input.Name,
+		input.Description,
+		input.A,
+		input.B,
+		input.NoiseMean,
+		input.NoiseStdev,
+		input.FalseWarningProb,
+		input.MissingDangerProb,
+		input.ScoringConfig,
+		input.HintCost,
+		input.HintConfig,
+		input.AdvisorConfig,
+		input.FalseAlarmThreshold,
+		input.Rules,
+		id,
+	)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ArchiveParSet archives a parameter set that nothing refers to.
+func (p *ChartPostgres) ArchiveParSet(id int) error {
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	if err := lockUnusedParSet(tx, id, "archived"); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET archived_at=$1 WHERE id=$2 AND archived_at IS NULL", parameterSetsTable)
+
+	timeNow := time.Now().UTC().Add(3 * time.Hour)
+	if _, err := tx.Exec(query, timeNow, id); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteParSet deletes a parameter set that nothing refers to.
+func (p *ChartPostgres) DeleteParSet(id int) error {
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	if err := lockUnusedParSet(tx, id, "deleted"); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE id=$1", parameterSetsTable)
+	if _, err := tx.Exec(query, id); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (p *ChartPostgres) GetParSetReferences(id int) (gameServer.ParSetReferences, error) {
+	return parSetReferences(p.db, id)
+}
+
+// lockParSet locks the parameter set for the rest of the transaction. The
+// charts, groups and users that refer to the set lock it too, so they cannot
+// be added while it is locked.
+func lockParSet(tx *sqlx.Tx, id int) (gameServer.ParameterSet, error) {
+	var parSet gameServer.ParameterSet
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE id=$1 FOR UPDATE", parSetColumns, parameterSetsTable)
+
+	err := tx.Get(&parSet, query, id)
+	return parSet, err
+}
+
+func lockUnusedParSet(tx *sqlx.Tx, id int, action string) error {
+	if _, err := lockParSet(tx, id); err != nil {
+		return err
+	}
+
+	references, err := parSetReferences(tx, id)
+	if err != nil {
+		return err
+	}
+	if !references.IsEmpty() {
+		return &gameServer.ParSetConflictError{Action: action, References: references}
+	}
+	return nil
+}
+
+// lockActiveParSet makes sure that a parameter set being assigned exists and
+// is not archived, and keeps it from being archived until the transaction
+// ends.
+func lockActiveParSet(tx *sqlx.Tx, id int) error {
+	var isArchived bool
+	query := fmt.Sprintf("SELECT archived_at IS NOT NULL FROM %s WHERE id=$1 FOR SHARE", parameterSetsTable)
+	if err := tx.Get(&isArchived, query, id); err != nil {
+		return err
+	}
+	if isArchived {
+		return gameServer.ErrParSetArchived
+	}
+	return nil
+}
+
+func parSetReferences(q sqlx.Queryer, id int) (gameServer.ParSetReferences, error) {
+	references := gameServer.ParSetReferences{GroupIds: []int{}, UserIds: []int{}}
+
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE parameter_set_id=$1", chartsTable)
+	if err := sqlx.Get(q, &references.ChartCount, query, id); err != nil {
+		return gameServer.ParSetReferences{}, err
+	}
+
+	query = fmt.Sprintf("SELECT id FROM %s WHERE parameter_set_id=$1 ORDER BY id", groupsTable)
+	if err := sqlx.Select(q, &references.GroupIds, query, id); err != nil {
+		return gameServer.ParSetReferences{}, err
+	}
+
+	query = fmt.Sprintf(`SELECT user_id FROM %s WHERE cur_par_set_id=$1
+						 UNION
+						 SELECT user_id FROM %s WHERE parameter_set_id=$1
+						 ORDER BY user_id`, usersTable, userParameterSetsTable)
+	if err := sqlx.Select(q, &references.UserIds, query, id); err != nil {
+		return gameServer.ParSetReferences{}, err
+	}
+
+	return references, nil
+}
+
+func (p *ChartPostgres) CreateHint(hint gameServer.Hint) (int, error) {
+	var id int
+	query := fmt.Sprintf(`INSERT INTO %s (chart_id, hint_type, disclosure, x, y, crash_probability, risk_level, cost, created_at)
+						 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`, chartHintsTable)
+
+	timeNow := time.Now().UTC().Add(3 * time.Hour)
+	row := p.db.QueryRow(query, hint.ChartId, hint.Type, hint.Disclosure, hint.X, hint.Y, hint.CrashProbability, hint.RiskLevel, hint.Cost, timeNow)
+	if err := row.Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// SaveAdvice stores the advice given at a step of the game. The advice that
+// is already stored for the step wins, so a repeated request cannot change it.
+func (p *ChartPostgres) SaveAdvice(chartId int, advice gameServer.Advice) (gameServer.Advice, error) {
+	var saved gameServer.Advice
+	query := fmt.Sprintf(`INSERT INTO %s (chart_id, x, signal, is_danger, confidence, created_at)
+						 VALUES ($1, $2, $3, $4, $5, $6)
+						 ON CONFLICT (chart_id, x) DO UPDATE SET chart_id = EXCLUDED.chart_id
+						 RETURNING x, signal, is_danger, confidence`, chartAdviceTable)
+
+	timeNow := time.Now().UTC().Add(3 * time.Hour)
+	err := p.db.Get(&saved, query, chartId, advice.X, advice.Signal, advice.IsDanger, advice.Confidence, timeNow)
+
+	return saved, err
+}
+
+func (p *ChartPostgres) GetChartAdvice(chartId int) ([]gameServer.Advice, error) {
+	var advice []gameServer.Advice
+	query := fmt.Sprintf("SELECT x, signal, is_danger, confidence FROM %s WHERE chart_id=$1 ORDER BY x", chartAdviceTable)
+	err := p.db.Select(&advice, query, chartId)
+
+	return advice, err
+}
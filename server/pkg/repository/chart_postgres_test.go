@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"encoding/json"
+	"testing"
+
+	gameServer "example.com/gameHoldTheProcessServer"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChartPostgres_UpdateParSet(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewChartPostgres(db)
+
+	// 0.1 has no exact float32 value, the stored float must not be rewritten.
+	input := gameServer.CreateParSetInput{Name: "played", A: 0.1, B: 0.2, NoiseMean: 0.18, NoiseStdev: 0.03}
+	input.ApplyDefaults()
+	id, err := repo.CreateParSet(input)
+	require.NoError(t, err)
+
+	var storedA float64
+	require.NoError(t, db.Get(&storedA, "SELECT a FROM parameter_sets WHERE id=$1", id))
+
+	// The admin created by the first migration plays a game with the set.
+	_, err = repo.CreateChart(gameServer.CreateChartInput{ParameterSetId: id, UserId: 1})
+	require.NoError(t, err)
+
+	parSet, err := repo.GetOneParSet(id)
+	require.NoError(t, err)
+
+	renamed := parSet.ToCreateInput()
+	renamed.Name = "renamed"
+	renamed.Description = "the set of the first study"
+	renamed.Rules = json.RawMessage(`{"en": "Stop the process before it crashes"}`)
+	require.NoError(t, repo.UpdateParSet(id, renamed))
+
+	parSet, err = repo.GetOneParSet(id)
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", parSet.Name)
+	assert.Equal(t, "the set of the first study", parSet.Description)
+	assert.JSONEq(t, `{"en": "Stop the process before it crashes"}`, string(parSet.Rules))
+	var a float64
+	require.NoError(t, db.Get(&a, "SELECT a FROM parameter_sets WHERE id=$1", id))
+	assert.Equal(t, storedA, a)
+
+	changed := parSet.ToCreateInput()
+	changed.A = 0.7
+	var conflictErr *gameServer.ParSetConflictError
+	assert.ErrorAs(t, repo.UpdateParSet(id, changed), &conflictErr)
+
+	// The trigger rejects the changes ChangesGame rejects.
+	_, err = db.Exec("UPDATE parameter_sets SET a=0.7 WHERE id=$1", id)
+	var pqErr *pq.Error
+	if assert.ErrorAs(t, err, &pqErr) {
+		assert.Equal(t, pq.ErrorCode("23001"), pqErr.Code)
+	}
+}
@@ -17,23 +17,57 @@ func NewPointPostgres(db *sqlx.DB) *PointPostgres {
 }
 
 func (p *PointPostgres) CreatePoint(input gameServer.Point) (int, error) {
-	var id int
-	query := fmt.Sprintf("INSERT INTO %s (x, y, score, is_crash, is_useful_ai_signal, is_deceptive_ai_signal, is_stop, is_pause, is_check, created_at, chart_id, check_info) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING id", pointsTable)
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return 0, err
+	}
 
-	timeNow := time.Now().UTC().Add(3 * time.Hour)
-	row := p.db.QueryRow(query, input.X, input.Y, input.Score, input.IsCrash,
-		input.IsUsefulAiSignal, input.IsDeceptiveAiSignal, input.IsStop, input.IsPause,
-		input.IsCheck, timeNow, input.ChartId, input.CheckInfo)
-	if err := row.Scan(&id); err != nil {
+	id, err := insertPoint(tx, input, time.Now().UTC().Add(3*time.Hour))
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	// Games uploaded point by point get their end reason from the final point
+	// unless the client has already sent it with the chart, a chart created
+	// without one is marked unknown until then.
+	var endReason *string
+	if input.IsCrash {
+		reason := gameServer.ChartEndReasonCrash
+		endReason = &reason
+	} else if input.IsStop {
+		reason := gameServer.ChartEndReasonStop
+		endReason = &reason
+	}
+	query := fmt.Sprintf(`UPDATE %s SET point_count=point_count+1,
+						 end_reason=CASE WHEN end_reason IS NULL OR end_reason=$2 THEN COALESCE($1, end_reason) ELSE end_reason END
+						 WHERE id=$3`, chartsTable)
+	if _, err := tx.Exec(query, endReason, gameServer.ChartEndReasonUnknown, input.ChartId); err != nil {
+		tx.Rollback()
 		return 0, err
 	}
 
-	return id, nil
+	return id, tx.Commit()
+}
+
+func insertPoint(tx *sqlx.Tx, input gameServer.Point, createdAt time.Time) (int, error) {
+	var id int
+	query := fmt.Sprintf(`INSERT INTO %s (x, y, score, is_crash, is_useful_ai_signal, is_deceptive_ai_signal, is_stop, is_pause, is_check, created_at, chart_id, hint_type, risk_level, crash_probability,
+						 ai_confidence, reaction_time_ms, signal_shown_at, responded_at)
+						 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18) RETURNING id`, pointsTable)
+
+	row := tx.QueryRow(query, input.X, input.Y, input.Score, input.IsCrash,
+		input.IsUsefulAiSignal, input.IsDeceptiveAiSignal, input.IsStop, input.IsPause,
+		input.IsCheck, createdAt, input.ChartId, input.HintType, input.RiskLevel, input.CrashProbability,
+		input.AiConfidence, input.ReactionTimeMs, input.SignalShownAt, input.RespondedAt)
+	err := row.Scan(&id)
+
+	return id, err
 }
 
 func (p *PointPostgres) GetOnePoint(id int) (gameServer.Point, error) {
 	var point gameServer.Point
-	query := fmt.Sprintf("SELECT id, x, y, score, is_crash, is_useful_ai_signal, is_deceptive_ai_signal, is_stop, is_pause, is_check, created_at, chart_id, check_info FROM %s WHERE id=$1", pointsTable)
+	query := fmt.Sprintf("SELECT id, x, y, score, is_crash, is_useful_ai_signal, is_deceptive_ai_signal, is_stop, is_pause, is_check, created_at, chart_id, hint_type, risk_level, crash_probability, ai_confidence, reaction_time_ms, signal_shown_at, responded_at FROM %s WHERE id=$1", pointsTable)
 
 	err := p.db.Get(&point, query, id)
 	return point, err
@@ -41,7 +75,7 @@ func (p *PointPostgres) GetOnePoint(id int) (gameServer.Point, error) {
 
 func (p *PointPostgres) GetAllPointsById(id int) ([]gameServer.Point, error) {
 	var points []gameServer.Point
-	query := fmt.Sprintf("SELECT id, x, y, score, is_crash, is_useful_ai_signal, is_deceptive_ai_signal, is_stop, is_pause, is_check, created_at, chart_id, check_info FROM %s WHERE chart_id=$1 ORDER BY x", pointsTable)
+	query := fmt.Sprintf("SELECT id, x, y, score, is_crash, is_useful_ai_signal, is_deceptive_ai_signal, is_stop, is_pause, is_check, created_at, chart_id, hint_type, risk_level, crash_probability, ai_confidence, reaction_time_ms, signal_shown_at, responded_at FROM %s WHERE chart_id=$1 ORDER BY x", pointsTable)
 	err := p.db.Select(&points, query, id)
 
 	return points, err
@@ -57,7 +91,7 @@ func (p *PointPostgres) DeletePoint(id int) error {
 func (p *PointPostgres) GetAllPointsForCSV() ([]gameServer.PointForCSV, error) {
 	var points []gameServer.PointForCSV
 	query := fmt.Sprintf(`SELECT pt.id, pt.x, pt.y, pt.score, pt.is_crash, pt.is_useful_ai_signal, pt.is_deceptive_ai_signal,
-	pt.is_stop, pt.is_pause, pt.is_check, pt.chart_id, pt.check_info, ct.user_id, ct.parameter_set_id, ct.is_training
+	pt.is_stop, pt.is_pause, pt.is_check, pt.chart_id, pt.hint_type, pt.risk_level, pt.crash_probability, pt.ai_confidence, pt.reaction_time_ms, ct.user_id, ct.parameter_set_id, ct.is_training, ct.scenario_id
 	FROM %s AS pt JOIN %s AS ct ON pt.chart_id=ct.id ORDER BY ct.parameter_set_id, ct.user_id, pt.chart_id, pt.id`, pointsTable, chartsTable)
 	err := p.db.Select(&points, query)
 
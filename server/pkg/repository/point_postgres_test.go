@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"testing"
+
+	gameServer "example.com/gameHoldTheProcessServer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPointPostgres_CreatePoint(t *testing.T) {
+	db := newTestDB(t)
+	charts := NewChartPostgres(db)
+	repo := NewPointPostgres(db)
+
+	exit := gameServer.ChartEndReasonExit
+
+	tests := []struct {
+		name              string
+		endReason         *string
+		points            []gameServer.Point
+		expectedEndReason string
+	}{
+		{
+			name:              "end reason from the final point",
+			points:            []gameServer.Point{{X: 1}, {X: 2, IsCrash: true}},
+			expectedEndReason: gameServer.ChartEndReasonCrash,
+		},
+		{
+			name:              "no final point",
+			points:            []gameServer.Point{{X: 1}, {X: 2}},
+			expectedEndReason: gameServer.ChartEndReasonUnknown,
+		},
+		{
+			name:              "end reason sent with the chart",
+			endReason:         &exit,
+			points:            []gameServer.Point{{X: 1}, {X: 2, IsStop: true}},
+			expectedEndReason: gameServer.ChartEndReasonExit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The admin created by the first migration plays with the first
+			// parameter set.
+			chartId, err := charts.CreateChart(gameServer.CreateChartInput{ParameterSetId: 1, UserId: 1, EndReason: tt.endReason})
+			require.NoError(t, err)
+
+			for _, point := range tt.points {
+				point.ChartId = chartId
+				_, err := repo.CreatePoint(point)
+				require.NoError(t, err)
+			}
+
+			chart, err := charts.GetOneChart(chartId)
+			require.NoError(t, err)
+			if assert.NotNil(t, chart.EndReason) {
+				assert.Equal(t, tt.expectedEndReason, *chart.EndReason)
+			}
+			assert.Equal(t, len(tt.points), chart.PointCount)
+		})
+	}
+}
@@ -26,10 +26,18 @@ const (
 	statisticsTable        = "statistics"
 	testsTable             = "tests"
 	testResultsTable       = "test_results"
-	parSetColumns          = "id, a, b, noise_mean, noise_stdev, false_warning_prob, missing_danger_prob, scoring_config, hint_cost, false_alarm_threshold, rules_text, created_at"
-	parSetAliasedColumns   = "pst.id, pst.a, pst.b, pst.noise_mean, pst.noise_stdev, pst.false_warning_prob, pst.missing_danger_prob, pst.scoring_config, pst.hint_cost, pst.false_alarm_threshold, pst.rules_text, pst.created_at"
+	testVersionsTable      = "test_versions"
+	testDraftsTable        = "test_drafts"
+	scenariosTable         = "scenarios"
+	chartHintsTable        = "chart_hints"
+	chartAdviceTable       = "chart_advice"
+	parSetColumns          = "id, parent_id, name, description, a, b, noise_mean, noise_stdev, false_warning_prob, missing_danger_prob, scoring_config, hint_cost, hint_config, advisor_config, false_alarm_threshold, rules, created_at, archived_at"
+	parSetAliasedColumns   = "pst.id, pst.parent_id, pst.name, pst.description, pst.a, pst.b, pst.noise_mean, pst.noise_stdev, pst.false_warning_prob, pst.missing_danger_prob, pst.scoring_config, pst.hint_cost, pst.hint_config, pst.advisor_config, pst.false_alarm_threshold, pst.rules, pst.created_at, pst.archived_at"
 )
 
+// uniqueViolation is the code of the error of a violated unique constraint.
+const uniqueViolation = "23505"
+
 func NewPostgresDB(cfg Config) (*sqlx.DB, error) {
 	db, err := sqlx.Open("postgres", fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=%s",
 		cfg.Host, cfg.Port, cfg.Username, cfg.DBName, cfg.Password, cfg.SSLMode))
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+)
+
+// newTestDB opens the database of TEST_DB_DSN with the migrations of schema/
+// applied in a schema of its own, which is dropped when the test ends. The
+// test is skipped when TEST_DB_DSN is not set.
+func newTestDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DB_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DB_DSN is not set")
+	}
+
+	db, err := sqlx.Open("postgres", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The search path is set on the connection, so the pool keeps only one.
+	db.SetMaxOpenConns(1)
+
+	schema := fmt.Sprintf("test_%d", time.Now().UnixNano())
+	if _, err := db.Exec(fmt.Sprintf("CREATE SCHEMA %s", schema)); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		db.Exec(fmt.Sprintf("DROP SCHEMA %s CASCADE", schema))
+		db.Close()
+	})
+	if _, err := db.Exec(fmt.Sprintf("SET search_path TO %s", schema)); err != nil {
+		t.Fatal(err)
+	}
+
+	migrations, err := filepath.Glob("../../schema/*.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(migrations)
+	for _, migration := range migrations {
+		query, err := os.ReadFile(migration)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := db.Exec(string(query)); err != nil {
+			t.Fatalf("%s: %v", filepath.Base(migration), err)
+		}
+	}
+
+	return db
+}
@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"encoding/json"
+	"time"
+
 	gameServer "example.com/gameHoldTheProcessServer"
 	"github.com/jmoiron/sqlx"
 )
@@ -27,17 +30,32 @@ type User interface {
 	UpdateUserUserParSet(id int, input gameServer.UpdateUserUserParSetInput) error
 	ChangeGroupParSet(input gameServer.ChangeGroupParSetInput) error
 	GetCharts(start, end int) (map[int]float64, error)
+	IsUserInCreatorGroups(creatorId, userId int) (bool, error)
+	GetOneGroup(id int) (gameServer.Group, error)
+	GetUserGroupIds(userId int) ([]int, error)
 }
 
 type Chart interface {
 	CreateChart(chart gameServer.CreateChartInput) (int, error)
+	OpenChart(chart gameServer.CreateChartInput) (int, error)
+	AppendPoints(chartId int, points []gameServer.Point) ([]int, error)
+	CloseChart(chartId int, endReason string) error
+	AbandonStaleCharts(inactiveSince time.Time) (int, error)
 	GetOneChart(id int) (gameServer.Chart, error)
 	GetChartsCount(input gameServer.GetChartsPageCountInput) (int, error)
 	GetAllCharts(input gameServer.GetAllChartsInput) ([]gameServer.Chart, error)
 	DeleteChart(id int) error
-	GetAllParSets(input gameServer.GetAllParSetsInput) ([]gameServer.ParameterSet, error)
+	GetAllParSets(input gameServer.GetAllParSetsInput) ([]gameServer.ParameterSetWithUsage, error)
+	GetOneParSet(id int) (gameServer.ParameterSet, error)
 	GetParSetsCount() (int, error)
 	CreateParSet(input gameServer.CreateParSetInput) (int, error)
+	UpdateParSet(id int, input gameServer.CreateParSetInput) error
+	ArchiveParSet(id int) error
+	DeleteParSet(id int) error
+	GetParSetReferences(id int) (gameServer.ParSetReferences, error)
+	CreateHint(hint gameServer.Hint) (int, error)
+	SaveAdvice(chartId int, advice gameServer.Advice) (gameServer.Advice, error)
+	GetChartAdvice(chartId int) ([]gameServer.Advice, error)
 }
 
 type Point interface {
@@ -50,6 +68,8 @@ type Point interface {
 
 type Statistics interface {
 	CountGames(input gameServer.ComputeStatisticsInput) (int, error)
+	CountGamesByEndReason(input gameServer.ComputeStatisticsInput) (map[string]int, error)
+	CountHintsByType(input gameServer.ComputeStatisticsInput) (map[string]int, error)
 	CountStops(input gameServer.ComputeStatisticsInput) (int, error)
 	CountCrashes(input gameServer.ComputeStatisticsInput) (int, error)
 	GetYStopsOnSignal(input gameServer.ComputeStatisticsInput) ([]float64, error)
@@ -61,6 +81,8 @@ type Statistics interface {
 	GetStatistics(userId, parSetId int) (gameServer.Statistics, error)
 	GetTotalScore(input gameServer.ComputeStatisticsInput) (int, error)
 	GetAllEvents(input gameServer.ComputeStatisticsInput) ([]gameServer.Point, error)
+	GetReactionTimes(input gameServer.ComputeStatisticsInput) ([]gameServer.Point, error)
+	GetGroupStatistics(groupId, parSetId int) ([]gameServer.ParticipantStatistics, error)
 }
 
 type Test interface {
@@ -70,10 +92,22 @@ type Test interface {
 	CreateTest(input gameServer.CreateTestInput) (int, error)
 	UpdateTest(id int, input gameServer.UpdateTestInput) error
 	DeleteTest(id int) error
-	GetCompletedTestIds(userId int) (map[int]bool, error)
-	CreateTestResult(userId int, input gameServer.SubmitTestResultInput, score *float64) (int, error)
+	GetCompletedTestIds(userId int, context string) (map[int]bool, error)
+	CreateTestResult(userId int, test gameServer.Test, input gameServer.SubmitTestResultInput, score *float64, scoreDetails json.RawMessage) (int, error)
+	SaveTestDraft(userId, testId, testVersion int, input gameServer.SaveTestProgressInput) error
+	GetTestDrafts(userId, testId int) ([]gameServer.TestDraft, error)
 	GetUserResults(userId int) ([]gameServer.TestResult, error)
 	GetUserResultsWithTests(userId int) ([]gameServer.TestResultWithTest, error)
+	GetTestVersion(testId, version int) (gameServer.TestVersion, error)
+	GetReportAnswers(testId, version int, input gameServer.TestReportInput) ([]json.RawMessage, error)
+	GetGroupTestScores(groupId int, context, phase string) ([]gameServer.ParticipantTestScore, error)
+}
+
+type Scenario interface {
+	CreateScenarios(scenarios []gameServer.Scenario) ([]int, error)
+	GetOneScenario(id int) (gameServer.Scenario, error)
+	GetAllScenarios(parSetId int) ([]gameServer.Scenario, error)
+	AssignScenario(chartId int) (*int, error)
 }
 
 type Repository struct {
@@ -82,6 +116,7 @@ type Repository struct {
 	Point
 	Statistics
 	Test
+	Scenario
 }
 
 func NewRepository(db *sqlx.DB) *Repository {
@@ -91,5 +126,6 @@ func NewRepository(db *sqlx.DB) *Repository {
 		Point:      NewPointPostgres(db),
 		Statistics: NewStatisticsPostgres(db),
 		Test:       NewTestPostgres(db),
+		Scenario:   NewScenarioPostgres(db),
 	}
 }
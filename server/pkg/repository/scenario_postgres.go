@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	gameServer "example.com/gameHoldTheProcessServer"
+	"github.com/jmoiron/sqlx"
+)
+
+type ScenarioPostgres struct {
+	db *sqlx.DB
+}
+
+func NewScenarioPostgres(db *sqlx.DB) *ScenarioPostgres {
+	return &ScenarioPostgres{db: db}
+}
+
+func (p *ScenarioPostgres) CreateScenarios(scenarios []gameServer.Scenario) ([]int, error) {
+	tx, err := p.db.Beginx()
+	if err != nil {
+		return nil, err
+	}
+
+	timeNow := time.Now().UTC().Add(3 * time.Hour)
+	query := fmt.Sprintf(`INSERT INTO %s (parameter_set_id, seed, trajectory, danger_points, ai_signals, created_at)
+						 VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`, scenariosTable)
+
+	ids := make([]int, 0, len(scenarios))
+	for _, scenario := range scenarios {
+		var id int
+		row := tx.QueryRow(query, scenario.ParameterSetId, scenario.Seed, scenario.Trajectory,
+			scenario.DangerPoints, scenario.AiSignals, timeNow)
+		if err := row.Scan(&id); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, tx.Commit()
+}
+
+func (p *ScenarioPostgres) GetOneScenario(id int) (gameServer.Scenario, error) {
+	var scenario gameServer.Scenario
+	query := fmt.Sprintf("SELECT id, parameter_set_id, seed, trajectory, danger_points, ai_signals, created_at FROM %s WHERE id=$1", scenariosTable)
+
+	err := p.db.Get(&scenario, query, id)
+	return scenario, err
+}
+
+func (p *ScenarioPostgres) GetAllScenarios(parSetId int) ([]gameServer.Scenario, error) {
+	var scenarios []gameServer.Scenario
+	query := fmt.Sprintf("SELECT id, parameter_set_id, seed, trajectory, danger_points, ai_signals, created_at FROM %s WHERE parameter_set_id=$1 ORDER BY id", scenariosTable)
+
+	err := p.db.Select(&scenarios, query, parSetId)
+	return scenarios, err
+}
+
+// AssignScenario gives the chart the next scenario of its parameter set in
+// the order every participant goes through them: the n-th game of a user
+// gets the n-th scenario of the bank.
+func (p *ScenarioPostgres) AssignScenario(chartId int) (*int, error) {
+	var scenarioId *int
+	query := fmt.Sprintf(`
+				UPDATE %[1]s AS ct
+				SET scenario_id = (
+					SELECT sc.id
+					FROM %[2]s AS sc
+					WHERE sc.parameter_set_id = ct.parameter_set_id
+					ORDER BY sc.id
+					OFFSET (
+						SELECT COUNT(*)
+						FROM %[1]s
+						WHERE user_id = ct.user_id
+						AND parameter_set_id = ct.parameter_set_id
+						AND is_training = ct.is_training
+						AND scenario_id IS NOT NULL
+					) %% GREATEST((SELECT COUNT(*) FROM %[2]s WHERE parameter_set_id = ct.parameter_set_id), 1)
+					LIMIT 1
+				)
+				WHERE ct.id = $1
+				RETURNING ct.scenario_id
+			`, chartsTable, scenariosTable)
+
+	err := p.db.Get(&scenarioId, query, chartId)
+	return scenarioId, err
+}
@@ -15,12 +15,29 @@ func NewStatisticsPostgres(db *sqlx.DB) *StatisticsPostgres {
 	return &StatisticsPostgres{db: db}
 }
 
+// statisticsCharts selects the charts of a user and a parameter set that the
+// statistics are computed over: the finished games with points by default, or
+// every game including those in progress or abandoned with IncludeUnfinished.
+const statisticsCharts = `user_id = $1
+				AND parameter_set_id = $2
+				AND NOT is_training
+				AND ($3 = '' OR end_reason = $3)
+				AND ($4 OR (status = $5 AND point_count > 0))`
+
+func statisticsChartsArgs(input gameServer.ComputeStatisticsInput) []any {
+	return []any{input.UserId, input.ParSetId, input.EndReason, input.IncludeUnfinished, gameServer.ChartStatusFinished}
+}
+
 func (p *StatisticsPostgres) CountGames(input gameServer.ComputeStatisticsInput) (int, error) {
 	var gamesCount int
 
-	query := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE user_id = $1 AND parameter_set_id = $2 AND NOT is_training`, chartsTable)
+	query := fmt.Sprintf(`
+				SELECT COUNT(*)
+				FROM %s
+				WHERE %s
+			`, chartsTable, statisticsCharts)
 
-	row := p.db.QueryRow(query, input.UserId, input.ParSetId)
+	row := p.db.QueryRow(query, statisticsChartsArgs(input)...)
 	if err := row.Scan(&gamesCount); err != nil {
 		return 0, err
 	}
@@ -28,23 +45,68 @@ func (p *StatisticsPostgres) CountGames(input gameServer.ComputeStatisticsInput)
 	return gamesCount, nil
 }
 
+func (p *StatisticsPostgres) CountGamesByEndReason(input gameServer.ComputeStatisticsInput) (map[string]int, error) {
+	var rows []struct {
+		EndReason string `db:"end_reason"`
+		Count     int    `db:"count"`
+	}
+
+	query := fmt.Sprintf(`
+				SELECT COALESCE(end_reason, '') AS end_reason, COUNT(*) AS count
+				FROM %s
+				WHERE %s
+				GROUP BY end_reason
+			`, chartsTable, statisticsCharts)
+
+	if err := p.db.Select(&rows, query, statisticsChartsArgs(input)...); err != nil {
+		return nil, err
+	}
+
+	gamesByEndReason := make(map[string]int, len(rows))
+	for _, row := range rows {
+		gamesByEndReason[row.EndReason] = row.Count
+	}
+
+	return gamesByEndReason, nil
+}
+
+func (p *StatisticsPostgres) CountHintsByType(input gameServer.ComputeStatisticsInput) (map[string]int, error) {
+	var rows []struct {
+		HintType string `db:"hint_type"`
+		Count    int    `db:"count"`
+	}
+
+	query := fmt.Sprintf(`
+				SELECT COALESCE(hint_type, '') AS hint_type, COUNT(*) AS count
+				FROM %s
+				WHERE chart_id IN (SELECT id FROM %s WHERE %s)
+				AND is_check
+				GROUP BY hint_type
+			`, pointsTable, chartsTable, statisticsCharts)
+
+	if err := p.db.Select(&rows, query, statisticsChartsArgs(input)...); err != nil {
+		return nil, err
+	}
+
+	hintsByType := make(map[string]int, len(rows))
+	for _, row := range rows {
+		hintsByType[row.HintType] = row.Count
+	}
+
+	return hintsByType, nil
+}
+
 func (p *StatisticsPostgres) CountStops(input gameServer.ComputeStatisticsInput) (int, error) {
 	var stopsCount int
 
 	query := fmt.Sprintf(`
 				SELECT COUNT(*)
 				FROM %s
-				WHERE chart_id IN ( 
-					SELECT id
-					FROM %s
-					WHERE user_id = $1
-					AND parameter_set_id = $2
-					AND NOT is_training
-				)
+				WHERE chart_id IN (SELECT id FROM %s WHERE %s)
 				AND is_stop
-			`, pointsTable, chartsTable)
+			`, pointsTable, chartsTable, statisticsCharts)
 
-	row := p.db.QueryRow(query, input.UserId, input.ParSetId)
+	row := p.db.QueryRow(query, statisticsChartsArgs(input)...)
 	if err := row.Scan(&stopsCount); err != nil {
 		return 0, err
 	}
@@ -58,17 +120,11 @@ func (p *StatisticsPostgres) CountCrashes(input gameServer.ComputeStatisticsInpu
 	query := fmt.Sprintf(`
 				SELECT COUNT(*)
 				FROM %s
-				WHERE chart_id IN ( 
-					SELECT id
-					FROM %s
-					WHERE user_id = $1
-					AND parameter_set_id = $2
-					AND NOT is_training
-				)
+				WHERE chart_id IN (SELECT id FROM %s WHERE %s)
 				AND is_crash
-			`, pointsTable, chartsTable)
+			`, pointsTable, chartsTable, statisticsCharts)
 
-	row := p.db.QueryRow(query, input.UserId, input.ParSetId)
+	row := p.db.QueryRow(query, statisticsChartsArgs(input)...)
 	if err := row.Scan(&crashesCount); err != nil {
 		return 0, err
 	}
@@ -82,17 +138,11 @@ func (p *StatisticsPostgres) GetYStopsOnSignal(input gameServer.ComputeStatistic
 	query := fmt.Sprintf(`
 				SELECT y
 				FROM %s
-				WHERE chart_id IN ( 
-					SELECT id
-					FROM %s
-					WHERE user_id = $1
-					AND parameter_set_id = $2
-					AND NOT is_training
-				)
+				WHERE chart_id IN (SELECT id FROM %s WHERE %s)
 				AND is_stop AND (is_useful_ai_signal OR is_deceptive_ai_signal)
-			`, pointsTable, chartsTable)
+			`, pointsTable, chartsTable, statisticsCharts)
 
-	err := p.db.Select(&ySl, query, input.UserId, input.ParSetId)
+	err := p.db.Select(&ySl, query, statisticsChartsArgs(input)...)
 
 	return ySl, err
 }
@@ -103,17 +153,11 @@ func (p *StatisticsPostgres) GetYStopsWithoutSignal(input gameServer.ComputeStat
 	query := fmt.Sprintf(`
 				SELECT y
 				FROM %s
-				WHERE chart_id IN ( 
-					SELECT id
-					FROM %s
-					WHERE user_id = $1
-					AND parameter_set_id = $2
-					AND NOT is_training
-				)
+				WHERE chart_id IN (SELECT id FROM %s WHERE %s)
 				AND is_stop AND NOT (is_useful_ai_signal OR is_deceptive_ai_signal)
-			`, pointsTable, chartsTable)
+			`, pointsTable, chartsTable, statisticsCharts)
 
-	err := p.db.Select(&ySl, query, input.UserId, input.ParSetId)
+	err := p.db.Select(&ySl, query, statisticsChartsArgs(input)...)
 
 	return ySl, err
 }
@@ -124,17 +168,11 @@ func (p *StatisticsPostgres) GetYHintsOnSignal(input gameServer.ComputeStatistic
 	query := fmt.Sprintf(`
 				SELECT y
 				FROM %s
-				WHERE chart_id IN ( 
-					SELECT id
-					FROM %s
-					WHERE user_id = $1
-					AND parameter_set_id = $2
-					AND NOT is_training
-				)
+				WHERE chart_id IN (SELECT id FROM %s WHERE %s)
 				AND is_check AND (is_useful_ai_signal OR is_deceptive_ai_signal)
-			`, pointsTable, chartsTable)
+			`, pointsTable, chartsTable, statisticsCharts)
 
-	err := p.db.Select(&ySl, query, input.UserId, input.ParSetId)
+	err := p.db.Select(&ySl, query, statisticsChartsArgs(input)...)
 
 	return ySl, err
 }
@@ -145,17 +183,11 @@ func (p *StatisticsPostgres) GetYHintsWithoutSignal(input gameServer.ComputeStat
 	query := fmt.Sprintf(`
 				SELECT y
 				FROM %s
-				WHERE chart_id IN ( 
-					SELECT id
-					FROM %s
-					WHERE user_id = $1
-					AND parameter_set_id = $2
-					AND NOT is_training
-				)
+				WHERE chart_id IN (SELECT id FROM %s WHERE %s)
 				AND is_check AND NOT (is_useful_ai_signal OR is_deceptive_ai_signal)
-			`, pointsTable, chartsTable)
+			`, pointsTable, chartsTable, statisticsCharts)
 
-	err := p.db.Select(&ySl, query, input.UserId, input.ParSetId)
+	err := p.db.Select(&ySl, query, statisticsChartsArgs(input)...)
 
 	return ySl, err
 }
@@ -166,39 +198,42 @@ func (p *StatisticsPostgres) GetYContinuesAfterSignal(input gameServer.ComputeSt
 	query := fmt.Sprintf(`
 				SELECT y
 				FROM %s
-				WHERE chart_id IN ( 
-					SELECT id
-					FROM %s
-					WHERE user_id = $1
-					AND parameter_set_id = $2
-					AND NOT is_training
-				)
+				WHERE chart_id IN (SELECT id FROM %s WHERE %s)
 				AND NOT is_stop AND (is_useful_ai_signal OR is_deceptive_ai_signal)
-			`, pointsTable, chartsTable)
+			`, pointsTable, chartsTable, statisticsCharts)
 
-	err := p.db.Select(&ySl, query, input.UserId, input.ParSetId)
+	err := p.db.Select(&ySl, query, statisticsChartsArgs(input)...)
 
 	return ySl, err
 }
 
+func (p *StatisticsPostgres) GetReactionTimes(input gameServer.ComputeStatisticsInput) ([]gameServer.Point, error) {
+	var points []gameServer.Point
+
+	query := fmt.Sprintf(`
+				SELECT is_useful_ai_signal, is_deceptive_ai_signal, is_check, reaction_time_ms
+				FROM %s
+				WHERE chart_id IN (SELECT id FROM %s WHERE %s)
+				AND reaction_time_ms IS NOT NULL
+			`, pointsTable, chartsTable, statisticsCharts)
+
+	err := p.db.Select(&points, query, statisticsChartsArgs(input)...)
+
+	return points, err
+}
+
 func (p *StatisticsPostgres) GetAllEvents(input gameServer.ComputeStatisticsInput) ([]gameServer.Point, error) {
 	var points []gameServer.Point
 
 	query := fmt.Sprintf(`
-				SELECT y, score, is_crash, is_useful_ai_signal, is_deceptive_ai_signal, is_stop, is_pause, is_check, chart_id, check_info
+				SELECT y, score, is_crash, is_useful_ai_signal, is_deceptive_ai_signal, is_stop, is_pause, is_check, chart_id, hint_type, risk_level, crash_probability
 				FROM %s
-				WHERE chart_id IN ( 
-					SELECT id
-					FROM %s
-					WHERE user_id = $1
-					AND parameter_set_id = $2
-					AND NOT is_training
-				)
+				WHERE chart_id IN (SELECT id FROM %s WHERE %s)
 				AND (is_crash OR is_useful_ai_signal OR is_deceptive_ai_signal OR is_stop OR is_pause OR is_check)
 				ORDER BY chart_id, x ASC
-			`, pointsTable, chartsTable)
+			`, pointsTable, chartsTable, statisticsCharts)
 
-	err := p.db.Select(&points, query, input.UserId, input.ParSetId)
+	err := p.db.Select(&points, query, statisticsChartsArgs(input)...)
 
 	return points, err
 }
@@ -208,21 +243,24 @@ func (p *StatisticsPostgres) UpsertStatistics(input gameServer.ComputeStatistics
 	                     mean_stop_without_signal, stdev_stop_without_signal, mean_hint_on_signal, stdev_hint_on_signal,
 						 mean_hint_without_signal, stdev_hint_without_signal, mean_continue_after_signal, stdev_continue_after_signal,
 						 stop_on_signal_num, stop_without_signal_num, hint_on_signal_num, hint_without_signal_num, continue_after_signal_num,
-						 total_score, choice_stats, choice_stats_venger_table, choice_stats_venger_charts)
-	                    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24)
+						 total_score, choice_stats, choice_stats_venger_table, choice_stats_venger_charts, games_by_end_reason,
+						 reaction_time_stats, hints_by_type)
+	                    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27)
 						ON CONFLICT (user_id, parameter_set_id) DO UPDATE SET
 						games_num = $3, stops_num = $4, crashes_num = $5, mean_stop_on_signal = $6, stdev_stop_on_signal = $7, mean_stop_without_signal = $8,
 						stdev_stop_without_signal = $9, mean_hint_on_signal = $10, stdev_hint_on_signal = $11, mean_hint_without_signal = $12,
 						stdev_hint_without_signal = $13, mean_continue_after_signal = $14, stdev_continue_after_signal = $15,
 						stop_on_signal_num = $16, stop_without_signal_num = $17, hint_on_signal_num = $18,
-						hint_without_signal_num = $19, continue_after_signal_num = $20, total_score = $21, choice_stats = $22, choice_stats_venger_table = $23, choice_stats_venger_charts = $24
+						hint_without_signal_num = $19, continue_after_signal_num = $20, total_score = $21, choice_stats = $22, choice_stats_venger_table = $23, choice_stats_venger_charts = $24,
+						games_by_end_reason = $25, reaction_time_stats = $26, hints_by_type = $27
 						`, statisticsTable)
 
 	_, err := p.db.Exec(query, input.UserId, input.ParSetId, s.GamesNum, s.StopsNum, s.CrashesNum, s.MeanStopOnSignal, s.StdevStopOnSignal,
 		s.MeanStopWithoutSignal, s.StdevStopWithoutSignal, s.MeanHintOnSignal, s.StdevHintOnSignal,
 		s.MeanHintWithoutSignal, s.StdevHintWithoutSignal, s.MeanContinueAfterSignal, s.StdevContinueAfterSignal,
 		s.StopOnSignalNum, s.StopWithoutSignalNum, s.HintOnSignalNum, s.HintWithoutSignalNum, s.ContinueAfterSignalNum,
-		s.TotalScore, s.ChoiceStats, s.ChoiceStatsVengerTable, s.ChoiceStatsVengerCharts)
+		s.TotalScore, s.ChoiceStats, s.ChoiceStatsVengerTable, s.ChoiceStatsVengerCharts, s.GamesByEndReason,
+		s.ReactionTimeStats, s.HintsByType)
 
 	return err
 }
@@ -233,7 +271,7 @@ func (p *StatisticsPostgres) GetStatistics(userId, parSetId int) (gameServer.Sta
 	                     mean_stop_without_signal, stdev_stop_without_signal, mean_hint_on_signal, stdev_hint_on_signal,
 						 mean_hint_without_signal, stdev_hint_without_signal, mean_continue_after_signal, stdev_continue_after_signal,
 						 stop_on_signal_num, stop_without_signal_num, hint_on_signal_num, hint_without_signal_num, continue_after_signal_num,
-						 total_score, choice_stats, choice_stats_venger_table, choice_stats_venger_charts
+						 total_score, choice_stats, choice_stats_venger_table, choice_stats_venger_charts, games_by_end_reason, reaction_time_stats, hints_by_type
 						 FROM %s WHERE user_id=$1 AND parameter_set_id=$2`, statisticsTable)
 
 	err := p.db.Get(&stats, query, userId, parSetId)
@@ -253,3 +291,25 @@ func (p *StatisticsPostgres) GetTotalScore(input gameServer.ComputeStatisticsInp
 
 	return totalScore, nil
 }
+
+// GetGroupStatistics returns the players of the group with their stored
+// statistics of the parameter set, if any.
+func (p *StatisticsPostgres) GetGroupStatistics(groupId, parSetId int) ([]gameServer.ParticipantStatistics, error) {
+	participants := make([]gameServer.ParticipantStatistics, 0)
+
+	query := fmt.Sprintf(`
+				SELECT u.user_id, u.name, s.games_num, s.total_score, s.stop_on_signal_num, s.mean_stop_on_signal,
+				       s.stop_without_signal_num, s.mean_stop_without_signal, s.hint_on_signal_num, s.hint_without_signal_num,
+				       s.continue_after_signal_num
+				FROM %s ug
+				JOIN %s u ON u.user_id = ug.user_id
+				LEFT JOIN %s s ON s.user_id = u.user_id AND s.parameter_set_id = $2
+				WHERE ug.group_id = $1
+				AND u.role = $3
+				ORDER BY u.user_id
+			`, userGroupsTable, usersTable, statisticsTable)
+
+	err := p.db.Select(&participants, query, groupId, parSetId, gameServer.RoleUser)
+
+	return participants, err
+}
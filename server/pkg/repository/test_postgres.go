@@ -1,12 +1,16 @@
 package repository
 
 import (
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	gameServer "example.com/gameHoldTheProcessServer"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type TestPostgres struct {
@@ -20,7 +24,7 @@ func NewTestPostgres(db *sqlx.DB) *TestPostgres {
 func (t *TestPostgres) GetAllTests() ([]gameServer.Test, error) {
 	var tests []gameServer.Test
 	query := fmt.Sprintf(
-		"SELECT id, slug, title, description, config, is_active, sort_order, created_at, updated_at FROM %s ORDER BY sort_order, id",
+		"SELECT id, slug, type, title, description, config, version, is_active, retake_policy, sort_order, min_duration_seconds, created_at, updated_at FROM %s ORDER BY sort_order, id",
 		testsTable,
 	)
 	err := t.db.Select(&tests, query)
@@ -30,7 +34,7 @@ func (t *TestPostgres) GetAllTests() ([]gameServer.Test, error) {
 func (t *TestPostgres) GetActiveTests() ([]gameServer.Test, error) {
 	var tests []gameServer.Test
 	query := fmt.Sprintf(
-		"SELECT id, slug, title, description, config, is_active, sort_order, created_at, updated_at FROM %s WHERE is_active=true ORDER BY sort_order, id",
+		"SELECT id, slug, type, title, description, config, version, is_active, retake_policy, sort_order, min_duration_seconds, created_at, updated_at FROM %s WHERE is_active=true ORDER BY sort_order, id",
 		testsTable,
 	)
 	err := t.db.Select(&tests, query)
@@ -40,7 +44,7 @@ func (t *TestPostgres) GetActiveTests() ([]gameServer.Test, error) {
 func (t *TestPostgres) GetOneTest(id int) (gameServer.Test, error) {
 	var test gameServer.Test
 	query := fmt.Sprintf(
-		"SELECT id, slug, title, description, config, is_active, sort_order, created_at, updated_at FROM %s WHERE id=$1",
+		"SELECT id, slug, type, title, description, config, version, is_active, retake_policy, sort_order, min_duration_seconds, created_at, updated_at FROM %s WHERE id=$1",
 		testsTable,
 	)
 	err := t.db.Get(&test, query, id)
@@ -48,17 +52,77 @@ func (t *TestPostgres) GetOneTest(id int) (gameServer.Test, error) {
 }
 
 func (t *TestPostgres) CreateTest(input gameServer.CreateTestInput) (int, error) {
+	tx, err := t.db.Beginx()
+	if err != nil {
+		return 0, err
+	}
+
 	var id int
 	timeNow := time.Now().UTC().Add(3 * time.Hour)
 	query := fmt.Sprintf(
-		"INSERT INTO %s (slug, title, description, config, is_active, sort_order, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id",
+		"INSERT INTO %s (slug, type, title, description, config, is_active, retake_policy, sort_order, min_duration_seconds, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id",
 		testsTable,
 	)
-	err := t.db.QueryRow(query, input.Slug, input.Title, input.Description, input.Config, input.IsActive, input.SortOrder, timeNow, timeNow).Scan(&id)
-	return id, err
+	err = tx.QueryRow(query, input.Slug, input.Type, input.Title, input.Description, input.Config, input.IsActive, input.RetakePolicy, input.SortOrder, minDuration(input.MinDurationSeconds), timeNow, timeNow).Scan(&id)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	query = fmt.Sprintf("INSERT INTO %s (test_id, version, type, config, created_at) VALUES ($1, 1, $2, $3, $4)", testVersionsTable)
+	if _, err := tx.Exec(query, id, input.Type, input.Config, timeNow); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return id, tx.Commit()
 }
 
+// UpdateTest updates the test with its row locked. A change of the type or
+// config is checked against the stored test: a version that already has
+// results is kept for them and the change becomes the next version, any
+// other version is edited in place.
 func (t *TestPostgres) UpdateTest(id int, input gameServer.UpdateTestInput) error {
+	tx, err := t.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	var test gameServer.Test
+	query := fmt.Sprintf("SELECT id, type, config, version FROM %s WHERE id=$1 FOR UPDATE", testsTable)
+	if err := tx.Get(&test, query, id); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if input.Type != nil || input.Config != nil {
+		testType, config := input.TestConfig(test)
+		if err := gameServer.ValidateTestConfig(testType, config); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if !input.ChangesConfig(test) {
+			input.Type, input.Config = nil, nil
+		} else {
+			hasResults, err := hasTestVersionResults(tx, id, test.Version)
+			if err != nil {
+				tx.Rollback()
+				return err
+			}
+			if hasResults {
+				if err := createTestVersion(tx, id, testType, config); err != nil {
+					tx.Rollback()
+					return err
+				}
+				input.Type, input.Config = nil, nil
+			}
+		}
+	}
+	if input.IsEmpty() {
+		return tx.Commit()
+	}
+
 	setValues := make([]string, 0)
 	args := make([]any, 0)
 	argId := 1
@@ -68,6 +132,11 @@ func (t *TestPostgres) UpdateTest(id int, input gameServer.UpdateTestInput) erro
 		args = append(args, *input.Slug)
 		argId++
 	}
+	if input.Type != nil {
+		setValues = append(setValues, fmt.Sprintf("type=$%d", argId))
+		args = append(args, *input.Type)
+		argId++
+	}
 	if input.Title != nil {
 		setValues = append(setValues, fmt.Sprintf("title=$%d", argId))
 		args = append(args, *input.Title)
@@ -88,30 +157,86 @@ func (t *TestPostgres) UpdateTest(id int, input gameServer.UpdateTestInput) erro
 		args = append(args, *input.IsActive)
 		argId++
 	}
+	if input.RetakePolicy != nil {
+		setValues = append(setValues, fmt.Sprintf("retake_policy=$%d", argId))
+		args = append(args, *input.RetakePolicy)
+		argId++
+	}
 	if input.SortOrder != nil {
 		setValues = append(setValues, fmt.Sprintf("sort_order=$%d", argId))
 		args = append(args, *input.SortOrder)
 		argId++
 	}
+	if input.MinDurationSeconds != nil {
+		setValues = append(setValues, fmt.Sprintf("min_duration_seconds=$%d", argId))
+		args = append(args, minDuration(input.MinDurationSeconds))
+		argId++
+	}
 
 	setValues = append(setValues, fmt.Sprintf("updated_at=$%d", argId))
 	args = append(args, time.Now().UTC().Add(3*time.Hour))
 	argId++
 
-	query := fmt.Sprintf("UPDATE %s SET %s WHERE id=$%d", testsTable, strings.Join(setValues, ", "), argId)
+	query = fmt.Sprintf("UPDATE %s SET %s WHERE id=$%d", testsTable, strings.Join(setValues, ", "), argId)
 	args = append(args, id)
-	_, err := t.db.Exec(query, args...)
+	if _, err := tx.Exec(query, args...); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// The current version has no results yet, so it is edited in place.
+	if input.Type != nil || input.Config != nil {
+		query = fmt.Sprintf(
+			"UPDATE %s tv SET type=t.type, config=t.config FROM %s t WHERE t.id=$1 AND tv.test_id=t.id AND tv.version=t.version",
+			testVersionsTable,
+			testsTable,
+		)
+		if _, err := tx.Exec(query, id); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// minDuration stores a minimum duration of zero as NULL, no flagging.
+func minDuration(seconds *int) any {
+	if seconds == nil || *seconds == 0 {
+		return nil
+	}
+	return *seconds
+}
+
+// createTestVersion makes the type and config the next version of the test.
+func createTestVersion(tx *sqlx.Tx, id int, testType string, config json.RawMessage) error {
+	var version int
+	timeNow := time.Now().UTC().Add(3 * time.Hour)
+	query := fmt.Sprintf("UPDATE %s SET version=version+1, type=$1, config=$2, updated_at=$3 WHERE id=$4 RETURNING version", testsTable)
+	if err := tx.QueryRow(query, testType, config, timeNow, id).Scan(&version); err != nil {
+		return err
+	}
+
+	query = fmt.Sprintf("INSERT INTO %s (test_id, version, type, config, created_at) VALUES ($1, $2, $3, $4, $5)", testVersionsTable)
+	_, err := tx.Exec(query, id, version, testType, config, timeNow)
 	return err
 }
 
+func hasTestVersionResults(q sqlx.Queryer, id, version int) (bool, error) {
+	var exists bool
+	query := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s WHERE test_id=$1 AND test_version=$2)", testResultsTable)
+	err := sqlx.Get(q, &exists, query, id, version)
+	return exists, err
+}
+
 func (t *TestPostgres) DeleteTest(id int) error {
 	query := fmt.Sprintf("DELETE FROM %s WHERE id=$1", testsTable)
 	_, err := t.db.Exec(query, id)
 	return err
 }
 
-func (t *TestPostgres) GetCompletedTestIds(userId int) (map[int]bool, error) {
-	rows, err := t.db.Queryx(fmt.Sprintf("SELECT test_id FROM %s WHERE user_id=$1", testResultsTable), userId)
+func (t *TestPostgres) GetCompletedTestIds(userId int, context string) (map[int]bool, error) {
+	rows, err := t.db.Queryx(fmt.Sprintf("SELECT DISTINCT test_id FROM %s WHERE user_id=$1 AND context=$2", testResultsTable), userId, context)
 	if err != nil {
 		return nil, err
 	}
@@ -128,23 +253,124 @@ func (t *TestPostgres) GetCompletedTestIds(userId int) (map[int]bool, error) {
 	return completed, rows.Err()
 }
 
-func (t *TestPostgres) CreateTestResult(userId int, input gameServer.SubmitTestResultInput, score *float64) (int, error) {
-	var id int
+// CreateTestResult stores the answers as the next attempt of the test and
+// drops the saved progress of the attempt, the start of which times the
+// attempt. The row of the user is locked
+// while the retake policy is checked, so concurrent submissions of the user
+// are checked one after another, and the unique attempt number rejects any
+// that still get through.
+func (t *TestPostgres) CreateTestResult(userId int, test gameServer.Test, input gameServer.SubmitTestResultInput, score *float64, scoreDetails json.RawMessage) (int, error) {
+	var itemTimes json.RawMessage
+	if len(input.ItemTimes) > 0 {
+		var err error
+		if itemTimes, err = json.Marshal(input.ItemTimes); err != nil {
+			return 0, err
+		}
+	}
+
+	tx, err := t.db.Beginx()
+	if err != nil {
+		return 0, err
+	}
+
+	query := fmt.Sprintf("SELECT user_id FROM %s WHERE user_id=$1 FOR UPDATE", usersTable)
+	if _, err := tx.Exec(query, userId); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	attempts, err := testAttempts(tx, userId, test.Id)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	if !gameServer.RetakeAllowed(test.RetakePolicy, attempts, input.Context, input.Phase) {
+		tx.Rollback()
+		return 0, gameServer.ErrRetakeNotAllowed
+	}
+
+	// The attempt lasted from the first saved progress to the submission.
+	var durationMs *int64
+	var startedAt time.Time
 	timeNow := time.Now().UTC().Add(3 * time.Hour)
-	query := fmt.Sprintf(
-		`INSERT INTO %s (user_id, test_id, answers, score, completed_at) VALUES ($1, $2, $3, $4, $5)
-		 ON CONFLICT (user_id, test_id) DO UPDATE SET answers=EXCLUDED.answers, score=EXCLUDED.score, completed_at=EXCLUDED.completed_at
+	query = fmt.Sprintf("DELETE FROM %s WHERE user_id=$1 AND test_id=$2 AND context=$3 AND phase=$4 RETURNING started_at", testDraftsTable)
+	err = tx.Get(&startedAt, query, userId, test.Id, input.Context, input.Phase)
+	switch {
+	case err == nil:
+		duration := timeNow.Sub(startedAt).Milliseconds()
+		durationMs = &duration
+	case !errors.Is(err, sql.ErrNoRows):
+		tx.Rollback()
+		return 0, err
+	}
+
+	var id int
+	query = fmt.Sprintf(
+		`INSERT INTO %[1]s (user_id, test_id, test_version, attempt, context, phase, answers, score, score_details, item_times, duration_ms, completed_at)
+		 VALUES ($1, $2, $3, (SELECT COALESCE(MAX(attempt), 0) + 1 FROM %[1]s WHERE user_id=$1 AND test_id=$2), $4, $5, $6, $7, $8, $9, $10, $11)
 		 RETURNING id`,
 		testResultsTable,
 	)
-	err := t.db.QueryRow(query, userId, input.TestId, input.Answers, score, timeNow).Scan(&id)
-	return id, err
+	err = tx.QueryRow(query, userId, test.Id, test.Version, input.Context, input.Phase, input.Answers, score, scoreDetails, itemTimes, durationMs, timeNow).Scan(&id)
+	if err != nil {
+		tx.Rollback()
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return 0, gameServer.ErrRetakeNotAllowed
+		}
+		return 0, err
+	}
+
+	return id, tx.Commit()
+}
+
+func testAttempts(q sqlx.Queryer, userId, testId int) ([]gameServer.TestAttempt, error) {
+	var attempts []gameServer.TestAttempt
+	query := fmt.Sprintf(
+		"SELECT attempt, context, phase FROM %s WHERE user_id=$1 AND test_id=$2 ORDER BY attempt",
+		testResultsTable,
+	)
+	err := sqlx.Select(q, &attempts, query, userId, testId)
+	return attempts, err
+}
+
+// SaveTestDraft replaces the saved progress of the test in the context, the
+// start of the first save is kept.
+func (t *TestPostgres) SaveTestDraft(userId, testId, testVersion int, input gameServer.SaveTestProgressInput) error {
+	itemTimes, err := json.Marshal(input.ItemTimes)
+	if err != nil {
+		return err
+	}
+	if input.ItemTimes == nil {
+		itemTimes = json.RawMessage("{}")
+	}
+
+	timeNow := time.Now().UTC().Add(3 * time.Hour)
+	query := fmt.Sprintf(
+		`INSERT INTO %s (user_id, test_id, test_version, context, phase, answers, item_times, started_at, updated_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8)
+		 ON CONFLICT (user_id, test_id, context, phase)
+		 DO UPDATE SET test_version=EXCLUDED.test_version, answers=EXCLUDED.answers, item_times=EXCLUDED.item_times, updated_at=EXCLUDED.updated_at`,
+		testDraftsTable,
+	)
+	_, err = t.db.Exec(query, userId, testId, testVersion, input.Context, input.Phase, input.Answers, itemTimes, timeNow)
+	return err
+}
+
+func (t *TestPostgres) GetTestDrafts(userId, testId int) ([]gameServer.TestDraft, error) {
+	var drafts []gameServer.TestDraft
+	query := fmt.Sprintf(
+		"SELECT test_id, test_version, context, phase, answers, item_times, started_at, updated_at FROM %s WHERE user_id=$1 AND test_id=$2 ORDER BY updated_at DESC",
+		testDraftsTable,
+	)
+	err := t.db.Select(&drafts, query, userId, testId)
+	return drafts, err
 }
 
 func (t *TestPostgres) GetUserResults(userId int) ([]gameServer.TestResult, error) {
 	var results []gameServer.TestResult
 	query := fmt.Sprintf(
-		"SELECT id, user_id, test_id, answers, score, completed_at FROM %s WHERE user_id=$1 ORDER BY completed_at",
+		"SELECT id, user_id, test_id, test_version, attempt, context, phase, answers, score, score_details, item_times, duration_ms, completed_at FROM %s WHERE user_id=$1 ORDER BY completed_at, id",
 		testResultsTable,
 	)
 	err := t.db.Select(&results, query, userId)
@@ -154,15 +380,91 @@ func (t *TestPostgres) GetUserResults(userId int) ([]gameServer.TestResult, erro
 func (t *TestPostgres) GetUserResultsWithTests(userId int) ([]gameServer.TestResultWithTest, error) {
 	var results []gameServer.TestResultWithTest
 	query := fmt.Sprintf(
-		`SELECT tr.id, tr.user_id, tr.test_id, tr.answers, tr.score, tr.completed_at,
-		        t.slug, t.title, t.description, t.config
+		`SELECT tr.id, tr.user_id, tr.test_id, tr.test_version, tr.attempt, tr.context, tr.phase, tr.answers, tr.score, tr.score_details,
+		        tr.item_times, tr.duration_ms, tr.completed_at,
+		        t.slug, tv.type, t.title, t.description, tv.config,
+		        COALESCE(tr.duration_ms < t.min_duration_seconds * 1000, false) AS speeder
 		 FROM %s tr
 		 INNER JOIN %s t ON t.id = tr.test_id
+		 INNER JOIN %s tv ON tv.test_id = tr.test_id AND tv.version = tr.test_version
 		 WHERE tr.user_id=$1
 		 ORDER BY tr.completed_at, tr.id`,
 		testResultsTable,
 		testsTable,
+		testVersionsTable,
 	)
 	err := t.db.Select(&results, query, userId)
 	return results, err
 }
+
+func (t *TestPostgres) GetTestVersion(testId, version int) (gameServer.TestVersion, error) {
+	var testVersion gameServer.TestVersion
+	query := fmt.Sprintf("SELECT test_id, version, type, config FROM %s WHERE test_id=$1 AND version=$2", testVersionsTable)
+	err := t.db.Get(&testVersion, query, testId, version)
+	return testVersion, err
+}
+
+// GetReportAnswers returns the answers of the results of the test version
+// that pass the filters of the report.
+func (t *TestPostgres) GetReportAnswers(testId, version int, input gameServer.TestReportInput) ([]json.RawMessage, error) {
+	conditions := []string{"tr.test_id=$1", "tr.test_version=$2"}
+	args := []any{testId, version}
+	argId := 3
+
+	if input.GroupId != nil {
+		conditions = append(conditions, fmt.Sprintf("tr.user_id IN (SELECT user_id FROM %s WHERE group_id=$%d)", userGroupsTable, argId))
+		args = append(args, *input.GroupId)
+		argId++
+	}
+	if input.ParSetId != nil {
+		conditions = append(conditions, fmt.Sprintf("tr.user_id IN (SELECT user_id FROM %s WHERE parameter_set_id=$%d)", userParameterSetsTable, argId))
+		args = append(args, *input.ParSetId)
+		argId++
+	}
+	if input.CreatorId != nil {
+		conditions = append(conditions, fmt.Sprintf(
+			"tr.user_id IN (SELECT ug.user_id FROM %s ug JOIN %s g ON g.id=ug.group_id WHERE g.creator_id=$%d)",
+			userGroupsTable,
+			groupsTable,
+			argId,
+		))
+		args = append(args, *input.CreatorId)
+		argId++
+	}
+	if input.Context != "" {
+		conditions = append(conditions, fmt.Sprintf("tr.context=$%d", argId))
+		args = append(args, input.Context)
+		argId++
+	}
+	if input.Phase != "" {
+		conditions = append(conditions, fmt.Sprintf("tr.phase=$%d", argId))
+		args = append(args, input.Phase)
+	}
+
+	var answers []json.RawMessage
+	query := fmt.Sprintf("SELECT tr.answers FROM %s tr WHERE %s ORDER BY tr.id", testResultsTable, strings.Join(conditions, " AND "))
+	err := t.db.Select(&answers, query, args...)
+	return answers, err
+}
+
+// GetGroupTestScores returns the latest scored attempt of every version of
+// every test by the users of the group, in the context and phase when they
+// are set.
+func (t *TestPostgres) GetGroupTestScores(groupId int, context, phase string) ([]gameServer.ParticipantTestScore, error) {
+	var scores []gameServer.ParticipantTestScore
+	query := fmt.Sprintf(
+		`SELECT DISTINCT ON (tr.user_id, tr.test_id, tr.test_version) tr.user_id, tr.test_id, tr.test_version, t.slug, t.title, tr.score, tr.score_details
+		 FROM %s tr
+		 INNER JOIN %s t ON t.id = tr.test_id
+		 WHERE tr.user_id IN (SELECT user_id FROM %s WHERE group_id=$1)
+		 AND tr.score_details IS NOT NULL
+		 AND ($2 = '' OR tr.context = $2)
+		 AND ($3 = '' OR tr.phase = $3)
+		 ORDER BY tr.user_id, tr.test_id, tr.test_version, tr.completed_at DESC, tr.id DESC`,
+		testResultsTable,
+		testsTable,
+		userGroupsTable,
+	)
+	err := t.db.Select(&scores, query, groupId, context, phase)
+	return scores, err
+}
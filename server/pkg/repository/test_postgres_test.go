@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"encoding/json"
+	"testing"
+
+	gameServer "example.com/gameHoldTheProcessServer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestPostgres_CreateTestResult(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewTestPostgres(db)
+
+	// The admin created by the first migration takes the test.
+	userId := 1
+	testId, err := repo.CreateTest(gameServer.CreateTestInput{
+		Slug:         "about",
+		Type:         gameServer.TestTypeText,
+		Title:        "About",
+		Config:       json.RawMessage(`{"questions": [{"id": "q1", "text": "Who are you?"}]}`),
+		IsActive:     true,
+		RetakePolicy: gameServer.RetakeNever,
+	})
+	require.NoError(t, err)
+	test, err := repo.GetOneTest(testId)
+	require.NoError(t, err)
+
+	answers := json.RawMessage(`{"q1": "a player"}`)
+	err = repo.SaveTestDraft(userId, testId, test.Version, gameServer.SaveTestProgressInput{
+		Answers: answers,
+		Context: gameServer.TestContextOnboarding,
+	})
+	require.NoError(t, err)
+
+	input := gameServer.SubmitTestResultInput{
+		TestId:  testId,
+		Answers: answers,
+		Context: gameServer.TestContextOnboarding,
+	}
+	id, err := repo.CreateTestResult(userId, test, input, nil, nil)
+	require.NoError(t, err)
+	assert.NotZero(t, id)
+
+	var result struct {
+		Attempt    int    `db:"attempt"`
+		DurationMs *int64 `db:"duration_ms"`
+	}
+	err = db.Get(&result, "SELECT attempt, duration_ms FROM test_results WHERE id=$1", id)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Attempt)
+	assert.NotNil(t, result.DurationMs)
+
+	var drafts int
+	err = db.Get(&drafts, "SELECT COUNT(*) FROM test_drafts WHERE user_id=$1 AND test_id=$2", userId, testId)
+	require.NoError(t, err)
+	assert.Zero(t, drafts)
+
+	_, err = repo.CreateTestResult(userId, test, input, nil, nil)
+	assert.ErrorIs(t, err, gameServer.ErrRetakeNotAllowed)
+}
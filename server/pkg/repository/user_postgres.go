@@ -32,7 +32,7 @@ func (u *UserPostgres) CreateUser(input gameServer.RegisterUserInput) (int, erro
 			return 0, err
 		}
 	} else {
-		query := fmt.Sprintf("SELECT id FROM %s LIMIT 1", parameterSetsTable)
+		query := fmt.Sprintf("SELECT id FROM %s WHERE archived_at IS NULL ORDER BY id LIMIT 1", parameterSetsTable)
 		row := tx.QueryRow(query)
 		if err := row.Scan(&parSetId); err != nil {
 			tx.Rollback()
@@ -249,17 +249,46 @@ func (u *UserPostgres) GetAllGroups() ([]gameServer.Group, error) {
 	return groups, err
 }
 
+func (u *UserPostgres) GetOneGroup(id int) (gameServer.Group, error) {
+	var group gameServer.Group
+	query := fmt.Sprintf("SELECT id, name, created_at, creator_id, parameter_set_id FROM %s WHERE id=$1", groupsTable)
+
+	err := u.db.Get(&group, query, id)
+
+	return group, err
+}
+
+func (u *UserPostgres) GetUserGroupIds(userId int) ([]int, error) {
+	groupIds := make([]int, 0)
+	query := fmt.Sprintf("SELECT group_id FROM %s WHERE user_id=$1", userGroupsTable)
+
+	err := u.db.Select(&groupIds, query, userId)
+
+	return groupIds, err
+}
+
 func (u *UserPostgres) CreateGroup(input gameServer.CreateGroupInput) (int, error) {
+	tx, err := u.db.Beginx()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := lockActiveParSet(tx, input.ParSetId); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
 	var id int
 	query := fmt.Sprintf("INSERT INTO %s (name, creator_id, parameter_set_id, created_at) VALUES ($1, $2, $3, $4) RETURNING id", groupsTable)
 
 	timeNow := time.Now().UTC().Add(3 * time.Hour)
-	row := u.db.QueryRow(query, input.Name, input.CreatorId, input.ParSetId, timeNow)
+	row := tx.QueryRow(query, input.Name, input.CreatorId, input.ParSetId, timeNow)
 	if err := row.Scan(&id); err != nil {
+		tx.Rollback()
 		return 0, err
 	}
 
-	return id, nil
+	return id, tx.Commit()
 }
 
 func (u *UserPostgres) GetPlayersStat(input gameServer.GetPlayersStatInput) ([]gameServer.PlayerStat, error) {
@@ -361,7 +390,7 @@ func (u *UserPostgres) GetPlayersPointsWithEvents(input gameServer.GetPlayersEve
 	case "stop":
 		{
 			query = fmt.Sprintf(`
-				SELECT y, score, is_crash, is_useful_ai_signal, is_deceptive_ai_signal, is_stop, is_pause, is_check, chart_id, check_info
+				SELECT y, score, is_crash, is_useful_ai_signal, is_deceptive_ai_signal, is_stop, is_pause, is_check, chart_id, hint_type, risk_level, crash_probability
 				FROM %s
 				WHERE chart_id IN ( 
 					SELECT id
@@ -378,7 +407,7 @@ func (u *UserPostgres) GetPlayersPointsWithEvents(input gameServer.GetPlayersEve
 	case "pause":
 		{
 			query = fmt.Sprintf(`
-				SELECT y, score, is_crash, is_useful_ai_signal, is_deceptive_ai_signal, is_stop, is_pause, is_check, chart_id, check_info
+				SELECT y, score, is_crash, is_useful_ai_signal, is_deceptive_ai_signal, is_stop, is_pause, is_check, chart_id, hint_type, risk_level, crash_probability
 				FROM %s
 				WHERE chart_id IN ( 
 					SELECT id
@@ -395,7 +424,7 @@ func (u *UserPostgres) GetPlayersPointsWithEvents(input gameServer.GetPlayersEve
 	case "check":
 		{
 			query = fmt.Sprintf(`
-				SELECT y, score, is_crash, is_useful_ai_signal, is_deceptive_ai_signal, is_stop, is_pause, is_check, chart_id, check_info
+				SELECT y, score, is_crash, is_useful_ai_signal, is_deceptive_ai_signal, is_stop, is_pause, is_check, chart_id, hint_type, risk_level, crash_probability
 				FROM %s
 				WHERE chart_id IN ( 
 					SELECT id
@@ -412,7 +441,7 @@ func (u *UserPostgres) GetPlayersPointsWithEvents(input gameServer.GetPlayersEve
 	case "reject_advice":
 		{
 			query = fmt.Sprintf(`
-				SELECT y, score, is_crash, is_useful_ai_signal, is_deceptive_ai_signal, is_stop, is_pause, is_check, chart_id, check_info
+				SELECT y, score, is_crash, is_useful_ai_signal, is_deceptive_ai_signal, is_stop, is_pause, is_check, chart_id, hint_type, risk_level, crash_probability
 				FROM %s
 				WHERE chart_id IN ( 
 					SELECT id
@@ -430,7 +459,7 @@ func (u *UserPostgres) GetPlayersPointsWithEvents(input gameServer.GetPlayersEve
 	default:
 		{
 			query = fmt.Sprintf(`
-				SELECT y, score, is_crash, is_useful_ai_signal, is_deceptive_ai_signal, is_stop, is_pause, is_check, chart_id, check_info
+				SELECT y, score, is_crash, is_useful_ai_signal, is_deceptive_ai_signal, is_stop, is_pause, is_check, chart_id, hint_type, risk_level, crash_probability
 				FROM %s
 				WHERE chart_id IN ( 
 					SELECT id
@@ -548,6 +577,11 @@ func (u *UserPostgres) UpdateUserParSet(id int, input gameServer.UpdateUserParSe
 		return err
 	}
 
+	if err := lockActiveParSet(tx, input.ParSetId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	query := fmt.Sprintf("UPDATE %s SET cur_par_set_id = $1 WHERE user_id=$2", usersTable)
 	_, err = tx.Exec(query, input.ParSetId, id)
 	if err != nil {
@@ -604,6 +638,11 @@ func (u *UserPostgres) ChangeGroupParSet(input gameServer.ChangeGroupParSetInput
 		return err
 	}
 
+	if err := lockActiveParSet(tx, input.ParSetId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	query := fmt.Sprintf("UPDATE %s SET parameter_set_id=$1 WHERE id=$2", groupsTable)
 	_, err = tx.Exec(query, input.ParSetId, input.GroupId)
 	if err != nil {
@@ -731,3 +770,21 @@ func (u *UserPostgres) GetCharts(start, end int) (map[int]float64, error) {
 
 	return results, nil
 }
+
+func (u *UserPostgres) IsUserInCreatorGroups(creatorId, userId int) (bool, error) {
+	var exists bool
+	query := fmt.Sprintf(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM %s AS gt
+			JOIN %s AS ugt ON gt.id=ugt.group_id
+			WHERE gt.creator_id=$1 AND ugt.user_id=$2
+		)`, groupsTable, userGroupsTable)
+
+	row := u.db.QueryRow(query, creatorId, userId)
+	if err := row.Scan(&exists); err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"encoding/json"
+	"math/rand/v2"
+	"slices"
+
+	gameServer "example.com/gameHoldTheProcessServer"
+	"example.com/gameHoldTheProcessServer/pkg/lib"
+	"example.com/gameHoldTheProcessServer/pkg/repository"
+)
+
+type AdvisorService struct {
+	repo         repository.Chart
+	scenarioRepo repository.Scenario
+}
+
+func NewAdvisorService(repo repository.Chart, scenarioRepo repository.Scenario) *AdvisorService {
+	return &AdvisorService{repo: repo, scenarioRepo: scenarioRepo}
+}
+
+// GetAdvice decides the AI signal for a step of the game and stores it, so
+// the points sent later are checked against the advice actually given. The
+// danger is taken from the scenario of the game if it has one.
+func (s *AdvisorService) GetAdvice(chartId int, input gameServer.GetAdviceInput) (gameServer.Advice, error) {
+	chart, err := s.repo.GetOneChart(chartId)
+	if err != nil {
+		return gameServer.Advice{}, err
+	}
+
+	parSet, err := s.repo.GetOneParSet(chart.ParameterSetId)
+	if err != nil {
+		return gameServer.Advice{}, err
+	}
+
+	config, err := gameServer.ParseAdvisorConfig(parSet.AdvisorConfig)
+	if err != nil {
+		return gameServer.Advice{}, err
+	}
+	advisor := newAdvisor(config, parSet.FalseWarningProb, parSet.MissingDangerProb)
+
+	state := lib.AdvisorState{
+		Step:          int(input.X),
+		Y:             float64(input.Y),
+		CriticalValue: criticalValue,
+		IsDanger:      float64(input.NextY) >= criticalValue,
+	}
+
+	var signal *bool
+	if chart.ScenarioId != nil {
+		scenario, err := s.scenarioRepo.GetOneScenario(*chart.ScenarioId)
+		if err != nil {
+			return gameServer.Advice{}, err
+		}
+		signal, err = scenarioAdvice(scenario, &state)
+		if err != nil {
+			return gameServer.Advice{}, err
+		}
+	}
+	if signal == nil {
+		// Seeding by chart and step makes a repeated request get the same advice
+		rng := rand.New(rand.NewPCG(uint64(chartId), uint64(state.Step)))
+		falseAlarmLevel := float64(parSet.FalseAlarmThreshold) * criticalValue
+		advise := (state.IsDanger || state.Y >= falseAlarmLevel) && lib.Advise(advisor, state, rng)
+		signal = &advise
+	}
+
+	advice := gameServer.Advice{
+		X:        input.X,
+		Signal:   *signal,
+		IsDanger: state.IsDanger,
+	}
+	if config.ShowConfidence {
+		confidence := advisor.Confidence(state)
+		advice.Confidence = &confidence
+	}
+
+	return s.repo.SaveAdvice(chartId, advice)
+}
+
+// scenarioAdvice replays the advice of the scenario at the step of state and
+// fills the state with the values of the scenario. It returns nil once the
+// game has gone past the end of the scenario.
+func scenarioAdvice(scenario gameServer.Scenario, state *lib.AdvisorState) (*bool, error) {
+	var trajectory []float64
+	if err := json.Unmarshal(scenario.Trajectory, &trajectory); err != nil {
+		return nil, err
+	}
+	if state.Step < 0 || state.Step+1 >= len(trajectory) {
+		return nil, nil
+	}
+
+	var aiSignals []int
+	if err := json.Unmarshal(scenario.AiSignals, &aiSignals); err != nil {
+		return nil, err
+	}
+
+	state.Y = trajectory[state.Step]
+	state.IsDanger = trajectory[state.Step+1] >= criticalValue
+	signal := slices.Contains(aiSignals, state.Step)
+	return &signal, nil
+}
+
+// checkPointAdvice makes the AI signal flags of the points agree with the
+// advice the server gave: a signal the server did not give is dropped, and
+// whether it was useful or deceptive is decided by the stored danger.
+func checkPointAdvice(repo repository.Chart, chartId int, points []gameServer.Point) error {
+	if !slices.ContainsFunc(points, func(point gameServer.Point) bool {
+		return point.IsUsefulAiSignal || point.IsDeceptiveAiSignal
+	}) {
+		return nil
+	}
+
+	given, err := repo.GetChartAdvice(chartId)
+	if err != nil {
+		return err
+	}
+	adviceByX := make(map[float32]gameServer.Advice, len(given))
+	for _, advice := range given {
+		adviceByX[advice.X] = advice
+	}
+
+	for i := range points {
+		point := &points[i]
+		if !point.IsUsefulAiSignal && !point.IsDeceptiveAiSignal {
+			continue
+		}
+		advice, ok := adviceByX[point.X]
+		if !ok || !advice.Signal {
+			point.IsUsefulAiSignal = false
+			point.IsDeceptiveAiSignal = false
+			point.AiConfidence = nil
+			continue
+		}
+		point.IsUsefulAiSignal = advice.IsDanger
+		point.IsDeceptiveAiSignal = !advice.IsDanger
+		point.AiConfidence = advice.Confidence
+	}
+
+	return nil
+}
+
+func newAdvisor(config gameServer.AdvisorConfig, falseWarningProb, missingDangerProb float32) lib.Advisor {
+	switch config.Model {
+	case gameServer.AdvisorModelDrifting:
+		return lib.DriftingAdvisor{
+			Start: config.StartReliability,
+			End:   config.EndReliability,
+			Steps: config.DriftSteps,
+		}
+	case gameServer.AdvisorModelProximity:
+		return lib.ProximityAdvisor{
+			Near:  config.NearReliability,
+			Far:   config.FarReliability,
+			Range: config.ProximityRange,
+		}
+	case gameServer.AdvisorModelScripted:
+		return lib.ScriptedAdvisor{Schedule: config.Schedule}
+	default:
+		return lib.BernoulliAdvisor{
+			FalseWarningProb:  float64(falseWarningProb),
+			MissingDangerProb: float64(missingDangerProb),
+		}
+	}
+}
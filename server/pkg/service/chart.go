@@ -1,22 +1,121 @@
 package service
 
 import (
+	"database/sql"
+	"errors"
 	"math"
+	"time"
 
 	gameServer "example.com/gameHoldTheProcessServer"
 	"example.com/gameHoldTheProcessServer/pkg/repository"
 )
 
 type ChartService struct {
-	repo repository.Chart
+	repo         repository.Chart
+	scenarioRepo repository.Scenario
+	hub          *LiveHub
 }
 
-func NewChartService(repo repository.Chart) *ChartService {
-	return &ChartService{repo: repo}
+func NewChartService(repo repository.Chart, scenarioRepo repository.Scenario, hub *LiveHub) *ChartService {
+	return &ChartService{repo: repo, scenarioRepo: scenarioRepo, hub: hub}
+}
+
+// checkScenario makes sure that the scenario sent by the client was generated
+// for the parameter set of the game.
+func (s *ChartService) checkScenario(chart gameServer.CreateChartInput) error {
+	if chart.ScenarioId == nil {
+		return nil
+	}
+
+	scenario, err := s.scenarioRepo.GetOneScenario(*chart.ScenarioId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return gameServer.ErrScenarioMismatch
+	}
+	if err != nil {
+		return err
+	}
+	if scenario.ParameterSetId != chart.ParameterSetId {
+		return gameServer.ErrScenarioMismatch
+	}
+	return nil
 }
 
 func (s *ChartService) CreateChart(chart gameServer.CreateChartInput) (int, error) {
-	return s.repo.CreateChart(chart)
+	if err := s.checkScenario(chart); err != nil {
+		return 0, err
+	}
+
+	id, err := s.repo.CreateChart(chart)
+	if err != nil {
+		return 0, err
+	}
+
+	s.hub.Publish(gameServer.LiveEvent{
+		Type:       gameServer.LiveEventChartCreated,
+		UserId:     chart.UserId,
+		ChartId:    id,
+		ParSetId:   chart.ParameterSetId,
+		IsTraining: chart.IsTraining,
+	})
+
+	return id, nil
+}
+
+func (s *ChartService) OpenChart(chart gameServer.CreateChartInput) (int, error) {
+	if err := s.checkScenario(chart); err != nil {
+		return 0, err
+	}
+
+	id, err := s.repo.OpenChart(chart)
+	if err != nil {
+		return 0, err
+	}
+
+	s.hub.Publish(gameServer.LiveEvent{
+		Type:       gameServer.LiveEventChartCreated,
+		UserId:     chart.UserId,
+		ChartId:    id,
+		ParSetId:   chart.ParameterSetId,
+		IsTraining: chart.IsTraining,
+	})
+
+	return id, nil
+}
+
+func (s *ChartService) AppendPoints(chartId int, input gameServer.AppendChartPointsInput) ([]int, error) {
+	if err := checkPointHints(s.repo, chartId, input.Points); err != nil {
+		return nil, err
+	}
+	if err := checkPointAdvice(s.repo, chartId, input.Points); err != nil {
+		return nil, err
+	}
+
+	ids, err := s.repo.AppendPoints(chartId, input.Points)
+	if err != nil {
+		return nil, err
+	}
+
+	chart, err := s.repo.GetOneChart(chartId)
+	if err != nil {
+		return ids, nil
+	}
+	for _, point := range input.Points {
+		for _, event := range pointLiveEvents(point, chart) {
+			s.hub.Publish(event)
+		}
+	}
+
+	return ids, nil
+}
+
+func (s *ChartService) CloseChart(chartId int, input gameServer.CloseChartInput) error {
+	return s.repo.CloseChart(chartId, input.EndReason)
+}
+
+// AbandonStaleCharts closes the games whose client stopped sending points,
+// e.g. after a closed tab or a lost connection.
+func (s *ChartService) AbandonStaleCharts(timeout time.Duration) (int, error) {
+	return s.repo.AbandonStaleCharts(time.Now().UTC().Add(3 * time.Hour).Add(-timeout))
 }
 
 func (s *ChartService) GetOneChart(id int) (gameServer.Chart, error) {
@@ -53,7 +152,7 @@ func (s *ChartService) DeleteChart(id int) error {
 	return s.repo.DeleteChart(id)
 }
 
-func (s *ChartService) GetAllParSets(input gameServer.GetAllParSetsInput) ([]gameServer.ParameterSet, error) {
+func (s *ChartService) GetAllParSets(input gameServer.GetAllParSetsInput) ([]gameServer.ParameterSetWithUsage, error) {
 	return s.repo.GetAllParSets(input)
 }
 
@@ -71,3 +170,44 @@ func (s *ChartService) CreateParSet(input gameServer.CreateParSetInput) (int, er
 	input.ApplyDefaults()
 	return s.repo.CreateParSet(input)
 }
+
+// CreateParSetVersion stores an edited parameter set as a new version, the
+// parent stays unchanged for the charts already played with it.
+func (s *ChartService) CreateParSetVersion(parentId int, input gameServer.CreateParSetInput) (int, error) {
+	if _, err := s.repo.GetOneParSet(parentId); err != nil {
+		return 0, err
+	}
+
+	input.ApplyDefaults()
+	input.ParentId = &parentId
+	return s.repo.CreateParSet(input)
+}
+
+func (s *ChartService) GetOneParSet(id int) (gameServer.ParameterSet, error) {
+	return s.repo.GetOneParSet(id)
+}
+
+// UpdateParSet changes a parameter set in place, the game parameters can only
+// change while no games have been played with it.
+func (s *ChartService) UpdateParSet(id int, input gameServer.CreateParSetInput) error {
+	input.ApplyDefaults()
+	return s.repo.UpdateParSet(id, input)
+}
+
+func (s *ChartService) ArchiveParSet(id int) error {
+	return s.repo.ArchiveParSet(id)
+}
+
+func (s *ChartService) DeleteParSet(id int) error {
+	return s.repo.DeleteParSet(id)
+}
+
+// PreviewParSet simulates games with the parameter set before it is used in
+// a study, the same seed gives the same preview.
+func (s *ChartService) PreviewParSet(input gameServer.PreviewParSetInput) (gameServer.ParSetPreview, error) {
+	seed := time.Now().UnixNano()
+	if input.Seed != nil {
+		seed = *input.Seed
+	}
+	return previewParSet(input, seed)
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"math"
+	"slices"
+
+	gameServer "example.com/gameHoldTheProcessServer"
+	"example.com/gameHoldTheProcessServer/pkg/lib"
+	"example.com/gameHoldTheProcessServer/pkg/repository"
+)
+
+const (
+	// Критическое значение процесса и управляющее воздействие, как в клиенте
+	criticalValue = 1.0
+	controlSignal = 1.0
+	// Запас до критического значения (в СКО шума) для уровней риска
+	lowRiskMinMargin    = 2.5
+	mediumRiskMinMargin = 2.0
+)
+
+type HintService struct {
+	repo repository.Chart
+}
+
+func NewHintService(repo repository.Chart) *HintService {
+	return &HintService{repo: repo}
+}
+
+func (s *HintService) GetCrashHint(chartId int, input gameServer.GetHintInput) (gameServer.Hint, error) {
+	chart, err := s.repo.GetOneChart(chartId)
+	if err != nil {
+		return gameServer.Hint{}, err
+	}
+
+	parSet, err := s.repo.GetOneParSet(chart.ParameterSetId)
+	if err != nil {
+		return gameServer.Hint{}, err
+	}
+
+	options, err := gameServer.ParseHintConfig(parSet.HintConfig)
+	if err != nil {
+		return gameServer.Hint{}, err
+	}
+	idx := slices.IndexFunc(options, func(option gameServer.HintOption) bool {
+		return option.Type == input.Type
+	})
+	if idx < 0 {
+		return gameServer.Hint{}, gameServer.ErrHintNotAvailable
+	}
+	option := options[idx]
+
+	hint := gameServer.Hint{
+		ChartId:    chartId,
+		Type:       option.Type,
+		Disclosure: option.Disclosure,
+		X:          input.X,
+		Y:          input.Y,
+		Cost:       option.Cost,
+	}
+
+	crashProb, riskLevel := crashRisk(parSet, float64(input.Y))
+	switch option.Disclosure {
+	case gameServer.HintDisclosureExactProbability:
+		hint.CrashProbability = &crashProb
+		hint.RiskLevel = &riskLevel
+	case gameServer.HintDisclosureRiskBand:
+		hint.RiskLevel = &riskLevel
+	}
+
+	hint.Id, err = s.repo.CreateHint(hint)
+	if err != nil {
+		return gameServer.Hint{}, err
+	}
+
+	return hint, nil
+}
+
+// crashRisk returns the probability that the next value of the process
+// y' = A*y + B*U + noise reaches the critical value, and its risk level.
+func crashRisk(parSet gameServer.ParameterSet, y float64) (float64, string) {
+	knownPart := float64(parSet.A)*y + float64(parSet.B)*controlSignal
+	noiseMean := float64(parSet.NoiseMean)
+	noiseStDev := float64(parSet.NoiseStDev)
+
+	crashProb := 1 - lib.NormalCDF(criticalValue-knownPart, noiseMean, noiseStDev)
+
+	margin := math.Inf(1)
+	if criticalValue-knownPart-noiseMean <= 0 {
+		margin = math.Inf(-1)
+	}
+	if noiseStDev > 0 {
+		margin = (criticalValue - knownPart - noiseMean) / noiseStDev
+	}
+
+	switch {
+	case margin >= lowRiskMinMargin:
+		return crashProb, gameServer.RiskLevelLow
+	case margin >= mediumRiskMinMargin:
+		return crashProb, gameServer.RiskLevelMedium
+	default:
+		return crashProb, gameServer.RiskLevelHigh
+	}
+}
+
+// checkPointHints makes sure that the points only record the hints that the
+// parameter set of the chart offers.
+func checkPointHints(repo repository.Chart, chartId int, points []gameServer.Point) error {
+	if !slices.ContainsFunc(points, func(point gameServer.Point) bool { return point.HintType != nil }) {
+		return nil
+	}
+
+	chart, err := repo.GetOneChart(chartId)
+	if err != nil {
+		return err
+	}
+
+	parSet, err := repo.GetOneParSet(chart.ParameterSetId)
+	if err != nil {
+		return err
+	}
+
+	options, err := gameServer.ParseHintConfig(parSet.HintConfig)
+	if err != nil {
+		return err
+	}
+	for _, point := range points {
+		if point.HintType == nil {
+			continue
+		}
+		if !slices.ContainsFunc(options, func(option gameServer.HintOption) bool { return option.Type == *point.HintType }) {
+			return gameServer.ErrHintNotAvailable
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	gameServer "example.com/gameHoldTheProcessServer"
+	"example.com/gameHoldTheProcessServer/pkg/repository"
+	"github.com/sirupsen/logrus"
+)
+
+const liveSubscriberBuffer = 64
+
+type liveSubscriber struct {
+	groupId int
+	events  chan gameServer.LiveEvent
+}
+
+// LiveHub fans game events out to researchers watching a lab session. The
+// groups of a participant are looked up for every event, so that the users
+// who join a group while it is watched show up in the stream.
+type LiveHub struct {
+	repo        repository.User
+	mu          sync.RWMutex
+	subscribers map[*liveSubscriber]struct{}
+}
+
+func NewLiveHub(repo repository.User) *LiveHub {
+	return &LiveHub{repo: repo, subscribers: make(map[*liveSubscriber]struct{})}
+}
+
+func (h *LiveHub) Subscribe(groupId int) (<-chan gameServer.LiveEvent, func()) {
+	sub := &liveSubscriber{
+		groupId: groupId,
+		events:  make(chan gameServer.LiveEvent, liveSubscriberBuffer),
+	}
+
+	h.mu.Lock()
+	h.subscribers[sub] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			delete(h.subscribers, sub)
+			close(sub.events)
+			h.mu.Unlock()
+		})
+	}
+
+	return sub.events, unsubscribe
+}
+
+// Publish never blocks: a slow subscriber misses events instead of stalling
+// the participant's request.
+func (h *LiveHub) Publish(event gameServer.LiveEvent) {
+	if event.CreatedAt == "" {
+		event.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	h.mu.RLock()
+	watched := len(h.subscribers) > 0
+	h.mu.RUnlock()
+	if !watched {
+		return
+	}
+
+	groupIds, err := h.repo.GetUserGroupIds(event.UserId)
+	if err != nil {
+		logrus.Errorf("live event of user %d: %s", event.UserId, err.Error())
+		return
+	}
+	groups := make(map[int]bool, len(groupIds))
+	for _, id := range groupIds {
+		groups[id] = true
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for sub := range h.subscribers {
+		if !groups[sub.groupId] {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+		}
+	}
+}
+
+type LiveService struct {
+	hub *LiveHub
+}
+
+func NewLiveService(hub *LiveHub) *LiveService {
+	return &LiveService{hub: hub}
+}
+
+// Subscribe listens to the members of the group, including the users who
+// join it later.
+func (s *LiveService) Subscribe(groupId int) (<-chan gameServer.LiveEvent, func()) {
+	return s.hub.Subscribe(groupId)
+}
+
+func pointLiveEvents(point gameServer.Point, chart gameServer.Chart) []gameServer.LiveEvent {
+	base := gameServer.LiveEvent{
+		UserId:     chart.UserId,
+		ChartId:    chart.Id,
+		ParSetId:   chart.ParameterSetId,
+		IsTraining: chart.IsTraining,
+		X:          point.X,
+		Y:          point.Y,
+		Score:      point.Score,
+	}
+
+	var events []gameServer.LiveEvent
+	add := func(eventType string) {
+		event := base
+		event.Type = eventType
+		events = append(events, event)
+	}
+
+	if point.IsUsefulAiSignal {
+		add(gameServer.LiveEventUsefulAiSignal)
+	}
+	if point.IsDeceptiveAiSignal {
+		add(gameServer.LiveEventDeceptiveAiSignal)
+	}
+	if point.IsCheck {
+		event := base
+		event.Type = gameServer.LiveEventHint
+		event.RiskLevel = point.RiskLevel
+		events = append(events, event)
+	}
+	if point.IsStop {
+		add(gameServer.LiveEventStop)
+	}
+	if point.IsCrash {
+		add(gameServer.LiveEventCrash)
+	}
+
+	return events
+}
@@ -7,6 +7,7 @@ package service
 import (
 	"example.com/gameHoldTheProcessServer"
 	mock "github.com/stretchr/testify/mock"
+	"time"
 )
 
 // NewMockUser creates a new instance of MockUser. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -36,6 +37,57 @@ func (_m *MockUser) EXPECT() *MockUser_Expecter {
 	return &MockUser_Expecter{mock: &_m.Mock}
 }
 
+// ChangeGroupParSet provides a mock function for the type MockUser
+func (_mock *MockUser) ChangeGroupParSet(input gameServer.ChangeGroupParSetInput) error {
+	ret := _mock.Called(input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeGroupParSet")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(gameServer.ChangeGroupParSetInput) error); ok {
+		r0 = returnFunc(input)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUser_ChangeGroupParSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeGroupParSet'
+type MockUser_ChangeGroupParSet_Call struct {
+	*mock.Call
+}
+
+// ChangeGroupParSet is a helper method to define mock.On call
+//   - input gameServer.ChangeGroupParSetInput
+func (_e *MockUser_Expecter) ChangeGroupParSet(input interface{}) *MockUser_ChangeGroupParSet_Call {
+	return &MockUser_ChangeGroupParSet_Call{Call: _e.mock.On("ChangeGroupParSet", input)}
+}
+
+func (_c *MockUser_ChangeGroupParSet_Call) Run(run func(input gameServer.ChangeGroupParSetInput)) *MockUser_ChangeGroupParSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 gameServer.ChangeGroupParSetInput
+		if args[0] != nil {
+			arg0 = args[0].(gameServer.ChangeGroupParSetInput)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUser_ChangeGroupParSet_Call) Return(err error) *MockUser_ChangeGroupParSet_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUser_ChangeGroupParSet_Call) RunAndReturn(run func(input gameServer.ChangeGroupParSetInput) error) *MockUser_ChangeGroupParSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateGroup provides a mock function for the type MockUser
 func (_mock *MockUser) CreateGroup(input gameServer.CreateGroupInput) (int, error) {
 	ret := _mock.Called(input)
@@ -207,6 +259,74 @@ func (_c *MockUser_DeleteUser_Call) RunAndReturn(run func(id int) error) *MockUs
 	return _c
 }
 
+// FixBugStat provides a mock function for the type MockUser
+func (_mock *MockUser) FixBugStat(start int, end int) (map[int]float64, error) {
+	ret := _mock.Called(start, end)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FixBugStat")
+	}
+
+	var r0 map[int]float64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, int) (map[int]float64, error)); ok {
+		return returnFunc(start, end)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int) map[int]float64); ok {
+		r0 = returnFunc(start, end)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int]float64)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = returnFunc(start, end)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUser_FixBugStat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FixBugStat'
+type MockUser_FixBugStat_Call struct {
+	*mock.Call
+}
+
+// FixBugStat is a helper method to define mock.On call
+//   - start int
+//   - end int
+func (_e *MockUser_Expecter) FixBugStat(start interface{}, end interface{}) *MockUser_FixBugStat_Call {
+	return &MockUser_FixBugStat_Call{Call: _e.mock.On("FixBugStat", start, end)}
+}
+
+func (_c *MockUser_FixBugStat_Call) Run(run func(start int, end int)) *MockUser_FixBugStat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUser_FixBugStat_Call) Return(intToFloat64 map[int]float64, err error) *MockUser_FixBugStat_Call {
+	_c.Call.Return(intToFloat64, err)
+	return _c
+}
+
+func (_c *MockUser_FixBugStat_Call) RunAndReturn(run func(start int, end int) (map[int]float64, error)) *MockUser_FixBugStat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GenerateToken provides a mock function for the type MockUser
 func (_mock *MockUser) GenerateToken(login string, password string) (string, error) {
 	ret := _mock.Called(login, password)
@@ -390,6 +510,66 @@ func (_c *MockUser_GetAllUsers_Call) RunAndReturn(run func(input gameServer.GetA
 	return _c
 }
 
+// GetOneGroup provides a mock function for the type MockUser
+func (_mock *MockUser) GetOneGroup(id int) (gameServer.Group, error) {
+	ret := _mock.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOneGroup")
+	}
+
+	var r0 gameServer.Group
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int) (gameServer.Group, error)); ok {
+		return returnFunc(id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int) gameServer.Group); ok {
+		r0 = returnFunc(id)
+	} else {
+		r0 = ret.Get(0).(gameServer.Group)
+	}
+	if returnFunc, ok := ret.Get(1).(func(int) error); ok {
+		r1 = returnFunc(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUser_GetOneGroup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOneGroup'
+type MockUser_GetOneGroup_Call struct {
+	*mock.Call
+}
+
+// GetOneGroup is a helper method to define mock.On call
+//   - id int
+func (_e *MockUser_Expecter) GetOneGroup(id interface{}) *MockUser_GetOneGroup_Call {
+	return &MockUser_GetOneGroup_Call{Call: _e.mock.On("GetOneGroup", id)}
+}
+
+func (_c *MockUser_GetOneGroup_Call) Run(run func(id int)) *MockUser_GetOneGroup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUser_GetOneGroup_Call) Return(group gameServer.Group, err error) *MockUser_GetOneGroup_Call {
+	_c.Call.Return(group, err)
+	return _c
+}
+
+func (_c *MockUser_GetOneGroup_Call) RunAndReturn(run func(id int) (gameServer.Group, error)) *MockUser_GetOneGroup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetOneUser provides a mock function for the type MockUser
 func (_mock *MockUser) GetOneUser(id int) (gameServer.User, error) {
 	ret := _mock.Called(id)
@@ -820,6 +1000,72 @@ func (_c *MockUser_GetScore_Call) RunAndReturn(run func(userId int, parSetId int
 	return _c
 }
 
+// GetUserParameterSet provides a mock function for the type MockUser
+func (_mock *MockUser) GetUserParameterSet(userId int, parSetId int) (gameServer.UserParameterSet, error) {
+	ret := _mock.Called(userId, parSetId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserParameterSet")
+	}
+
+	var r0 gameServer.UserParameterSet
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, int) (gameServer.UserParameterSet, error)); ok {
+		return returnFunc(userId, parSetId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int) gameServer.UserParameterSet); ok {
+		r0 = returnFunc(userId, parSetId)
+	} else {
+		r0 = ret.Get(0).(gameServer.UserParameterSet)
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = returnFunc(userId, parSetId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUser_GetUserParameterSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserParameterSet'
+type MockUser_GetUserParameterSet_Call struct {
+	*mock.Call
+}
+
+// GetUserParameterSet is a helper method to define mock.On call
+//   - userId int
+//   - parSetId int
+func (_e *MockUser_Expecter) GetUserParameterSet(userId interface{}, parSetId interface{}) *MockUser_GetUserParameterSet_Call {
+	return &MockUser_GetUserParameterSet_Call{Call: _e.mock.On("GetUserParameterSet", userId, parSetId)}
+}
+
+func (_c *MockUser_GetUserParameterSet_Call) Run(run func(userId int, parSetId int)) *MockUser_GetUserParameterSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUser_GetUserParameterSet_Call) Return(userParameterSet gameServer.UserParameterSet, err error) *MockUser_GetUserParameterSet_Call {
+	_c.Call.Return(userParameterSet, err)
+	return _c
+}
+
+func (_c *MockUser_GetUserParameterSet_Call) RunAndReturn(run func(userId int, parSetId int) (gameServer.UserParameterSet, error)) *MockUser_GetUserParameterSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetUsersPageCount provides a mock function for the type MockUser
 func (_mock *MockUser) GetUsersPageCount(input gameServer.GetUsersPageCountInput) (int, error) {
 	ret := _mock.Called(input)
@@ -880,6 +1126,72 @@ func (_c *MockUser_GetUsersPageCount_Call) RunAndReturn(run func(input gameServe
 	return _c
 }
 
+// HasResearcherAccess provides a mock function for the type MockUser
+func (_mock *MockUser) HasResearcherAccess(researcherId int, userId int) (bool, error) {
+	ret := _mock.Called(researcherId, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasResearcherAccess")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, int) (bool, error)); ok {
+		return returnFunc(researcherId, userId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int) bool); ok {
+		r0 = returnFunc(researcherId, userId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = returnFunc(researcherId, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUser_HasResearcherAccess_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasResearcherAccess'
+type MockUser_HasResearcherAccess_Call struct {
+	*mock.Call
+}
+
+// HasResearcherAccess is a helper method to define mock.On call
+//   - researcherId int
+//   - userId int
+func (_e *MockUser_Expecter) HasResearcherAccess(researcherId interface{}, userId interface{}) *MockUser_HasResearcherAccess_Call {
+	return &MockUser_HasResearcherAccess_Call{Call: _e.mock.On("HasResearcherAccess", researcherId, userId)}
+}
+
+func (_c *MockUser_HasResearcherAccess_Call) Run(run func(researcherId int, userId int)) *MockUser_HasResearcherAccess_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUser_HasResearcherAccess_Call) Return(b bool, err error) *MockUser_HasResearcherAccess_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockUser_HasResearcherAccess_Call) RunAndReturn(run func(researcherId int, userId int) (bool, error)) *MockUser_HasResearcherAccess_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ParseToken provides a mock function for the type MockUser
 func (_mock *MockUser) ParseToken(token string) (*TokenClaims, error) {
 	ret := _mock.Called(token)
@@ -1167,75 +1479,132 @@ func (_c *MockUser_UpdateUserParSet_Call) RunAndReturn(run func(id int, input ga
 	return _c
 }
 
-// NewMockChart creates a new instance of MockChart. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockChart(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockChart {
-	mock := &MockChart{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
+// UpdateUserUserParSet provides a mock function for the type MockUser
+func (_mock *MockUser) UpdateUserUserParSet(id int, input gameServer.UpdateUserUserParSetInput) error {
+	ret := _mock.Called(id, input)
 
-	return mock
-}
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserUserParSet")
+	}
 
-// MockChart is an autogenerated mock type for the Chart type
-type MockChart struct {
-	mock.Mock
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int, gameServer.UpdateUserUserParSetInput) error); ok {
+		r0 = returnFunc(id, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
 }
 
-type MockChart_Expecter struct {
-	mock *mock.Mock
+// MockUser_UpdateUserUserParSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUserUserParSet'
+type MockUser_UpdateUserUserParSet_Call struct {
+	*mock.Call
 }
 
-func (_m *MockChart) EXPECT() *MockChart_Expecter {
-	return &MockChart_Expecter{mock: &_m.Mock}
+// UpdateUserUserParSet is a helper method to define mock.On call
+//   - id int
+//   - input gameServer.UpdateUserUserParSetInput
+func (_e *MockUser_Expecter) UpdateUserUserParSet(id interface{}, input interface{}) *MockUser_UpdateUserUserParSet_Call {
+	return &MockUser_UpdateUserUserParSet_Call{Call: _e.mock.On("UpdateUserUserParSet", id, input)}
 }
 
-// CreateChart provides a mock function for the type MockChart
-func (_mock *MockChart) CreateChart(chart gameServer.CreateChartInput) (int, error) {
-	ret := _mock.Called(chart)
-
-	if len(ret) == 0 {
-		panic("no return value specified for CreateChart")
+func (_c *MockUser_UpdateUserUserParSet_Call) Run(run func(id int, input gameServer.UpdateUserUserParSetInput)) *MockUser_UpdateUserUserParSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 gameServer.UpdateUserUserParSetInput
+		if args[1] != nil {
+			arg1 = args[1].(gameServer.UpdateUserUserParSetInput)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUser_UpdateUserUserParSet_Call) Return(err error) *MockUser_UpdateUserUserParSet_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUser_UpdateUserUserParSet_Call) RunAndReturn(run func(id int, input gameServer.UpdateUserUserParSetInput) error) *MockUser_UpdateUserUserParSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockChart creates a new instance of MockChart. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockChart(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockChart {
+	mock := &MockChart{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockChart is an autogenerated mock type for the Chart type
+type MockChart struct {
+	mock.Mock
+}
+
+type MockChart_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockChart) EXPECT() *MockChart_Expecter {
+	return &MockChart_Expecter{mock: &_m.Mock}
+}
+
+// AbandonStaleCharts provides a mock function for the type MockChart
+func (_mock *MockChart) AbandonStaleCharts(timeout time.Duration) (int, error) {
+	ret := _mock.Called(timeout)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AbandonStaleCharts")
 	}
 
 	var r0 int
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(gameServer.CreateChartInput) (int, error)); ok {
-		return returnFunc(chart)
+	if returnFunc, ok := ret.Get(0).(func(time.Duration) (int, error)); ok {
+		return returnFunc(timeout)
 	}
-	if returnFunc, ok := ret.Get(0).(func(gameServer.CreateChartInput) int); ok {
-		r0 = returnFunc(chart)
+	if returnFunc, ok := ret.Get(0).(func(time.Duration) int); ok {
+		r0 = returnFunc(timeout)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
-	if returnFunc, ok := ret.Get(1).(func(gameServer.CreateChartInput) error); ok {
-		r1 = returnFunc(chart)
+	if returnFunc, ok := ret.Get(1).(func(time.Duration) error); ok {
+		r1 = returnFunc(timeout)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockChart_CreateChart_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateChart'
-type MockChart_CreateChart_Call struct {
+// MockChart_AbandonStaleCharts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AbandonStaleCharts'
+type MockChart_AbandonStaleCharts_Call struct {
 	*mock.Call
 }
 
-// CreateChart is a helper method to define mock.On call
-//   - chart gameServer.CreateChartInput
-func (_e *MockChart_Expecter) CreateChart(chart interface{}) *MockChart_CreateChart_Call {
-	return &MockChart_CreateChart_Call{Call: _e.mock.On("CreateChart", chart)}
+// AbandonStaleCharts is a helper method to define mock.On call
+//   - timeout time.Duration
+func (_e *MockChart_Expecter) AbandonStaleCharts(timeout interface{}) *MockChart_AbandonStaleCharts_Call {
+	return &MockChart_AbandonStaleCharts_Call{Call: _e.mock.On("AbandonStaleCharts", timeout)}
 }
 
-func (_c *MockChart_CreateChart_Call) Run(run func(chart gameServer.CreateChartInput)) *MockChart_CreateChart_Call {
+func (_c *MockChart_AbandonStaleCharts_Call) Run(run func(timeout time.Duration)) *MockChart_AbandonStaleCharts_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 gameServer.CreateChartInput
+		var arg0 time.Duration
 		if args[0] != nil {
-			arg0 = args[0].(gameServer.CreateChartInput)
+			arg0 = args[0].(time.Duration)
 		}
 		run(
 			arg0,
@@ -1244,82 +1613,90 @@ func (_c *MockChart_CreateChart_Call) Run(run func(chart gameServer.CreateChartI
 	return _c
 }
 
-func (_c *MockChart_CreateChart_Call) Return(n int, err error) *MockChart_CreateChart_Call {
+func (_c *MockChart_AbandonStaleCharts_Call) Return(n int, err error) *MockChart_AbandonStaleCharts_Call {
 	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockChart_CreateChart_Call) RunAndReturn(run func(chart gameServer.CreateChartInput) (int, error)) *MockChart_CreateChart_Call {
+func (_c *MockChart_AbandonStaleCharts_Call) RunAndReturn(run func(timeout time.Duration) (int, error)) *MockChart_AbandonStaleCharts_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateParSet provides a mock function for the type MockChart
-func (_mock *MockChart) CreateParSet(input gameServer.CreateParSetInput) (int, error) {
-	ret := _mock.Called(input)
+// AppendPoints provides a mock function for the type MockChart
+func (_mock *MockChart) AppendPoints(chartId int, input gameServer.AppendChartPointsInput) ([]int, error) {
+	ret := _mock.Called(chartId, input)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateParSet")
+		panic("no return value specified for AppendPoints")
 	}
 
-	var r0 int
+	var r0 []int
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(gameServer.CreateParSetInput) (int, error)); ok {
-		return returnFunc(input)
+	if returnFunc, ok := ret.Get(0).(func(int, gameServer.AppendChartPointsInput) ([]int, error)); ok {
+		return returnFunc(chartId, input)
 	}
-	if returnFunc, ok := ret.Get(0).(func(gameServer.CreateParSetInput) int); ok {
-		r0 = returnFunc(input)
+	if returnFunc, ok := ret.Get(0).(func(int, gameServer.AppendChartPointsInput) []int); ok {
+		r0 = returnFunc(chartId, input)
 	} else {
-		r0 = ret.Get(0).(int)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(gameServer.CreateParSetInput) error); ok {
-		r1 = returnFunc(input)
+	if returnFunc, ok := ret.Get(1).(func(int, gameServer.AppendChartPointsInput) error); ok {
+		r1 = returnFunc(chartId, input)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockChart_CreateParSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateParSet'
-type MockChart_CreateParSet_Call struct {
+// MockChart_AppendPoints_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AppendPoints'
+type MockChart_AppendPoints_Call struct {
 	*mock.Call
 }
 
-// CreateParSet is a helper method to define mock.On call
-//   - input gameServer.CreateParSetInput
-func (_e *MockChart_Expecter) CreateParSet(input interface{}) *MockChart_CreateParSet_Call {
-	return &MockChart_CreateParSet_Call{Call: _e.mock.On("CreateParSet", input)}
+// AppendPoints is a helper method to define mock.On call
+//   - chartId int
+//   - input gameServer.AppendChartPointsInput
+func (_e *MockChart_Expecter) AppendPoints(chartId interface{}, input interface{}) *MockChart_AppendPoints_Call {
+	return &MockChart_AppendPoints_Call{Call: _e.mock.On("AppendPoints", chartId, input)}
 }
 
-func (_c *MockChart_CreateParSet_Call) Run(run func(input gameServer.CreateParSetInput)) *MockChart_CreateParSet_Call {
+func (_c *MockChart_AppendPoints_Call) Run(run func(chartId int, input gameServer.AppendChartPointsInput)) *MockChart_AppendPoints_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 gameServer.CreateParSetInput
+		var arg0 int
 		if args[0] != nil {
-			arg0 = args[0].(gameServer.CreateParSetInput)
+			arg0 = args[0].(int)
+		}
+		var arg1 gameServer.AppendChartPointsInput
+		if args[1] != nil {
+			arg1 = args[1].(gameServer.AppendChartPointsInput)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockChart_CreateParSet_Call) Return(n int, err error) *MockChart_CreateParSet_Call {
-	_c.Call.Return(n, err)
+func (_c *MockChart_AppendPoints_Call) Return(ints []int, err error) *MockChart_AppendPoints_Call {
+	_c.Call.Return(ints, err)
 	return _c
 }
 
-func (_c *MockChart_CreateParSet_Call) RunAndReturn(run func(input gameServer.CreateParSetInput) (int, error)) *MockChart_CreateParSet_Call {
+func (_c *MockChart_AppendPoints_Call) RunAndReturn(run func(chartId int, input gameServer.AppendChartPointsInput) ([]int, error)) *MockChart_AppendPoints_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteChart provides a mock function for the type MockChart
-func (_mock *MockChart) DeleteChart(id int) error {
+// ArchiveParSet provides a mock function for the type MockChart
+func (_mock *MockChart) ArchiveParSet(id int) error {
 	ret := _mock.Called(id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteChart")
+		panic("no return value specified for ArchiveParSet")
 	}
 
 	var r0 error
@@ -1331,18 +1708,18 @@ func (_mock *MockChart) DeleteChart(id int) error {
 	return r0
 }
 
-// MockChart_DeleteChart_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteChart'
-type MockChart_DeleteChart_Call struct {
+// MockChart_ArchiveParSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArchiveParSet'
+type MockChart_ArchiveParSet_Call struct {
 	*mock.Call
 }
 
-// DeleteChart is a helper method to define mock.On call
+// ArchiveParSet is a helper method to define mock.On call
 //   - id int
-func (_e *MockChart_Expecter) DeleteChart(id interface{}) *MockChart_DeleteChart_Call {
-	return &MockChart_DeleteChart_Call{Call: _e.mock.On("DeleteChart", id)}
+func (_e *MockChart_Expecter) ArchiveParSet(id interface{}) *MockChart_ArchiveParSet_Call {
+	return &MockChart_ArchiveParSet_Call{Call: _e.mock.On("ArchiveParSet", id)}
 }
 
-func (_c *MockChart_DeleteChart_Call) Run(run func(id int)) *MockChart_DeleteChart_Call {
+func (_c *MockChart_ArchiveParSet_Call) Run(run func(id int)) *MockChart_ArchiveParSet_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 int
 		if args[0] != nil {
@@ -1355,122 +1732,115 @@ func (_c *MockChart_DeleteChart_Call) Run(run func(id int)) *MockChart_DeleteCha
 	return _c
 }
 
-func (_c *MockChart_DeleteChart_Call) Return(err error) *MockChart_DeleteChart_Call {
+func (_c *MockChart_ArchiveParSet_Call) Return(err error) *MockChart_ArchiveParSet_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockChart_DeleteChart_Call) RunAndReturn(run func(id int) error) *MockChart_DeleteChart_Call {
+func (_c *MockChart_ArchiveParSet_Call) RunAndReturn(run func(id int) error) *MockChart_ArchiveParSet_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAllCharts provides a mock function for the type MockChart
-func (_mock *MockChart) GetAllCharts(input gameServer.GetAllChartsInput) ([]gameServer.Chart, error) {
-	ret := _mock.Called(input)
+// CloseChart provides a mock function for the type MockChart
+func (_mock *MockChart) CloseChart(chartId int, input gameServer.CloseChartInput) error {
+	ret := _mock.Called(chartId, input)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllCharts")
+		panic("no return value specified for CloseChart")
 	}
 
-	var r0 []gameServer.Chart
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(gameServer.GetAllChartsInput) ([]gameServer.Chart, error)); ok {
-		return returnFunc(input)
-	}
-	if returnFunc, ok := ret.Get(0).(func(gameServer.GetAllChartsInput) []gameServer.Chart); ok {
-		r0 = returnFunc(input)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]gameServer.Chart)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(gameServer.GetAllChartsInput) error); ok {
-		r1 = returnFunc(input)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int, gameServer.CloseChartInput) error); ok {
+		r0 = returnFunc(chartId, input)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockChart_GetAllCharts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllCharts'
-type MockChart_GetAllCharts_Call struct {
+// MockChart_CloseChart_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloseChart'
+type MockChart_CloseChart_Call struct {
 	*mock.Call
 }
 
-// GetAllCharts is a helper method to define mock.On call
-//   - input gameServer.GetAllChartsInput
-func (_e *MockChart_Expecter) GetAllCharts(input interface{}) *MockChart_GetAllCharts_Call {
-	return &MockChart_GetAllCharts_Call{Call: _e.mock.On("GetAllCharts", input)}
+// CloseChart is a helper method to define mock.On call
+//   - chartId int
+//   - input gameServer.CloseChartInput
+func (_e *MockChart_Expecter) CloseChart(chartId interface{}, input interface{}) *MockChart_CloseChart_Call {
+	return &MockChart_CloseChart_Call{Call: _e.mock.On("CloseChart", chartId, input)}
 }
 
-func (_c *MockChart_GetAllCharts_Call) Run(run func(input gameServer.GetAllChartsInput)) *MockChart_GetAllCharts_Call {
+func (_c *MockChart_CloseChart_Call) Run(run func(chartId int, input gameServer.CloseChartInput)) *MockChart_CloseChart_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 gameServer.GetAllChartsInput
+		var arg0 int
 		if args[0] != nil {
-			arg0 = args[0].(gameServer.GetAllChartsInput)
+			arg0 = args[0].(int)
+		}
+		var arg1 gameServer.CloseChartInput
+		if args[1] != nil {
+			arg1 = args[1].(gameServer.CloseChartInput)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockChart_GetAllCharts_Call) Return(charts []gameServer.Chart, err error) *MockChart_GetAllCharts_Call {
-	_c.Call.Return(charts, err)
+func (_c *MockChart_CloseChart_Call) Return(err error) *MockChart_CloseChart_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockChart_GetAllCharts_Call) RunAndReturn(run func(input gameServer.GetAllChartsInput) ([]gameServer.Chart, error)) *MockChart_GetAllCharts_Call {
+func (_c *MockChart_CloseChart_Call) RunAndReturn(run func(chartId int, input gameServer.CloseChartInput) error) *MockChart_CloseChart_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAllParSets provides a mock function for the type MockChart
-func (_mock *MockChart) GetAllParSets(input gameServer.GetAllParSetsInput) ([]gameServer.ParameterSet, error) {
-	ret := _mock.Called(input)
+// CreateChart provides a mock function for the type MockChart
+func (_mock *MockChart) CreateChart(chart gameServer.CreateChartInput) (int, error) {
+	ret := _mock.Called(chart)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllParSets")
+		panic("no return value specified for CreateChart")
 	}
 
-	var r0 []gameServer.ParameterSet
+	var r0 int
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(gameServer.GetAllParSetsInput) ([]gameServer.ParameterSet, error)); ok {
-		return returnFunc(input)
+	if returnFunc, ok := ret.Get(0).(func(gameServer.CreateChartInput) (int, error)); ok {
+		return returnFunc(chart)
 	}
-	if returnFunc, ok := ret.Get(0).(func(gameServer.GetAllParSetsInput) []gameServer.ParameterSet); ok {
-		r0 = returnFunc(input)
+	if returnFunc, ok := ret.Get(0).(func(gameServer.CreateChartInput) int); ok {
+		r0 = returnFunc(chart)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]gameServer.ParameterSet)
-		}
+		r0 = ret.Get(0).(int)
 	}
-	if returnFunc, ok := ret.Get(1).(func(gameServer.GetAllParSetsInput) error); ok {
-		r1 = returnFunc(input)
+	if returnFunc, ok := ret.Get(1).(func(gameServer.CreateChartInput) error); ok {
+		r1 = returnFunc(chart)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockChart_GetAllParSets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllParSets'
-type MockChart_GetAllParSets_Call struct {
+// MockChart_CreateChart_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateChart'
+type MockChart_CreateChart_Call struct {
 	*mock.Call
 }
 
-// GetAllParSets is a helper method to define mock.On call
-//   - input gameServer.GetAllParSetsInput
-func (_e *MockChart_Expecter) GetAllParSets(input interface{}) *MockChart_GetAllParSets_Call {
-	return &MockChart_GetAllParSets_Call{Call: _e.mock.On("GetAllParSets", input)}
+// CreateChart is a helper method to define mock.On call
+//   - chart gameServer.CreateChartInput
+func (_e *MockChart_Expecter) CreateChart(chart interface{}) *MockChart_CreateChart_Call {
+	return &MockChart_CreateChart_Call{Call: _e.mock.On("CreateChart", chart)}
 }
 
-func (_c *MockChart_GetAllParSets_Call) Run(run func(input gameServer.GetAllParSetsInput)) *MockChart_GetAllParSets_Call {
+func (_c *MockChart_CreateChart_Call) Run(run func(chart gameServer.CreateChartInput)) *MockChart_CreateChart_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 gameServer.GetAllParSetsInput
+		var arg0 gameServer.CreateChartInput
 		if args[0] != nil {
-			arg0 = args[0].(gameServer.GetAllParSetsInput)
+			arg0 = args[0].(gameServer.CreateChartInput)
 		}
 		run(
 			arg0,
@@ -1479,35 +1849,35 @@ func (_c *MockChart_GetAllParSets_Call) Run(run func(input gameServer.GetAllParS
 	return _c
 }
 
-func (_c *MockChart_GetAllParSets_Call) Return(parameterSets []gameServer.ParameterSet, err error) *MockChart_GetAllParSets_Call {
-	_c.Call.Return(parameterSets, err)
+func (_c *MockChart_CreateChart_Call) Return(n int, err error) *MockChart_CreateChart_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockChart_GetAllParSets_Call) RunAndReturn(run func(input gameServer.GetAllParSetsInput) ([]gameServer.ParameterSet, error)) *MockChart_GetAllParSets_Call {
+func (_c *MockChart_CreateChart_Call) RunAndReturn(run func(chart gameServer.CreateChartInput) (int, error)) *MockChart_CreateChart_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetChartsCount provides a mock function for the type MockChart
-func (_mock *MockChart) GetChartsCount(input gameServer.GetChartsCountInput) (int, error) {
+// CreateParSet provides a mock function for the type MockChart
+func (_mock *MockChart) CreateParSet(input gameServer.CreateParSetInput) (int, error) {
 	ret := _mock.Called(input)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetChartsCount")
+		panic("no return value specified for CreateParSet")
 	}
 
 	var r0 int
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(gameServer.GetChartsCountInput) (int, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(gameServer.CreateParSetInput) (int, error)); ok {
 		return returnFunc(input)
 	}
-	if returnFunc, ok := ret.Get(0).(func(gameServer.GetChartsCountInput) int); ok {
+	if returnFunc, ok := ret.Get(0).(func(gameServer.CreateParSetInput) int); ok {
 		r0 = returnFunc(input)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
-	if returnFunc, ok := ret.Get(1).(func(gameServer.GetChartsCountInput) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(gameServer.CreateParSetInput) error); ok {
 		r1 = returnFunc(input)
 	} else {
 		r1 = ret.Error(1)
@@ -1515,22 +1885,22 @@ func (_mock *MockChart) GetChartsCount(input gameServer.GetChartsCountInput) (in
 	return r0, r1
 }
 
-// MockChart_GetChartsCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChartsCount'
-type MockChart_GetChartsCount_Call struct {
+// MockChart_CreateParSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateParSet'
+type MockChart_CreateParSet_Call struct {
 	*mock.Call
 }
 
-// GetChartsCount is a helper method to define mock.On call
-//   - input gameServer.GetChartsCountInput
-func (_e *MockChart_Expecter) GetChartsCount(input interface{}) *MockChart_GetChartsCount_Call {
-	return &MockChart_GetChartsCount_Call{Call: _e.mock.On("GetChartsCount", input)}
+// CreateParSet is a helper method to define mock.On call
+//   - input gameServer.CreateParSetInput
+func (_e *MockChart_Expecter) CreateParSet(input interface{}) *MockChart_CreateParSet_Call {
+	return &MockChart_CreateParSet_Call{Call: _e.mock.On("CreateParSet", input)}
 }
 
-func (_c *MockChart_GetChartsCount_Call) Run(run func(input gameServer.GetChartsCountInput)) *MockChart_GetChartsCount_Call {
+func (_c *MockChart_CreateParSet_Call) Run(run func(input gameServer.CreateParSetInput)) *MockChart_CreateParSet_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 gameServer.GetChartsCountInput
+		var arg0 gameServer.CreateParSetInput
 		if args[0] != nil {
-			arg0 = args[0].(gameServer.GetChartsCountInput)
+			arg0 = args[0].(gameServer.CreateParSetInput)
 		}
 		run(
 			arg0,
@@ -1539,72 +1909,424 @@ func (_c *MockChart_GetChartsCount_Call) Run(run func(input gameServer.GetCharts
 	return _c
 }
 
-func (_c *MockChart_GetChartsCount_Call) Return(n int, err error) *MockChart_GetChartsCount_Call {
+func (_c *MockChart_CreateParSet_Call) Return(n int, err error) *MockChart_CreateParSet_Call {
 	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockChart_GetChartsCount_Call) RunAndReturn(run func(input gameServer.GetChartsCountInput) (int, error)) *MockChart_GetChartsCount_Call {
+func (_c *MockChart_CreateParSet_Call) RunAndReturn(run func(input gameServer.CreateParSetInput) (int, error)) *MockChart_CreateParSet_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetChartsPageCount provides a mock function for the type MockChart
-func (_mock *MockChart) GetChartsPageCount(input gameServer.GetChartsPageCountInput) (int, error) {
-	ret := _mock.Called(input)
+// CreateParSetVersion provides a mock function for the type MockChart
+func (_mock *MockChart) CreateParSetVersion(parentId int, input gameServer.CreateParSetInput) (int, error) {
+	ret := _mock.Called(parentId, input)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetChartsPageCount")
+		panic("no return value specified for CreateParSetVersion")
 	}
 
 	var r0 int
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(gameServer.GetChartsPageCountInput) (int, error)); ok {
-		return returnFunc(input)
+	if returnFunc, ok := ret.Get(0).(func(int, gameServer.CreateParSetInput) (int, error)); ok {
+		return returnFunc(parentId, input)
 	}
-	if returnFunc, ok := ret.Get(0).(func(gameServer.GetChartsPageCountInput) int); ok {
-		r0 = returnFunc(input)
+	if returnFunc, ok := ret.Get(0).(func(int, gameServer.CreateParSetInput) int); ok {
+		r0 = returnFunc(parentId, input)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
-	if returnFunc, ok := ret.Get(1).(func(gameServer.GetChartsPageCountInput) error); ok {
-		r1 = returnFunc(input)
+	if returnFunc, ok := ret.Get(1).(func(int, gameServer.CreateParSetInput) error); ok {
+		r1 = returnFunc(parentId, input)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockChart_GetChartsPageCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChartsPageCount'
-type MockChart_GetChartsPageCount_Call struct {
+// MockChart_CreateParSetVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateParSetVersion'
+type MockChart_CreateParSetVersion_Call struct {
 	*mock.Call
 }
 
-// GetChartsPageCount is a helper method to define mock.On call
-//   - input gameServer.GetChartsPageCountInput
-func (_e *MockChart_Expecter) GetChartsPageCount(input interface{}) *MockChart_GetChartsPageCount_Call {
-	return &MockChart_GetChartsPageCount_Call{Call: _e.mock.On("GetChartsPageCount", input)}
+// CreateParSetVersion is a helper method to define mock.On call
+//   - parentId int
+//   - input gameServer.CreateParSetInput
+func (_e *MockChart_Expecter) CreateParSetVersion(parentId interface{}, input interface{}) *MockChart_CreateParSetVersion_Call {
+	return &MockChart_CreateParSetVersion_Call{Call: _e.mock.On("CreateParSetVersion", parentId, input)}
 }
 
-func (_c *MockChart_GetChartsPageCount_Call) Run(run func(input gameServer.GetChartsPageCountInput)) *MockChart_GetChartsPageCount_Call {
+func (_c *MockChart_CreateParSetVersion_Call) Run(run func(parentId int, input gameServer.CreateParSetInput)) *MockChart_CreateParSetVersion_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 gameServer.GetChartsPageCountInput
+		var arg0 int
 		if args[0] != nil {
-			arg0 = args[0].(gameServer.GetChartsPageCountInput)
+			arg0 = args[0].(int)
+		}
+		var arg1 gameServer.CreateParSetInput
+		if args[1] != nil {
+			arg1 = args[1].(gameServer.CreateParSetInput)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockChart_GetChartsPageCount_Call) Return(n int, err error) *MockChart_GetChartsPageCount_Call {
+func (_c *MockChart_CreateParSetVersion_Call) Return(n int, err error) *MockChart_CreateParSetVersion_Call {
 	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockChart_GetChartsPageCount_Call) RunAndReturn(run func(input gameServer.GetChartsPageCountInput) (int, error)) *MockChart_GetChartsPageCount_Call {
+func (_c *MockChart_CreateParSetVersion_Call) RunAndReturn(run func(parentId int, input gameServer.CreateParSetInput) (int, error)) *MockChart_CreateParSetVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteChart provides a mock function for the type MockChart
+func (_mock *MockChart) DeleteChart(id int) error {
+	ret := _mock.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteChart")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int) error); ok {
+		r0 = returnFunc(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockChart_DeleteChart_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteChart'
+type MockChart_DeleteChart_Call struct {
+	*mock.Call
+}
+
+// DeleteChart is a helper method to define mock.On call
+//   - id int
+func (_e *MockChart_Expecter) DeleteChart(id interface{}) *MockChart_DeleteChart_Call {
+	return &MockChart_DeleteChart_Call{Call: _e.mock.On("DeleteChart", id)}
+}
+
+func (_c *MockChart_DeleteChart_Call) Run(run func(id int)) *MockChart_DeleteChart_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChart_DeleteChart_Call) Return(err error) *MockChart_DeleteChart_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockChart_DeleteChart_Call) RunAndReturn(run func(id int) error) *MockChart_DeleteChart_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteParSet provides a mock function for the type MockChart
+func (_mock *MockChart) DeleteParSet(id int) error {
+	ret := _mock.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteParSet")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int) error); ok {
+		r0 = returnFunc(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockChart_DeleteParSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteParSet'
+type MockChart_DeleteParSet_Call struct {
+	*mock.Call
+}
+
+// DeleteParSet is a helper method to define mock.On call
+//   - id int
+func (_e *MockChart_Expecter) DeleteParSet(id interface{}) *MockChart_DeleteParSet_Call {
+	return &MockChart_DeleteParSet_Call{Call: _e.mock.On("DeleteParSet", id)}
+}
+
+func (_c *MockChart_DeleteParSet_Call) Run(run func(id int)) *MockChart_DeleteParSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChart_DeleteParSet_Call) Return(err error) *MockChart_DeleteParSet_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockChart_DeleteParSet_Call) RunAndReturn(run func(id int) error) *MockChart_DeleteParSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllCharts provides a mock function for the type MockChart
+func (_mock *MockChart) GetAllCharts(input gameServer.GetAllChartsInput) ([]gameServer.Chart, error) {
+	ret := _mock.Called(input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllCharts")
+	}
+
+	var r0 []gameServer.Chart
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(gameServer.GetAllChartsInput) ([]gameServer.Chart, error)); ok {
+		return returnFunc(input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(gameServer.GetAllChartsInput) []gameServer.Chart); ok {
+		r0 = returnFunc(input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gameServer.Chart)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(gameServer.GetAllChartsInput) error); ok {
+		r1 = returnFunc(input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChart_GetAllCharts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllCharts'
+type MockChart_GetAllCharts_Call struct {
+	*mock.Call
+}
+
+// GetAllCharts is a helper method to define mock.On call
+//   - input gameServer.GetAllChartsInput
+func (_e *MockChart_Expecter) GetAllCharts(input interface{}) *MockChart_GetAllCharts_Call {
+	return &MockChart_GetAllCharts_Call{Call: _e.mock.On("GetAllCharts", input)}
+}
+
+func (_c *MockChart_GetAllCharts_Call) Run(run func(input gameServer.GetAllChartsInput)) *MockChart_GetAllCharts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 gameServer.GetAllChartsInput
+		if args[0] != nil {
+			arg0 = args[0].(gameServer.GetAllChartsInput)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChart_GetAllCharts_Call) Return(charts []gameServer.Chart, err error) *MockChart_GetAllCharts_Call {
+	_c.Call.Return(charts, err)
+	return _c
+}
+
+func (_c *MockChart_GetAllCharts_Call) RunAndReturn(run func(input gameServer.GetAllChartsInput) ([]gameServer.Chart, error)) *MockChart_GetAllCharts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllParSets provides a mock function for the type MockChart
+func (_mock *MockChart) GetAllParSets(input gameServer.GetAllParSetsInput) ([]gameServer.ParameterSetWithUsage, error) {
+	ret := _mock.Called(input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllParSets")
+	}
+
+	var r0 []gameServer.ParameterSetWithUsage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(gameServer.GetAllParSetsInput) ([]gameServer.ParameterSetWithUsage, error)); ok {
+		return returnFunc(input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(gameServer.GetAllParSetsInput) []gameServer.ParameterSetWithUsage); ok {
+		r0 = returnFunc(input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gameServer.ParameterSetWithUsage)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(gameServer.GetAllParSetsInput) error); ok {
+		r1 = returnFunc(input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChart_GetAllParSets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllParSets'
+type MockChart_GetAllParSets_Call struct {
+	*mock.Call
+}
+
+// GetAllParSets is a helper method to define mock.On call
+//   - input gameServer.GetAllParSetsInput
+func (_e *MockChart_Expecter) GetAllParSets(input interface{}) *MockChart_GetAllParSets_Call {
+	return &MockChart_GetAllParSets_Call{Call: _e.mock.On("GetAllParSets", input)}
+}
+
+func (_c *MockChart_GetAllParSets_Call) Run(run func(input gameServer.GetAllParSetsInput)) *MockChart_GetAllParSets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 gameServer.GetAllParSetsInput
+		if args[0] != nil {
+			arg0 = args[0].(gameServer.GetAllParSetsInput)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChart_GetAllParSets_Call) Return(parameterSetWithUsages []gameServer.ParameterSetWithUsage, err error) *MockChart_GetAllParSets_Call {
+	_c.Call.Return(parameterSetWithUsages, err)
+	return _c
+}
+
+func (_c *MockChart_GetAllParSets_Call) RunAndReturn(run func(input gameServer.GetAllParSetsInput) ([]gameServer.ParameterSetWithUsage, error)) *MockChart_GetAllParSets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChartsCount provides a mock function for the type MockChart
+func (_mock *MockChart) GetChartsCount(input gameServer.GetChartsCountInput) (int, error) {
+	ret := _mock.Called(input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChartsCount")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(gameServer.GetChartsCountInput) (int, error)); ok {
+		return returnFunc(input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(gameServer.GetChartsCountInput) int); ok {
+		r0 = returnFunc(input)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(gameServer.GetChartsCountInput) error); ok {
+		r1 = returnFunc(input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChart_GetChartsCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChartsCount'
+type MockChart_GetChartsCount_Call struct {
+	*mock.Call
+}
+
+// GetChartsCount is a helper method to define mock.On call
+//   - input gameServer.GetChartsCountInput
+func (_e *MockChart_Expecter) GetChartsCount(input interface{}) *MockChart_GetChartsCount_Call {
+	return &MockChart_GetChartsCount_Call{Call: _e.mock.On("GetChartsCount", input)}
+}
+
+func (_c *MockChart_GetChartsCount_Call) Run(run func(input gameServer.GetChartsCountInput)) *MockChart_GetChartsCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 gameServer.GetChartsCountInput
+		if args[0] != nil {
+			arg0 = args[0].(gameServer.GetChartsCountInput)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChart_GetChartsCount_Call) Return(n int, err error) *MockChart_GetChartsCount_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockChart_GetChartsCount_Call) RunAndReturn(run func(input gameServer.GetChartsCountInput) (int, error)) *MockChart_GetChartsCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChartsPageCount provides a mock function for the type MockChart
+func (_mock *MockChart) GetChartsPageCount(input gameServer.GetChartsPageCountInput) (int, error) {
+	ret := _mock.Called(input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChartsPageCount")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(gameServer.GetChartsPageCountInput) (int, error)); ok {
+		return returnFunc(input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(gameServer.GetChartsPageCountInput) int); ok {
+		r0 = returnFunc(input)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(gameServer.GetChartsPageCountInput) error); ok {
+		r1 = returnFunc(input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChart_GetChartsPageCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChartsPageCount'
+type MockChart_GetChartsPageCount_Call struct {
+	*mock.Call
+}
+
+// GetChartsPageCount is a helper method to define mock.On call
+//   - input gameServer.GetChartsPageCountInput
+func (_e *MockChart_Expecter) GetChartsPageCount(input interface{}) *MockChart_GetChartsPageCount_Call {
+	return &MockChart_GetChartsPageCount_Call{Call: _e.mock.On("GetChartsPageCount", input)}
+}
+
+func (_c *MockChart_GetChartsPageCount_Call) Run(run func(input gameServer.GetChartsPageCountInput)) *MockChart_GetChartsPageCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 gameServer.GetChartsPageCountInput
+		if args[0] != nil {
+			arg0 = args[0].(gameServer.GetChartsPageCountInput)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChart_GetChartsPageCount_Call) Return(n int, err error) *MockChart_GetChartsPageCount_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockChart_GetChartsPageCount_Call) RunAndReturn(run func(input gameServer.GetChartsPageCountInput) (int, error)) *MockChart_GetChartsPageCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1614,39 +2336,1830 @@ func (_mock *MockChart) GetOneChart(id int) (gameServer.Chart, error) {
 	ret := _mock.Called(id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetOneChart")
+		panic("no return value specified for GetOneChart")
+	}
+
+	var r0 gameServer.Chart
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int) (gameServer.Chart, error)); ok {
+		return returnFunc(id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int) gameServer.Chart); ok {
+		r0 = returnFunc(id)
+	} else {
+		r0 = ret.Get(0).(gameServer.Chart)
+	}
+	if returnFunc, ok := ret.Get(1).(func(int) error); ok {
+		r1 = returnFunc(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChart_GetOneChart_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOneChart'
+type MockChart_GetOneChart_Call struct {
+	*mock.Call
+}
+
+// GetOneChart is a helper method to define mock.On call
+//   - id int
+func (_e *MockChart_Expecter) GetOneChart(id interface{}) *MockChart_GetOneChart_Call {
+	return &MockChart_GetOneChart_Call{Call: _e.mock.On("GetOneChart", id)}
+}
+
+func (_c *MockChart_GetOneChart_Call) Run(run func(id int)) *MockChart_GetOneChart_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChart_GetOneChart_Call) Return(chart gameServer.Chart, err error) *MockChart_GetOneChart_Call {
+	_c.Call.Return(chart, err)
+	return _c
+}
+
+func (_c *MockChart_GetOneChart_Call) RunAndReturn(run func(id int) (gameServer.Chart, error)) *MockChart_GetOneChart_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOneParSet provides a mock function for the type MockChart
+func (_mock *MockChart) GetOneParSet(id int) (gameServer.ParameterSet, error) {
+	ret := _mock.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOneParSet")
+	}
+
+	var r0 gameServer.ParameterSet
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int) (gameServer.ParameterSet, error)); ok {
+		return returnFunc(id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int) gameServer.ParameterSet); ok {
+		r0 = returnFunc(id)
+	} else {
+		r0 = ret.Get(0).(gameServer.ParameterSet)
+	}
+	if returnFunc, ok := ret.Get(1).(func(int) error); ok {
+		r1 = returnFunc(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChart_GetOneParSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOneParSet'
+type MockChart_GetOneParSet_Call struct {
+	*mock.Call
+}
+
+// GetOneParSet is a helper method to define mock.On call
+//   - id int
+func (_e *MockChart_Expecter) GetOneParSet(id interface{}) *MockChart_GetOneParSet_Call {
+	return &MockChart_GetOneParSet_Call{Call: _e.mock.On("GetOneParSet", id)}
+}
+
+func (_c *MockChart_GetOneParSet_Call) Run(run func(id int)) *MockChart_GetOneParSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChart_GetOneParSet_Call) Return(parameterSet gameServer.ParameterSet, err error) *MockChart_GetOneParSet_Call {
+	_c.Call.Return(parameterSet, err)
+	return _c
+}
+
+func (_c *MockChart_GetOneParSet_Call) RunAndReturn(run func(id int) (gameServer.ParameterSet, error)) *MockChart_GetOneParSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetParSetRules provides a mock function for the type MockChart
+func (_mock *MockChart) GetParSetRules(id int, acceptLanguage string) (gameServer.ParSetRules, error) {
+	ret := _mock.Called(id, acceptLanguage)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetParSetRules")
+	}
+
+	var r0 gameServer.ParSetRules
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, string) (gameServer.ParSetRules, error)); ok {
+		return returnFunc(id, acceptLanguage)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, string) gameServer.ParSetRules); ok {
+		r0 = returnFunc(id, acceptLanguage)
+	} else {
+		r0 = ret.Get(0).(gameServer.ParSetRules)
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, string) error); ok {
+		r1 = returnFunc(id, acceptLanguage)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChart_GetParSetRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetParSetRules'
+type MockChart_GetParSetRules_Call struct {
+	*mock.Call
+}
+
+// GetParSetRules is a helper method to define mock.On call
+//   - id int
+//   - acceptLanguage string
+func (_e *MockChart_Expecter) GetParSetRules(id interface{}, acceptLanguage interface{}) *MockChart_GetParSetRules_Call {
+	return &MockChart_GetParSetRules_Call{Call: _e.mock.On("GetParSetRules", id, acceptLanguage)}
+}
+
+func (_c *MockChart_GetParSetRules_Call) Run(run func(id int, acceptLanguage string)) *MockChart_GetParSetRules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChart_GetParSetRules_Call) Return(parSetRules gameServer.ParSetRules, err error) *MockChart_GetParSetRules_Call {
+	_c.Call.Return(parSetRules, err)
+	return _c
+}
+
+func (_c *MockChart_GetParSetRules_Call) RunAndReturn(run func(id int, acceptLanguage string) (gameServer.ParSetRules, error)) *MockChart_GetParSetRules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetParSetsPageCount provides a mock function for the type MockChart
+func (_mock *MockChart) GetParSetsPageCount() (int, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetParSetsPageCount")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() (int, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() int); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChart_GetParSetsPageCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetParSetsPageCount'
+type MockChart_GetParSetsPageCount_Call struct {
+	*mock.Call
+}
+
+// GetParSetsPageCount is a helper method to define mock.On call
+func (_e *MockChart_Expecter) GetParSetsPageCount() *MockChart_GetParSetsPageCount_Call {
+	return &MockChart_GetParSetsPageCount_Call{Call: _e.mock.On("GetParSetsPageCount")}
+}
+
+func (_c *MockChart_GetParSetsPageCount_Call) Run(run func()) *MockChart_GetParSetsPageCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockChart_GetParSetsPageCount_Call) Return(n int, err error) *MockChart_GetParSetsPageCount_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockChart_GetParSetsPageCount_Call) RunAndReturn(run func() (int, error)) *MockChart_GetParSetsPageCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OpenChart provides a mock function for the type MockChart
+func (_mock *MockChart) OpenChart(chart gameServer.CreateChartInput) (int, error) {
+	ret := _mock.Called(chart)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenChart")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(gameServer.CreateChartInput) (int, error)); ok {
+		return returnFunc(chart)
+	}
+	if returnFunc, ok := ret.Get(0).(func(gameServer.CreateChartInput) int); ok {
+		r0 = returnFunc(chart)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(gameServer.CreateChartInput) error); ok {
+		r1 = returnFunc(chart)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChart_OpenChart_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenChart'
+type MockChart_OpenChart_Call struct {
+	*mock.Call
+}
+
+// OpenChart is a helper method to define mock.On call
+//   - chart gameServer.CreateChartInput
+func (_e *MockChart_Expecter) OpenChart(chart interface{}) *MockChart_OpenChart_Call {
+	return &MockChart_OpenChart_Call{Call: _e.mock.On("OpenChart", chart)}
+}
+
+func (_c *MockChart_OpenChart_Call) Run(run func(chart gameServer.CreateChartInput)) *MockChart_OpenChart_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 gameServer.CreateChartInput
+		if args[0] != nil {
+			arg0 = args[0].(gameServer.CreateChartInput)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChart_OpenChart_Call) Return(n int, err error) *MockChart_OpenChart_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockChart_OpenChart_Call) RunAndReturn(run func(chart gameServer.CreateChartInput) (int, error)) *MockChart_OpenChart_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PreviewParSet provides a mock function for the type MockChart
+func (_mock *MockChart) PreviewParSet(input gameServer.PreviewParSetInput) (gameServer.ParSetPreview, error) {
+	ret := _mock.Called(input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreviewParSet")
+	}
+
+	var r0 gameServer.ParSetPreview
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(gameServer.PreviewParSetInput) (gameServer.ParSetPreview, error)); ok {
+		return returnFunc(input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(gameServer.PreviewParSetInput) gameServer.ParSetPreview); ok {
+		r0 = returnFunc(input)
+	} else {
+		r0 = ret.Get(0).(gameServer.ParSetPreview)
+	}
+	if returnFunc, ok := ret.Get(1).(func(gameServer.PreviewParSetInput) error); ok {
+		r1 = returnFunc(input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChart_PreviewParSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PreviewParSet'
+type MockChart_PreviewParSet_Call struct {
+	*mock.Call
+}
+
+// PreviewParSet is a helper method to define mock.On call
+//   - input gameServer.PreviewParSetInput
+func (_e *MockChart_Expecter) PreviewParSet(input interface{}) *MockChart_PreviewParSet_Call {
+	return &MockChart_PreviewParSet_Call{Call: _e.mock.On("PreviewParSet", input)}
+}
+
+func (_c *MockChart_PreviewParSet_Call) Run(run func(input gameServer.PreviewParSetInput)) *MockChart_PreviewParSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 gameServer.PreviewParSetInput
+		if args[0] != nil {
+			arg0 = args[0].(gameServer.PreviewParSetInput)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChart_PreviewParSet_Call) Return(parSetPreview gameServer.ParSetPreview, err error) *MockChart_PreviewParSet_Call {
+	_c.Call.Return(parSetPreview, err)
+	return _c
+}
+
+func (_c *MockChart_PreviewParSet_Call) RunAndReturn(run func(input gameServer.PreviewParSetInput) (gameServer.ParSetPreview, error)) *MockChart_PreviewParSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateParSet provides a mock function for the type MockChart
+func (_mock *MockChart) UpdateParSet(id int, input gameServer.CreateParSetInput) error {
+	ret := _mock.Called(id, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateParSet")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int, gameServer.CreateParSetInput) error); ok {
+		r0 = returnFunc(id, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockChart_UpdateParSet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateParSet'
+type MockChart_UpdateParSet_Call struct {
+	*mock.Call
+}
+
+// UpdateParSet is a helper method to define mock.On call
+//   - id int
+//   - input gameServer.CreateParSetInput
+func (_e *MockChart_Expecter) UpdateParSet(id interface{}, input interface{}) *MockChart_UpdateParSet_Call {
+	return &MockChart_UpdateParSet_Call{Call: _e.mock.On("UpdateParSet", id, input)}
+}
+
+func (_c *MockChart_UpdateParSet_Call) Run(run func(id int, input gameServer.CreateParSetInput)) *MockChart_UpdateParSet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 gameServer.CreateParSetInput
+		if args[1] != nil {
+			arg1 = args[1].(gameServer.CreateParSetInput)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChart_UpdateParSet_Call) Return(err error) *MockChart_UpdateParSet_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockChart_UpdateParSet_Call) RunAndReturn(run func(id int, input gameServer.CreateParSetInput) error) *MockChart_UpdateParSet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPoint creates a new instance of MockPoint. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPoint(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPoint {
+	mock := &MockPoint{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockPoint is an autogenerated mock type for the Point type
+type MockPoint struct {
+	mock.Mock
+}
+
+type MockPoint_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPoint) EXPECT() *MockPoint_Expecter {
+	return &MockPoint_Expecter{mock: &_m.Mock}
+}
+
+// CreatePoint provides a mock function for the type MockPoint
+func (_mock *MockPoint) CreatePoint(input gameServer.Point) (int, error) {
+	ret := _mock.Called(input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePoint")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(gameServer.Point) (int, error)); ok {
+		return returnFunc(input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(gameServer.Point) int); ok {
+		r0 = returnFunc(input)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(gameServer.Point) error); ok {
+		r1 = returnFunc(input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPoint_CreatePoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePoint'
+type MockPoint_CreatePoint_Call struct {
+	*mock.Call
+}
+
+// CreatePoint is a helper method to define mock.On call
+//   - input gameServer.Point
+func (_e *MockPoint_Expecter) CreatePoint(input interface{}) *MockPoint_CreatePoint_Call {
+	return &MockPoint_CreatePoint_Call{Call: _e.mock.On("CreatePoint", input)}
+}
+
+func (_c *MockPoint_CreatePoint_Call) Run(run func(input gameServer.Point)) *MockPoint_CreatePoint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 gameServer.Point
+		if args[0] != nil {
+			arg0 = args[0].(gameServer.Point)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPoint_CreatePoint_Call) Return(n int, err error) *MockPoint_CreatePoint_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockPoint_CreatePoint_Call) RunAndReturn(run func(input gameServer.Point) (int, error)) *MockPoint_CreatePoint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeletePoint provides a mock function for the type MockPoint
+func (_mock *MockPoint) DeletePoint(id int) error {
+	ret := _mock.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePoint")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int) error); ok {
+		r0 = returnFunc(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockPoint_DeletePoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePoint'
+type MockPoint_DeletePoint_Call struct {
+	*mock.Call
+}
+
+// DeletePoint is a helper method to define mock.On call
+//   - id int
+func (_e *MockPoint_Expecter) DeletePoint(id interface{}) *MockPoint_DeletePoint_Call {
+	return &MockPoint_DeletePoint_Call{Call: _e.mock.On("DeletePoint", id)}
+}
+
+func (_c *MockPoint_DeletePoint_Call) Run(run func(id int)) *MockPoint_DeletePoint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPoint_DeletePoint_Call) Return(err error) *MockPoint_DeletePoint_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockPoint_DeletePoint_Call) RunAndReturn(run func(id int) error) *MockPoint_DeletePoint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllPointsById provides a mock function for the type MockPoint
+func (_mock *MockPoint) GetAllPointsById(id int) ([]gameServer.Point, error) {
+	ret := _mock.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllPointsById")
+	}
+
+	var r0 []gameServer.Point
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int) ([]gameServer.Point, error)); ok {
+		return returnFunc(id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int) []gameServer.Point); ok {
+		r0 = returnFunc(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gameServer.Point)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int) error); ok {
+		r1 = returnFunc(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPoint_GetAllPointsById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllPointsById'
+type MockPoint_GetAllPointsById_Call struct {
+	*mock.Call
+}
+
+// GetAllPointsById is a helper method to define mock.On call
+//   - id int
+func (_e *MockPoint_Expecter) GetAllPointsById(id interface{}) *MockPoint_GetAllPointsById_Call {
+	return &MockPoint_GetAllPointsById_Call{Call: _e.mock.On("GetAllPointsById", id)}
+}
+
+func (_c *MockPoint_GetAllPointsById_Call) Run(run func(id int)) *MockPoint_GetAllPointsById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPoint_GetAllPointsById_Call) Return(points []gameServer.Point, err error) *MockPoint_GetAllPointsById_Call {
+	_c.Call.Return(points, err)
+	return _c
+}
+
+func (_c *MockPoint_GetAllPointsById_Call) RunAndReturn(run func(id int) ([]gameServer.Point, error)) *MockPoint_GetAllPointsById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCsvOfPoints provides a mock function for the type MockPoint
+func (_mock *MockPoint) GetCsvOfPoints() (string, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCsvOfPoints")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() (string, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPoint_GetCsvOfPoints_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCsvOfPoints'
+type MockPoint_GetCsvOfPoints_Call struct {
+	*mock.Call
+}
+
+// GetCsvOfPoints is a helper method to define mock.On call
+func (_e *MockPoint_Expecter) GetCsvOfPoints() *MockPoint_GetCsvOfPoints_Call {
+	return &MockPoint_GetCsvOfPoints_Call{Call: _e.mock.On("GetCsvOfPoints")}
+}
+
+func (_c *MockPoint_GetCsvOfPoints_Call) Run(run func()) *MockPoint_GetCsvOfPoints_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockPoint_GetCsvOfPoints_Call) Return(s string, err error) *MockPoint_GetCsvOfPoints_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *MockPoint_GetCsvOfPoints_Call) RunAndReturn(run func() (string, error)) *MockPoint_GetCsvOfPoints_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOnePoint provides a mock function for the type MockPoint
+func (_mock *MockPoint) GetOnePoint(id int) (gameServer.Point, error) {
+	ret := _mock.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOnePoint")
+	}
+
+	var r0 gameServer.Point
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int) (gameServer.Point, error)); ok {
+		return returnFunc(id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int) gameServer.Point); ok {
+		r0 = returnFunc(id)
+	} else {
+		r0 = ret.Get(0).(gameServer.Point)
+	}
+	if returnFunc, ok := ret.Get(1).(func(int) error); ok {
+		r1 = returnFunc(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPoint_GetOnePoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOnePoint'
+type MockPoint_GetOnePoint_Call struct {
+	*mock.Call
+}
+
+// GetOnePoint is a helper method to define mock.On call
+//   - id int
+func (_e *MockPoint_Expecter) GetOnePoint(id interface{}) *MockPoint_GetOnePoint_Call {
+	return &MockPoint_GetOnePoint_Call{Call: _e.mock.On("GetOnePoint", id)}
+}
+
+func (_c *MockPoint_GetOnePoint_Call) Run(run func(id int)) *MockPoint_GetOnePoint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPoint_GetOnePoint_Call) Return(point gameServer.Point, err error) *MockPoint_GetOnePoint_Call {
+	_c.Call.Return(point, err)
+	return _c
+}
+
+func (_c *MockPoint_GetOnePoint_Call) RunAndReturn(run func(id int) (gameServer.Point, error)) *MockPoint_GetOnePoint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockStatistics creates a new instance of MockStatistics. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStatistics(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStatistics {
+	mock := &MockStatistics{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockStatistics is an autogenerated mock type for the Statistics type
+type MockStatistics struct {
+	mock.Mock
+}
+
+type MockStatistics_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStatistics) EXPECT() *MockStatistics_Expecter {
+	return &MockStatistics_Expecter{mock: &_m.Mock}
+}
+
+// ComputeStatistics provides a mock function for the type MockStatistics
+func (_mock *MockStatistics) ComputeStatistics(input gameServer.ComputeStatisticsInput) (gameServer.Statistics, error) {
+	ret := _mock.Called(input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ComputeStatistics")
+	}
+
+	var r0 gameServer.Statistics
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(gameServer.ComputeStatisticsInput) (gameServer.Statistics, error)); ok {
+		return returnFunc(input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(gameServer.ComputeStatisticsInput) gameServer.Statistics); ok {
+		r0 = returnFunc(input)
+	} else {
+		r0 = ret.Get(0).(gameServer.Statistics)
+	}
+	if returnFunc, ok := ret.Get(1).(func(gameServer.ComputeStatisticsInput) error); ok {
+		r1 = returnFunc(input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStatistics_ComputeStatistics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ComputeStatistics'
+type MockStatistics_ComputeStatistics_Call struct {
+	*mock.Call
+}
+
+// ComputeStatistics is a helper method to define mock.On call
+//   - input gameServer.ComputeStatisticsInput
+func (_e *MockStatistics_Expecter) ComputeStatistics(input interface{}) *MockStatistics_ComputeStatistics_Call {
+	return &MockStatistics_ComputeStatistics_Call{Call: _e.mock.On("ComputeStatistics", input)}
+}
+
+func (_c *MockStatistics_ComputeStatistics_Call) Run(run func(input gameServer.ComputeStatisticsInput)) *MockStatistics_ComputeStatistics_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 gameServer.ComputeStatisticsInput
+		if args[0] != nil {
+			arg0 = args[0].(gameServer.ComputeStatisticsInput)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStatistics_ComputeStatistics_Call) Return(statistics gameServer.Statistics, err error) *MockStatistics_ComputeStatistics_Call {
+	_c.Call.Return(statistics, err)
+	return _c
+}
+
+func (_c *MockStatistics_ComputeStatistics_Call) RunAndReturn(run func(input gameServer.ComputeStatisticsInput) (gameServer.Statistics, error)) *MockStatistics_ComputeStatistics_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStatistics provides a mock function for the type MockStatistics
+func (_mock *MockStatistics) GetStatistics(userId int, parSetId int) (gameServer.Statistics, error) {
+	ret := _mock.Called(userId, parSetId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatistics")
+	}
+
+	var r0 gameServer.Statistics
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, int) (gameServer.Statistics, error)); ok {
+		return returnFunc(userId, parSetId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int) gameServer.Statistics); ok {
+		r0 = returnFunc(userId, parSetId)
+	} else {
+		r0 = ret.Get(0).(gameServer.Statistics)
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = returnFunc(userId, parSetId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStatistics_GetStatistics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStatistics'
+type MockStatistics_GetStatistics_Call struct {
+	*mock.Call
+}
+
+// GetStatistics is a helper method to define mock.On call
+//   - userId int
+//   - parSetId int
+func (_e *MockStatistics_Expecter) GetStatistics(userId interface{}, parSetId interface{}) *MockStatistics_GetStatistics_Call {
+	return &MockStatistics_GetStatistics_Call{Call: _e.mock.On("GetStatistics", userId, parSetId)}
+}
+
+func (_c *MockStatistics_GetStatistics_Call) Run(run func(userId int, parSetId int)) *MockStatistics_GetStatistics_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStatistics_GetStatistics_Call) Return(statistics gameServer.Statistics, err error) *MockStatistics_GetStatistics_Call {
+	_c.Call.Return(statistics, err)
+	return _c
+}
+
+func (_c *MockStatistics_GetStatistics_Call) RunAndReturn(run func(userId int, parSetId int) (gameServer.Statistics, error)) *MockStatistics_GetStatistics_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTestGameCorrelations provides a mock function for the type MockStatistics
+func (_mock *MockStatistics) GetTestGameCorrelations(input gameServer.TestGameCorrelationsInput) (gameServer.TestGameCorrelations, error) {
+	ret := _mock.Called(input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTestGameCorrelations")
+	}
+
+	var r0 gameServer.TestGameCorrelations
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(gameServer.TestGameCorrelationsInput) (gameServer.TestGameCorrelations, error)); ok {
+		return returnFunc(input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(gameServer.TestGameCorrelationsInput) gameServer.TestGameCorrelations); ok {
+		r0 = returnFunc(input)
+	} else {
+		r0 = ret.Get(0).(gameServer.TestGameCorrelations)
+	}
+	if returnFunc, ok := ret.Get(1).(func(gameServer.TestGameCorrelationsInput) error); ok {
+		r1 = returnFunc(input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStatistics_GetTestGameCorrelations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTestGameCorrelations'
+type MockStatistics_GetTestGameCorrelations_Call struct {
+	*mock.Call
+}
+
+// GetTestGameCorrelations is a helper method to define mock.On call
+//   - input gameServer.TestGameCorrelationsInput
+func (_e *MockStatistics_Expecter) GetTestGameCorrelations(input interface{}) *MockStatistics_GetTestGameCorrelations_Call {
+	return &MockStatistics_GetTestGameCorrelations_Call{Call: _e.mock.On("GetTestGameCorrelations", input)}
+}
+
+func (_c *MockStatistics_GetTestGameCorrelations_Call) Run(run func(input gameServer.TestGameCorrelationsInput)) *MockStatistics_GetTestGameCorrelations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 gameServer.TestGameCorrelationsInput
+		if args[0] != nil {
+			arg0 = args[0].(gameServer.TestGameCorrelationsInput)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStatistics_GetTestGameCorrelations_Call) Return(testGameCorrelations gameServer.TestGameCorrelations, err error) *MockStatistics_GetTestGameCorrelations_Call {
+	_c.Call.Return(testGameCorrelations, err)
+	return _c
+}
+
+func (_c *MockStatistics_GetTestGameCorrelations_Call) RunAndReturn(run func(input gameServer.TestGameCorrelationsInput) (gameServer.TestGameCorrelations, error)) *MockStatistics_GetTestGameCorrelations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockHint creates a new instance of MockHint. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockHint(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockHint {
+	mock := &MockHint{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockHint is an autogenerated mock type for the Hint type
+type MockHint struct {
+	mock.Mock
+}
+
+type MockHint_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockHint) EXPECT() *MockHint_Expecter {
+	return &MockHint_Expecter{mock: &_m.Mock}
+}
+
+// GetCrashHint provides a mock function for the type MockHint
+func (_mock *MockHint) GetCrashHint(chartId int, input gameServer.GetHintInput) (gameServer.Hint, error) {
+	ret := _mock.Called(chartId, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCrashHint")
+	}
+
+	var r0 gameServer.Hint
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, gameServer.GetHintInput) (gameServer.Hint, error)); ok {
+		return returnFunc(chartId, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, gameServer.GetHintInput) gameServer.Hint); ok {
+		r0 = returnFunc(chartId, input)
+	} else {
+		r0 = ret.Get(0).(gameServer.Hint)
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, gameServer.GetHintInput) error); ok {
+		r1 = returnFunc(chartId, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockHint_GetCrashHint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCrashHint'
+type MockHint_GetCrashHint_Call struct {
+	*mock.Call
+}
+
+// GetCrashHint is a helper method to define mock.On call
+//   - chartId int
+//   - input gameServer.GetHintInput
+func (_e *MockHint_Expecter) GetCrashHint(chartId interface{}, input interface{}) *MockHint_GetCrashHint_Call {
+	return &MockHint_GetCrashHint_Call{Call: _e.mock.On("GetCrashHint", chartId, input)}
+}
+
+func (_c *MockHint_GetCrashHint_Call) Run(run func(chartId int, input gameServer.GetHintInput)) *MockHint_GetCrashHint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 gameServer.GetHintInput
+		if args[1] != nil {
+			arg1 = args[1].(gameServer.GetHintInput)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockHint_GetCrashHint_Call) Return(hint gameServer.Hint, err error) *MockHint_GetCrashHint_Call {
+	_c.Call.Return(hint, err)
+	return _c
+}
+
+func (_c *MockHint_GetCrashHint_Call) RunAndReturn(run func(chartId int, input gameServer.GetHintInput) (gameServer.Hint, error)) *MockHint_GetCrashHint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAdvisor creates a new instance of MockAdvisor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAdvisor(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAdvisor {
+	mock := &MockAdvisor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAdvisor is an autogenerated mock type for the Advisor type
+type MockAdvisor struct {
+	mock.Mock
+}
+
+type MockAdvisor_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAdvisor) EXPECT() *MockAdvisor_Expecter {
+	return &MockAdvisor_Expecter{mock: &_m.Mock}
+}
+
+// GetAdvice provides a mock function for the type MockAdvisor
+func (_mock *MockAdvisor) GetAdvice(chartId int, input gameServer.GetAdviceInput) (gameServer.Advice, error) {
+	ret := _mock.Called(chartId, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAdvice")
+	}
+
+	var r0 gameServer.Advice
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, gameServer.GetAdviceInput) (gameServer.Advice, error)); ok {
+		return returnFunc(chartId, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, gameServer.GetAdviceInput) gameServer.Advice); ok {
+		r0 = returnFunc(chartId, input)
+	} else {
+		r0 = ret.Get(0).(gameServer.Advice)
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, gameServer.GetAdviceInput) error); ok {
+		r1 = returnFunc(chartId, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAdvisor_GetAdvice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAdvice'
+type MockAdvisor_GetAdvice_Call struct {
+	*mock.Call
+}
+
+// GetAdvice is a helper method to define mock.On call
+//   - chartId int
+//   - input gameServer.GetAdviceInput
+func (_e *MockAdvisor_Expecter) GetAdvice(chartId interface{}, input interface{}) *MockAdvisor_GetAdvice_Call {
+	return &MockAdvisor_GetAdvice_Call{Call: _e.mock.On("GetAdvice", chartId, input)}
+}
+
+func (_c *MockAdvisor_GetAdvice_Call) Run(run func(chartId int, input gameServer.GetAdviceInput)) *MockAdvisor_GetAdvice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 gameServer.GetAdviceInput
+		if args[1] != nil {
+			arg1 = args[1].(gameServer.GetAdviceInput)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAdvisor_GetAdvice_Call) Return(advice gameServer.Advice, err error) *MockAdvisor_GetAdvice_Call {
+	_c.Call.Return(advice, err)
+	return _c
+}
+
+func (_c *MockAdvisor_GetAdvice_Call) RunAndReturn(run func(chartId int, input gameServer.GetAdviceInput) (gameServer.Advice, error)) *MockAdvisor_GetAdvice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockScenario creates a new instance of MockScenario. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockScenario(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockScenario {
+	mock := &MockScenario{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockScenario is an autogenerated mock type for the Scenario type
+type MockScenario struct {
+	mock.Mock
+}
+
+type MockScenario_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockScenario) EXPECT() *MockScenario_Expecter {
+	return &MockScenario_Expecter{mock: &_m.Mock}
+}
+
+// CreateScenarios provides a mock function for the type MockScenario
+func (_mock *MockScenario) CreateScenarios(parSetId int, input gameServer.CreateScenariosInput) ([]int, error) {
+	ret := _mock.Called(parSetId, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateScenarios")
+	}
+
+	var r0 []int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, gameServer.CreateScenariosInput) ([]int, error)); ok {
+		return returnFunc(parSetId, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, gameServer.CreateScenariosInput) []int); ok {
+		r0 = returnFunc(parSetId, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, gameServer.CreateScenariosInput) error); ok {
+		r1 = returnFunc(parSetId, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockScenario_CreateScenarios_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateScenarios'
+type MockScenario_CreateScenarios_Call struct {
+	*mock.Call
+}
+
+// CreateScenarios is a helper method to define mock.On call
+//   - parSetId int
+//   - input gameServer.CreateScenariosInput
+func (_e *MockScenario_Expecter) CreateScenarios(parSetId interface{}, input interface{}) *MockScenario_CreateScenarios_Call {
+	return &MockScenario_CreateScenarios_Call{Call: _e.mock.On("CreateScenarios", parSetId, input)}
+}
+
+func (_c *MockScenario_CreateScenarios_Call) Run(run func(parSetId int, input gameServer.CreateScenariosInput)) *MockScenario_CreateScenarios_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 gameServer.CreateScenariosInput
+		if args[1] != nil {
+			arg1 = args[1].(gameServer.CreateScenariosInput)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockScenario_CreateScenarios_Call) Return(ints []int, err error) *MockScenario_CreateScenarios_Call {
+	_c.Call.Return(ints, err)
+	return _c
+}
+
+func (_c *MockScenario_CreateScenarios_Call) RunAndReturn(run func(parSetId int, input gameServer.CreateScenariosInput) ([]int, error)) *MockScenario_CreateScenarios_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllScenarios provides a mock function for the type MockScenario
+func (_mock *MockScenario) GetAllScenarios(parSetId int) ([]gameServer.Scenario, error) {
+	ret := _mock.Called(parSetId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllScenarios")
+	}
+
+	var r0 []gameServer.Scenario
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int) ([]gameServer.Scenario, error)); ok {
+		return returnFunc(parSetId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int) []gameServer.Scenario); ok {
+		r0 = returnFunc(parSetId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gameServer.Scenario)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int) error); ok {
+		r1 = returnFunc(parSetId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockScenario_GetAllScenarios_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllScenarios'
+type MockScenario_GetAllScenarios_Call struct {
+	*mock.Call
+}
+
+// GetAllScenarios is a helper method to define mock.On call
+//   - parSetId int
+func (_e *MockScenario_Expecter) GetAllScenarios(parSetId interface{}) *MockScenario_GetAllScenarios_Call {
+	return &MockScenario_GetAllScenarios_Call{Call: _e.mock.On("GetAllScenarios", parSetId)}
+}
+
+func (_c *MockScenario_GetAllScenarios_Call) Run(run func(parSetId int)) *MockScenario_GetAllScenarios_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockScenario_GetAllScenarios_Call) Return(scenarios []gameServer.Scenario, err error) *MockScenario_GetAllScenarios_Call {
+	_c.Call.Return(scenarios, err)
+	return _c
+}
+
+func (_c *MockScenario_GetAllScenarios_Call) RunAndReturn(run func(parSetId int) ([]gameServer.Scenario, error)) *MockScenario_GetAllScenarios_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChartScenario provides a mock function for the type MockScenario
+func (_mock *MockScenario) GetChartScenario(chartId int) (gameServer.Scenario, error) {
+	ret := _mock.Called(chartId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChartScenario")
+	}
+
+	var r0 gameServer.Scenario
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int) (gameServer.Scenario, error)); ok {
+		return returnFunc(chartId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int) gameServer.Scenario); ok {
+		r0 = returnFunc(chartId)
+	} else {
+		r0 = ret.Get(0).(gameServer.Scenario)
+	}
+	if returnFunc, ok := ret.Get(1).(func(int) error); ok {
+		r1 = returnFunc(chartId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockScenario_GetChartScenario_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChartScenario'
+type MockScenario_GetChartScenario_Call struct {
+	*mock.Call
+}
+
+// GetChartScenario is a helper method to define mock.On call
+//   - chartId int
+func (_e *MockScenario_Expecter) GetChartScenario(chartId interface{}) *MockScenario_GetChartScenario_Call {
+	return &MockScenario_GetChartScenario_Call{Call: _e.mock.On("GetChartScenario", chartId)}
+}
+
+func (_c *MockScenario_GetChartScenario_Call) Run(run func(chartId int)) *MockScenario_GetChartScenario_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockScenario_GetChartScenario_Call) Return(scenario gameServer.Scenario, err error) *MockScenario_GetChartScenario_Call {
+	_c.Call.Return(scenario, err)
+	return _c
+}
+
+func (_c *MockScenario_GetChartScenario_Call) RunAndReturn(run func(chartId int) (gameServer.Scenario, error)) *MockScenario_GetChartScenario_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTest creates a new instance of MockTest. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTest(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTest {
+	mock := &MockTest{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTest is an autogenerated mock type for the Test type
+type MockTest struct {
+	mock.Mock
+}
+
+type MockTest_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTest) EXPECT() *MockTest_Expecter {
+	return &MockTest_Expecter{mock: &_m.Mock}
+}
+
+// CreateTest provides a mock function for the type MockTest
+func (_mock *MockTest) CreateTest(input gameServer.CreateTestInput) (int, error) {
+	ret := _mock.Called(input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTest")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(gameServer.CreateTestInput) (int, error)); ok {
+		return returnFunc(input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(gameServer.CreateTestInput) int); ok {
+		r0 = returnFunc(input)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(gameServer.CreateTestInput) error); ok {
+		r1 = returnFunc(input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTest_CreateTest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTest'
+type MockTest_CreateTest_Call struct {
+	*mock.Call
+}
+
+// CreateTest is a helper method to define mock.On call
+//   - input gameServer.CreateTestInput
+func (_e *MockTest_Expecter) CreateTest(input interface{}) *MockTest_CreateTest_Call {
+	return &MockTest_CreateTest_Call{Call: _e.mock.On("CreateTest", input)}
+}
+
+func (_c *MockTest_CreateTest_Call) Run(run func(input gameServer.CreateTestInput)) *MockTest_CreateTest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 gameServer.CreateTestInput
+		if args[0] != nil {
+			arg0 = args[0].(gameServer.CreateTestInput)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTest_CreateTest_Call) Return(n int, err error) *MockTest_CreateTest_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockTest_CreateTest_Call) RunAndReturn(run func(input gameServer.CreateTestInput) (int, error)) *MockTest_CreateTest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateTestFromInstrument provides a mock function for the type MockTest
+func (_mock *MockTest) CreateTestFromInstrument(instrumentId string, input gameServer.CreateInstrumentTestInput) (int, error) {
+	ret := _mock.Called(instrumentId, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTestFromInstrument")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, gameServer.CreateInstrumentTestInput) (int, error)); ok {
+		return returnFunc(instrumentId, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, gameServer.CreateInstrumentTestInput) int); ok {
+		r0 = returnFunc(instrumentId, input)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, gameServer.CreateInstrumentTestInput) error); ok {
+		r1 = returnFunc(instrumentId, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTest_CreateTestFromInstrument_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTestFromInstrument'
+type MockTest_CreateTestFromInstrument_Call struct {
+	*mock.Call
+}
+
+// CreateTestFromInstrument is a helper method to define mock.On call
+//   - instrumentId string
+//   - input gameServer.CreateInstrumentTestInput
+func (_e *MockTest_Expecter) CreateTestFromInstrument(instrumentId interface{}, input interface{}) *MockTest_CreateTestFromInstrument_Call {
+	return &MockTest_CreateTestFromInstrument_Call{Call: _e.mock.On("CreateTestFromInstrument", instrumentId, input)}
+}
+
+func (_c *MockTest_CreateTestFromInstrument_Call) Run(run func(instrumentId string, input gameServer.CreateInstrumentTestInput)) *MockTest_CreateTestFromInstrument_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 gameServer.CreateInstrumentTestInput
+		if args[1] != nil {
+			arg1 = args[1].(gameServer.CreateInstrumentTestInput)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTest_CreateTestFromInstrument_Call) Return(n int, err error) *MockTest_CreateTestFromInstrument_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockTest_CreateTestFromInstrument_Call) RunAndReturn(run func(instrumentId string, input gameServer.CreateInstrumentTestInput) (int, error)) *MockTest_CreateTestFromInstrument_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTest provides a mock function for the type MockTest
+func (_mock *MockTest) DeleteTest(id int) error {
+	ret := _mock.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTest")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int) error); ok {
+		r0 = returnFunc(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTest_DeleteTest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTest'
+type MockTest_DeleteTest_Call struct {
+	*mock.Call
+}
+
+// DeleteTest is a helper method to define mock.On call
+//   - id int
+func (_e *MockTest_Expecter) DeleteTest(id interface{}) *MockTest_DeleteTest_Call {
+	return &MockTest_DeleteTest_Call{Call: _e.mock.On("DeleteTest", id)}
+}
+
+func (_c *MockTest_DeleteTest_Call) Run(run func(id int)) *MockTest_DeleteTest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTest_DeleteTest_Call) Return(err error) *MockTest_DeleteTest_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTest_DeleteTest_Call) RunAndReturn(run func(id int) error) *MockTest_DeleteTest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllTests provides a mock function for the type MockTest
+func (_mock *MockTest) GetAllTests() ([]gameServer.Test, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllTests")
+	}
+
+	var r0 []gameServer.Test
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() ([]gameServer.Test, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() []gameServer.Test); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gameServer.Test)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTest_GetAllTests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllTests'
+type MockTest_GetAllTests_Call struct {
+	*mock.Call
+}
+
+// GetAllTests is a helper method to define mock.On call
+func (_e *MockTest_Expecter) GetAllTests() *MockTest_GetAllTests_Call {
+	return &MockTest_GetAllTests_Call{Call: _e.mock.On("GetAllTests")}
+}
+
+func (_c *MockTest_GetAllTests_Call) Run(run func()) *MockTest_GetAllTests_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockTest_GetAllTests_Call) Return(tests []gameServer.Test, err error) *MockTest_GetAllTests_Call {
+	_c.Call.Return(tests, err)
+	return _c
+}
+
+func (_c *MockTest_GetAllTests_Call) RunAndReturn(run func() ([]gameServer.Test, error)) *MockTest_GetAllTests_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetInstruments provides a mock function for the type MockTest
+func (_mock *MockTest) GetInstruments(locale string) []gameServer.Instrument {
+	ret := _mock.Called(locale)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInstruments")
+	}
+
+	var r0 []gameServer.Instrument
+	if returnFunc, ok := ret.Get(0).(func(string) []gameServer.Instrument); ok {
+		r0 = returnFunc(locale)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gameServer.Instrument)
+		}
+	}
+	return r0
+}
+
+// MockTest_GetInstruments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInstruments'
+type MockTest_GetInstruments_Call struct {
+	*mock.Call
+}
+
+// GetInstruments is a helper method to define mock.On call
+//   - locale string
+func (_e *MockTest_Expecter) GetInstruments(locale interface{}) *MockTest_GetInstruments_Call {
+	return &MockTest_GetInstruments_Call{Call: _e.mock.On("GetInstruments", locale)}
+}
+
+func (_c *MockTest_GetInstruments_Call) Run(run func(locale string)) *MockTest_GetInstruments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTest_GetInstruments_Call) Return(instruments []gameServer.Instrument) *MockTest_GetInstruments_Call {
+	_c.Call.Return(instruments)
+	return _c
+}
+
+func (_c *MockTest_GetInstruments_Call) RunAndReturn(run func(locale string) []gameServer.Instrument) *MockTest_GetInstruments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProgress provides a mock function for the type MockTest
+func (_mock *MockTest) GetProgress(userId int, testId int) ([]gameServer.TestDraft, error) {
+	ret := _mock.Called(userId, testId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProgress")
+	}
+
+	var r0 []gameServer.TestDraft
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, int) ([]gameServer.TestDraft, error)); ok {
+		return returnFunc(userId, testId)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int) []gameServer.TestDraft); ok {
+		r0 = returnFunc(userId, testId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gameServer.TestDraft)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = returnFunc(userId, testId)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTest_GetProgress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProgress'
+type MockTest_GetProgress_Call struct {
+	*mock.Call
+}
+
+// GetProgress is a helper method to define mock.On call
+//   - userId int
+//   - testId int
+func (_e *MockTest_Expecter) GetProgress(userId interface{}, testId interface{}) *MockTest_GetProgress_Call {
+	return &MockTest_GetProgress_Call{Call: _e.mock.On("GetProgress", userId, testId)}
+}
+
+func (_c *MockTest_GetProgress_Call) Run(run func(userId int, testId int)) *MockTest_GetProgress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTest_GetProgress_Call) Return(testDrafts []gameServer.TestDraft, err error) *MockTest_GetProgress_Call {
+	_c.Call.Return(testDrafts, err)
+	return _c
+}
+
+func (_c *MockTest_GetProgress_Call) RunAndReturn(run func(userId int, testId int) ([]gameServer.TestDraft, error)) *MockTest_GetProgress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReport provides a mock function for the type MockTest
+func (_mock *MockTest) GetReport(testId int, input gameServer.TestReportInput) (gameServer.TestReport, error) {
+	ret := _mock.Called(testId, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReport")
+	}
+
+	var r0 gameServer.TestReport
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, gameServer.TestReportInput) (gameServer.TestReport, error)); ok {
+		return returnFunc(testId, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, gameServer.TestReportInput) gameServer.TestReport); ok {
+		r0 = returnFunc(testId, input)
+	} else {
+		r0 = ret.Get(0).(gameServer.TestReport)
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, gameServer.TestReportInput) error); ok {
+		r1 = returnFunc(testId, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTest_GetReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReport'
+type MockTest_GetReport_Call struct {
+	*mock.Call
+}
+
+// GetReport is a helper method to define mock.On call
+//   - testId int
+//   - input gameServer.TestReportInput
+func (_e *MockTest_Expecter) GetReport(testId interface{}, input interface{}) *MockTest_GetReport_Call {
+	return &MockTest_GetReport_Call{Call: _e.mock.On("GetReport", testId, input)}
+}
+
+func (_c *MockTest_GetReport_Call) Run(run func(testId int, input gameServer.TestReportInput)) *MockTest_GetReport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 gameServer.TestReportInput
+		if args[1] != nil {
+			arg1 = args[1].(gameServer.TestReportInput)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTest_GetReport_Call) Return(testReport gameServer.TestReport, err error) *MockTest_GetReport_Call {
+	_c.Call.Return(testReport, err)
+	return _c
+}
+
+func (_c *MockTest_GetReport_Call) RunAndReturn(run func(testId int, input gameServer.TestReportInput) (gameServer.TestReport, error)) *MockTest_GetReport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSessionStatus provides a mock function for the type MockTest
+func (_mock *MockTest) GetSessionStatus(userId int) (gameServer.TestSessionStatus, error) {
+	ret := _mock.Called(userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSessionStatus")
 	}
 
-	var r0 gameServer.Chart
+	var r0 gameServer.TestSessionStatus
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(int) (gameServer.Chart, error)); ok {
-		return returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(int) (gameServer.TestSessionStatus, error)); ok {
+		return returnFunc(userId)
 	}
-	if returnFunc, ok := ret.Get(0).(func(int) gameServer.Chart); ok {
-		r0 = returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(int) gameServer.TestSessionStatus); ok {
+		r0 = returnFunc(userId)
 	} else {
-		r0 = ret.Get(0).(gameServer.Chart)
+		r0 = ret.Get(0).(gameServer.TestSessionStatus)
 	}
 	if returnFunc, ok := ret.Get(1).(func(int) error); ok {
-		r1 = returnFunc(id)
+		r1 = returnFunc(userId)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockChart_GetOneChart_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOneChart'
-type MockChart_GetOneChart_Call struct {
+// MockTest_GetSessionStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSessionStatus'
+type MockTest_GetSessionStatus_Call struct {
 	*mock.Call
 }
 
-// GetOneChart is a helper method to define mock.On call
-//   - id int
-func (_e *MockChart_Expecter) GetOneChart(id interface{}) *MockChart_GetOneChart_Call {
-	return &MockChart_GetOneChart_Call{Call: _e.mock.On("GetOneChart", id)}
+// GetSessionStatus is a helper method to define mock.On call
+//   - userId int
+func (_e *MockTest_Expecter) GetSessionStatus(userId interface{}) *MockTest_GetSessionStatus_Call {
+	return &MockTest_GetSessionStatus_Call{Call: _e.mock.On("GetSessionStatus", userId)}
 }
 
-func (_c *MockChart_GetOneChart_Call) Run(run func(id int)) *MockChart_GetOneChart_Call {
+func (_c *MockTest_GetSessionStatus_Call) Run(run func(userId int)) *MockTest_GetSessionStatus_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 int
 		if args[0] != nil {
@@ -1659,138 +4172,122 @@ func (_c *MockChart_GetOneChart_Call) Run(run func(id int)) *MockChart_GetOneCha
 	return _c
 }
 
-func (_c *MockChart_GetOneChart_Call) Return(chart gameServer.Chart, err error) *MockChart_GetOneChart_Call {
-	_c.Call.Return(chart, err)
+func (_c *MockTest_GetSessionStatus_Call) Return(testSessionStatus gameServer.TestSessionStatus, err error) *MockTest_GetSessionStatus_Call {
+	_c.Call.Return(testSessionStatus, err)
 	return _c
 }
 
-func (_c *MockChart_GetOneChart_Call) RunAndReturn(run func(id int) (gameServer.Chart, error)) *MockChart_GetOneChart_Call {
+func (_c *MockTest_GetSessionStatus_Call) RunAndReturn(run func(userId int) (gameServer.TestSessionStatus, error)) *MockTest_GetSessionStatus_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetParSetsPageCount provides a mock function for the type MockChart
-func (_mock *MockChart) GetParSetsPageCount() (int, error) {
-	ret := _mock.Called()
+// GetUserResults provides a mock function for the type MockTest
+func (_mock *MockTest) GetUserResults(userId int) ([]gameServer.TestResult, error) {
+	ret := _mock.Called(userId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetParSetsPageCount")
+		panic("no return value specified for GetUserResults")
 	}
 
-	var r0 int
+	var r0 []gameServer.TestResult
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func() (int, error)); ok {
-		return returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(int) ([]gameServer.TestResult, error)); ok {
+		return returnFunc(userId)
 	}
-	if returnFunc, ok := ret.Get(0).(func() int); ok {
-		r0 = returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(int) []gameServer.TestResult); ok {
+		r0 = returnFunc(userId)
 	} else {
-		r0 = ret.Get(0).(int)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gameServer.TestResult)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func() error); ok {
-		r1 = returnFunc()
+	if returnFunc, ok := ret.Get(1).(func(int) error); ok {
+		r1 = returnFunc(userId)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockChart_GetParSetsPageCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetParSetsPageCount'
-type MockChart_GetParSetsPageCount_Call struct {
+// MockTest_GetUserResults_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserResults'
+type MockTest_GetUserResults_Call struct {
 	*mock.Call
 }
 
-// GetParSetsPageCount is a helper method to define mock.On call
-func (_e *MockChart_Expecter) GetParSetsPageCount() *MockChart_GetParSetsPageCount_Call {
-	return &MockChart_GetParSetsPageCount_Call{Call: _e.mock.On("GetParSetsPageCount")}
+// GetUserResults is a helper method to define mock.On call
+//   - userId int
+func (_e *MockTest_Expecter) GetUserResults(userId interface{}) *MockTest_GetUserResults_Call {
+	return &MockTest_GetUserResults_Call{Call: _e.mock.On("GetUserResults", userId)}
 }
 
-func (_c *MockChart_GetParSetsPageCount_Call) Run(run func()) *MockChart_GetParSetsPageCount_Call {
+func (_c *MockTest_GetUserResults_Call) Run(run func(userId int)) *MockTest_GetUserResults_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		run(
+			arg0,
+		)
 	})
 	return _c
 }
 
-func (_c *MockChart_GetParSetsPageCount_Call) Return(n int, err error) *MockChart_GetParSetsPageCount_Call {
-	_c.Call.Return(n, err)
+func (_c *MockTest_GetUserResults_Call) Return(testResults []gameServer.TestResult, err error) *MockTest_GetUserResults_Call {
+	_c.Call.Return(testResults, err)
 	return _c
 }
 
-func (_c *MockChart_GetParSetsPageCount_Call) RunAndReturn(run func() (int, error)) *MockChart_GetParSetsPageCount_Call {
+func (_c *MockTest_GetUserResults_Call) RunAndReturn(run func(userId int) ([]gameServer.TestResult, error)) *MockTest_GetUserResults_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockPoint creates a new instance of MockPoint. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockPoint(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockPoint {
-	mock := &MockPoint{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockPoint is an autogenerated mock type for the Point type
-type MockPoint struct {
-	mock.Mock
-}
-
-type MockPoint_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockPoint) EXPECT() *MockPoint_Expecter {
-	return &MockPoint_Expecter{mock: &_m.Mock}
-}
-
-// CreatePoint provides a mock function for the type MockPoint
-func (_mock *MockPoint) CreatePoint(input gameServer.Point) (int, error) {
-	ret := _mock.Called(input)
+// GetUserResultsWithTests provides a mock function for the type MockTest
+func (_mock *MockTest) GetUserResultsWithTests(userId int) ([]gameServer.TestResultWithTest, error) {
+	ret := _mock.Called(userId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreatePoint")
+		panic("no return value specified for GetUserResultsWithTests")
 	}
 
-	var r0 int
+	var r0 []gameServer.TestResultWithTest
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(gameServer.Point) (int, error)); ok {
-		return returnFunc(input)
+	if returnFunc, ok := ret.Get(0).(func(int) ([]gameServer.TestResultWithTest, error)); ok {
+		return returnFunc(userId)
 	}
-	if returnFunc, ok := ret.Get(0).(func(gameServer.Point) int); ok {
-		r0 = returnFunc(input)
+	if returnFunc, ok := ret.Get(0).(func(int) []gameServer.TestResultWithTest); ok {
+		r0 = returnFunc(userId)
 	} else {
-		r0 = ret.Get(0).(int)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gameServer.TestResultWithTest)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(gameServer.Point) error); ok {
-		r1 = returnFunc(input)
+	if returnFunc, ok := ret.Get(1).(func(int) error); ok {
+		r1 = returnFunc(userId)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockPoint_CreatePoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePoint'
-type MockPoint_CreatePoint_Call struct {
+// MockTest_GetUserResultsWithTests_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserResultsWithTests'
+type MockTest_GetUserResultsWithTests_Call struct {
 	*mock.Call
 }
 
-// CreatePoint is a helper method to define mock.On call
-//   - input gameServer.Point
-func (_e *MockPoint_Expecter) CreatePoint(input interface{}) *MockPoint_CreatePoint_Call {
-	return &MockPoint_CreatePoint_Call{Call: _e.mock.On("CreatePoint", input)}
+// GetUserResultsWithTests is a helper method to define mock.On call
+//   - userId int
+func (_e *MockTest_Expecter) GetUserResultsWithTests(userId interface{}) *MockTest_GetUserResultsWithTests_Call {
+	return &MockTest_GetUserResultsWithTests_Call{Call: _e.mock.On("GetUserResultsWithTests", userId)}
 }
 
-func (_c *MockPoint_CreatePoint_Call) Run(run func(input gameServer.Point)) *MockPoint_CreatePoint_Call {
+func (_c *MockTest_GetUserResultsWithTests_Call) Run(run func(userId int)) *MockTest_GetUserResultsWithTests_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 gameServer.Point
+		var arg0 int
 		if args[0] != nil {
-			arg0 = args[0].(gameServer.Point)
+			arg0 = args[0].(int)
 		}
 		run(
 			arg0,
@@ -1799,238 +4296,270 @@ func (_c *MockPoint_CreatePoint_Call) Run(run func(input gameServer.Point)) *Moc
 	return _c
 }
 
-func (_c *MockPoint_CreatePoint_Call) Return(n int, err error) *MockPoint_CreatePoint_Call {
-	_c.Call.Return(n, err)
+func (_c *MockTest_GetUserResultsWithTests_Call) Return(testResultWithTests []gameServer.TestResultWithTest, err error) *MockTest_GetUserResultsWithTests_Call {
+	_c.Call.Return(testResultWithTests, err)
 	return _c
 }
 
-func (_c *MockPoint_CreatePoint_Call) RunAndReturn(run func(input gameServer.Point) (int, error)) *MockPoint_CreatePoint_Call {
+func (_c *MockTest_GetUserResultsWithTests_Call) RunAndReturn(run func(userId int) ([]gameServer.TestResultWithTest, error)) *MockTest_GetUserResultsWithTests_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeletePoint provides a mock function for the type MockPoint
-func (_mock *MockPoint) DeletePoint(id int) error {
-	ret := _mock.Called(id)
+// GetVisibility provides a mock function for the type MockTest
+func (_mock *MockTest) GetVisibility(userId int, testId int, input gameServer.TestVisibilityInput) (gameServer.TestVisibility, error) {
+	ret := _mock.Called(userId, testId, input)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeletePoint")
+		panic("no return value specified for GetVisibility")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(int) error); ok {
-		r0 = returnFunc(id)
+	var r0 gameServer.TestVisibility
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, int, gameServer.TestVisibilityInput) (gameServer.TestVisibility, error)); ok {
+		return returnFunc(userId, testId, input)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int, gameServer.TestVisibilityInput) gameServer.TestVisibility); ok {
+		r0 = returnFunc(userId, testId, input)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(gameServer.TestVisibility)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(int, int, gameServer.TestVisibilityInput) error); ok {
+		r1 = returnFunc(userId, testId, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockPoint_DeletePoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePoint'
-type MockPoint_DeletePoint_Call struct {
+// MockTest_GetVisibility_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVisibility'
+type MockTest_GetVisibility_Call struct {
 	*mock.Call
 }
 
-// DeletePoint is a helper method to define mock.On call
-//   - id int
-func (_e *MockPoint_Expecter) DeletePoint(id interface{}) *MockPoint_DeletePoint_Call {
-	return &MockPoint_DeletePoint_Call{Call: _e.mock.On("DeletePoint", id)}
+// GetVisibility is a helper method to define mock.On call
+//   - userId int
+//   - testId int
+//   - input gameServer.TestVisibilityInput
+func (_e *MockTest_Expecter) GetVisibility(userId interface{}, testId interface{}, input interface{}) *MockTest_GetVisibility_Call {
+	return &MockTest_GetVisibility_Call{Call: _e.mock.On("GetVisibility", userId, testId, input)}
 }
 
-func (_c *MockPoint_DeletePoint_Call) Run(run func(id int)) *MockPoint_DeletePoint_Call {
+func (_c *MockTest_GetVisibility_Call) Run(run func(userId int, testId int, input gameServer.TestVisibilityInput)) *MockTest_GetVisibility_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 int
 		if args[0] != nil {
 			arg0 = args[0].(int)
 		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 gameServer.TestVisibilityInput
+		if args[2] != nil {
+			arg2 = args[2].(gameServer.TestVisibilityInput)
+		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockPoint_DeletePoint_Call) Return(err error) *MockPoint_DeletePoint_Call {
-	_c.Call.Return(err)
+func (_c *MockTest_GetVisibility_Call) Return(testVisibility gameServer.TestVisibility, err error) *MockTest_GetVisibility_Call {
+	_c.Call.Return(testVisibility, err)
 	return _c
 }
 
-func (_c *MockPoint_DeletePoint_Call) RunAndReturn(run func(id int) error) *MockPoint_DeletePoint_Call {
+func (_c *MockTest_GetVisibility_Call) RunAndReturn(run func(userId int, testId int, input gameServer.TestVisibilityInput) (gameServer.TestVisibility, error)) *MockTest_GetVisibility_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAllPointsById provides a mock function for the type MockPoint
-func (_mock *MockPoint) GetAllPointsById(id int) ([]gameServer.Point, error) {
-	ret := _mock.Called(id)
+// SaveProgress provides a mock function for the type MockTest
+func (_mock *MockTest) SaveProgress(userId int, testId int, input gameServer.SaveTestProgressInput) error {
+	ret := _mock.Called(userId, testId, input)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllPointsById")
+		panic("no return value specified for SaveProgress")
 	}
 
-	var r0 []gameServer.Point
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(int) ([]gameServer.Point, error)); ok {
-		return returnFunc(id)
-	}
-	if returnFunc, ok := ret.Get(0).(func(int) []gameServer.Point); ok {
-		r0 = returnFunc(id)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]gameServer.Point)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(int) error); ok {
-		r1 = returnFunc(id)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int, int, gameServer.SaveTestProgressInput) error); ok {
+		r0 = returnFunc(userId, testId, input)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockPoint_GetAllPointsById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllPointsById'
-type MockPoint_GetAllPointsById_Call struct {
+// MockTest_SaveProgress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveProgress'
+type MockTest_SaveProgress_Call struct {
 	*mock.Call
 }
 
-// GetAllPointsById is a helper method to define mock.On call
-//   - id int
-func (_e *MockPoint_Expecter) GetAllPointsById(id interface{}) *MockPoint_GetAllPointsById_Call {
-	return &MockPoint_GetAllPointsById_Call{Call: _e.mock.On("GetAllPointsById", id)}
+// SaveProgress is a helper method to define mock.On call
+//   - userId int
+//   - testId int
+//   - input gameServer.SaveTestProgressInput
+func (_e *MockTest_Expecter) SaveProgress(userId interface{}, testId interface{}, input interface{}) *MockTest_SaveProgress_Call {
+	return &MockTest_SaveProgress_Call{Call: _e.mock.On("SaveProgress", userId, testId, input)}
 }
 
-func (_c *MockPoint_GetAllPointsById_Call) Run(run func(id int)) *MockPoint_GetAllPointsById_Call {
+func (_c *MockTest_SaveProgress_Call) Run(run func(userId int, testId int, input gameServer.SaveTestProgressInput)) *MockTest_SaveProgress_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 int
 		if args[0] != nil {
 			arg0 = args[0].(int)
 		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 gameServer.SaveTestProgressInput
+		if args[2] != nil {
+			arg2 = args[2].(gameServer.SaveTestProgressInput)
+		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockPoint_GetAllPointsById_Call) Return(points []gameServer.Point, err error) *MockPoint_GetAllPointsById_Call {
-	_c.Call.Return(points, err)
+func (_c *MockTest_SaveProgress_Call) Return(err error) *MockTest_SaveProgress_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockPoint_GetAllPointsById_Call) RunAndReturn(run func(id int) ([]gameServer.Point, error)) *MockPoint_GetAllPointsById_Call {
+func (_c *MockTest_SaveProgress_Call) RunAndReturn(run func(userId int, testId int, input gameServer.SaveTestProgressInput) error) *MockTest_SaveProgress_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetCsvOfPoints provides a mock function for the type MockPoint
-func (_mock *MockPoint) GetCsvOfPoints() (string, error) {
-	ret := _mock.Called()
+// SubmitResult provides a mock function for the type MockTest
+func (_mock *MockTest) SubmitResult(userId int, input gameServer.SubmitTestResultInput) (int, error) {
+	ret := _mock.Called(userId, input)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetCsvOfPoints")
+		panic("no return value specified for SubmitResult")
 	}
 
-	var r0 string
+	var r0 int
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func() (string, error)); ok {
-		return returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(int, gameServer.SubmitTestResultInput) (int, error)); ok {
+		return returnFunc(userId, input)
 	}
-	if returnFunc, ok := ret.Get(0).(func() string); ok {
-		r0 = returnFunc()
+	if returnFunc, ok := ret.Get(0).(func(int, gameServer.SubmitTestResultInput) int); ok {
+		r0 = returnFunc(userId, input)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(int)
 	}
-	if returnFunc, ok := ret.Get(1).(func() error); ok {
-		r1 = returnFunc()
+	if returnFunc, ok := ret.Get(1).(func(int, gameServer.SubmitTestResultInput) error); ok {
+		r1 = returnFunc(userId, input)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockPoint_GetCsvOfPoints_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCsvOfPoints'
-type MockPoint_GetCsvOfPoints_Call struct {
+// MockTest_SubmitResult_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubmitResult'
+type MockTest_SubmitResult_Call struct {
 	*mock.Call
 }
 
-// GetCsvOfPoints is a helper method to define mock.On call
-func (_e *MockPoint_Expecter) GetCsvOfPoints() *MockPoint_GetCsvOfPoints_Call {
-	return &MockPoint_GetCsvOfPoints_Call{Call: _e.mock.On("GetCsvOfPoints")}
+// SubmitResult is a helper method to define mock.On call
+//   - userId int
+//   - input gameServer.SubmitTestResultInput
+func (_e *MockTest_Expecter) SubmitResult(userId interface{}, input interface{}) *MockTest_SubmitResult_Call {
+	return &MockTest_SubmitResult_Call{Call: _e.mock.On("SubmitResult", userId, input)}
 }
 
-func (_c *MockPoint_GetCsvOfPoints_Call) Run(run func()) *MockPoint_GetCsvOfPoints_Call {
+func (_c *MockTest_SubmitResult_Call) Run(run func(userId int, input gameServer.SubmitTestResultInput)) *MockTest_SubmitResult_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 gameServer.SubmitTestResultInput
+		if args[1] != nil {
+			arg1 = args[1].(gameServer.SubmitTestResultInput)
+		}
+		run(
+			arg0,
+			arg1,
+		)
 	})
 	return _c
 }
 
-func (_c *MockPoint_GetCsvOfPoints_Call) Return(s string, err error) *MockPoint_GetCsvOfPoints_Call {
-	_c.Call.Return(s, err)
+func (_c *MockTest_SubmitResult_Call) Return(n int, err error) *MockTest_SubmitResult_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockPoint_GetCsvOfPoints_Call) RunAndReturn(run func() (string, error)) *MockPoint_GetCsvOfPoints_Call {
+func (_c *MockTest_SubmitResult_Call) RunAndReturn(run func(userId int, input gameServer.SubmitTestResultInput) (int, error)) *MockTest_SubmitResult_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetOnePoint provides a mock function for the type MockPoint
-func (_mock *MockPoint) GetOnePoint(id int) (gameServer.Point, error) {
-	ret := _mock.Called(id)
+// UpdateTest provides a mock function for the type MockTest
+func (_mock *MockTest) UpdateTest(id int, input gameServer.UpdateTestInput) error {
+	ret := _mock.Called(id, input)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetOnePoint")
+		panic("no return value specified for UpdateTest")
 	}
 
-	var r0 gameServer.Point
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(int) (gameServer.Point, error)); ok {
-		return returnFunc(id)
-	}
-	if returnFunc, ok := ret.Get(0).(func(int) gameServer.Point); ok {
-		r0 = returnFunc(id)
-	} else {
-		r0 = ret.Get(0).(gameServer.Point)
-	}
-	if returnFunc, ok := ret.Get(1).(func(int) error); ok {
-		r1 = returnFunc(id)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(int, gameServer.UpdateTestInput) error); ok {
+		r0 = returnFunc(id, input)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockPoint_GetOnePoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOnePoint'
-type MockPoint_GetOnePoint_Call struct {
+// MockTest_UpdateTest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTest'
+type MockTest_UpdateTest_Call struct {
 	*mock.Call
 }
 
-// GetOnePoint is a helper method to define mock.On call
+// UpdateTest is a helper method to define mock.On call
 //   - id int
-func (_e *MockPoint_Expecter) GetOnePoint(id interface{}) *MockPoint_GetOnePoint_Call {
-	return &MockPoint_GetOnePoint_Call{Call: _e.mock.On("GetOnePoint", id)}
+//   - input gameServer.UpdateTestInput
+func (_e *MockTest_Expecter) UpdateTest(id interface{}, input interface{}) *MockTest_UpdateTest_Call {
+	return &MockTest_UpdateTest_Call{Call: _e.mock.On("UpdateTest", id, input)}
 }
 
-func (_c *MockPoint_GetOnePoint_Call) Run(run func(id int)) *MockPoint_GetOnePoint_Call {
+func (_c *MockTest_UpdateTest_Call) Run(run func(id int, input gameServer.UpdateTestInput)) *MockTest_UpdateTest_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 int
 		if args[0] != nil {
 			arg0 = args[0].(int)
 		}
+		var arg1 gameServer.UpdateTestInput
+		if args[1] != nil {
+			arg1 = args[1].(gameServer.UpdateTestInput)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockPoint_GetOnePoint_Call) Return(point gameServer.Point, err error) *MockPoint_GetOnePoint_Call {
-	_c.Call.Return(point, err)
+func (_c *MockTest_UpdateTest_Call) Return(err error) *MockTest_UpdateTest_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockPoint_GetOnePoint_Call) RunAndReturn(run func(id int) (gameServer.Point, error)) *MockPoint_GetOnePoint_Call {
+func (_c *MockTest_UpdateTest_Call) RunAndReturn(run func(id int, input gameServer.UpdateTestInput) error) *MockTest_UpdateTest_Call {
 	_c.Call.Return(run)
 	return _c
 }
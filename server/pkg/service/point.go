@@ -8,15 +8,44 @@ import (
 )
 
 type PointService struct {
-	repo repository.Point
+	repo      repository.Point
+	chartRepo repository.Chart
+	hub       *LiveHub
 }
 
-func NewPointService(repo repository.Point) *PointService {
-	return &PointService{repo: repo}
+func NewPointService(repo repository.Point, chartRepo repository.Chart, hub *LiveHub) *PointService {
+	return &PointService{repo: repo, chartRepo: chartRepo, hub: hub}
 }
 
 func (s *PointService) CreatePoint(input gameServer.Point) (int, error) {
-	return s.repo.CreatePoint(input)
+	points := []gameServer.Point{input}
+	if err := checkPointHints(s.chartRepo, input.ChartId, points); err != nil {
+		return 0, err
+	}
+	if err := checkPointAdvice(s.chartRepo, input.ChartId, points); err != nil {
+		return 0, err
+	}
+	input = points[0]
+
+	id, err := s.repo.CreatePoint(input)
+	if err != nil {
+		return 0, err
+	}
+
+	if !input.IsUsefulAiSignal && !input.IsDeceptiveAiSignal && !input.IsCheck && !input.IsStop && !input.IsCrash {
+		return id, nil
+	}
+
+	// The point is already stored, so a failed lookup only costs the dashboard an event.
+	chart, err := s.chartRepo.GetOneChart(input.ChartId)
+	if err != nil {
+		return id, nil
+	}
+	for _, event := range pointLiveEvents(input, chart) {
+		s.hub.Publish(event)
+	}
+
+	return id, nil
 }
 
 func (s *PointService) GetOnePoint(id int) (gameServer.Point, error) {
@@ -36,10 +65,34 @@ func (s *PointService) GetCsvOfPoints() (string, error) {
 	if err != nil {
 		return "", err
 	}
-	csv := "parameter_set_id, user_id, chart_id, point_id, x, y, score, is_crash, is_useful_ai_signal, is_deceptive_ai_signal, is_stop, is_pause, is_check, check_info\r\n"
+	csv := "parameter_set_id, user_id, scenario_id, chart_id, point_id, x, y, score, is_crash, is_useful_ai_signal, is_deceptive_ai_signal, is_stop, is_pause, is_check, hint_type, risk_level, crash_probability, ai_confidence, reaction_time_ms\r\n"
 	for _, p := range points {
-		csv += fmt.Sprintf("%v, %v, %v, %v, %v, %v, %v, %v, %v, %v, %v, %v, %v, %v\r\n", p.ParameterSetId, p.UserId, p.ChartId, p.Id, p.X, p.Y, p.Score,
-			p.IsCrash, p.IsUsefulAiSignal, p.IsDeceptiveAiSignal, p.IsStop, p.IsPause, p.IsCheck, p.CheckInfo)
+		scenarioId := ""
+		if p.ScenarioId != nil {
+			scenarioId = fmt.Sprintf("%v", *p.ScenarioId)
+		}
+		hintType := ""
+		if p.HintType != nil {
+			hintType = *p.HintType
+		}
+		riskLevel := ""
+		if p.RiskLevel != nil {
+			riskLevel = *p.RiskLevel
+		}
+		crashProbability := ""
+		if p.CrashProbability != nil {
+			crashProbability = fmt.Sprintf("%v", *p.CrashProbability)
+		}
+		aiConfidence := ""
+		if p.AiConfidence != nil {
+			aiConfidence = fmt.Sprintf("%v", *p.AiConfidence)
+		}
+		reactionTime := ""
+		if p.ReactionTimeMs != nil {
+			reactionTime = fmt.Sprintf("%v", *p.ReactionTimeMs)
+		}
+		csv += fmt.Sprintf("%v, %v, %v, %v, %v, %v, %v, %v, %v, %v, %v, %v, %v, %v, %v, %v, %v, %v, %v\r\n", p.ParameterSetId, p.UserId, scenarioId, p.ChartId, p.Id, p.X, p.Y, p.Score,
+			p.IsCrash, p.IsUsefulAiSignal, p.IsDeceptiveAiSignal, p.IsStop, p.IsPause, p.IsCheck, hintType, riskLevel, crashProbability, aiConfidence, reactionTime)
 	}
 	return csv, nil
 }
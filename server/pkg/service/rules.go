@@ -0,0 +1,71 @@
+package service
+
+import (
+	gameServer "example.com/gameHoldTheProcessServer"
+	"example.com/gameHoldTheProcessServer/pkg/lib"
+	"golang.org/x/text/language"
+)
+
+// GetParSetRules renders the rules of the parameter set in the locale that
+// best matches the Accept-Language header. The scoring values are filled in
+// from the parameter set itself, so the rules always agree with the scoring.
+func (s *ChartService) GetParSetRules(id int, acceptLanguage string) (gameServer.ParSetRules, error) {
+	parSet, err := s.repo.GetOneParSet(id)
+	if err != nil {
+		return gameServer.ParSetRules{}, err
+	}
+
+	rules, err := gameServer.ParseRules(parSet.Rules)
+	if err != nil {
+		return gameServer.ParSetRules{}, err
+	}
+	locales := gameServer.RulesLocales(rules)
+	if len(locales) == 0 {
+		return gameServer.ParSetRules{Locales: locales}, nil
+	}
+
+	scoring, err := gameServer.ParseScoringConfig(parSet.ScoringConfig)
+	if err != nil {
+		return gameServer.ParSetRules{}, err
+	}
+	hints, err := gameServer.ParseHintConfig(parSet.HintConfig)
+	if err != nil {
+		return gameServer.ParSetRules{}, err
+	}
+
+	locale := matchRulesLocale(acceptLanguage, locales)
+	markdown, err := gameServer.ExpandRules(
+		rules[locale],
+		gameServer.RulesValues(scoring, parSet.HintCost, hints, parSet.FalseAlarmThreshold),
+	)
+	if err != nil {
+		return gameServer.ParSetRules{}, err
+	}
+
+	return gameServer.ParSetRules{
+		Locale:   locale,
+		Locales:  locales,
+		Markdown: markdown,
+		Html:     lib.RenderMarkdown(markdown),
+	}, nil
+}
+
+// matchRulesLocale picks one of the locales for the Accept-Language header,
+// falling back to the first locale when nothing matches.
+func matchRulesLocale(acceptLanguage string, locales []string) string {
+	preferred, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(preferred) == 0 {
+		return locales[0]
+	}
+
+	supported := make([]language.Tag, len(locales))
+	for i, locale := range locales {
+		supported[i] = language.Make(locale)
+	}
+
+	_, index, confidence := language.NewMatcher(supported).Match(preferred...)
+	if confidence == language.No {
+		return locales[0]
+	}
+	return locales[index]
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"encoding/json"
+	"math/rand/v2"
+	"time"
+
+	gameServer "example.com/gameHoldTheProcessServer"
+	"example.com/gameHoldTheProcessServer/pkg/lib"
+	"example.com/gameHoldTheProcessServer/pkg/repository"
+)
+
+type ScenarioService struct {
+	repo      repository.Scenario
+	chartRepo repository.Chart
+}
+
+func NewScenarioService(repo repository.Scenario, chartRepo repository.Chart) *ScenarioService {
+	return &ScenarioService{repo: repo, chartRepo: chartRepo}
+}
+
+func (s *ScenarioService) CreateScenarios(parSetId int, input gameServer.CreateScenariosInput) ([]int, error) {
+	parSet, err := s.chartRepo.GetOneParSet(parSetId)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := gameServer.ParseAdvisorConfig(parSet.AdvisorConfig)
+	if err != nil {
+		return nil, err
+	}
+	advisor := newAdvisor(config, parSet.FalseWarningProb, parSet.MissingDangerProb)
+
+	seed := time.Now().UnixNano()
+	if input.Seed != nil {
+		seed = *input.Seed
+	}
+
+	scenarios := make([]gameServer.Scenario, 0, input.Count)
+	for i := 0; i < input.Count; i++ {
+		scenario, err := generateScenario(parSet, advisor, seed+int64(i), input.Length)
+		if err != nil {
+			return nil, err
+		}
+		scenarios = append(scenarios, scenario)
+	}
+
+	return s.repo.CreateScenarios(scenarios)
+}
+
+func (s *ScenarioService) GetAllScenarios(parSetId int) ([]gameServer.Scenario, error) {
+	return s.repo.GetAllScenarios(parSetId)
+}
+
+func (s *ScenarioService) GetChartScenario(chartId int) (gameServer.Scenario, error) {
+	chart, err := s.chartRepo.GetOneChart(chartId)
+	if err != nil {
+		return gameServer.Scenario{}, err
+	}
+
+	scenarioId := chart.ScenarioId
+	if scenarioId == nil {
+		scenarioId, err = s.repo.AssignScenario(chartId)
+		if err != nil {
+			return gameServer.Scenario{}, err
+		}
+		if scenarioId == nil {
+			return gameServer.Scenario{}, gameServer.ErrNoScenarios
+		}
+	}
+
+	return s.repo.GetOneScenario(*scenarioId)
+}
+
+// generateScenario plays the game the same way the client does: the advisor
+// looks one step ahead and false warnings are only given above the false
+// alarm threshold.
+func generateScenario(parSet gameServer.ParameterSet, advisor lib.Advisor, seed int64, length int) (gameServer.Scenario, error) {
+	rng := rand.New(rand.NewPCG(uint64(seed), 0))
+
+	params := processParams(parSet.A, parSet.B, parSet.NoiseMean, parSet.NoiseStDev)
+	trajectory := lib.SimulateProcess(params, length, rng)
+
+	dangerPoints := make([]int, 0)
+	aiSignals := make([]int, 0)
+	falseAlarmLevel := float64(parSet.FalseAlarmThreshold) * criticalValue
+	for x := 0; x < len(trajectory)-1; x++ {
+		state := lib.AdvisorState{
+			Step:          x,
+			Y:             trajectory[x],
+			CriticalValue: criticalValue,
+			IsDanger:      trajectory[x+1] >= criticalValue,
+		}
+		if state.IsDanger {
+			dangerPoints = append(dangerPoints, x)
+		}
+		if !state.IsDanger && state.Y < falseAlarmLevel {
+			continue
+		}
+		if lib.Advise(advisor, state, rng) {
+			aiSignals = append(aiSignals, x)
+		}
+	}
+
+	jsonTrajectory, err := json.Marshal(trajectory)
+	if err != nil {
+		return gameServer.Scenario{}, err
+	}
+	jsonDangerPoints, err := json.Marshal(dangerPoints)
+	if err != nil {
+		return gameServer.Scenario{}, err
+	}
+	jsonAiSignals, err := json.Marshal(aiSignals)
+	if err != nil {
+		return gameServer.Scenario{}, err
+	}
+
+	return gameServer.Scenario{
+		ParameterSetId: parSet.Id,
+		Seed:           seed,
+		Trajectory:     jsonTrajectory,
+		DangerPoints:   jsonDangerPoints,
+		AiSignals:      jsonAiSignals,
+	}, nil
+}
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"time"
+
 	gameServer "example.com/gameHoldTheProcessServer"
 	"example.com/gameHoldTheProcessServer/pkg/repository"
 )
@@ -29,18 +31,31 @@ type User interface {
 	UpdateUserUserParSet(id int, input gameServer.UpdateUserUserParSetInput) error
 	ChangeGroupParSet(input gameServer.ChangeGroupParSetInput) error
 	FixBugStat(start, end int) (map[int]float64, error)
+	HasResearcherAccess(researcherId, userId int) (bool, error)
+	GetOneGroup(id int) (gameServer.Group, error)
 }
 
 type Chart interface {
 	CreateChart(chart gameServer.CreateChartInput) (int, error)
+	OpenChart(chart gameServer.CreateChartInput) (int, error)
+	AppendPoints(chartId int, input gameServer.AppendChartPointsInput) ([]int, error)
+	CloseChart(chartId int, input gameServer.CloseChartInput) error
+	AbandonStaleCharts(timeout time.Duration) (int, error)
 	GetOneChart(id int) (gameServer.Chart, error)
 	GetChartsPageCount(input gameServer.GetChartsPageCountInput) (int, error)
 	GetChartsCount(input gameServer.GetChartsCountInput) (int, error)
 	GetAllCharts(input gameServer.GetAllChartsInput) ([]gameServer.Chart, error)
 	DeleteChart(id int) error
-	GetAllParSets(input gameServer.GetAllParSetsInput) ([]gameServer.ParameterSet, error)
+	GetAllParSets(input gameServer.GetAllParSetsInput) ([]gameServer.ParameterSetWithUsage, error)
 	GetParSetsPageCount() (int, error)
 	CreateParSet(input gameServer.CreateParSetInput) (int, error)
+	CreateParSetVersion(parentId int, input gameServer.CreateParSetInput) (int, error)
+	GetOneParSet(id int) (gameServer.ParameterSet, error)
+	UpdateParSet(id int, input gameServer.CreateParSetInput) error
+	ArchiveParSet(id int) error
+	DeleteParSet(id int) error
+	PreviewParSet(input gameServer.PreviewParSetInput) (gameServer.ParSetPreview, error)
+	GetParSetRules(id int, acceptLanguage string) (gameServer.ParSetRules, error)
 }
 
 type Point interface {
@@ -54,17 +69,42 @@ type Point interface {
 type Statistics interface {
 	ComputeStatistics(input gameServer.ComputeStatisticsInput) (gameServer.Statistics, error)
 	GetStatistics(userId, parSetId int) (gameServer.Statistics, error)
+	GetTestGameCorrelations(input gameServer.TestGameCorrelationsInput) (gameServer.TestGameCorrelations, error)
 }
 
 type Test interface {
 	GetAllTests() ([]gameServer.Test, error)
 	GetSessionStatus(userId int) (gameServer.TestSessionStatus, error)
 	SubmitResult(userId int, input gameServer.SubmitTestResultInput) (int, error)
+	GetVisibility(userId, testId int, input gameServer.TestVisibilityInput) (gameServer.TestVisibility, error)
+	SaveProgress(userId, testId int, input gameServer.SaveTestProgressInput) error
+	GetProgress(userId, testId int) ([]gameServer.TestDraft, error)
 	CreateTest(input gameServer.CreateTestInput) (int, error)
+	GetInstruments(locale string) []gameServer.Instrument
+	CreateTestFromInstrument(instrumentId string, input gameServer.CreateInstrumentTestInput) (int, error)
 	UpdateTest(id int, input gameServer.UpdateTestInput) error
 	DeleteTest(id int) error
 	GetUserResults(userId int) ([]gameServer.TestResult, error)
 	GetUserResultsWithTests(userId int) ([]gameServer.TestResultWithTest, error)
+	GetReport(testId int, input gameServer.TestReportInput) (gameServer.TestReport, error)
+}
+
+type Hint interface {
+	GetCrashHint(chartId int, input gameServer.GetHintInput) (gameServer.Hint, error)
+}
+
+type Advisor interface {
+	GetAdvice(chartId int, input gameServer.GetAdviceInput) (gameServer.Advice, error)
+}
+
+type Scenario interface {
+	CreateScenarios(parSetId int, input gameServer.CreateScenariosInput) ([]int, error)
+	GetAllScenarios(parSetId int) ([]gameServer.Scenario, error)
+	GetChartScenario(chartId int) (gameServer.Scenario, error)
+}
+
+type Live interface {
+	Subscribe(groupId int) (<-chan gameServer.LiveEvent, func())
 }
 
 type Service struct {
@@ -73,14 +113,24 @@ type Service struct {
 	Point
 	Statistics
 	Test
+	Hint
+	Advisor
+	Scenario
+	Live
 }
 
 func NewService(repo *repository.Repository) *Service {
+	hub := NewLiveHub(repo.User)
+
 	return &Service{
 		User:       NewUserService(repo.User),
-		Chart:      NewChartService(repo.Chart),
-		Point:      NewPointService(repo.Point),
-		Statistics: NewStatisticsService(repo.Statistics),
-		Test:       NewTestService(repo.Test),
+		Chart:      NewChartService(repo.Chart, repo.Scenario, hub),
+		Point:      NewPointService(repo.Point, repo.Chart, hub),
+		Statistics: NewStatisticsService(repo.Statistics, repo.Test),
+		Test:       NewTestService(repo.Test, repo.User),
+		Hint:       NewHintService(repo.Chart),
+		Advisor:    NewAdvisorService(repo.Chart, repo.Scenario),
+		Scenario:   NewScenarioService(repo.Scenario, repo.Chart),
+		Live:       NewLiveService(hub),
 	}
 }
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"math/rand/v2"
+
+	gameServer "example.com/gameHoldTheProcessServer"
+	"example.com/gameHoldTheProcessServer/pkg/lib"
+)
+
+func processParams(a, b, noiseMean, noiseStdev float32) lib.ProcessParams {
+	return lib.ProcessParams{
+		A:             float64(a),
+		B:             float64(b),
+		Control:       controlSignal,
+		NoiseMean:     float64(noiseMean),
+		NoiseStdev:    float64(noiseStdev),
+		CriticalValue: criticalValue,
+	}
+}
+
+type gameOutcome struct {
+	isCrash     bool
+	isStop      bool
+	length      int
+	signalCount int
+	score       float64
+}
+
+// stopDecision tells whether the player stops the process at the value y
+// when the advisor did or did not warn about danger.
+type stopDecision func(y float64, signal bool) bool
+
+func strategyDecision(strategy string, stopThreshold float64) stopDecision {
+	switch strategy {
+	case gameServer.StrategyFollowAi:
+		return func(y float64, signal bool) bool { return signal }
+	case gameServer.StrategyStopAtThreshold:
+		return func(y float64, signal bool) bool { return y >= stopThreshold }
+	default:
+		return func(y float64, signal bool) bool { return false }
+	}
+}
+
+// simulateGame plays one game without hints the way ChartData scores it: the
+// advisor looks one step ahead, false warnings are given above the false alarm
+// threshold, and crashing without a warning costs nothing.
+func simulateGame(params lib.ProcessParams, advisor lib.Advisor, scoring gameServer.ScoringConfig,
+	falseAlarmLevel float64, maxSteps int, decide stopDecision, rng *rand.Rand) gameOutcome {
+	var outcome gameOutcome
+	y := 0.0
+	for outcome.length < maxSteps {
+		next := lib.NextProcessValue(params, y, rng)
+		state := lib.AdvisorState{
+			Step:          outcome.length,
+			Y:             y,
+			CriticalValue: params.CriticalValue,
+			IsDanger:      next >= params.CriticalValue,
+		}
+
+		signal := false
+		if state.IsDanger || y >= falseAlarmLevel {
+			signal = lib.Advise(advisor, state, rng)
+		}
+		if signal {
+			outcome.signalCount++
+		}
+
+		if decide(y, signal) {
+			switch {
+			case signal && state.IsDanger:
+				outcome.score += scoring.BonusAcceptCorrectAdviceNoCheck - 2*scoring.BonusStep
+			case signal:
+				outcome.score -= scoring.PenaltyAcceptIncorrectAdviceNoCheck + 2*scoring.BonusStep
+			}
+			outcome.isStop = true
+			return outcome
+		}
+
+		if signal && !state.IsDanger {
+			outcome.score += scoring.BonusRejectIncorrectAdviceNoCheck
+		}
+		y = next
+		outcome.length++
+		outcome.score += scoring.BonusStep
+
+		if y >= params.CriticalValue {
+			if signal {
+				outcome.score = min(outcome.score, 0) - scoring.PenaltyRejectCorrectAdviceNoCheck
+			}
+			outcome.isCrash = true
+			return outcome
+		}
+	}
+	return outcome
+}
+
+func previewParSet(input gameServer.PreviewParSetInput, seed int64) (gameServer.ParSetPreview, error) {
+	parSet := input.ParSet
+	scoring, err := gameServer.ParseScoringConfig(parSet.ScoringConfig)
+	if err != nil {
+		return gameServer.ParSetPreview{}, err
+	}
+	config, err := gameServer.ParseAdvisorConfig(parSet.AdvisorConfig)
+	if err != nil {
+		return gameServer.ParSetPreview{}, err
+	}
+
+	params := processParams(parSet.A, parSet.B, parSet.NoiseMean, parSet.NoiseStdev)
+	advisor := newAdvisor(config, parSet.FalseWarningProb, parSet.MissingDangerProb)
+	falseAlarmLevel := float64(parSet.FalseAlarmThreshold) * criticalValue
+
+	preview := gameServer.ParSetPreview{
+		Games:    input.Games,
+		MaxSteps: input.MaxSteps,
+	}
+	strategies := []string{gameServer.StrategyFollowAi, gameServer.StrategyNeverFollow, gameServer.StrategyStopAtThreshold}
+	for _, strategy := range strategies {
+		decide := strategyDecision(strategy, *input.StopThreshold)
+
+		var crashes, stops, length, signals int
+		var score float64
+		for i := 0; i < input.Games; i++ {
+			// Every game has a stream of its own, so a strategy that ends a
+			// game early does not shift the games after it and every strategy
+			// plays the same games.
+			rng := rand.New(rand.NewPCG(uint64(seed), uint64(i)))
+			outcome := simulateGame(params, advisor, scoring, falseAlarmLevel, input.MaxSteps, decide, rng)
+			if outcome.isCrash {
+				crashes++
+			}
+			if outcome.isStop {
+				stops++
+			}
+			length += outcome.length
+			signals += outcome.signalCount
+			score += outcome.score
+		}
+
+		games := float64(input.Games)
+		preview.Strategies = append(preview.Strategies, gameServer.StrategyPreview{
+			Strategy:        strategy,
+			CrashRate:       float64(crashes) / games,
+			StopRate:        float64(stops) / games,
+			MeanGameLength:  float64(length) / games,
+			MeanSignalCount: float64(signals) / games,
+			MeanScore:       score / games,
+		})
+	}
+
+	return preview, nil
+}
@@ -2,12 +2,12 @@ package service
 
 import (
 	"encoding/json"
-	"fmt"
 	"math"
 	"slices"
 	"strconv"
 
 	gameServer "example.com/gameHoldTheProcessServer"
+	"example.com/gameHoldTheProcessServer/pkg/i18n"
 	"example.com/gameHoldTheProcessServer/pkg/lib"
 	"example.com/gameHoldTheProcessServer/pkg/repository"
 )
@@ -23,17 +23,27 @@ const (
 	choiceContinueLow  = "contL"
 	choiceContinueMed  = "contM"
 	choiceContinueHigh = "contH"
-	lowRiskLevel       = "Низкий уровень риска"
-	medRiskLevel       = "Средний уровень риска"
-	hightRiskLevel     = "Высокий уровень риска"
+)
+
+const (
+	// Группы времени реакции
+	reactionAll            = "all"
+	reactionUseful         = "useful"
+	reactionDeceptive      = "deceptive"
+	reactionNoSignal       = "no_signal"
+	reactionHint           = "hint"
+	reactionNoHint         = "no_hint"
+	reactionBinWidthMs     = 250
+	reactionLastBinStartMs = 5000
 )
 
 type StatisticsService struct {
-	repo repository.Statistics
+	repo     repository.Statistics
+	testRepo repository.Test
 }
 
-func NewStatisticsService(repo repository.Statistics) *StatisticsService {
-	return &StatisticsService{repo: repo}
+func NewStatisticsService(repo repository.Statistics, testRepo repository.Test) *StatisticsService {
+	return &StatisticsService{repo: repo, testRepo: testRepo}
 }
 
 func (s *StatisticsService) ComputeStatistics(input gameServer.ComputeStatisticsInput) (gameServer.Statistics, error) {
@@ -42,6 +52,24 @@ func (s *StatisticsService) ComputeStatistics(input gameServer.ComputeStatistics
 		return gameServer.Statistics{}, err
 	}
 
+	gamesByEndReason, err := s.repo.CountGamesByEndReason(input)
+	if err != nil {
+		return gameServer.Statistics{}, err
+	}
+	jsonGamesByEndReason, err := json.Marshal(gamesByEndReason)
+	if err != nil {
+		return gameServer.Statistics{}, err
+	}
+
+	hintsByType, err := s.repo.CountHintsByType(input)
+	if err != nil {
+		return gameServer.Statistics{}, err
+	}
+	jsonHintsByType, err := json.Marshal(hintsByType)
+	if err != nil {
+		return gameServer.Statistics{}, err
+	}
+
 	stopsCount, err := s.repo.CountStops(input)
 	if err != nil {
 		return gameServer.Statistics{}, err
@@ -82,6 +110,16 @@ func (s *StatisticsService) ComputeStatistics(input gameServer.ComputeStatistics
 	}
 	meanCAS, stdevCAS := lib.MeanAndStdev(continuesAfterSignal)
 
+	reactionPoints, err := s.repo.GetReactionTimes(input)
+	if err != nil {
+		return gameServer.Statistics{}, err
+	}
+
+	jsonReactionTimeStats, err := computeReactionTimeStats(reactionPoints)
+	if err != nil {
+		return gameServer.Statistics{}, err
+	}
+
 	totalScore, err := s.repo.GetTotalScore(input)
 
 	points, err := s.repo.GetAllEvents(input)
@@ -89,7 +127,7 @@ func (s *StatisticsService) ComputeStatistics(input gameServer.ComputeStatistics
 		return gameServer.Statistics{}, err
 	}
 
-	jsonChoiceStatsAnikin, err := computeChoiceStatsAnikin(points)
+	jsonChoiceStatsAnikin, err := computeChoiceStatsAnikin(points, input.Locale)
 	if err != nil {
 		return gameServer.Statistics{}, err
 	}
@@ -99,7 +137,7 @@ func (s *StatisticsService) ComputeStatistics(input gameServer.ComputeStatistics
 		return gameServer.Statistics{}, err
 	}
 
-	jsonChoiceStatsVengerCharts, err := computeChoiceStatsVengerCharts(points)
+	jsonChoiceStatsVengerCharts, err := computeChoiceStatsVengerCharts(points, input.Locale)
 	if err != nil {
 		return gameServer.Statistics{}, err
 	}
@@ -127,6 +165,14 @@ func (s *StatisticsService) ComputeStatistics(input gameServer.ComputeStatistics
 		ChoiceStats:              jsonChoiceStatsAnikin,
 		ChoiceStatsVengerTable:   jsonChoiceStatsVengerTable,
 		ChoiceStatsVengerCharts:  jsonChoiceStatsVengerCharts,
+		GamesByEndReason:         string(jsonGamesByEndReason),
+		ReactionTimeStats:        jsonReactionTimeStats,
+		HintsByType:              string(jsonHintsByType),
+	}
+
+	// Filtered statistics are returned as is so they don't replace the stored ones.
+	if input.IsFiltered() {
+		return stats, nil
 	}
 
 	err = s.repo.UpsertStatistics(input, stats)
@@ -141,7 +187,7 @@ func (s *StatisticsService) GetStatistics(userId, parSetId int) (gameServer.Stat
 	return s.repo.GetStatistics(userId, parSetId)
 }
 
-func computeChoiceStatsAnikin(points []gameServer.Point) (jsonChoiceStats string, err error) {
+func computeChoiceStatsAnikin(points []gameServer.Point, locale string) (jsonChoiceStats string, err error) {
 	var pointsWithChoices []gameServer.ChoiceStats
 	for _, point := range points {
 		if !(point.IsUsefulAiSignal || point.IsDeceptiveAiSignal) {
@@ -189,8 +235,12 @@ func computeChoiceStatsAnikin(points []gameServer.Point) (jsonChoiceStats string
 	numOfChunks := len(pointsWithChoices) / chunkSize
 	curChunkNum := 0
 
+	// TitleKey, From and To let the client translate the title itself.
 	type chunkWithTitle struct {
 		Title           string
+		TitleKey        string
+		From            int
+		To              int
 		ChunkChoiceStat map[string]pointStat
 	}
 
@@ -221,8 +271,14 @@ func computeChoiceStatsAnikin(points []gameServer.Point) (jsonChoiceStats string
 				chunk[strconv.FormatFloat(cs.Y, 'f', 0, 64)] = c
 			}
 		}
-		title := fmt.Sprintf("Точки принятия решений %v-%v", curChunkNum*chunkSize+1, (curChunkNum+1)*chunkSize)
-		chunks = append(chunks, chunkWithTitle{Title: title, ChunkChoiceStat: chunk})
+		from, to := curChunkNum*chunkSize+1, (curChunkNum+1)*chunkSize
+		chunks = append(chunks, chunkWithTitle{
+			Title:           i18n.T(locale, i18n.KeyDecisionPointsTitle, from, to),
+			TitleKey:        i18n.KeyDecisionPointsTitle,
+			From:            from,
+			To:              to,
+			ChunkChoiceStat: chunk,
+		})
 		curChunkNum++
 	}
 
@@ -247,8 +303,13 @@ func computeChoiceStatsAnikin(points []gameServer.Point) (jsonChoiceStats string
 			chunk[strconv.FormatFloat(cs.Y, 'f', 0, 64)] = c
 		}
 	}
-	title := fmt.Sprintf("Все точки принятия решений %v-%v", 1, len(pointsWithChoices))
-	chunks = append(chunks, chunkWithTitle{Title: title, ChunkChoiceStat: chunk})
+	chunks = append(chunks, chunkWithTitle{
+		Title:           i18n.T(locale, i18n.KeyAllDecisionPointsTitle, 1, len(pointsWithChoices)),
+		TitleKey:        i18n.KeyAllDecisionPointsTitle,
+		From:            1,
+		To:              len(pointsWithChoices),
+		ChunkChoiceStat: chunk,
+	})
 	curChunkNum++
 
 	for _, chunk := range chunks {
@@ -284,28 +345,28 @@ func computeChoiceStatsVengerTable(points []gameServer.Point) (jsonChoiceStats s
 		}
 		pointWithChoices := ChoiceStatsVenger{Y: math.Round(float64(point.Y * 100))}
 		if point.IsCheck {
-			checkInfo := ""
-			if point.CheckInfo != nil {
-				checkInfo = *point.CheckInfo
+			riskLevel := ""
+			if point.RiskLevel != nil {
+				riskLevel = *point.RiskLevel
 			}
 			if point.IsStop {
-				switch checkInfo {
-				case lowRiskLevel:
+				switch riskLevel {
+				case gameServer.RiskLevelLow:
 					pointWithChoices.ChoiceType = choiceStopLow
-				case medRiskLevel:
+				case gameServer.RiskLevelMedium:
 					pointWithChoices.ChoiceType = choiceStopMed
-				case hightRiskLevel:
+				case gameServer.RiskLevelHigh:
 					pointWithChoices.ChoiceType = choiceStopHigh
 				default:
 					pointWithChoices.ChoiceType = choiceStop
 				}
 			} else {
-				switch checkInfo {
-				case lowRiskLevel:
+				switch riskLevel {
+				case gameServer.RiskLevelLow:
 					pointWithChoices.ChoiceType = choiceContinueLow
-				case medRiskLevel:
+				case gameServer.RiskLevelMedium:
 					pointWithChoices.ChoiceType = choiceContinueMed
-				case hightRiskLevel:
+				case gameServer.RiskLevelHigh:
 					pointWithChoices.ChoiceType = choiceContinueHigh
 				default:
 					pointWithChoices.ChoiceType = choiceContinue
@@ -327,7 +388,7 @@ func computeChoiceStatsVengerTable(points []gameServer.Point) (jsonChoiceStats s
 	return string(json), nil
 }
 
-func computeChoiceStatsVengerCharts(points []gameServer.Point) (jsonChoiceStats string, err error) {
+func computeChoiceStatsVengerCharts(points []gameServer.Point, locale string) (jsonChoiceStats string, err error) {
 	var pointsWithChoices []gameServer.ChoiceStats
 	for _, point := range points {
 		if !(point.IsUsefulAiSignal || point.IsDeceptiveAiSignal) {
@@ -373,8 +434,12 @@ func computeChoiceStatsVengerCharts(points []gameServer.Point) (jsonChoiceStats
 	numOfChunks := len(pointsWithChoices) / chunkSize
 	curChunkNum := 0
 
+	// TitleKey, From and To let the client translate the title itself.
 	type chunkWithTitle struct {
 		Title           string
+		TitleKey        string
+		From            int
+		To              int
 		ChunkChoiceStat map[string]pointStat
 	}
 
@@ -405,8 +470,14 @@ func computeChoiceStatsVengerCharts(points []gameServer.Point) (jsonChoiceStats
 				chunk[strconv.FormatFloat(cs.Y, 'f', 0, 64)] = c
 			}
 		}
-		title := fmt.Sprintf("Точки принятия решений %v-%v", curChunkNum*chunkSize+1, (curChunkNum+1)*chunkSize)
-		chunks = append(chunks, chunkWithTitle{Title: title, ChunkChoiceStat: chunk})
+		from, to := curChunkNum*chunkSize+1, (curChunkNum+1)*chunkSize
+		chunks = append(chunks, chunkWithTitle{
+			Title:           i18n.T(locale, i18n.KeyDecisionPointsTitle, from, to),
+			TitleKey:        i18n.KeyDecisionPointsTitle,
+			From:            from,
+			To:              to,
+			ChunkChoiceStat: chunk,
+		})
 		curChunkNum++
 	}
 
@@ -431,8 +502,13 @@ func computeChoiceStatsVengerCharts(points []gameServer.Point) (jsonChoiceStats
 			chunk[strconv.FormatFloat(cs.Y, 'f', 0, 64)] = c
 		}
 	}
-	title := fmt.Sprintf("Все точки принятия решений %v-%v", 1, len(pointsWithChoices))
-	chunks = append(chunks, chunkWithTitle{Title: title, ChunkChoiceStat: chunk})
+	chunks = append(chunks, chunkWithTitle{
+		Title:           i18n.T(locale, i18n.KeyAllDecisionPointsTitle, 1, len(pointsWithChoices)),
+		TitleKey:        i18n.KeyAllDecisionPointsTitle,
+		From:            1,
+		To:              len(pointsWithChoices),
+		ChunkChoiceStat: chunk,
+	})
 	curChunkNum++
 
 	for _, chunk := range chunks {
@@ -455,3 +531,215 @@ func computeChoiceStatsVengerCharts(points []gameServer.Point) (jsonChoiceStats
 
 	return string(jsonChunks), nil
 }
+
+// computeReactionTimeStats splits reaction times by the kind of the signal
+// and by hint usage, e.g. "useful", "useful_hint", "deceptive_no_hint".
+func computeReactionTimeStats(points []gameServer.Point) (jsonReactionTimeStats string, err error) {
+	groups := make(map[string][]float64)
+	for _, point := range points {
+		if point.ReactionTimeMs == nil {
+			continue
+		}
+		rt := *point.ReactionTimeMs
+
+		signal := reactionNoSignal
+		if point.IsUsefulAiSignal {
+			signal = reactionUseful
+		} else if point.IsDeceptiveAiSignal {
+			signal = reactionDeceptive
+		}
+		hint := reactionNoHint
+		if point.IsCheck {
+			hint = reactionHint
+		}
+
+		groups[reactionAll] = append(groups[reactionAll], rt)
+		groups[signal] = append(groups[signal], rt)
+		groups[hint] = append(groups[hint], rt)
+		groups[signal+"_"+hint] = append(groups[signal+"_"+hint], rt)
+	}
+
+	stats := make(map[string]gameServer.ReactionTimeStats, len(groups))
+	for group, values := range groups {
+		mean, stdev := lib.MeanAndStdev(values)
+		stats[group] = gameServer.ReactionTimeStats{
+			Count:     len(values),
+			Mean:      mean,
+			Stdev:     stdev,
+			Min:       lib.Quantile(values, 0),
+			P25:       lib.Quantile(values, 0.25),
+			Median:    lib.Quantile(values, 0.5),
+			P75:       lib.Quantile(values, 0.75),
+			P90:       lib.Quantile(values, 0.9),
+			Max:       lib.Quantile(values, 1),
+			Histogram: reactionTimeHistogram(values),
+		}
+	}
+
+	jsonStats, err := json.Marshal(stats)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jsonStats), nil
+}
+
+// reactionTimeHistogram uses fixed-width bins so that distributions of
+// different participants can be compared; the last bin is open-ended.
+func reactionTimeHistogram(values []float64) []gameServer.ReactionTimeBin {
+	binsNum := reactionLastBinStartMs/reactionBinWidthMs + 1
+	bins := make([]gameServer.ReactionTimeBin, binsNum)
+	for i := range bins {
+		bins[i].FromMs = float64(i * reactionBinWidthMs)
+		if i < binsNum-1 {
+			toMs := float64((i + 1) * reactionBinWidthMs)
+			bins[i].ToMs = &toMs
+		}
+	}
+
+	for _, val := range values {
+		i := min(int(val/reactionBinWidthMs), binsNum-1)
+		bins[i].Count++
+	}
+
+	return bins
+}
+
+// GetTestGameCorrelations builds the table of the test scores and the game
+// metrics of the players of the group and correlates every pair of its
+// variables over the players that have both.
+func (s *StatisticsService) GetTestGameCorrelations(input gameServer.TestGameCorrelationsInput) (gameServer.TestGameCorrelations, error) {
+	participants, err := s.repo.GetGroupStatistics(input.GroupId, input.ParSetId)
+	if err != nil {
+		return gameServer.TestGameCorrelations{}, err
+	}
+	scores, err := s.testRepo.GetGroupTestScores(input.GroupId, input.Context, input.Phase)
+	if err != nil {
+		return gameServer.TestGameCorrelations{}, err
+	}
+
+	result := gameServer.TestGameCorrelations{
+		GroupId:      input.GroupId,
+		ParSetId:     input.ParSetId,
+		Variables:    []gameServer.CorrelationVariable{},
+		Participants: make([]gameServer.ParticipantMeasures, 0, len(participants)),
+	}
+	rows := make(map[int]int, len(participants))
+	for _, participant := range participants {
+		rows[participant.UserId] = len(result.Participants)
+		result.Participants = append(result.Participants, gameServer.ParticipantMeasures{
+			UserId: participant.UserId,
+			Name:   participant.Name,
+			Values: gameMetrics(participant),
+		})
+	}
+
+	// The test variables come first, ordered as the tests and their
+	// subscales are first met.
+	seen := map[string]bool{}
+	addVariable := func(variable gameServer.CorrelationVariable) {
+		if !seen[variable.Id] {
+			seen[variable.Id] = true
+			result.Variables = append(result.Variables, variable)
+		}
+	}
+	for _, score := range scores {
+		row, ok := rows[score.UserId]
+		if !ok {
+			continue
+		}
+		var details gameServer.TestScore
+		if err := json.Unmarshal(score.ScoreDetails, &details); err != nil {
+			return gameServer.TestGameCorrelations{}, err
+		}
+
+		version := strconv.Itoa(score.TestVersion)
+		testId := "test." + score.Slug + ".v" + version
+		title := score.Title + " (v" + version + ")"
+		if details.Total != nil {
+			addVariable(gameServer.CorrelationVariable{Id: testId, Title: title, Source: gameServer.VariableSourceTest})
+			result.Participants[row].Values[testId] = *details.Total
+		}
+		for _, subscale := range details.Subscales {
+			subscaleId := testId + "." + subscale.Id
+			addVariable(gameServer.CorrelationVariable{
+				Id:     subscaleId,
+				Title:  title + ": " + subscale.Title,
+				Source: gameServer.VariableSourceTest,
+			})
+			result.Participants[row].Values[subscaleId] = subscale.Score
+		}
+	}
+	for _, metric := range gameServer.GameMetrics {
+		addVariable(gameServer.CorrelationVariable{
+			Id:     metric,
+			Title:  i18n.T(input.Locale, i18n.MetricKey(metric)),
+			Source: gameServer.VariableSourceGame,
+		})
+	}
+
+	k := len(result.Variables)
+	result.R = make([][]*float64, k)
+	result.P = make([][]*float64, k)
+	result.N = make([][]int, k)
+	for i := range result.Variables {
+		result.R[i] = make([]*float64, k)
+		result.P[i] = make([]*float64, k)
+		result.N[i] = make([]int, k)
+	}
+	for i, x := range result.Variables {
+		for j := i; j < k; j++ {
+			var xs, ys []float64
+			for _, participant := range result.Participants {
+				xValue, xOk := participant.Values[x.Id]
+				yValue, yOk := participant.Values[result.Variables[j].Id]
+				if xOk && yOk {
+					xs = append(xs, xValue)
+					ys = append(ys, yValue)
+				}
+			}
+			r := lib.Correlation(xs, ys)
+			result.R[i][j], result.R[j][i] = defined(r), defined(r)
+			result.P[i][j], result.P[j][i] = defined(lib.CorrelationPValue(r, len(xs))), defined(lib.CorrelationPValue(r, len(xs)))
+			result.N[i][j], result.N[j][i] = len(xs), len(xs)
+		}
+	}
+	return result, nil
+}
+
+// gameMetrics derives the game metrics from the stored statistics, a metric
+// is left out when the player has nothing it could be computed from.
+func gameMetrics(participant gameServer.ParticipantStatistics) map[string]float64 {
+	values := map[string]float64{}
+	if participant.GamesNum == nil {
+		return values
+	}
+	count := func(value *int) float64 {
+		if value == nil {
+			return 0
+		}
+		return float64(*value)
+	}
+	mean := func(value *float64) float64 {
+		if value == nil {
+			return 0
+		}
+		return *value
+	}
+
+	if participant.TotalScore != nil {
+		values[gameServer.MetricTotalScore] = float64(*participant.TotalScore)
+	}
+	stopsOnSignal, continues := count(participant.StopOnSignalNum), count(participant.ContinueAfterSignalNum)
+	if signals := stopsOnSignal + continues; signals > 0 {
+		values[gameServer.MetricAdviceAcceptanceRate] = stopsOnSignal / signals
+	}
+	if games := count(participant.GamesNum); games > 0 {
+		values[gameServer.MetricHintsPerGame] = (count(participant.HintOnSignalNum) + count(participant.HintWithoutSignalNum)) / games
+	}
+	stopsWithoutSignal := count(participant.StopWithoutSignalNum)
+	if stops := stopsOnSignal + stopsWithoutSignal; stops > 0 {
+		values[gameServer.MetricMeanStopY] = (stopsOnSignal*mean(participant.MeanStopOnSignal) + stopsWithoutSignal*mean(participant.MeanStopWithoutSignal)) / stops
+	}
+	return values
+}
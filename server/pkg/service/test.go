@@ -5,15 +5,17 @@ import (
 	"errors"
 
 	gameServer "example.com/gameHoldTheProcessServer"
+	"example.com/gameHoldTheProcessServer/pkg/instrument"
 	"example.com/gameHoldTheProcessServer/pkg/repository"
 )
 
 type TestService struct {
-	repo repository.Test
+	repo     repository.Test
+	userRepo repository.User
 }
 
-func NewTestService(repo repository.Test) *TestService {
-	return &TestService{repo: repo}
+func NewTestService(repo repository.Test, userRepo repository.User) *TestService {
+	return &TestService{repo: repo, userRepo: userRepo}
 }
 
 func (t *TestService) GetAllTests() ([]gameServer.Test, error) {
@@ -26,7 +28,7 @@ func (t *TestService) GetSessionStatus(userId int) (gameServer.TestSessionStatus
 		return gameServer.TestSessionStatus{}, err
 	}
 
-	completed, err := t.repo.GetCompletedTestIds(userId)
+	completed, err := t.repo.GetCompletedTestIds(userId, gameServer.TestContextOnboarding)
 	if err != nil {
 		return gameServer.TestSessionStatus{}, err
 	}
@@ -62,18 +64,126 @@ func (t *TestService) SubmitResult(userId int, input gameServer.SubmitTestResult
 		return 0, errors.New("test is not active")
 	}
 
-	score, err := calculateTestScore(test.Slug, test.Config, input.Answers)
+	config, err := gameServer.ParseTestConfig(test.Type, test.Config)
 	if err != nil {
 		return 0, err
 	}
+	answers, err := gameServer.ParseAnswers(input.Answers)
+	if err != nil {
+		return 0, err
+	}
+	user, err := t.userRepo.GetOneUser(userId)
+	if err != nil {
+		return 0, err
+	}
+	if answersErr := config.ValidateAnswers(answers, gameServer.NewUserProfile(user)); answersErr != nil {
+		return 0, answersErr
+	}
+	if timesErr := gameServer.ValidateItemTimes(config, input.ItemTimes); timesErr != nil {
+		return 0, timesErr
+	}
+
+	score, err := calculateTestScore(config, answers)
+	if err != nil {
+		return 0, err
+	}
+	if score == nil {
+		return t.repo.CreateTestResult(userId, test, input, nil, nil)
+	}
+
+	scoreDetails, err := json.Marshal(score)
+	if err != nil {
+		return 0, err
+	}
+	return t.repo.CreateTestResult(userId, test, input, score.Total, scoreDetails)
+}
 
-	return t.repo.CreateTestResult(userId, input, score)
+// SaveProgress stores the answers given so far to the current version of
+// an active test.
+func (t *TestService) SaveProgress(userId, testId int, input gameServer.SaveTestProgressInput) error {
+	test, err := t.repo.GetOneTest(testId)
+	if err != nil {
+		return err
+	}
+	if !test.IsActive {
+		return errors.New("test is not active")
+	}
+	return t.repo.SaveTestDraft(userId, test.Id, test.Version, input)
+}
+
+// GetProgress returns the saved progress of the test in every context. The
+// progress saved against an earlier version is left out, its answers may not
+// fit the current questions.
+func (t *TestService) GetProgress(userId, testId int) ([]gameServer.TestDraft, error) {
+	test, err := t.repo.GetOneTest(testId)
+	if err != nil {
+		return nil, err
+	}
+	drafts, err := t.repo.GetTestDrafts(userId, testId)
+	if err != nil {
+		return nil, err
+	}
+
+	current := make([]gameServer.TestDraft, 0, len(drafts))
+	for _, draft := range drafts {
+		if draft.TestVersion == test.Version {
+			current = append(current, draft)
+		}
+	}
+	return current, nil
+}
+
+// GetVisibility evaluates the conditions of the test for the answers given
+// so far and the profile of the user.
+func (t *TestService) GetVisibility(userId, testId int, input gameServer.TestVisibilityInput) (gameServer.TestVisibility, error) {
+	test, err := t.repo.GetOneTest(testId)
+	if err != nil {
+		return gameServer.TestVisibility{}, err
+	}
+	config, err := gameServer.ParseTestConfig(test.Type, test.Config)
+	if err != nil {
+		return gameServer.TestVisibility{}, err
+	}
+	answers, err := gameServer.ParseAnswers(input.Answers)
+	if err != nil {
+		return gameServer.TestVisibility{}, err
+	}
+	user, err := t.userRepo.GetOneUser(userId)
+	if err != nil {
+		return gameServer.TestVisibility{}, err
+	}
+
+	visibility := gameServer.TestVisibility{Visible: config.VisibleQuestions(answers, gameServer.NewUserProfile(user))}
+	for _, id := range visibility.Visible {
+		if raw, ok := answers[id]; !ok || string(raw) == "null" {
+			visibility.Next = id
+			break
+		}
+	}
+	return visibility, nil
 }
 
 func (t *TestService) CreateTest(input gameServer.CreateTestInput) (int, error) {
 	return t.repo.CreateTest(input)
 }
 
+func (t *TestService) GetInstruments(locale string) []gameServer.Instrument {
+	return instrument.List(locale)
+}
+
+// CreateTestFromInstrument adds the instrument in the locale as a mixed
+// test, its config goes through the same validation as a pasted one.
+func (t *TestService) CreateTestFromInstrument(instrumentId string, input gameServer.CreateInstrumentTestInput) (int, error) {
+	testInput, err := instrument.Build(instrumentId, input)
+	if err != nil {
+		return 0, err
+	}
+	if err := testInput.Validate(); err != nil {
+		return 0, err
+	}
+	return t.repo.CreateTest(testInput)
+}
+
 func (t *TestService) UpdateTest(id int, input gameServer.UpdateTestInput) error {
 	return t.repo.UpdateTest(id, input)
 }
@@ -90,48 +200,169 @@ func (t *TestService) GetUserResultsWithTests(userId int) ([]gameServer.TestResu
 	return t.repo.GetUserResultsWithTests(userId)
 }
 
-func calculateTestScore(slug string, config json.RawMessage, answers json.RawMessage) (*float64, error) {
-	switch slug {
-	case gameServer.TestTypeLikert:
-		return calculateLikertScore(config, answers)
+// calculateTestScore scores the validated answers, the skipped optional
+// questions are left out. An answer that cannot be scored is reported as an
+// AnswersError.
+func calculateTestScore(config gameServer.TestConfig, answers map[string]json.RawMessage) (*gameServer.TestScore, error) {
+	switch config := config.(type) {
+	case gameServer.LikertConfig:
+		return scoreItems(config.Items(), config.Scale, config.ScoringRules, answers)
+	case gameServer.MixedConfig:
+		if score := instrument.Scorer(config.Instrument); score != nil {
+			return score(config, answers)
+		}
+		return scoreItems(config.Items, config.Scale, config.ScoringRules, answers)
 	default:
 		return nil, nil
 	}
 }
 
-func calculateLikertScore(config json.RawMessage, answers json.RawMessage) (*float64, error) {
-	var configData struct {
-		Questions []struct {
-			Id string `json:"id"`
-		} `json:"questions"`
+// scoredValue is an answer that adds to the score, already flipped when its
+// item is reverse-keyed.
+type scoredValue struct {
+	value     float64
+	weight    float64
+	threshold *float64
+}
+
+// scoreItems scores the answers to the whole test and to every subscale,
+// nil when none of the scored items is answered.
+func scoreItems(items []gameServer.TestItem, scale gameServer.LikertScale, rules gameServer.ScoringRules, answers map[string]json.RawMessage) (*gameServer.TestScore, error) {
+	values := map[string]scoredValue{}
+	keys := make([]string, 0, len(items))
+	refs := map[string][]string{}
+	for _, item := range items {
+		itemKeys := item.ScoreKeys()
+		keys = append(keys, itemKeys...)
+		refs[item.Id] = itemKeys
+		for _, key := range itemKeys {
+			refs[key] = []string{key}
+		}
+
+		raw, ok := answers[item.Id]
+		if !ok || len(itemKeys) == 0 {
+			continue
+		}
+		if err := addScoredValues(values, item, item.ScaleOf(scale), raw); err != nil {
+			return nil, &gameServer.AnswersError{Questions: map[string]string{item.Id: gameServer.AnswerInvalidType}}
+		}
 	}
-	if err := json.Unmarshal(config, &configData); err != nil {
-		return nil, err
+
+	total, answered := aggregateScore(keys, values, rules.Scoring)
+	if answered == 0 {
+		return nil, nil
 	}
 
-	var answerData map[string]float64
-	if err := json.Unmarshal(answers, &answerData); err != nil {
-		return nil, err
+	score := &gameServer.TestScore{Answered: answered}
+	if rules.Scoring != gameServer.ScoringNone {
+		score.Total = &total
+		score.Interpretation = gameServer.Interpret(rules.Interpretations, total)
+	}
+	for _, subscale := range rules.Subscales {
+		var subscaleKeys []string
+		for _, ref := range subscale.Items {
+			subscaleKeys = append(subscaleKeys, refs[ref]...)
+		}
+		subscaleScore, subscaleAnswered := aggregateScore(subscaleKeys, values, subscale.Scoring)
+		score.Subscales = append(score.Subscales, gameServer.SubscaleScore{
+			Id:             subscale.Id,
+			Title:          subscale.Title,
+			Score:          subscaleScore,
+			Answered:       subscaleAnswered,
+			Interpretation: gameServer.Interpret(subscale.Interpretations, subscaleScore),
+		})
+	}
+	return score, nil
+}
+
+// addScoredValues adds the values of the answer to the item, keyed by the
+// score keys of the item.
+func addScoredValues(values map[string]scoredValue, item gameServer.TestItem, scale gameServer.LikertScale, raw json.RawMessage) error {
+	weight := 1.0
+	if item.Weight != nil {
+		weight = *item.Weight
+	}
+	add := func(key string, value float64, reverse bool) {
+		if reverse {
+			value = scale.Min + scale.Max - value
+		}
+		values[key] = scoredValue{value: value, weight: weight, threshold: item.Threshold}
 	}
 
-	if len(configData.Questions) == 0 {
-		return nil, nil
+	switch item.Type {
+	case gameServer.ItemTypeLikert, gameServer.ItemTypeSlider:
+		var value *float64
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return err
+		}
+		if value != nil {
+			add(item.Id, *value, item.Reverse)
+		}
+	case gameServer.ItemTypeMatrix:
+		var rows map[string]*float64
+		if err := json.Unmarshal(raw, &rows); err != nil {
+			return err
+		}
+		for _, row := range item.Rows {
+			if value := rows[row.Id]; value != nil {
+				add(gameServer.MatrixKey(item.Id, row.Id), *value, item.Reverse || row.Reverse)
+			}
+		}
+	case gameServer.ItemTypeSingleChoice:
+		var option *string
+		if err := json.Unmarshal(raw, &option); err != nil {
+			return err
+		}
+		if option != nil {
+			add(item.Id, item.OptionScores[*option], false)
+		}
+	case gameServer.ItemTypeMultiSelect:
+		var options []string
+		if err := json.Unmarshal(raw, &options); err != nil {
+			return err
+		}
+		if options != nil {
+			var sum float64
+			for _, option := range options {
+				sum += item.OptionScores[option]
+			}
+			add(item.Id, sum, false)
+		}
+	case gameServer.ItemTypeRanking:
+		var ranking []string
+		if err := json.Unmarshal(raw, &ranking); err != nil {
+			return err
+		}
+		if ranking != nil {
+			add(item.Id, item.RankingScore(ranking), false)
+		}
 	}
+	return nil
+}
 
-	var sum float64
-	var count float64
-	for _, question := range configData.Questions {
-		value, ok := answerData[question.Id]
+func aggregateScore(keys []string, values map[string]scoredValue, method string) (float64, int) {
+	var sum, weights float64
+	answered := 0
+	for _, key := range keys {
+		value, ok := values[key]
 		if !ok {
 			continue
 		}
-		sum += value
-		count++
-	}
-	if count == 0 {
-		return nil, nil
+		answered++
+
+		if method == gameServer.ScoringThresholdCount {
+			if value.threshold != nil && value.value >= *value.threshold {
+				sum++
+			}
+			continue
+		}
+
+		sum += value.value * value.weight
+		weights += value.weight
 	}
 
-	score := sum / count
-	return &score, nil
+	if method == gameServer.ScoringMean && weights > 0 {
+		return sum / weights, answered
+	}
+	return sum, answered
 }
@@ -0,0 +1,306 @@
+package service
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"math"
+	"sort"
+
+	gameServer "example.com/gameHoldTheProcessServer"
+	"example.com/gameHoldTheProcessServer/pkg/lib"
+)
+
+// maxListedScalePoints is the widest scale whose distribution lists every
+// point, the distribution of a wider one such as a slider lists the answered
+// values only.
+const maxListedScalePoints = 21
+
+// reportItem is a column of the report: a likert or slider item, or a row of
+// a matrix.
+type reportItem struct {
+	id      string
+	itemId  string
+	rowId   string
+	text    string
+	reverse bool
+	scale   gameServer.LikertScale
+	listed  bool
+}
+
+// value returns the answer to the item in the answers of a result.
+func (i reportItem) value(answers map[string]json.RawMessage) (float64, bool) {
+	raw, ok := answers[i.itemId]
+	if !ok {
+		return 0, false
+	}
+	if i.rowId != "" {
+		var rows map[string]*float64
+		if json.Unmarshal(raw, &rows) != nil || rows[i.rowId] == nil {
+			return 0, false
+		}
+		return *rows[i.rowId], true
+	}
+	var value *float64
+	if json.Unmarshal(raw, &value) != nil || value == nil {
+		return 0, false
+	}
+	return *value, true
+}
+
+// keyed flips the value of a reverse-keyed item, as the scoring does.
+func (i reportItem) keyed(value float64) float64 {
+	if i.reverse {
+		return i.scale.Min + i.scale.Max - value
+	}
+	return value
+}
+
+func reportItems(items []gameServer.TestItem, scale gameServer.LikertScale) []reportItem {
+	var columns []reportItem
+	for _, item := range items {
+		itemScale := item.ScaleOf(scale)
+		switch item.Type {
+		case gameServer.ItemTypeLikert, gameServer.ItemTypeSlider:
+			columns = append(columns, reportItem{
+				id:      item.Id,
+				itemId:  item.Id,
+				text:    item.Text,
+				reverse: item.Reverse,
+				scale:   itemScale,
+				listed:  item.Type == gameServer.ItemTypeLikert,
+			})
+		case gameServer.ItemTypeMatrix:
+			for _, row := range item.Rows {
+				columns = append(columns, reportItem{
+					id:      gameServer.MatrixKey(item.Id, row.Id),
+					itemId:  item.Id,
+					rowId:   row.Id,
+					text:    item.Text + ": " + row.Text,
+					reverse: item.Reverse || row.Reverse,
+					scale:   itemScale,
+					listed:  true,
+				})
+			}
+		}
+	}
+	return columns
+}
+
+// GetReport computes the psychometric report of a version of the test over
+// the results selected by the input. Only the items answered on a scale are
+// reported.
+func (t *TestService) GetReport(testId int, input gameServer.TestReportInput) (gameServer.TestReport, error) {
+	version := 0
+	if input.Version != nil {
+		version = *input.Version
+	} else {
+		test, err := t.repo.GetOneTest(testId)
+		if errors.Is(err, sql.ErrNoRows) {
+			return gameServer.TestReport{}, gameServer.ErrTestNotFound
+		}
+		if err != nil {
+			return gameServer.TestReport{}, err
+		}
+		version = test.Version
+	}
+
+	testVersion, err := t.repo.GetTestVersion(testId, version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return gameServer.TestReport{}, gameServer.ErrTestNotFound
+	}
+	if err != nil {
+		return gameServer.TestReport{}, err
+	}
+	config, err := gameServer.ParseTestConfig(testVersion.Type, testVersion.Config)
+	if err != nil {
+		return gameServer.TestReport{}, err
+	}
+
+	var items []reportItem
+	var subscales []gameServer.LikertSubscale
+	switch config := config.(type) {
+	case gameServer.LikertConfig:
+		items = reportItems(config.Items(), config.Scale)
+		subscales = config.Subscales
+	case gameServer.MixedConfig:
+		items = reportItems(config.Items, config.Scale)
+		subscales = config.Subscales
+	}
+	if len(items) == 0 {
+		return gameServer.TestReport{}, gameServer.ErrTestNotScaled
+	}
+
+	results, err := t.repo.GetReportAnswers(testId, version, input)
+	if err != nil {
+		return gameServer.TestReport{}, err
+	}
+
+	// answered[r][j] is the answer of the result r to the item j, present
+	// tells which of them are answered.
+	answered := make([][]float64, len(results))
+	present := make([][]bool, len(results))
+	for r, raw := range results {
+		answers, err := gameServer.ParseAnswers(raw)
+		if err != nil {
+			return gameServer.TestReport{}, err
+		}
+		answered[r] = make([]float64, len(items))
+		present[r] = make([]bool, len(items))
+		for j, item := range items {
+			answered[r][j], present[r][j] = item.value(answers)
+		}
+	}
+
+	report := gameServer.TestReport{
+		TestId:  testId,
+		Version: version,
+		Results: len(results),
+	}
+
+	// The subscales of a test measure different constructs, so its items
+	// are checked against the other items of their subscale and the whole
+	// test gets no reliability of its own.
+	all := make([]int, len(items))
+	for j := range items {
+		all[j] = j
+	}
+	itemTotal := make([]*float64, len(items))
+	alphaIfDeleted := make([]*float64, len(items))
+	var total, ifDeleted []float64
+	report.Scale, total, ifDeleted = scaleReliability(items, all, answered, present)
+	if len(subscales) == 0 {
+		for j := range items {
+			itemTotal[j], alphaIfDeleted[j] = definedAt(total, j), definedAt(ifDeleted, j)
+		}
+	} else {
+		report.Scale.Alpha, report.Scale.Omega = nil, nil
+	}
+
+	report.Subscales = []gameServer.ScaleReliability{}
+	inSubscale := make([]bool, len(items))
+	for _, subscale := range subscales {
+		var columns []int
+		for j, item := range items {
+			if containsRef(subscale.Items, item) {
+				columns = append(columns, j)
+			}
+		}
+		if len(columns) == 0 {
+			continue
+		}
+		reliability, total, ifDeleted := scaleReliability(items, columns, answered, present)
+		reliability.Id = subscale.Id
+		reliability.Title = subscale.Title
+		report.Subscales = append(report.Subscales, reliability)
+		for k, j := range columns {
+			if !inSubscale[j] {
+				itemTotal[j], alphaIfDeleted[j] = definedAt(total, k), definedAt(ifDeleted, k)
+				inSubscale[j] = true
+			}
+		}
+	}
+
+	for j, item := range items {
+		var values []float64
+		for r := range answered {
+			if present[r][j] {
+				values = append(values, answered[r][j])
+			}
+		}
+		mean, _ := lib.MeanAndStdev(values)
+		itemReport := gameServer.TestItemReport{
+			Id:             item.id,
+			Text:           item.text,
+			Reverse:        item.reverse,
+			Answered:       len(values),
+			Sd:             defined(math.Sqrt(lib.SampleVariance(values))),
+			ItemTotal:      itemTotal[j],
+			AlphaIfDeleted: alphaIfDeleted[j],
+			Distribution:   distribution(item, values),
+		}
+		if len(values) > 0 {
+			itemReport.Mean = defined(mean)
+		}
+		report.Items = append(report.Items, itemReport)
+	}
+	return report, nil
+}
+
+// containsRef tells whether the refs of a subscale name the item, a matrix
+// is named whole or by its rows.
+func containsRef(refs []string, item reportItem) bool {
+	for _, ref := range refs {
+		if ref == item.id || ref == item.itemId {
+			return true
+		}
+	}
+	return false
+}
+
+// scaleReliability estimates the reliability of the items in columns over
+// the results that answered all of them, along with the item-total
+// correlations and the alpha without each item.
+func scaleReliability(items []reportItem, columns []int, answered [][]float64, present [][]bool) (gameServer.ScaleReliability, []float64, []float64) {
+	var responses [][]float64
+	for r := range answered {
+		row := make([]float64, 0, len(columns))
+		for _, j := range columns {
+			if !present[r][j] {
+				break
+			}
+			row = append(row, items[j].keyed(answered[r][j]))
+		}
+		if len(row) == len(columns) {
+			responses = append(responses, row)
+		}
+	}
+
+	reliability := gameServer.ScaleReliability{CompleteCases: len(responses)}
+	for _, j := range columns {
+		reliability.Items = append(reliability.Items, items[j].id)
+	}
+	if len(responses) == 0 {
+		return reliability, nil, nil
+	}
+	reliability.Alpha = defined(lib.CronbachAlpha(responses))
+	reliability.Omega = defined(lib.McDonaldOmega(responses))
+	return reliability, lib.CorrectedItemTotal(responses), lib.AlphaIfDeleted(responses)
+}
+
+// distribution counts the answers by value. The points of a narrow scale
+// are all listed, so that the options nobody chose show up with no answers.
+func distribution(item reportItem, values []float64) []gameServer.ResponseCount {
+	counts := map[float64]int{}
+	for _, value := range values {
+		counts[value]++
+	}
+	if item.listed && item.scale.Max-item.scale.Min < maxListedScalePoints {
+		for point := item.scale.Min; point <= item.scale.Max; point++ {
+			counts[point] += 0
+		}
+	}
+
+	result := make([]gameServer.ResponseCount, 0, len(counts))
+	for value, count := range counts {
+		result = append(result, gameServer.ResponseCount{Value: value, Count: count})
+	}
+	sort.Slice(result, func(a, b int) bool { return result[a].Value < result[b].Value })
+	return result
+}
+
+// defined returns nil for the statistics that are not defined, they have no
+// JSON encoding.
+func defined(value float64) *float64 {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil
+	}
+	return &value
+}
+
+func definedAt(values []float64, index int) *float64 {
+	if index >= len(values) {
+		return nil
+	}
+	return defined(values[index])
+}
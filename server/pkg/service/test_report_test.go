@@ -0,0 +1,111 @@
+package service
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	gameServer "example.com/gameHoldTheProcessServer"
+	"example.com/gameHoldTheProcessServer/pkg/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// reportRepository serves a stored version of a test and its answers, the
+// rest of the repository is not used by the report.
+type reportRepository struct {
+	repository.Test
+	version gameServer.TestVersion
+	answers []json.RawMessage
+}
+
+func (r reportRepository) GetTestVersion(testId, version int) (gameServer.TestVersion, error) {
+	return r.version, nil
+}
+
+func (r reportRepository) GetReportAnswers(testId, version int, input gameServer.TestReportInput) ([]json.RawMessage, error) {
+	return r.answers, nil
+}
+
+func TestTestService_GetReport(t *testing.T) {
+	version := 1
+
+	// The items of subscale a all correlate at 0.5 with equal variances, so
+	// alpha and omega are 3·0.5 / (1 + 2·0.5) and the other two items of a
+	// have the alpha 2·0.5 / (1 + 0.5). The reverse-keyed b2 is the same as
+	// b1 once flipped, the last result answers b only.
+	config := json.RawMessage(`{
+		"scale": {"min": 1, "max": 3},
+		"questions": [
+			{"id": "a1", "text": "A1"},
+			{"id": "a2", "text": "A2"},
+			{"id": "a3", "text": "A3"},
+			{"id": "b1", "text": "B1"},
+			{"id": "b2", "text": "B2", "reverse": true}
+		],
+		"subscales": [
+			{"id": "a", "title": "A", "items": ["a1", "a2", "a3"]},
+			{"id": "b", "title": "B", "items": ["b1", "b2"]}
+		]
+	}`)
+	answers := []json.RawMessage{
+		json.RawMessage(`{"a1": 1, "a2": 1, "a3": 2, "b1": 1, "b2": 3}`),
+		json.RawMessage(`{"a1": 1, "a2": 2, "a3": 1, "b1": 2, "b2": 2}`),
+		json.RawMessage(`{"a1": 2, "a2": 1, "a3": 1, "b1": 3, "b2": 1}`),
+		json.RawMessage(`{"a1": 3, "a2": 3, "a3": 2, "b1": 1, "b2": 3}`),
+		json.RawMessage(`{"a1": 3, "a2": 2, "a3": 3, "b1": 2, "b2": 2}`),
+		json.RawMessage(`{"a1": 2, "a2": 3, "a3": 3, "b1": 3, "b2": 1}`),
+		json.RawMessage(`{"b1": 2, "b2": 2}`),
+	}
+
+	repo := reportRepository{
+		version: gameServer.TestVersion{TestId: 1, Version: version, Type: gameServer.TestTypeLikert, Config: config},
+		answers: answers,
+	}
+	service := NewTestService(repo, nil)
+
+	report, err := service.GetReport(1, gameServer.TestReportInput{Version: &version})
+	require.NoError(t, err)
+
+	assert.Equal(t, 7, report.Results)
+	assert.Nil(t, report.Scale.Alpha)
+	assert.Nil(t, report.Scale.Omega)
+
+	require.Len(t, report.Subscales, 2)
+	a, b := report.Subscales[0], report.Subscales[1]
+
+	assert.Equal(t, "a", a.Id)
+	assert.Equal(t, []string{"a1", "a2", "a3"}, a.Items)
+	assert.Equal(t, 6, a.CompleteCases)
+	if assert.NotNil(t, a.Alpha) {
+		assert.InDelta(t, 0.75, *a.Alpha, 1e-6)
+	}
+	if assert.NotNil(t, a.Omega) {
+		assert.InDelta(t, 0.75, *a.Omega, 1e-6)
+	}
+
+	assert.Equal(t, "b", b.Id)
+	assert.Equal(t, 7, b.CompleteCases)
+	if assert.NotNil(t, b.Alpha) {
+		assert.InDelta(t, 1, *b.Alpha, 1e-6)
+	}
+	// Omega needs three items.
+	assert.Nil(t, b.Omega)
+
+	require.Len(t, report.Items, 5)
+	for _, item := range report.Items[:3] {
+		if assert.NotNil(t, item.AlphaIfDeleted, item.Id) {
+			assert.InDelta(t, 2.0/3, *item.AlphaIfDeleted, 1e-6, item.Id)
+		}
+		if assert.NotNil(t, item.ItemTotal, item.Id) {
+			assert.InDelta(t, 1/math.Sqrt(3), *item.ItemTotal, 1e-6, item.Id)
+		}
+	}
+	// Without one of its two items, b has no alpha.
+	assert.Nil(t, report.Items[3].AlphaIfDeleted)
+	if assert.NotNil(t, report.Items[4].ItemTotal) {
+		assert.InDelta(t, 1, *report.Items[4].ItemTotal, 1e-6)
+	}
+	assert.True(t, report.Items[4].Reverse)
+	assert.Equal(t, 7, report.Items[4].Answered)
+}
@@ -17,14 +17,6 @@ const (
 	tokenTTL              = 6 * time.Hour
 	defaultPageLimit      = 9
 	playerEventsPageLimit = 20
-	// Виды событий в игре
-	eventCrash             = "Взрыв"
-	eventUsefulAiSignal    = "Верный совет ИИ"
-	eventDeceptiveAiSignal = "Ложный совет ИИ"
-	eventStop              = "Ручная остановка"
-	eventPause             = "Пауза"
-	eventCheck             = "Использована подсказка"
-	eventRejectAdvice      = "Отклонение совета ИИ"
 )
 
 type TokenClaims struct {
@@ -165,6 +157,10 @@ func (u *UserService) GetAllGroups() ([]gameServer.Group, error) {
 	return u.repo.GetAllGroups()
 }
 
+func (u *UserService) GetOneGroup(id int) (gameServer.Group, error) {
+	return u.repo.GetOneGroup(id)
+}
+
 func (u *UserService) CreateGroup(input gameServer.CreateGroupInput) (int, error) {
 	return u.repo.CreateGroup(input)
 }
@@ -194,29 +190,29 @@ func (u *UserService) GetPlayersEvents(input gameServer.GetPlayersEventsInput) (
 	for _, point := range points {
 		playerEvent := gameServer.PlayerEvent{Y: float64(point.Y)}
 		if point.IsUsefulAiSignal {
-			playerEvent.Name = append(playerEvent.Name, eventUsefulAiSignal)
+			playerEvent.Name = append(playerEvent.Name, gameServer.EventUsefulAiSignal)
 		}
 		if point.IsDeceptiveAiSignal {
-			playerEvent.Name = append(playerEvent.Name, eventDeceptiveAiSignal)
+			playerEvent.Name = append(playerEvent.Name, gameServer.EventDeceptiveAiSignal)
 		}
 		if point.IsUsefulAiSignal && !point.IsStop {
-			playerEvent.Name = append(playerEvent.Name, eventRejectAdvice)
+			playerEvent.Name = append(playerEvent.Name, gameServer.EventRejectAdvice)
 		}
 		if point.IsDeceptiveAiSignal && !point.IsStop {
-			playerEvent.Name = append(playerEvent.Name, eventRejectAdvice)
+			playerEvent.Name = append(playerEvent.Name, gameServer.EventRejectAdvice)
 		}
 		if point.IsPause {
-			playerEvent.Name = append(playerEvent.Name, eventPause)
+			playerEvent.Name = append(playerEvent.Name, gameServer.EventPause)
 		}
 		if point.IsCheck {
-			playerEvent.Name = append(playerEvent.Name, eventCheck)
-			playerEvent.CheckInfo = point.CheckInfo
+			playerEvent.Name = append(playerEvent.Name, gameServer.EventCheck)
+			playerEvent.RiskLevel = point.RiskLevel
 		}
 		if point.IsStop {
-			playerEvent.Name = append(playerEvent.Name, eventStop)
+			playerEvent.Name = append(playerEvent.Name, gameServer.EventStop)
 		}
 		if point.IsCrash {
-			playerEvent.Name = append(playerEvent.Name, eventCrash)
+			playerEvent.Name = append(playerEvent.Name, gameServer.EventCrash)
 		}
 		events = append(events, playerEvent)
 	}
@@ -250,3 +246,7 @@ func (u *UserService) FixBugStat(start, end int) (map[int]float64, error) {
 	res, err := u.repo.GetCharts(start, end)
 	return res, err
 }
+
+func (u *UserService) HasResearcherAccess(researcherId, userId int) (bool, error) {
+	return u.repo.IsUserInCreatorGroups(researcherId, userId)
+}
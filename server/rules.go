@@ -0,0 +1,107 @@
+package gameServer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+const (
+	DefaultRulesLocale = "ru"
+	MaxRulesLength     = 100000
+)
+
+var (
+	rulesLocaleRe      = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+	rulesPlaceholderRe = regexp.MustCompile(`\{\{\s*([a-z_]+)\s*\}\}`)
+)
+
+// ParSetRules is the rules text of a parameter set rendered for the locale
+// that matched the Accept-Language header of the request.
+type ParSetRules struct {
+	Locale   string   `json:"locale"`
+	Locales  []string `json:"locales"`
+	Markdown string   `json:"markdown"`
+	Html     string   `json:"html"`
+}
+
+// ParseRules parses the rules of a parameter set: markdown templates keyed by
+// locale, e.g. {"ru": "...", "en": "..."}.
+func ParseRules(raw json.RawMessage) (map[string]string, error) {
+	rules := map[string]string{}
+	if len(raw) == 0 {
+		return rules, nil
+	}
+	if err := json.Unmarshal(raw, &rules); err != nil {
+		return nil, errors.New("rules must be an object of markdown texts keyed by locale")
+	}
+	for locale, text := range rules {
+		if !rulesLocaleRe.MatchString(locale) {
+			return nil, fmt.Errorf("rules locale %q is not a language tag", locale)
+		}
+		if len(text) > MaxRulesLength {
+			return nil, fmt.Errorf("rules for locale %s are longer than %d characters", locale, MaxRulesLength)
+		}
+	}
+	return rules, nil
+}
+
+// RulesLocales returns the locales of the rules, the default locale first.
+func RulesLocales(rules map[string]string) []string {
+	locales := make([]string, 0, len(rules))
+	for locale := range rules {
+		locales = append(locales, locale)
+	}
+	sort.Slice(locales, func(i, j int) bool {
+		if locales[i] == DefaultRulesLocale || locales[j] == DefaultRulesLocale {
+			return locales[i] == DefaultRulesLocale
+		}
+		return locales[i] < locales[j]
+	})
+	return locales
+}
+
+// RulesValues returns the values that the rules template can refer to as
+// {{name}}: the scoring config fields, hint_cost, hint_cost_<type> for every
+// offered hint and false_alarm_threshold.
+func RulesValues(scoring ScoringConfig, hintCost float32, hints []HintOption, falseAlarmThreshold float32) map[string]string {
+	values := map[string]string{}
+
+	var scoringValues map[string]float64
+	raw, _ := json.Marshal(scoring)
+	_ = json.Unmarshal(raw, &scoringValues)
+	for name, value := range scoringValues {
+		values[name] = strconv.FormatFloat(value, 'f', -1, 64)
+	}
+
+	values["hint_cost"] = strconv.FormatFloat(float64(hintCost), 'f', -1, 32)
+	for _, hint := range hints {
+		values["hint_cost_"+hint.Type] = strconv.FormatFloat(float64(hint.Cost), 'f', -1, 32)
+	}
+	values["false_alarm_threshold"] = strconv.FormatFloat(float64(falseAlarmThreshold), 'f', -1, 32)
+	return values
+}
+
+// ExpandRules replaces the {{name}} placeholders of the rules template with
+// the values of the parameter set.
+func ExpandRules(text string, values map[string]string) (string, error) {
+	var unknown string
+	expanded := rulesPlaceholderRe.ReplaceAllStringFunc(text, func(placeholder string) string {
+		name := rulesPlaceholderRe.FindStringSubmatch(placeholder)[1]
+		value, ok := values[name]
+		if !ok {
+			if unknown == "" {
+				unknown = name
+			}
+			return placeholder
+		}
+		return value
+	})
+	if unknown != "" {
+		return "", fmt.Errorf("unknown rules placeholder {{%s}}", unknown)
+	}
+	return expanded, nil
+}
@@ -0,0 +1,50 @@
+package gameServer
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+const (
+	DefaultScenarioLength = 200
+	MaxScenarioLength     = 1000
+	MaxScenarioCount      = 100
+)
+
+var (
+	ErrNoScenarios      = errors.New("parameter set has no scenarios")
+	ErrScenarioMismatch = errors.New("the scenario does not belong to the parameter set")
+)
+
+// Scenario is a pre-generated game: Trajectory holds the process values by
+// step, DangerPoints the steps right before the process reaches the critical
+// value and AiSignals the steps at which the advisor warns about danger.
+type Scenario struct {
+	Id             int             `json:"id" db:"id"`
+	ParameterSetId int             `json:"parameter_set_id" db:"parameter_set_id"`
+	Seed           int64           `json:"seed" db:"seed"`
+	Trajectory     json.RawMessage `json:"trajectory" db:"trajectory"`
+	DangerPoints   json.RawMessage `json:"danger_points" db:"danger_points"`
+	AiSignals      json.RawMessage `json:"ai_signals" db:"ai_signals"`
+	CreatedAt      string          `json:"created_at" db:"created_at"`
+}
+
+type CreateScenariosInput struct {
+	Count  int `json:"count"`
+	Length int `json:"length"`
+	// Seed of the first scenario, the next ones use Seed+1, Seed+2 and so on.
+	Seed *int64 `json:"seed"`
+}
+
+func (i *CreateScenariosInput) Validate() error {
+	if i.Length == 0 {
+		i.Length = DefaultScenarioLength
+	}
+	if i.Count <= 0 || i.Count > MaxScenarioCount {
+		return errors.New("scenario count must be between 1 and 100")
+	}
+	if i.Length < 0 || i.Length > MaxScenarioLength {
+		return errors.New("scenario length must be between 1 and 1000")
+	}
+	return nil
+}
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_charts_status_last_activity_at;
+
+ALTER TABLE charts
+    DROP COLUMN IF EXISTS status,
+    DROP COLUMN IF EXISTS end_reason,
+    DROP COLUMN IF EXISTS last_activity_at,
+    DROP COLUMN IF EXISTS ended_at;
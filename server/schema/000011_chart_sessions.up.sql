@@ -0,0 +1,7 @@
+ALTER TABLE charts
+    ADD COLUMN status           varchar(20) NOT NULL DEFAULT 'finished',
+    ADD COLUMN end_reason       varchar(20),
+    ADD COLUMN last_activity_at timestamp,
+    ADD COLUMN ended_at         timestamp;
+
+CREATE INDEX idx_charts_status_last_activity_at ON charts (status, last_activity_at);
@@ -0,0 +1,6 @@
+ALTER TABLE statistics
+    DROP COLUMN IF EXISTS games_by_end_reason;
+
+ALTER TABLE charts
+    DROP COLUMN IF EXISTS point_count,
+    DROP COLUMN IF EXISTS duration;
@@ -0,0 +1,18 @@
+ALTER TABLE charts
+    ADD COLUMN point_count int NOT NULL DEFAULT 0,
+    ADD COLUMN duration    float;
+
+UPDATE charts AS ct
+SET point_count = pt.cnt
+FROM (SELECT chart_id, COUNT(*) AS cnt FROM points GROUP BY chart_id) AS pt
+WHERE ct.id = pt.chart_id;
+
+UPDATE charts AS ct
+SET end_reason = CASE
+        WHEN EXISTS (SELECT 1 FROM points WHERE chart_id = ct.id AND is_crash) THEN 'crash'
+        WHEN EXISTS (SELECT 1 FROM points WHERE chart_id = ct.id AND is_stop) THEN 'stop'
+    END
+WHERE end_reason IS NULL;
+
+ALTER TABLE statistics
+    ADD COLUMN games_by_end_reason json default '{}';
@@ -0,0 +1,7 @@
+ALTER TABLE statistics
+    DROP COLUMN IF EXISTS reaction_time_stats;
+
+ALTER TABLE points
+    DROP COLUMN IF EXISTS reaction_time_ms,
+    DROP COLUMN IF EXISTS signal_shown_at,
+    DROP COLUMN IF EXISTS responded_at;
@@ -0,0 +1,7 @@
+ALTER TABLE points
+    ADD COLUMN reaction_time_ms float,
+    ADD COLUMN signal_shown_at  timestamp,
+    ADD COLUMN responded_at     timestamp;
+
+ALTER TABLE statistics
+    ADD COLUMN reaction_time_stats json default '{}';
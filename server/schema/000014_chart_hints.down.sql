@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS chart_hints;
+
+DROP TYPE IF EXISTS risk_level;
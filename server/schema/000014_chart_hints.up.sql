@@ -0,0 +1,15 @@
+CREATE TYPE risk_level AS ENUM ('low', 'medium', 'high');
+
+CREATE TABLE chart_hints
+(
+    id                serial     PRIMARY KEY,
+    chart_id          int        NOT NULL REFERENCES charts (id) ON DELETE CASCADE,
+    x                 float      NOT NULL,
+    y                 float      NOT NULL,
+    crash_probability float      NOT NULL,
+    risk_level        risk_level NOT NULL,
+    cost              float      NOT NULL,
+    created_at        timestamp  NOT NULL
+);
+
+CREATE INDEX idx_chart_hints_chart_id ON chart_hints (chart_id);
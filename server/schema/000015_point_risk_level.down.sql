@@ -0,0 +1,14 @@
+ALTER TABLE points
+    ADD COLUMN check_info varchar(255);
+
+UPDATE points
+SET check_info = CASE risk_level
+        WHEN 'low' THEN 'Низкий уровень риска'
+        WHEN 'medium' THEN 'Средний уровень риска'
+        WHEN 'high' THEN 'Высокий уровень риска'
+    END
+WHERE risk_level IS NOT NULL;
+
+ALTER TABLE points
+    DROP COLUMN IF EXISTS risk_level,
+    DROP COLUMN IF EXISTS crash_probability;
@@ -0,0 +1,14 @@
+ALTER TABLE points
+    ADD COLUMN risk_level        risk_level,
+    ADD COLUMN crash_probability float;
+
+UPDATE points
+SET risk_level = CASE check_info
+        WHEN 'Низкий уровень риска' THEN 'low'::risk_level
+        WHEN 'Средний уровень риска' THEN 'medium'::risk_level
+        WHEN 'Высокий уровень риска' THEN 'high'::risk_level
+    END
+WHERE check_info IS NOT NULL;
+
+ALTER TABLE points
+    DROP COLUMN check_info;
@@ -0,0 +1,17 @@
+ALTER TABLE statistics
+    DROP COLUMN IF EXISTS hints_by_type;
+
+DELETE FROM chart_hints
+WHERE crash_probability IS NULL OR risk_level IS NULL;
+
+ALTER TABLE chart_hints
+    DROP COLUMN IF EXISTS hint_type,
+    DROP COLUMN IF EXISTS disclosure,
+    ALTER COLUMN crash_probability SET NOT NULL,
+    ALTER COLUMN risk_level SET NOT NULL;
+
+ALTER TABLE points
+    DROP COLUMN IF EXISTS hint_type;
+
+ALTER TABLE parameter_sets
+    DROP COLUMN IF EXISTS hint_config;
@@ -0,0 +1,22 @@
+ALTER TABLE parameter_sets
+    ADD COLUMN hint_config jsonb NOT NULL DEFAULT '[{"type": "crash_probability", "cost": 250, "disclosure": "risk_band"}]'::jsonb;
+
+UPDATE parameter_sets
+SET hint_config = jsonb_build_array(
+        jsonb_build_object('type', 'crash_probability', 'cost', hint_cost, 'disclosure', 'risk_band'));
+
+ALTER TABLE points
+    ADD COLUMN hint_type varchar(30);
+
+UPDATE points
+SET hint_type = 'crash_probability'
+WHERE is_check AND risk_level IS NOT NULL;
+
+ALTER TABLE chart_hints
+    ADD COLUMN hint_type  varchar(30) NOT NULL DEFAULT 'crash_probability',
+    ADD COLUMN disclosure varchar(30) NOT NULL DEFAULT 'risk_band',
+    ALTER COLUMN crash_probability DROP NOT NULL,
+    ALTER COLUMN risk_level DROP NOT NULL;
+
+ALTER TABLE statistics
+    ADD COLUMN hints_by_type json default '{}';
@@ -0,0 +1,5 @@
+ALTER TABLE points
+    DROP COLUMN IF EXISTS ai_confidence;
+
+ALTER TABLE parameter_sets
+    DROP COLUMN IF EXISTS advisor_config;
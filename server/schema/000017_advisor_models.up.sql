@@ -0,0 +1,5 @@
+ALTER TABLE parameter_sets
+    ADD COLUMN advisor_config jsonb NOT NULL DEFAULT '{"model": "bernoulli", "show_confidence": false}'::jsonb;
+
+ALTER TABLE points
+    ADD COLUMN ai_confidence double precision;
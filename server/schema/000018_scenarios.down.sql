@@ -0,0 +1,4 @@
+ALTER TABLE charts
+    DROP COLUMN IF EXISTS scenario_id;
+
+DROP TABLE IF EXISTS scenarios;
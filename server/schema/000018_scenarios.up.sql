@@ -0,0 +1,15 @@
+CREATE TABLE scenarios
+(
+    id               serial    PRIMARY KEY,
+    parameter_set_id int       NOT NULL REFERENCES parameter_sets (id) ON DELETE CASCADE,
+    seed             bigint    NOT NULL,
+    trajectory       jsonb     NOT NULL,
+    danger_points    jsonb     NOT NULL,
+    ai_signals       jsonb     NOT NULL,
+    created_at       timestamp NOT NULL
+);
+
+CREATE INDEX idx_scenarios_parameter_set_id ON scenarios (parameter_set_id);
+
+ALTER TABLE charts
+    ADD COLUMN scenario_id int REFERENCES scenarios (id) ON DELETE SET NULL;
@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS parameter_sets_immutable_once_used ON parameter_sets;
+
+DROP FUNCTION IF EXISTS forbid_used_parameter_set_update();
+
+ALTER TABLE parameter_sets
+    DROP COLUMN IF EXISTS description,
+    DROP COLUMN IF EXISTS name,
+    DROP COLUMN IF EXISTS parent_id;
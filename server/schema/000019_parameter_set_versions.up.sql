@@ -0,0 +1,27 @@
+ALTER TABLE parameter_sets
+    ADD COLUMN parent_id   int          REFERENCES parameter_sets (id) ON DELETE SET NULL,
+    ADD COLUMN name        varchar(255) NOT NULL DEFAULT '',
+    ADD COLUMN description text         NOT NULL DEFAULT '';
+
+UPDATE parameter_sets
+SET name = 'Набор параметров ' || id;
+
+-- A parameter set that has been played is a record of the study conditions:
+-- only its name and description may change, other edits create a new version.
+CREATE FUNCTION forbid_used_parameter_set_update() RETURNS trigger AS
+$$
+BEGIN
+    IF EXISTS (SELECT 1 FROM charts WHERE parameter_set_id = OLD.id)
+        AND (to_jsonb(NEW) - 'name' - 'description') IS DISTINCT FROM (to_jsonb(OLD) - 'name' - 'description') THEN
+        RAISE EXCEPTION 'parameter set % is used by charts and cannot be changed', OLD.id
+            USING ERRCODE = 'restrict_violation';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER parameter_sets_immutable_once_used
+    BEFORE UPDATE
+    ON parameter_sets
+    FOR EACH ROW
+EXECUTE FUNCTION forbid_used_parameter_set_update();
@@ -0,0 +1,14 @@
+CREATE OR REPLACE FUNCTION forbid_used_parameter_set_update() RETURNS trigger AS
+$$
+BEGIN
+    IF EXISTS (SELECT 1 FROM charts WHERE parameter_set_id = OLD.id)
+        AND (to_jsonb(NEW) - 'name' - 'description') IS DISTINCT FROM (to_jsonb(OLD) - 'name' - 'description') THEN
+        RAISE EXCEPTION 'parameter set % is used by charts and cannot be changed', OLD.id
+            USING ERRCODE = 'restrict_violation';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE parameter_sets
+    DROP COLUMN IF EXISTS archived_at;
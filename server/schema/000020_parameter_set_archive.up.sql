@@ -0,0 +1,17 @@
+ALTER TABLE parameter_sets
+    ADD COLUMN archived_at timestamp;
+
+-- Archiving, unlinking a deleted parent version and editing the rules text
+-- do not change how the parameter set is played.
+CREATE OR REPLACE FUNCTION forbid_used_parameter_set_update() RETURNS trigger AS
+$$
+BEGIN
+    IF EXISTS (SELECT 1 FROM charts WHERE parameter_set_id = OLD.id)
+        AND (to_jsonb(NEW) - 'name' - 'description' - 'rules_text' - 'archived_at' - 'parent_id')
+            IS DISTINCT FROM (to_jsonb(OLD) - 'name' - 'description' - 'rules_text' - 'archived_at' - 'parent_id') THEN
+        RAISE EXCEPTION 'parameter set % is used by charts and cannot be changed', OLD.id
+            USING ERRCODE = 'restrict_violation';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,25 @@
+CREATE OR REPLACE FUNCTION forbid_used_parameter_set_update() RETURNS trigger AS
+$$
+BEGIN
+    IF EXISTS (SELECT 1 FROM charts WHERE parameter_set_id = OLD.id)
+        AND (to_jsonb(NEW) - 'name' - 'description' - 'rules_text' - 'archived_at' - 'parent_id')
+            IS DISTINCT FROM (to_jsonb(OLD) - 'name' - 'description' - 'rules_text' - 'archived_at' - 'parent_id') THEN
+        RAISE EXCEPTION 'parameter set % is used by charts and cannot be changed', OLD.id
+            USING ERRCODE = 'restrict_violation';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE parameter_sets
+    ADD COLUMN rules_text text NOT NULL DEFAULT '';
+
+ALTER TABLE parameter_sets DISABLE TRIGGER parameter_sets_immutable_once_used;
+
+UPDATE parameter_sets
+SET rules_text = COALESCE(rules ->> 'ru', '');
+
+ALTER TABLE parameter_sets ENABLE TRIGGER parameter_sets_immutable_once_used;
+
+ALTER TABLE parameter_sets
+    DROP COLUMN IF EXISTS rules;
@@ -0,0 +1,30 @@
+ALTER TABLE parameter_sets
+    ADD COLUMN rules jsonb NOT NULL DEFAULT '{}'::jsonb;
+
+-- Moving the existing rules text to the default locale does not change how
+-- the parameter set is played, so the immutability trigger is bypassed.
+ALTER TABLE parameter_sets DISABLE TRIGGER parameter_sets_immutable_once_used;
+
+UPDATE parameter_sets
+SET rules = jsonb_build_object('ru', rules_text)
+WHERE rules_text <> '';
+
+ALTER TABLE parameter_sets ENABLE TRIGGER parameter_sets_immutable_once_used;
+
+ALTER TABLE parameter_sets
+    DROP COLUMN rules_text;
+
+-- The rules replace the rules text as the part of a played parameter set that
+-- can still be edited.
+CREATE OR REPLACE FUNCTION forbid_used_parameter_set_update() RETURNS trigger AS
+$$
+BEGIN
+    IF EXISTS (SELECT 1 FROM charts WHERE parameter_set_id = OLD.id)
+        AND (to_jsonb(NEW) - 'name' - 'description' - 'rules' - 'archived_at' - 'parent_id')
+            IS DISTINCT FROM (to_jsonb(OLD) - 'name' - 'description' - 'rules' - 'archived_at' - 'parent_id') THEN
+        RAISE EXCEPTION 'parameter set % is used by charts and cannot be changed', OLD.id
+            USING ERRCODE = 'restrict_violation';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,5 @@
+ALTER TABLE test_results
+    DROP COLUMN IF EXISTS score_details;
+
+ALTER TABLE tests
+    DROP COLUMN IF EXISTS type;
@@ -0,0 +1,13 @@
+ALTER TABLE tests
+    ADD COLUMN type varchar(50) NOT NULL DEFAULT 'text';
+
+-- Until now the slug was the type of the test.
+UPDATE tests
+SET type = slug
+WHERE slug IN ('likert', 'single_choice', 'text');
+
+ALTER TABLE tests
+    ALTER COLUMN type DROP DEFAULT;
+
+ALTER TABLE test_results
+    ADD COLUMN score_details jsonb;
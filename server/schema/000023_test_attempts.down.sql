@@ -0,0 +1,23 @@
+-- Only the latest attempt of every test is kept.
+DELETE
+FROM test_results tr
+WHERE EXISTS (SELECT 1
+              FROM test_results later
+              WHERE later.user_id = tr.user_id
+                AND later.test_id = tr.test_id
+                AND later.attempt > tr.attempt);
+
+ALTER TABLE test_results
+    DROP CONSTRAINT IF EXISTS test_results_user_id_test_id_attempt_key;
+ALTER TABLE test_results
+    ADD CONSTRAINT test_results_user_id_test_id_key UNIQUE (user_id, test_id);
+
+ALTER TABLE test_results
+    DROP COLUMN IF EXISTS phase;
+ALTER TABLE test_results
+    DROP COLUMN IF EXISTS context;
+ALTER TABLE test_results
+    DROP COLUMN IF EXISTS attempt;
+
+ALTER TABLE tests
+    DROP COLUMN IF EXISTS retake_policy;
@@ -0,0 +1,19 @@
+ALTER TABLE tests
+    ADD COLUMN retake_policy varchar(50) NOT NULL DEFAULT 'never';
+
+ALTER TABLE test_results
+    ADD COLUMN attempt int NOT NULL DEFAULT 1;
+ALTER TABLE test_results
+    ADD COLUMN context varchar(50) NOT NULL DEFAULT 'onboarding';
+ALTER TABLE test_results
+    ADD COLUMN phase varchar(100) NOT NULL DEFAULT '';
+
+ALTER TABLE test_results
+    DROP CONSTRAINT test_results_user_id_test_id_key;
+ALTER TABLE test_results
+    ADD CONSTRAINT test_results_user_id_test_id_attempt_key UNIQUE (user_id, test_id, attempt);
+
+ALTER TABLE test_results
+    ALTER COLUMN attempt DROP DEFAULT;
+ALTER TABLE test_results
+    ALTER COLUMN context DROP DEFAULT;
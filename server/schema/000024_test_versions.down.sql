@@ -0,0 +1,9 @@
+ALTER TABLE test_results
+    DROP CONSTRAINT IF EXISTS test_results_test_version_fkey;
+ALTER TABLE test_results
+    DROP COLUMN IF EXISTS test_version;
+
+ALTER TABLE tests
+    DROP COLUMN IF EXISTS version;
+
+DROP TABLE IF EXISTS test_versions;
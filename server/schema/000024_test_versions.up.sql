@@ -0,0 +1,26 @@
+CREATE TABLE test_versions
+(
+    id         serial      PRIMARY KEY,
+    test_id    int         NOT NULL REFERENCES tests (id) ON DELETE CASCADE,
+    version    int         NOT NULL,
+    type       varchar(50) NOT NULL,
+    config     jsonb       NOT NULL,
+    created_at timestamp   NOT NULL,
+    UNIQUE (test_id, version)
+);
+
+ALTER TABLE tests
+    ADD COLUMN version int NOT NULL DEFAULT 1;
+
+-- The current config of every test becomes its first version.
+INSERT INTO test_versions (test_id, version, type, config, created_at)
+SELECT id, 1, type, config, created_at
+FROM tests;
+
+ALTER TABLE test_results
+    ADD COLUMN test_version int NOT NULL DEFAULT 1;
+ALTER TABLE test_results
+    ALTER COLUMN test_version DROP DEFAULT;
+ALTER TABLE test_results
+    ADD CONSTRAINT test_results_test_version_fkey FOREIGN KEY (test_id, test_version)
+        REFERENCES test_versions (test_id, version) ON DELETE CASCADE;
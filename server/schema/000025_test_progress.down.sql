@@ -0,0 +1,9 @@
+ALTER TABLE test_results
+    DROP COLUMN IF EXISTS duration_ms;
+ALTER TABLE test_results
+    DROP COLUMN IF EXISTS item_times;
+
+ALTER TABLE tests
+    DROP COLUMN IF EXISTS min_duration_seconds;
+
+DROP TABLE IF EXISTS test_drafts;
@@ -0,0 +1,22 @@
+CREATE TABLE test_drafts
+(
+    id           serial       PRIMARY KEY,
+    user_id      int          NOT NULL REFERENCES users (user_id) ON DELETE CASCADE,
+    test_id      int          NOT NULL REFERENCES tests (id) ON DELETE CASCADE,
+    test_version int          NOT NULL,
+    context      varchar(50)  NOT NULL,
+    phase        varchar(100) NOT NULL DEFAULT '',
+    answers      jsonb        NOT NULL,
+    item_times   jsonb        NOT NULL DEFAULT '{}',
+    started_at   timestamp    NOT NULL,
+    updated_at   timestamp    NOT NULL,
+    UNIQUE (user_id, test_id, context, phase)
+);
+
+ALTER TABLE tests
+    ADD COLUMN min_duration_seconds int;
+
+ALTER TABLE test_results
+    ADD COLUMN item_times jsonb;
+ALTER TABLE test_results
+    ADD COLUMN duration_ms bigint;
@@ -0,0 +1,3 @@
+UPDATE charts
+SET end_reason = NULL
+WHERE end_reason = 'unknown';
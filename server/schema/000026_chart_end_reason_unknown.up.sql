@@ -0,0 +1,4 @@
+UPDATE charts
+SET end_reason = 'unknown'
+WHERE end_reason IS NULL
+  AND status <> 'in_progress';
@@ -0,0 +1,10 @@
+CREATE TABLE chart_advice
+(
+    chart_id   int       NOT NULL REFERENCES charts (id) ON DELETE CASCADE,
+    x          float     NOT NULL,
+    signal     boolean   NOT NULL,
+    is_danger  boolean   NOT NULL,
+    confidence float,
+    created_at timestamp NOT NULL,
+    PRIMARY KEY (chart_id, x)
+);
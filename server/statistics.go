@@ -1,6 +1,10 @@
 package gameServer
 
-import "errors"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
 
 type Statistics struct {
 	GamesNum                 int     `json:"games_num" db:"games_num"`
@@ -25,6 +29,30 @@ type Statistics struct {
 	ChoiceStats              string  `json:"choice_stats" db:"choice_stats"`
 	ChoiceStatsVengerTable   string  `json:"choice_stats_venger_table" db:"choice_stats_venger_table"`
 	ChoiceStatsVengerCharts  string  `json:"choice_stats_venger_charts" db:"choice_stats_venger_charts"`
+	GamesByEndReason         string  `json:"games_by_end_reason" db:"games_by_end_reason"`
+	ReactionTimeStats        string  `json:"reaction_time_stats" db:"reaction_time_stats"`
+	HintsByType              string  `json:"hints_by_type" db:"hints_by_type"`
+}
+
+// ReactionTimeStats describes the distribution of reaction times in milliseconds.
+type ReactionTimeStats struct {
+	Count     int               `json:"count"`
+	Mean      float64           `json:"mean"`
+	Stdev     float64           `json:"stdev"`
+	Min       float64           `json:"min"`
+	P25       float64           `json:"p25"`
+	Median    float64           `json:"median"`
+	P75       float64           `json:"p75"`
+	P90       float64           `json:"p90"`
+	Max       float64           `json:"max"`
+	Histogram []ReactionTimeBin `json:"histogram"`
+}
+
+// ReactionTimeBin covers [FromMs, ToMs); ToMs is null for the last bin.
+type ReactionTimeBin struct {
+	FromMs float64  `json:"from_ms"`
+	ToMs   *float64 `json:"to_ms"`
+	Count  int      `json:"count"`
 }
 
 type ChoiceStats struct {
@@ -35,6 +63,14 @@ type ChoiceStats struct {
 type ComputeStatisticsInput struct {
 	UserId   int `json:"user_id" db:"user_id"`
 	ParSetId int `json:"par_set_id" db:"id"`
+	// IncludeUnfinished also counts games that are empty, partial, still in
+	// progress or abandoned.
+	IncludeUnfinished bool `json:"include_unfinished"`
+	// EndReason limits the statistics to the games ended this way.
+	EndReason string `json:"end_reason"`
+	// Locale of the titles in the choice statistics, set from the
+	// Accept-Language header of the request.
+	Locale string `json:"-"`
 }
 
 func (i *ComputeStatisticsInput) Validate() error {
@@ -44,5 +80,138 @@ func (i *ComputeStatisticsInput) Validate() error {
 	if i.ParSetId <= 0 {
 		return errors.New("parameter set id is equal or less than zero")
 	}
+	if i.EndReason != "" && !IsClientEndReason(i.EndReason) &&
+		i.EndReason != ChartEndReasonTimeout && i.EndReason != ChartEndReasonUnknown {
+		return errors.New("unknown end reason")
+	}
+	return nil
+}
+
+// IsFiltered reports whether the statistics differ from the stored per-user ones.
+func (i *ComputeStatisticsInput) IsFiltered() bool {
+	return i.IncludeUnfinished || i.EndReason != ""
+}
+
+const (
+	LiveEventChartCreated      = "chart_created"
+	LiveEventUsefulAiSignal    = "useful_ai_signal"
+	LiveEventDeceptiveAiSignal = "deceptive_ai_signal"
+	LiveEventStop              = "stop"
+	LiveEventHint              = "hint"
+	LiveEventCrash             = "crash"
+)
+
+type LiveEvent struct {
+	Type       string  `json:"type"`
+	UserId     int     `json:"user_id"`
+	ChartId    int     `json:"chart_id"`
+	ParSetId   int     `json:"par_set_id"`
+	IsTraining bool    `json:"is_training"`
+	X          float32 `json:"x"`
+	Y          float32 `json:"y"`
+	Score      float32 `json:"score"`
+	RiskLevel  *string `json:"risk_level,omitempty"`
+	CreatedAt  string  `json:"created_at"`
+}
+
+// Game metrics of a participant that are correlated with the test scores.
+// The advice acceptance rate is the share of AI signals the participant
+// stopped on, the mean stop Y covers the stops with and without a signal.
+const (
+	MetricTotalScore           = "total_score"
+	MetricAdviceAcceptanceRate = "advice_acceptance_rate"
+	MetricHintsPerGame         = "hints_per_game"
+	MetricMeanStopY            = "mean_stop_y"
+)
+
+var GameMetrics = []string{MetricTotalScore, MetricAdviceAcceptanceRate, MetricHintsPerGame, MetricMeanStopY}
+
+// Sources of the variables of TestGameCorrelations.
+const (
+	VariableSourceTest = "test"
+	VariableSourceGame = "game"
+)
+
+// TestGameCorrelationsInput selects the participants of a group and the
+// statistics of the parameter set they played. Context and Phase keep the
+// test attempts taken there, of several attempts the latest one is used.
+type TestGameCorrelationsInput struct {
+	GroupId  int    `form:"-"`
+	ParSetId int    `form:"-"`
+	Context  string `form:"context"`
+	Phase    string `form:"phase"`
+	// Locale of the titles of the game metrics, set from the Accept-Language
+	// header of the request.
+	Locale string `form:"-"`
+}
+
+func (i *TestGameCorrelationsInput) Validate() error {
+	if i.Context != "" && !IsTestContext(i.Context) {
+		return fmt.Errorf("unknown test context %q", i.Context)
+	}
+	if i.Phase != "" && i.Context != TestContextStudyPhase {
+		return errors.New("phase is only set for a study phase")
+	}
 	return nil
 }
+
+// ParticipantStatistics is a player of a group with the stored statistics
+// of the parameter set, the statistics are nil when they have not been
+// computed for the player.
+type ParticipantStatistics struct {
+	UserId                 int      `db:"user_id"`
+	Name                   string   `db:"name"`
+	GamesNum               *int     `db:"games_num"`
+	TotalScore             *int     `db:"total_score"`
+	StopOnSignalNum        *int     `db:"stop_on_signal_num"`
+	MeanStopOnSignal       *float64 `db:"mean_stop_on_signal"`
+	StopWithoutSignalNum   *int     `db:"stop_without_signal_num"`
+	MeanStopWithoutSignal  *float64 `db:"mean_stop_without_signal"`
+	HintOnSignalNum        *int     `db:"hint_on_signal_num"`
+	HintWithoutSignalNum   *int     `db:"hint_without_signal_num"`
+	ContinueAfterSignalNum *int     `db:"continue_after_signal_num"`
+}
+
+// ParticipantTestScore is the structured score of the latest scored attempt
+// of a version of a test by a participant.
+type ParticipantTestScore struct {
+	UserId       int             `db:"user_id"`
+	TestId       int             `db:"test_id"`
+	TestVersion  int             `db:"test_version"`
+	Slug         string          `db:"slug"`
+	Title        string          `db:"title"`
+	Score        *float64        `db:"score"`
+	ScoreDetails json.RawMessage `db:"score_details"`
+}
+
+// CorrelationVariable is a column of the participant table. A test total is
+// named test.<slug>.v<version> and a subscale
+// test.<slug>.v<version>.<subscale id>, since the versions of a test may
+// score different questions, a game metric by its code.
+type CorrelationVariable struct {
+	Id     string `json:"id"`
+	Title  string `json:"title"`
+	Source string `json:"source"`
+}
+
+// ParticipantMeasures holds the values of the variables for a participant,
+// the variables the participant has no value for are left out.
+type ParticipantMeasures struct {
+	UserId int                `json:"user_id"`
+	Name   string             `json:"name"`
+	Values map[string]float64 `json:"values"`
+}
+
+// TestGameCorrelations merges the test scores of the participants with their
+// game metrics. R[i][j] is the Pearson correlation of the variables i and j
+// over the N[i][j] participants that have both, P[i][j] its two-sided
+// p-value. The correlations that are not defined are nil.
+type TestGameCorrelations struct {
+	GroupId      int                   `json:"group_id"`
+	ParSetId     int                   `json:"par_set_id"`
+	Variables    []CorrelationVariable `json:"variables"`
+	Participants []ParticipantMeasures `json:"participants"`
+	R            [][]*float64          `json:"r"`
+	P            [][]*float64          `json:"p"`
+	N            [][]int               `json:"n"`
+}
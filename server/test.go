@@ -3,24 +3,122 @@ package gameServer
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
 )
 
 const (
 	TestTypeLikert       = "likert"
 	TestTypeSingleChoice = "single_choice"
 	TestTypeText         = "text"
+	TestTypeMixed        = "mixed"
 )
 
+func IsTestType(testType string) bool {
+	switch testType {
+	case TestTypeLikert, TestTypeSingleChoice, TestTypeText, TestTypeMixed:
+		return true
+	default:
+		return false
+	}
+}
+
+// Contexts in which a test is taken. A study phase is named by the phase of
+// the attempt.
+const (
+	TestContextOnboarding    = "onboarding"
+	TestContextAfterTraining = "after_training"
+	TestContextAfterGame     = "after_game"
+	TestContextStudyPhase    = "study_phase"
+)
+
+func IsTestContext(context string) bool {
+	switch context {
+	case TestContextOnboarding, TestContextAfterTraining, TestContextAfterGame, TestContextStudyPhase:
+		return true
+	default:
+		return false
+	}
+}
+
+// Retake policies of a test: a single attempt, one attempt per context (and
+// per phase of a study), or any number of attempts.
+const (
+	RetakeNever      = "never"
+	RetakePerContext = "per_context"
+	RetakeAlways     = "always"
+)
+
+func IsRetakePolicy(policy string) bool {
+	switch policy {
+	case RetakeNever, RetakePerContext, RetakeAlways:
+		return true
+	default:
+		return false
+	}
+}
+
+var (
+	ErrInvalidTestConfig = errors.New("invalid test config")
+	ErrRetakeNotAllowed  = errors.New("retake of the test is not allowed")
+	ErrUnknownInstrument = errors.New("unknown instrument")
+	ErrInstrumentLocale  = errors.New("instrument is not available in the locale")
+)
+
+// ValidateTestConfig checks the config against the type of the test.
+func ValidateTestConfig(testType string, config json.RawMessage) error {
+	if !IsTestType(testType) {
+		return fmt.Errorf("%w: unknown test type %q", ErrInvalidTestConfig, testType)
+	}
+	if len(config) == 0 || !json.Valid(config) {
+		return fmt.Errorf("%w: config must be valid json", ErrInvalidTestConfig)
+	}
+	if testType != TestTypeMixed && hasShowIf(config) {
+		return fmt.Errorf("%w: show_if is only supported in mixed tests", ErrInvalidTestConfig)
+	}
+	if _, err := ParseTestConfig(testType, config); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidTestConfig, err)
+	}
+	return nil
+}
+
+// hasShowIf tells whether a question or section of the config has a
+// show_if, which the configs other than the mixed one would drop silently.
+func hasShowIf(config json.RawMessage) bool {
+	var parts struct {
+		Questions []map[string]json.RawMessage `json:"questions"`
+		Sections  []map[string]json.RawMessage `json:"sections"`
+	}
+	if json.Unmarshal(config, &parts) != nil {
+		return false
+	}
+	for _, part := range append(parts.Questions, parts.Sections...) {
+		if _, ok := part["show_if"]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Test is a questionnaire with the type and config of its current version.
+// Editing the type or config of a version that already has results creates
+// the next version, the results keep the version they were answered against.
 type Test struct {
-	Id          int             `json:"id" db:"id"`
-	Slug        string          `json:"slug" db:"slug"`
-	Title       string          `json:"title" db:"title"`
-	Description string          `json:"description" db:"description"`
-	Config      json.RawMessage `json:"config" db:"config"`
-	IsActive    bool            `json:"is_active" db:"is_active"`
-	SortOrder   int             `json:"sort_order" db:"sort_order"`
-	CreatedAt   string          `json:"created_at" db:"created_at"`
-	UpdatedAt   string          `json:"updated_at" db:"updated_at"`
+	Id           int             `json:"id" db:"id"`
+	Slug         string          `json:"slug" db:"slug"`
+	Type         string          `json:"type" db:"type"`
+	Title        string          `json:"title" db:"title"`
+	Description  string          `json:"description" db:"description"`
+	Config       json.RawMessage `json:"config" db:"config"`
+	Version      int             `json:"version" db:"version"`
+	IsActive     bool            `json:"is_active" db:"is_active"`
+	RetakePolicy string          `json:"retake_policy" db:"retake_policy"`
+	SortOrder    int             `json:"sort_order" db:"sort_order"`
+	// MinDurationSeconds is the time below which an attempt is flagged as
+	// a speeder in the researcher views, nil when attempts are not flagged.
+	MinDurationSeconds *int   `json:"min_duration_seconds,omitempty" db:"min_duration_seconds"`
+	CreatedAt          string `json:"created_at" db:"created_at"`
+	UpdatedAt          string `json:"updated_at" db:"updated_at"`
 }
 
 type TestWithStatus struct {
@@ -28,21 +126,41 @@ type TestWithStatus struct {
 	Completed bool `json:"completed"`
 }
 
+// TestResult holds the answers of one attempt of a user, attempts of a test
+// are numbered from 1. Score is the total of ScoreDetails, a TestScore, for
+// the tests that are scored.
 type TestResult struct {
-	Id          int             `json:"id" db:"id"`
-	UserId      int             `json:"user_id" db:"user_id"`
-	TestId      int             `json:"test_id" db:"test_id"`
-	Answers     json.RawMessage `json:"answers" db:"answers"`
-	Score       *float64        `json:"score,omitempty" db:"score"`
+	Id           int             `json:"id" db:"id"`
+	UserId       int             `json:"user_id" db:"user_id"`
+	TestId       int             `json:"test_id" db:"test_id"`
+	TestVersion  int             `json:"test_version" db:"test_version"`
+	Attempt      int             `json:"attempt" db:"attempt"`
+	Context      string          `json:"context" db:"context"`
+	Phase        string          `json:"phase,omitempty" db:"phase"`
+	Answers      json.RawMessage `json:"answers" db:"answers"`
+	Score        *float64        `json:"score,omitempty" db:"score"`
+	ScoreDetails json.RawMessage `json:"score_details,omitempty" db:"score_details"`
+	// ItemTimes are the milliseconds spent on each question as reported by
+	// the client, nil for the results submitted without timing. DurationMs
+	// is measured by the server from the first saved progress of the attempt
+	// to its submission, nil when no progress was saved.
+	ItemTimes   json.RawMessage `json:"item_times,omitempty" db:"item_times"`
+	DurationMs  *int64          `json:"duration_ms,omitempty" db:"duration_ms"`
 	CompletedAt string          `json:"completed_at" db:"completed_at"`
 }
 
+// TestResultWithTest is a result with the test, Type and Config are those of
+// the version the result was answered against.
 type TestResultWithTest struct {
 	TestResult
 	Slug        string          `json:"slug" db:"slug"`
+	Type        string          `json:"type" db:"type"`
 	Title       string          `json:"title" db:"title"`
 	Description string          `json:"description" db:"description"`
 	Config      json.RawMessage `json:"config" db:"config"`
+	// Speeder is set when the attempt took less than the minimum duration of
+	// the test.
+	Speeder bool `json:"speeder" db:"speeder"`
 }
 
 type TestSessionStatus struct {
@@ -52,60 +170,497 @@ type TestSessionStatus struct {
 	ActiveTests    []TestWithStatus `json:"active_tests"`
 }
 
+// TestAttempt is a past attempt of a test, enough to apply the retake policy.
+type TestAttempt struct {
+	Attempt int    `db:"attempt"`
+	Context string `db:"context"`
+	Phase   string `db:"phase"`
+}
+
+// RetakeAllowed tells whether a test with the retake policy can be taken
+// again in the context and phase after the attempts.
+func RetakeAllowed(policy string, attempts []TestAttempt, context, phase string) bool {
+	switch policy {
+	case RetakeAlways:
+		return true
+	case RetakePerContext:
+		for _, attempt := range attempts {
+			if attempt.Context == context && attempt.Phase == phase {
+				return false
+			}
+		}
+		return true
+	default:
+		return len(attempts) == 0
+	}
+}
+
+// SubmitTestResultInput is the answers of an attempt and the milliseconds
+// spent on each question. The context defaults to onboarding, the phase
+// names the study phase and is only set for it.
 type SubmitTestResultInput struct {
-	TestId  int             `json:"test_id" binding:"required"`
-	Answers json.RawMessage `json:"answers" binding:"required"`
+	TestId    int                `json:"test_id" binding:"required"`
+	Answers   json.RawMessage    `json:"answers" binding:"required"`
+	ItemTimes map[string]float64 `json:"item_times"`
+	Context   string             `json:"context"`
+	Phase     string             `json:"phase"`
+}
+
+func (i *SubmitTestResultInput) ApplyDefaults() {
+	if i.Context == "" {
+		i.Context = TestContextOnboarding
+	}
 }
 
 func (i *SubmitTestResultInput) Validate() error {
+	i.ApplyDefaults()
 	if i.TestId <= 0 {
 		return errors.New("test id is non-positive")
 	}
+	if err := validateTestContext(i.Context, i.Phase); err != nil {
+		return err
+	}
 	if len(i.Answers) == 0 {
 		return errors.New("answers are empty")
 	}
-	if !json.Valid(i.Answers) {
-		return errors.New("answers must be valid json")
+	if _, err := ParseAnswers(i.Answers); err != nil {
+		return err
+	}
+	return validateItemTimes(i.ItemTimes)
+}
+
+func validateTestContext(context, phase string) error {
+	if !IsTestContext(context) {
+		return fmt.Errorf("unknown test context %q", context)
+	}
+	if context == TestContextStudyPhase && phase == "" {
+		return errors.New("phase is required for a study phase")
+	}
+	if context != TestContextStudyPhase && phase != "" {
+		return errors.New("phase is only set for a study phase")
+	}
+	if len(phase) > 100 {
+		return errors.New("phase is longer than 100 characters")
+	}
+	return nil
+}
+
+// MaxTimedItems bounds the number of questions in the item times of an
+// attempt.
+const MaxTimedItems = 1000
+
+func validateItemTimes(itemTimes map[string]float64) error {
+	if len(itemTimes) > MaxTimedItems {
+		return fmt.Errorf("item times have more than %d questions", MaxTimedItems)
+	}
+	for id, ms := range itemTimes {
+		if id == "" {
+			return errors.New("item times have an empty question id")
+		}
+		if ms < 0 || math.IsNaN(ms) || math.IsInf(ms, 0) {
+			return fmt.Errorf("time on question %q must be a non-negative number of milliseconds", id)
+		}
 	}
 	return nil
 }
 
+// TestDraft is the saved progress of a test being taken in a context: the
+// answers given so far and the time spent on each question. It is dropped
+// when the attempt is submitted.
+type TestDraft struct {
+	TestId      int             `json:"test_id" db:"test_id"`
+	TestVersion int             `json:"test_version" db:"test_version"`
+	Context     string          `json:"context" db:"context"`
+	Phase       string          `json:"phase,omitempty" db:"phase"`
+	Answers     json.RawMessage `json:"answers" db:"answers"`
+	ItemTimes   json.RawMessage `json:"item_times" db:"item_times"`
+	StartedAt   string          `json:"started_at" db:"started_at"`
+	UpdatedAt   string          `json:"updated_at" db:"updated_at"`
+}
+
+// SaveTestProgressInput is the progress of a test being taken, it replaces
+// the saved progress in the same context. The context defaults to
+// onboarding as for a submission.
+type SaveTestProgressInput struct {
+	Answers   json.RawMessage    `json:"answers" binding:"required"`
+	ItemTimes map[string]float64 `json:"item_times"`
+	Context   string             `json:"context"`
+	Phase     string             `json:"phase"`
+}
+
+func (i *SaveTestProgressInput) ApplyDefaults() {
+	if i.Context == "" {
+		i.Context = TestContextOnboarding
+	}
+}
+
+func (i *SaveTestProgressInput) Validate() error {
+	i.ApplyDefaults()
+	if err := validateTestContext(i.Context, i.Phase); err != nil {
+		return err
+	}
+	if _, err := ParseAnswers(i.Answers); err != nil {
+		return err
+	}
+	return validateItemTimes(i.ItemTimes)
+}
+
+// TestVisibilityInput is the answers given so far to a test being taken.
+type TestVisibilityInput struct {
+	Answers json.RawMessage `json:"answers" binding:"required"`
+}
+
+func (i *TestVisibilityInput) Validate() error {
+	_, err := ParseAnswers(i.Answers)
+	return err
+}
+
+// TestVisibility is what the client shows for the answers given so far: the
+// visible questions in order and the first of them without an answer, empty
+// when all of them are answered.
+type TestVisibility struct {
+	Visible []string `json:"visible"`
+	Next    string   `json:"next,omitempty"`
+}
+
 type CreateTestInput struct {
-	Slug        string          `json:"slug" binding:"required"`
-	Title       string          `json:"title" binding:"required"`
-	Description string          `json:"description"`
-	Config      json.RawMessage `json:"config" binding:"required"`
-	IsActive    bool            `json:"is_active"`
-	SortOrder   int             `json:"sort_order"`
+	Slug         string          `json:"slug" binding:"required"`
+	Type         string          `json:"type" binding:"required"`
+	Title        string          `json:"title" binding:"required"`
+	Description  string          `json:"description"`
+	Config       json.RawMessage `json:"config" binding:"required"`
+	IsActive     bool            `json:"is_active"`
+	RetakePolicy string          `json:"retake_policy"`
+	SortOrder    int             `json:"sort_order"`
+	// MinDurationSeconds of zero or nil leaves the attempts unflagged.
+	MinDurationSeconds *int `json:"min_duration_seconds"`
+}
+
+func (i *CreateTestInput) ApplyDefaults() {
+	if i.RetakePolicy == "" {
+		i.RetakePolicy = RetakeNever
+	}
 }
 
 func (i *CreateTestInput) Validate() error {
-	if len(i.Config) == 0 || !json.Valid(i.Config) {
-		return errors.New("config must be valid json")
+	i.ApplyDefaults()
+	if !IsRetakePolicy(i.RetakePolicy) {
+		return fmt.Errorf("unknown retake policy %q", i.RetakePolicy)
 	}
-	return nil
+	if i.MinDurationSeconds != nil && *i.MinDurationSeconds < 0 {
+		return errors.New("min duration must not be negative")
+	}
+	return ValidateTestConfig(i.Type, i.Config)
 }
 
 type UpdateTestInput struct {
-	Slug        *string          `json:"slug"`
-	Title       *string          `json:"title"`
-	Description *string          `json:"description"`
-	Config      *json.RawMessage `json:"config"`
-	IsActive    *bool            `json:"is_active"`
-	SortOrder   *int             `json:"sort_order"`
+	Slug         *string          `json:"slug"`
+	Type         *string          `json:"type"`
+	Title        *string          `json:"title"`
+	Description  *string          `json:"description"`
+	Config       *json.RawMessage `json:"config"`
+	IsActive     *bool            `json:"is_active"`
+	RetakePolicy *string          `json:"retake_policy"`
+	SortOrder    *int             `json:"sort_order"`
+	// MinDurationSeconds of zero stops flagging the attempts.
+	MinDurationSeconds *int `json:"min_duration_seconds"`
 }
 
-func (i *UpdateTestInput) Validate() error {
-	if i.Slug == nil &&
+func (i *UpdateTestInput) IsEmpty() bool {
+	return i.Slug == nil &&
+		i.Type == nil &&
 		i.Title == nil &&
 		i.Description == nil &&
 		i.Config == nil &&
 		i.IsActive == nil &&
-		i.SortOrder == nil {
+		i.RetakePolicy == nil &&
+		i.SortOrder == nil &&
+		i.MinDurationSeconds == nil
+}
+
+func (i *UpdateTestInput) Validate() error {
+	if i.IsEmpty() {
 		return errors.New("no values to update")
 	}
+	if i.RetakePolicy != nil && !IsRetakePolicy(*i.RetakePolicy) {
+		return fmt.Errorf("unknown retake policy %q", *i.RetakePolicy)
+	}
+	if i.Type != nil && !IsTestType(*i.Type) {
+		return fmt.Errorf("unknown test type %q", *i.Type)
+	}
 	if i.Config != nil && !json.Valid(*i.Config) {
 		return errors.New("config must be valid json")
 	}
+	if i.MinDurationSeconds != nil && *i.MinDurationSeconds < 0 {
+		return errors.New("min duration must not be negative")
+	}
+	return nil
+}
+
+// TestConfig returns the type and config of the test after the update, the
+// ones the input leaves out are taken from the test.
+func (i UpdateTestInput) TestConfig(test Test) (string, json.RawMessage) {
+	testType, config := test.Type, test.Config
+	if i.Type != nil {
+		testType = *i.Type
+	}
+	if i.Config != nil {
+		config = *i.Config
+	}
+	return testType, config
+}
+
+// ChangesConfig reports whether the input changes the type or config of the
+// test. The admin form sends the config back with every edit.
+func (i UpdateTestInput) ChangesConfig(test Test) bool {
+	testType, config := i.TestConfig(test)
+	return testType != test.Type || !jsonEqual(config, test.Config)
+}
+
+// Instrument is a standardized questionnaire of the instrument library. A
+// test created from it is a mixed test scored the way the instrument is.
+type Instrument struct {
+	Id          string   `json:"id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Reference   string   `json:"reference"`
+	Locales     []string `json:"locales"`
+	ItemCount   int      `json:"item_count"`
+}
+
+// CreateInstrumentTestInput creates a test from an instrument in the locale,
+// the slug defaults to the instrument id and the locale.
+type CreateInstrumentTestInput struct {
+	Locale       string `json:"locale" binding:"required"`
+	Slug         string `json:"slug"`
+	IsActive     bool   `json:"is_active"`
+	RetakePolicy string `json:"retake_policy"`
+	SortOrder    int    `json:"sort_order"`
+}
+
+func (i *CreateInstrumentTestInput) Validate() error {
+	if i.RetakePolicy != "" && !IsRetakePolicy(i.RetakePolicy) {
+		return fmt.Errorf("unknown retake policy %q", i.RetakePolicy)
+	}
+	return nil
+}
+
+const (
+	ScoringSum            = "sum"
+	ScoringMean           = "mean"
+	ScoringThresholdCount = "threshold_count"
+	// ScoringNone leaves out the total, e.g. for the Big Five whose traits
+	// are only scored as subscales.
+	ScoringNone = "none"
+)
+
+func isScoringMethod(method string) bool {
+	switch method {
+	case ScoringSum, ScoringMean, ScoringThresholdCount:
+		return true
+	default:
+		return false
+	}
+}
+
+type LikertScale struct {
+	Min    float64           `json:"min"`
+	Max    float64           `json:"max"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// DefaultLikertScale is the scale of a Likert test whose config has none.
+var DefaultLikertScale = LikertScale{Min: 1, Max: 5}
+
+// LikertQuestion is an item of a Likert test. The answer to a reverse-keyed
+// item is flipped on the scale before it is weighted or compared with
+// Threshold, the threshold_count scoring counts the answers at or above it.
+type LikertQuestion struct {
+	Id        string   `json:"id"`
+	Text      string   `json:"text"`
+	Reverse   bool     `json:"reverse,omitempty"`
+	Weight    *float64 `json:"weight,omitempty"`
+	Threshold *float64 `json:"threshold,omitempty"`
+	Optional  bool     `json:"optional,omitempty"`
+}
+
+// ScoreInterpretation labels the scores from Min (inclusive) to Max
+// (exclusive), an open bound is nil.
+type ScoreInterpretation struct {
+	Min   *float64 `json:"min,omitempty"`
+	Max   *float64 `json:"max,omitempty"`
+	Label string   `json:"label"`
+}
+
+type LikertSubscale struct {
+	Id              string                `json:"id"`
+	Title           string                `json:"title"`
+	Items           []string              `json:"items"`
+	Scoring         string                `json:"scoring,omitempty"`
+	Interpretations []ScoreInterpretation `json:"interpretations,omitempty"`
+}
+
+// ScoringRules are how a scored test is summed up: the scoring method of the
+// total, its interpretations and the subscales.
+type ScoringRules struct {
+	Scoring         string                `json:"scoring,omitempty"`
+	Interpretations []ScoreInterpretation `json:"interpretations,omitempty"`
+	Subscales       []LikertSubscale      `json:"subscales,omitempty"`
+}
+
+// validate defaults the scoring to the mean and checks that the subscales
+// refer to the scored items in refs.
+func (r *ScoringRules) validate(refs map[string]bool) error {
+	if r.Scoring == "" {
+		r.Scoring = ScoringMean
+	}
+	if !isScoringMethod(r.Scoring) && r.Scoring != ScoringNone {
+		return fmt.Errorf("unknown scoring %q", r.Scoring)
+	}
+	if r.Scoring == ScoringNone && len(r.Interpretations) > 0 {
+		return errors.New("a test without a total score cannot interpret it")
+	}
+	if err := validateInterpretations(r.Interpretations); err != nil {
+		return err
+	}
+
+	subscaleIds := make(map[string]bool, len(r.Subscales))
+	for i, subscale := range r.Subscales {
+		if subscale.Id == "" {
+			return errors.New("subscale id is empty")
+		}
+		if subscaleIds[subscale.Id] {
+			return fmt.Errorf("subscale id %q is not unique", subscale.Id)
+		}
+		subscaleIds[subscale.Id] = true
+		if len(subscale.Items) == 0 {
+			return fmt.Errorf("subscale %q has no items", subscale.Id)
+		}
+		for _, item := range subscale.Items {
+			if !refs[item] {
+				return fmt.Errorf("subscale %q refers to unknown question %q", subscale.Id, item)
+			}
+		}
+		if subscale.Scoring == "" {
+			r.Subscales[i].Scoring = r.Scoring
+			if r.Scoring == ScoringNone {
+				r.Subscales[i].Scoring = ScoringMean
+			}
+		}
+		if !isScoringMethod(r.Subscales[i].Scoring) {
+			return fmt.Errorf("unknown scoring %q of subscale %q", subscale.Scoring, subscale.Id)
+		}
+		if err := validateInterpretations(subscale.Interpretations); err != nil {
+			return fmt.Errorf("subscale %q: %w", subscale.Id, err)
+		}
+	}
 	return nil
 }
+
+type LikertConfig struct {
+	Scale     LikertScale      `json:"scale"`
+	Questions []LikertQuestion `json:"questions"`
+	ScoringRules
+}
+
+// ParseLikertConfig parses and checks the config of a Likert test, the
+// scale defaults to DefaultLikertScale and the scoring to the mean of the
+// answered items.
+func ParseLikertConfig(raw json.RawMessage) (LikertConfig, error) {
+	var config LikertConfig
+	if err := json.Unmarshal(raw, &config); err != nil {
+		return LikertConfig{}, errors.New("likert config must be an object with scale and questions")
+	}
+	if config.Scale.Min == 0 && config.Scale.Max == 0 {
+		config.Scale.Min, config.Scale.Max = DefaultLikertScale.Min, DefaultLikertScale.Max
+	}
+	if config.Scale.Min >= config.Scale.Max {
+		return LikertConfig{}, errors.New("likert scale min must be less than max")
+	}
+
+	questionIds := make(map[string]bool, len(config.Questions))
+	for _, question := range config.Questions {
+		if question.Id == "" {
+			return LikertConfig{}, errors.New("question id is empty")
+		}
+		if questionIds[question.Id] {
+			return LikertConfig{}, fmt.Errorf("question id %q is not unique", question.Id)
+		}
+		questionIds[question.Id] = true
+		if question.Weight != nil && *question.Weight <= 0 {
+			return LikertConfig{}, fmt.Errorf("weight of question %q must be greater than zero", question.Id)
+		}
+		if question.Threshold != nil && (*question.Threshold < config.Scale.Min || *question.Threshold > config.Scale.Max) {
+			return LikertConfig{}, fmt.Errorf("threshold of question %q is outside the scale", question.Id)
+		}
+	}
+
+	if err := config.ScoringRules.validate(questionIds); err != nil {
+		return LikertConfig{}, err
+	}
+	return config, nil
+}
+
+// Items returns the questions as Likert items on the scale of the config.
+func (c LikertConfig) Items() []TestItem {
+	items := make([]TestItem, 0, len(c.Questions))
+	for _, question := range c.Questions {
+		items = append(items, TestItem{
+			Id:        question.Id,
+			Type:      ItemTypeLikert,
+			Text:      question.Text,
+			Optional:  question.Optional,
+			Reverse:   question.Reverse,
+			Weight:    question.Weight,
+			Threshold: question.Threshold,
+		})
+	}
+	return items
+}
+
+func validateInterpretations(interpretations []ScoreInterpretation) error {
+	for _, interpretation := range interpretations {
+		if interpretation.Label == "" {
+			return errors.New("interpretation label is empty")
+		}
+		if interpretation.Min != nil && interpretation.Max != nil && *interpretation.Min >= *interpretation.Max {
+			return fmt.Errorf("interpretation %q has min not less than max", interpretation.Label)
+		}
+	}
+	return nil
+}
+
+// Interpret returns the label of the first interpretation that covers the
+// score, or an empty string.
+func Interpret(interpretations []ScoreInterpretation, score float64) string {
+	for _, interpretation := range interpretations {
+		if interpretation.Min != nil && score < *interpretation.Min {
+			continue
+		}
+		if interpretation.Max != nil && score >= *interpretation.Max {
+			continue
+		}
+		return interpretation.Label
+	}
+	return ""
+}
+
+// SubscaleScore is the score of a subscale. Weight is set for the subscales
+// that are weighted in the total, such as the dimensions of NASA-TLX.
+type SubscaleScore struct {
+	Id             string   `json:"id"`
+	Title          string   `json:"title"`
+	Score          float64  `json:"score"`
+	Answered       int      `json:"answered"`
+	Weight         *float64 `json:"weight,omitempty"`
+	Interpretation string   `json:"interpretation,omitempty"`
+}
+
+// TestScore is the structured score of a test result, Total is nil for the
+// tests scored without a total.
+type TestScore struct {
+	Total          *float64        `json:"total,omitempty"`
+	Answered       int             `json:"answered"`
+	Interpretation string          `json:"interpretation,omitempty"`
+	Subscales      []SubscaleScore `json:"subscales,omitempty"`
+}
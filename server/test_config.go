@@ -0,0 +1,875 @@
+package gameServer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const MaxTextAnswerLength = 10000
+
+// Codes of the errors of a single answer.
+const (
+	AnswerMissing          = "missing"
+	AnswerUnknownQuestion  = "unknown_question"
+	AnswerInvalidType      = "invalid_type"
+	AnswerOutOfRange       = "out_of_range"
+	AnswerOffStep          = "off_step"
+	AnswerInvalidOption    = "invalid_option"
+	AnswerInvalidSelection = "invalid_selection"
+	AnswerInvalidRanking   = "invalid_ranking"
+	AnswerTooLong          = "too_long"
+	AnswerHidden           = "hidden_question"
+)
+
+// Types of the items of a mixed questionnaire.
+const (
+	ItemTypeLikert       = "likert"
+	ItemTypeSingleChoice = "single_choice"
+	ItemTypeText         = "text"
+	ItemTypeSlider       = "slider"
+	ItemTypeMultiSelect  = "multi_select"
+	ItemTypeRanking      = "ranking"
+	ItemTypeMatrix       = "matrix"
+)
+
+// AnswersError is returned for a submission with invalid answers, Questions
+// maps the id of every invalid question to the code of its error. The rows
+// of a matrix are reported by their MatrixKey.
+type AnswersError struct {
+	Questions map[string]string
+}
+
+func (e *AnswersError) Error() string {
+	ids := make([]string, 0, len(e.Questions))
+	for id := range e.Questions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	problems := make([]string, 0, len(ids))
+	for _, id := range ids {
+		problems = append(problems, id+": "+e.Questions[id])
+	}
+	return "invalid answers: " + strings.Join(problems, ", ")
+}
+
+func (e *AnswersError) add(questionId, code string) {
+	if e.Questions == nil {
+		e.Questions = map[string]string{}
+	}
+	e.Questions[questionId] = code
+}
+
+func (e *AnswersError) orNil() *AnswersError {
+	if len(e.Questions) == 0 {
+		return nil
+	}
+	return e
+}
+
+// TestConfig is the parsed config of a test of one of the test types.
+type TestConfig interface {
+	// ValidateAnswers checks that every required question shown to the user
+	// is answered, every answer fits its question and no hidden question is
+	// answered, nil when the answers are valid.
+	ValidateAnswers(answers map[string]json.RawMessage, profile UserProfile) *AnswersError
+	// VisibleQuestions returns the ids of the questions shown for the
+	// answers given so far, in the order of the config.
+	VisibleQuestions(answers map[string]json.RawMessage, profile UserProfile) []string
+	// QuestionIds returns the ids of all the questions, in the order of the
+	// config.
+	QuestionIds() []string
+}
+
+// ParseTestConfig parses and checks the config of a test of the type.
+func ParseTestConfig(testType string, raw json.RawMessage) (TestConfig, error) {
+	switch testType {
+	case TestTypeLikert:
+		return ParseLikertConfig(raw)
+	case TestTypeSingleChoice:
+		return ParseSingleChoiceConfig(raw)
+	case TestTypeText:
+		return ParseTextConfig(raw)
+	case TestTypeMixed:
+		return ParseMixedConfig(raw)
+	default:
+		return nil, fmt.Errorf("unknown test type %q", testType)
+	}
+}
+
+// ParseAnswers parses the answers of a submission, an object keyed by
+// question id.
+func ParseAnswers(raw json.RawMessage) (map[string]json.RawMessage, error) {
+	var answers map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &answers); err != nil || answers == nil {
+		return nil, errors.New("answers must be an object keyed by question id")
+	}
+	return answers, nil
+}
+
+// MatrixKey is the key of a row of a matrix item in the scores and the
+// answer errors.
+func MatrixKey(itemId, rowId string) string {
+	return itemId + "." + rowId
+}
+
+type MatrixRow struct {
+	Id      string `json:"id"`
+	Text    string `json:"text"`
+	Reverse bool   `json:"reverse,omitempty"`
+}
+
+// TestItem is an item of a questionnaire. Which fields apply depends on the
+// type of the item:
+//   - likert is answered with a whole point of Scale, the scale of the config
+//     by default, or in steps of Step when it is set;
+//   - slider is a visual analogue scale answered with a number from Scale.Min
+//     to Scale.Max, in steps of Step when it is set;
+//   - single_choice picks one of Options, multi_select picks MinSelected to
+//     MaxSelected of them and ranking orders all of them;
+//   - matrix rates every row of Rows on Scale as a likert item;
+//   - text is a free text of at most MaxLength characters.
+//
+// Reverse, Weight and Threshold score the likert, slider and matrix answers
+// as for the questions of a Likert test, OptionScores scores the choices of
+// single_choice and multi_select items and RankingKey scores a ranking by
+// the pairs of options it orders as the key does.
+//
+// An item with ShowIf, or in a Section with ShowIf, is only shown when the
+// Condition holds for the answers to the items before it.
+type TestItem struct {
+	Id           string             `json:"id"`
+	Type         string             `json:"type"`
+	Text         string             `json:"text"`
+	Optional     bool               `json:"optional,omitempty"`
+	Section      string             `json:"section,omitempty"`
+	ShowIf       string             `json:"show_if,omitempty"`
+	Scale        *LikertScale       `json:"scale,omitempty"`
+	Step         float64            `json:"step,omitempty"`
+	Options      []string           `json:"options,omitempty"`
+	OptionScores map[string]float64 `json:"option_scores,omitempty"`
+	RankingKey   []string           `json:"ranking_key,omitempty"`
+	MinSelected  int                `json:"min_selected,omitempty"`
+	MaxSelected  int                `json:"max_selected,omitempty"`
+	Rows         []MatrixRow        `json:"rows,omitempty"`
+	MaxLength    int                `json:"max_length,omitempty"`
+	Reverse      bool               `json:"reverse,omitempty"`
+	Weight       *float64           `json:"weight,omitempty"`
+	Threshold    *float64           `json:"threshold,omitempty"`
+}
+
+// ScaleOf returns the scale of the item, the default scale unless the item
+// has its own.
+func (i TestItem) ScaleOf(defaultScale LikertScale) LikertScale {
+	if i.Scale != nil {
+		return *i.Scale
+	}
+	return defaultScale
+}
+
+// ScoreKeys returns the keys of the values the item adds to the score: the
+// item id, the keys of the rows of a matrix, or none for the items that are
+// not scored.
+func (i TestItem) ScoreKeys() []string {
+	switch i.Type {
+	case ItemTypeLikert, ItemTypeSlider:
+		return []string{i.Id}
+	case ItemTypeMatrix:
+		keys := make([]string, 0, len(i.Rows))
+		for _, row := range i.Rows {
+			keys = append(keys, MatrixKey(i.Id, row.Id))
+		}
+		return keys
+	case ItemTypeSingleChoice, ItemTypeMultiSelect:
+		if len(i.OptionScores) > 0 {
+			return []string{i.Id}
+		}
+	case ItemTypeRanking:
+		if len(i.RankingKey) > 0 {
+			return []string{i.Id}
+		}
+	}
+	return nil
+}
+
+// RankingScore is the number of pairs of options that the ranking orders as
+// RankingKey does, from zero for the reverse of the key to n(n-1)/2 for the
+// key itself: the Kendall tau distance counted from the other end.
+func (i TestItem) RankingScore(ranking []string) float64 {
+	position := make(map[string]int, len(ranking))
+	for index, option := range ranking {
+		position[option] = index
+	}
+	concordant := 0
+	for a := 0; a < len(i.RankingKey); a++ {
+		for b := a + 1; b < len(i.RankingKey); b++ {
+			if position[i.RankingKey[a]] < position[i.RankingKey[b]] {
+				concordant++
+			}
+		}
+	}
+	return float64(concordant)
+}
+
+func (i TestItem) validate(defaultScale LikertScale) error {
+	if strings.Contains(i.Id, ".") {
+		return fmt.Errorf("question id %q contains a dot", i.Id)
+	}
+	scale := i.ScaleOf(defaultScale)
+	numeric := i.Type == ItemTypeLikert || i.Type == ItemTypeSlider || i.Type == ItemTypeMatrix
+
+	switch i.Type {
+	case ItemTypeLikert, ItemTypeSlider, ItemTypeMatrix:
+		if scale.Min >= scale.Max {
+			return fmt.Errorf("scale of question %q must have min less than max", i.Id)
+		}
+		if i.Step < 0 || i.Step > scale.Max-scale.Min {
+			return fmt.Errorf("step of question %q must be between 0 and the length of the scale", i.Id)
+		}
+		if i.Threshold != nil && (*i.Threshold < scale.Min || *i.Threshold > scale.Max) {
+			return fmt.Errorf("threshold of question %q is outside the scale", i.Id)
+		}
+	case ItemTypeSingleChoice, ItemTypeMultiSelect, ItemTypeRanking:
+		if err := validateOptions(i.Id, i.Options); err != nil {
+			return err
+		}
+		for option := range i.OptionScores {
+			if i.Type == ItemTypeRanking || !containsString(i.Options, option) {
+				return fmt.Errorf("question %q scores unknown option %q", i.Id, option)
+			}
+		}
+	case ItemTypeText:
+		if i.MaxLength < 0 || i.MaxLength > MaxTextAnswerLength {
+			return fmt.Errorf("max length of question %q must be between 0 and %d", i.Id, MaxTextAnswerLength)
+		}
+	default:
+		return fmt.Errorf("unknown type %q of question %q", i.Type, i.Id)
+	}
+
+	if i.Type == ItemTypeRanking && len(i.Options) < 2 {
+		return fmt.Errorf("ranking question %q needs at least two options", i.Id)
+	}
+	if len(i.RankingKey) > 0 && (i.Type != ItemTypeRanking || !isOrderingOf(i.RankingKey, i.Options)) {
+		return fmt.Errorf("ranking key of question %q must order all of its options", i.Id)
+	}
+	if i.Type == ItemTypeMultiSelect {
+		maxSelected := i.MaxSelected
+		if maxSelected == 0 {
+			maxSelected = len(i.Options)
+		}
+		if i.MinSelected < 0 || i.MinSelected > maxSelected || maxSelected > len(i.Options) {
+			return fmt.Errorf("question %q must select from %d to %d of its options", i.Id, i.MinSelected, maxSelected)
+		}
+	}
+	if i.Type == ItemTypeMatrix {
+		if len(i.Rows) == 0 {
+			return fmt.Errorf("matrix question %q has no rows", i.Id)
+		}
+		rowIds := make([]string, 0, len(i.Rows))
+		for _, row := range i.Rows {
+			if strings.Contains(row.Id, ".") {
+				return fmt.Errorf("row id %q of question %q contains a dot", row.Id, i.Id)
+			}
+			rowIds = append(rowIds, row.Id)
+		}
+		if err := validateQuestionIds(rowIds); err != nil {
+			return fmt.Errorf("matrix question %q: %w", i.Id, err)
+		}
+	}
+	if !numeric && (i.Reverse || i.Threshold != nil) {
+		return fmt.Errorf("question %q of type %s cannot be reverse-keyed or have a threshold", i.Id, i.Type)
+	}
+	if i.Weight != nil && *i.Weight <= 0 {
+		return fmt.Errorf("weight of question %q must be greater than zero", i.Id)
+	}
+	return nil
+}
+
+// validateAnswer adds the error of the answer to the item, if any, to the
+// result.
+func (i TestItem) validateAnswer(raw json.RawMessage, defaultScale LikertScale, result *AnswersError) {
+	if isNullAnswer(raw) {
+		if !i.Optional {
+			result.add(i.Id, AnswerMissing)
+		}
+		return
+	}
+
+	scale := i.ScaleOf(defaultScale)
+	switch i.Type {
+	case ItemTypeLikert, ItemTypeSlider:
+		var value float64
+		if err := json.Unmarshal(raw, &value); err != nil {
+			result.add(i.Id, AnswerInvalidType)
+			return
+		}
+		if code := checkScaleValue(value, scale, i.answerStep()); code != "" {
+			result.add(i.Id, code)
+		}
+	case ItemTypeSingleChoice:
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			result.add(i.Id, AnswerInvalidType)
+			return
+		}
+		if !containsString(i.Options, value) {
+			result.add(i.Id, AnswerInvalidOption)
+		}
+	case ItemTypeMultiSelect, ItemTypeRanking:
+		var values []string
+		if err := json.Unmarshal(raw, &values); err != nil {
+			result.add(i.Id, AnswerInvalidType)
+			return
+		}
+		if len(values) == 0 && i.Type == ItemTypeMultiSelect && i.MinSelected == 0 {
+			if !i.Optional {
+				result.add(i.Id, AnswerMissing)
+			}
+			return
+		}
+		seen := make(map[string]bool, len(values))
+		for _, value := range values {
+			if !containsString(i.Options, value) || seen[value] {
+				result.add(i.Id, AnswerInvalidOption)
+				return
+			}
+			seen[value] = true
+		}
+		if i.Type == ItemTypeRanking && len(values) != len(i.Options) {
+			result.add(i.Id, AnswerInvalidRanking)
+		}
+		maxSelected := i.MaxSelected
+		if maxSelected == 0 {
+			maxSelected = len(i.Options)
+		}
+		if i.Type == ItemTypeMultiSelect && (len(values) < i.MinSelected || len(values) > maxSelected) {
+			result.add(i.Id, AnswerInvalidSelection)
+		}
+	case ItemTypeMatrix:
+		var values map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &values); err != nil || values == nil {
+			result.add(i.Id, AnswerInvalidType)
+			return
+		}
+		known := make(map[string]bool, len(i.Rows))
+		for _, row := range i.Rows {
+			known[row.Id] = true
+			key := MatrixKey(i.Id, row.Id)
+			rowRaw, ok := values[row.Id]
+			if !ok || isNullAnswer(rowRaw) {
+				if !i.Optional {
+					result.add(key, AnswerMissing)
+				}
+				continue
+			}
+			var value float64
+			if err := json.Unmarshal(rowRaw, &value); err != nil {
+				result.add(key, AnswerInvalidType)
+				continue
+			}
+			if code := checkScaleValue(value, scale, i.answerStep()); code != "" {
+				result.add(key, code)
+			}
+		}
+		for rowId := range values {
+			if !known[rowId] {
+				result.add(MatrixKey(i.Id, rowId), AnswerUnknownQuestion)
+			}
+		}
+	case ItemTypeText:
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			result.add(i.Id, AnswerInvalidType)
+			return
+		}
+		maxLength := i.MaxLength
+		if maxLength == 0 {
+			maxLength = MaxTextAnswerLength
+		}
+		switch {
+		case strings.TrimSpace(value) == "":
+			if !i.Optional {
+				result.add(i.Id, AnswerMissing)
+			}
+		case utf8.RuneCountInString(value) > maxLength:
+			result.add(i.Id, AnswerTooLong)
+		}
+	}
+}
+
+// answerStep is the step of the numeric answers to the item: the likert and
+// matrix answers are whole points of the scale unless the item sets a step.
+func (i TestItem) answerStep() float64 {
+	if i.Step == 0 && i.Type != ItemTypeSlider {
+		return 1
+	}
+	return i.Step
+}
+
+func checkScaleValue(value float64, scale LikertScale, step float64) string {
+	if value < scale.Min || value > scale.Max {
+		return AnswerOutOfRange
+	}
+	if step > 0 {
+		steps := (value - scale.Min) / step
+		if math.Abs(steps-math.Round(steps)) > 1e-9 {
+			return AnswerOffStep
+		}
+	}
+	return ""
+}
+
+// validateItemAnswers checks the answers to the items, a missing answer is
+// treated as null. Only the items in visible are checked, a hidden item must
+// not be answered.
+func validateItemAnswers(items []TestItem, defaultScale LikertScale, answers map[string]json.RawMessage, visible map[string]bool) *AnswersError {
+	result := &AnswersError{}
+	known := make(map[string]bool, len(items))
+	for _, item := range items {
+		known[item.Id] = true
+		raw, ok := answers[item.Id]
+		if !ok {
+			raw = json.RawMessage("null")
+		}
+		if !visible[item.Id] {
+			if !isNullAnswer(raw) {
+				result.add(item.Id, AnswerHidden)
+			}
+			continue
+		}
+		item.validateAnswer(raw, defaultScale, result)
+	}
+	for id := range answers {
+		if !known[id] {
+			result.add(id, AnswerUnknownQuestion)
+		}
+	}
+	return result.orNil()
+}
+
+// MixedConfig is the config of a questionnaire of items of any type. Scale
+// is the default scale of the likert and matrix items, the subscales refer
+// to the scored items by id or, for a single row of a matrix, by MatrixKey.
+// Instrument is set for the tests created from the instrument library, some
+// of which have their own scoring.
+type MixedConfig struct {
+	Instrument string        `json:"instrument,omitempty"`
+	Scale      LikertScale   `json:"scale"`
+	Sections   []TestSection `json:"sections,omitempty"`
+	Items      []TestItem    `json:"items"`
+	ScoringRules
+
+	// conditions are the compiled ShowIf of the items and sections by item
+	// id and by section id, set by ParseMixedConfig.
+	itemConditions    map[string]Condition
+	sectionConditions map[string]Condition
+}
+
+// TestSection groups items that are shown or skipped together.
+type TestSection struct {
+	Id     string `json:"id"`
+	Title  string `json:"title,omitempty"`
+	ShowIf string `json:"show_if,omitempty"`
+}
+
+func ParseMixedConfig(raw json.RawMessage) (MixedConfig, error) {
+	var config MixedConfig
+	if err := json.Unmarshal(raw, &config); err != nil {
+		return MixedConfig{}, errors.New("mixed config must be an object with items")
+	}
+
+	ids := make([]string, 0, len(config.Items))
+	refs := map[string]bool{}
+	for _, item := range config.Items {
+		ids = append(ids, item.Id)
+		if err := item.validate(config.Scale); err != nil {
+			return MixedConfig{}, err
+		}
+		keys := item.ScoreKeys()
+		for _, key := range keys {
+			refs[key] = true
+		}
+		if len(keys) > 0 {
+			refs[item.Id] = true
+		}
+	}
+	if err := validateQuestionIds(ids); err != nil {
+		return MixedConfig{}, err
+	}
+	if err := config.compileConditions(); err != nil {
+		return MixedConfig{}, err
+	}
+	if err := config.ScoringRules.validate(refs); err != nil {
+		return MixedConfig{}, err
+	}
+	return config, nil
+}
+
+// compileConditions compiles the conditions of the sections and items and
+// checks that they only refer to the profile and to the items before them:
+// the items before the first item of a section for a section.
+func (c *MixedConfig) compileConditions() error {
+	sections := make(map[string]TestSection, len(c.Sections))
+	for _, section := range c.Sections {
+		if section.Id == "" {
+			return errors.New("section id is empty")
+		}
+		if _, ok := sections[section.Id]; ok {
+			return fmt.Errorf("section id %q is not unique", section.Id)
+		}
+		sections[section.Id] = section
+	}
+
+	all := make(map[string]bool, len(c.Items))
+	for _, item := range c.Items {
+		all[item.Id] = true
+	}
+	compile := func(source, owner string, earlier map[string]bool) (Condition, error) {
+		condition, err := ParseCondition(source)
+		if err != nil {
+			return Condition{}, fmt.Errorf("condition of %s: %w", owner, err)
+		}
+		for _, ref := range condition.Refs() {
+			itemId, _, _ := strings.Cut(ref, ".")
+			if earlier[ref] || (!all[itemId] && isProfileField(ref)) {
+				continue
+			}
+			return Condition{}, fmt.Errorf("condition of %s refers to %q, which is neither an earlier question nor a profile field", owner, ref)
+		}
+		return condition, nil
+	}
+
+	c.itemConditions = map[string]Condition{}
+	c.sectionConditions = map[string]Condition{}
+	earlier := map[string]bool{}
+	used := map[string]bool{}
+	for _, item := range c.Items {
+		if item.Section != "" {
+			section, ok := sections[item.Section]
+			if !ok {
+				return fmt.Errorf("question %q is in unknown section %q", item.Id, item.Section)
+			}
+			if !used[section.Id] && section.ShowIf != "" {
+				condition, err := compile(section.ShowIf, fmt.Sprintf("section %q", section.Id), earlier)
+				if err != nil {
+					return err
+				}
+				c.sectionConditions[section.Id] = condition
+			}
+			used[section.Id] = true
+		}
+		if item.ShowIf != "" {
+			condition, err := compile(item.ShowIf, fmt.Sprintf("question %q", item.Id), earlier)
+			if err != nil {
+				return err
+			}
+			c.itemConditions[item.Id] = condition
+		}
+
+		earlier[item.Id] = true
+		for _, row := range item.Rows {
+			earlier[MatrixKey(item.Id, row.Id)] = true
+		}
+	}
+	for _, section := range c.Sections {
+		if !used[section.Id] {
+			return fmt.Errorf("section %q has no questions", section.Id)
+		}
+	}
+	return nil
+}
+
+// visibility tells which items are shown for the answers. The conditions
+// see an answer to a hidden item as null.
+func (c MixedConfig) visibility(answers map[string]json.RawMessage, profile UserProfile) map[string]bool {
+	visible := make(map[string]bool, len(c.Items))
+	isItem := make(map[string]bool, len(c.Items))
+	for _, item := range c.Items {
+		isItem[item.Id] = true
+	}
+	lookup := func(name string) any {
+		itemId, rowId, isRow := strings.Cut(name, ".")
+		if !isItem[itemId] {
+			return profile[name]
+		}
+		if !visible[itemId] {
+			return nil
+		}
+		value := decodeAnswer(answers[itemId])
+		if isRow {
+			rows, _ := value.(map[string]any)
+			return rows[rowId]
+		}
+		return value
+	}
+
+	shownSections := map[string]bool{}
+	for _, item := range c.Items {
+		if item.Section != "" {
+			shown, ok := shownSections[item.Section]
+			if !ok {
+				shown = c.sectionConditions[item.Section].Eval(lookup)
+				shownSections[item.Section] = shown
+			}
+			if !shown {
+				continue
+			}
+		}
+		visible[item.Id] = c.itemConditions[item.Id].Eval(lookup)
+	}
+	return visible
+}
+
+func decodeAnswer(raw json.RawMessage) any {
+	var value any
+	if len(raw) == 0 || json.Unmarshal(raw, &value) != nil {
+		return nil
+	}
+	return value
+}
+
+func (c MixedConfig) ValidateAnswers(answers map[string]json.RawMessage, profile UserProfile) *AnswersError {
+	return validateItemAnswers(c.Items, c.Scale, answers, c.visibility(answers, profile))
+}
+
+func (c MixedConfig) QuestionIds() []string {
+	return itemIds(c.Items)
+}
+
+func (c MixedConfig) VisibleQuestions(answers map[string]json.RawMessage, profile UserProfile) []string {
+	visible := c.visibility(answers, profile)
+	ids := make([]string, 0, len(c.Items))
+	for _, item := range c.Items {
+		if visible[item.Id] {
+			ids = append(ids, item.Id)
+		}
+	}
+	return ids
+}
+
+func (c LikertConfig) ValidateAnswers(answers map[string]json.RawMessage, _ UserProfile) *AnswersError {
+	items := c.Items()
+	return validateItemAnswers(items, c.Scale, answers, allVisible(items))
+}
+
+func (c LikertConfig) VisibleQuestions(map[string]json.RawMessage, UserProfile) []string {
+	return c.QuestionIds()
+}
+
+func (c LikertConfig) QuestionIds() []string {
+	return itemIds(c.Items())
+}
+
+type SingleChoiceQuestion struct {
+	Id       string   `json:"id"`
+	Text     string   `json:"text"`
+	Options  []string `json:"options"`
+	Optional bool     `json:"optional,omitempty"`
+}
+
+type SingleChoiceConfig struct {
+	Questions []SingleChoiceQuestion `json:"questions"`
+}
+
+func ParseSingleChoiceConfig(raw json.RawMessage) (SingleChoiceConfig, error) {
+	var config SingleChoiceConfig
+	if err := json.Unmarshal(raw, &config); err != nil {
+		return SingleChoiceConfig{}, errors.New("single choice config must be an object with questions")
+	}
+
+	ids := make([]string, 0, len(config.Questions))
+	for _, question := range config.Questions {
+		ids = append(ids, question.Id)
+		if err := validateOptions(question.Id, question.Options); err != nil {
+			return SingleChoiceConfig{}, err
+		}
+	}
+	if err := validateQuestionIds(ids); err != nil {
+		return SingleChoiceConfig{}, err
+	}
+	return config, nil
+}
+
+// Items returns the questions as single_choice items.
+func (c SingleChoiceConfig) Items() []TestItem {
+	items := make([]TestItem, 0, len(c.Questions))
+	for _, question := range c.Questions {
+		items = append(items, TestItem{
+			Id:       question.Id,
+			Type:     ItemTypeSingleChoice,
+			Text:     question.Text,
+			Optional: question.Optional,
+			Options:  question.Options,
+		})
+	}
+	return items
+}
+
+func (c SingleChoiceConfig) ValidateAnswers(answers map[string]json.RawMessage, _ UserProfile) *AnswersError {
+	items := c.Items()
+	return validateItemAnswers(items, LikertScale{}, answers, allVisible(items))
+}
+
+func (c SingleChoiceConfig) VisibleQuestions(map[string]json.RawMessage, UserProfile) []string {
+	return c.QuestionIds()
+}
+
+func (c SingleChoiceConfig) QuestionIds() []string {
+	return itemIds(c.Items())
+}
+
+// TextQuestion is a question with a free text answer of at most MaxLength
+// characters, MaxTextAnswerLength when it is not set.
+type TextQuestion struct {
+	Id        string `json:"id"`
+	Text      string `json:"text"`
+	MaxLength int    `json:"max_length,omitempty"`
+	Optional  bool   `json:"optional,omitempty"`
+}
+
+type TextConfig struct {
+	Questions []TextQuestion `json:"questions"`
+}
+
+func ParseTextConfig(raw json.RawMessage) (TextConfig, error) {
+	var config TextConfig
+	if err := json.Unmarshal(raw, &config); err != nil {
+		return TextConfig{}, errors.New("text config must be an object with questions")
+	}
+
+	ids := make([]string, 0, len(config.Questions))
+	for _, question := range config.Questions {
+		ids = append(ids, question.Id)
+		if question.MaxLength < 0 || question.MaxLength > MaxTextAnswerLength {
+			return TextConfig{}, fmt.Errorf("max length of question %q must be between 0 and %d", question.Id, MaxTextAnswerLength)
+		}
+	}
+	if err := validateQuestionIds(ids); err != nil {
+		return TextConfig{}, err
+	}
+	return config, nil
+}
+
+// Items returns the questions as text items.
+func (c TextConfig) Items() []TestItem {
+	items := make([]TestItem, 0, len(c.Questions))
+	for _, question := range c.Questions {
+		items = append(items, TestItem{
+			Id:        question.Id,
+			Type:      ItemTypeText,
+			Text:      question.Text,
+			Optional:  question.Optional,
+			MaxLength: question.MaxLength,
+		})
+	}
+	return items
+}
+
+func (c TextConfig) ValidateAnswers(answers map[string]json.RawMessage, _ UserProfile) *AnswersError {
+	items := c.Items()
+	return validateItemAnswers(items, LikertScale{}, answers, allVisible(items))
+}
+
+func (c TextConfig) VisibleQuestions(map[string]json.RawMessage, UserProfile) []string {
+	return c.QuestionIds()
+}
+
+func (c TextConfig) QuestionIds() []string {
+	return itemIds(c.Items())
+}
+
+// ValidateItemTimes checks that the item times are kept for the questions of
+// the config, nil when they are.
+func ValidateItemTimes(config TestConfig, itemTimes map[string]float64) *AnswersError {
+	known := map[string]bool{}
+	for _, id := range config.QuestionIds() {
+		known[id] = true
+	}
+	result := &AnswersError{}
+	for id := range itemTimes {
+		if !known[id] {
+			result.add(id, AnswerUnknownQuestion)
+		}
+	}
+	return result.orNil()
+}
+
+func allVisible(items []TestItem) map[string]bool {
+	visible := make(map[string]bool, len(items))
+	for _, item := range items {
+		visible[item.Id] = true
+	}
+	return visible
+}
+
+func itemIds(items []TestItem) []string {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.Id)
+	}
+	return ids
+}
+
+func validateQuestionIds(ids []string) error {
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if id == "" {
+			return errors.New("question id is empty")
+		}
+		if seen[id] {
+			return fmt.Errorf("question id %q is not unique", id)
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+func validateOptions(questionId string, options []string) error {
+	if len(options) == 0 {
+		return fmt.Errorf("question %q has no options", questionId)
+	}
+	seen := make(map[string]bool, len(options))
+	for _, option := range options {
+		if option == "" {
+			return fmt.Errorf("question %q has an empty option", questionId)
+		}
+		if seen[option] {
+			return fmt.Errorf("option %q of question %q is not unique", option, questionId)
+		}
+		seen[option] = true
+	}
+	return nil
+}
+
+func isNullAnswer(raw json.RawMessage) bool {
+	return strings.TrimSpace(string(raw)) == "null"
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// isOrderingOf tells whether values hold every one of the options once.
+func isOrderingOf(values, options []string) bool {
+	if len(values) != len(options) {
+		return false
+	}
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		if seen[value] || !containsString(options, value) {
+			return false
+		}
+		seen[value] = true
+	}
+	return true
+}
@@ -0,0 +1,103 @@
+package gameServer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrTestNotFound  = errors.New("test or its version is not found")
+	ErrTestNotScaled = errors.New("test has no items answered on a scale")
+)
+
+// TestReportInput selects the results a report is computed over. GroupId and
+// ParSetId keep the users of a group or of the parameter set of a study,
+// Context and Phase keep the attempts taken there, an empty phase of a study
+// keeps all its phases. Version defaults to the current version of the test.
+type TestReportInput struct {
+	GroupId  *int   `form:"group_id"`
+	ParSetId *int   `form:"par_set_id"`
+	Context  string `form:"context"`
+	Phase    string `form:"phase"`
+	Version  *int   `form:"version"`
+	// CreatorId limits the report to the users of the groups created by
+	// the researcher, it is set by the handler and not by the query.
+	CreatorId *int `form:"-"`
+}
+
+func (i TestReportInput) Validate() error {
+	if i.GroupId != nil && *i.GroupId <= 0 {
+		return errors.New("group id is non-positive")
+	}
+	if i.ParSetId != nil && *i.ParSetId <= 0 {
+		return errors.New("parameter set id is non-positive")
+	}
+	if i.Version != nil && *i.Version <= 0 {
+		return errors.New("version is non-positive")
+	}
+	if i.Context != "" && !IsTestContext(i.Context) {
+		return fmt.Errorf("unknown test context %q", i.Context)
+	}
+	if i.Phase != "" && i.Context != TestContextStudyPhase {
+		return errors.New("phase is only set for a study phase")
+	}
+	return nil
+}
+
+// TestVersion is the type and config of a test as they were in a version.
+type TestVersion struct {
+	TestId  int             `db:"test_id"`
+	Version int             `db:"version"`
+	Type    string          `db:"type"`
+	Config  json.RawMessage `db:"config"`
+}
+
+// ResponseCount is how many results answered an item with the value.
+type ResponseCount struct {
+	Value float64 `json:"value"`
+	Count int     `json:"count"`
+}
+
+// TestItemReport describes the answers to an item answered on a scale, the
+// rows of a matrix are reported as items of their own. Mean, Sd and the
+// distribution are those of the answers as given, ItemTotal (the correlation
+// with the total of the other items) and AlphaIfDeleted take the
+// reverse-keyed items flipped and, in a test with subscales, the other items
+// of the first subscale of the item only. The statistics that are not defined for the
+// answers are nil.
+type TestItemReport struct {
+	Id             string          `json:"id"`
+	Text           string          `json:"text"`
+	Reverse        bool            `json:"reverse,omitempty"`
+	Answered       int             `json:"answered"`
+	Mean           *float64        `json:"mean"`
+	Sd             *float64        `json:"sd"`
+	ItemTotal      *float64        `json:"item_total"`
+	AlphaIfDeleted *float64        `json:"alpha_if_deleted"`
+	Distribution   []ResponseCount `json:"distribution"`
+}
+
+// ScaleReliability is the reliability of the whole test or of a subscale
+// over the results that answered all its items. A test with subscales has
+// no Alpha and Omega of the whole test, its subscales measure different
+// constructs.
+type ScaleReliability struct {
+	Id            string   `json:"id,omitempty"`
+	Title         string   `json:"title,omitempty"`
+	Items         []string `json:"items"`
+	CompleteCases int      `json:"complete_cases"`
+	Alpha         *float64 `json:"alpha"`
+	Omega         *float64 `json:"omega"`
+}
+
+// TestReport is the psychometric report of a version of a test over the
+// results selected by TestReportInput.
+type TestReport struct {
+	TestId    int                `json:"test_id"`
+	Version   int                `json:"version"`
+	Results   int                `json:"results"`
+	Scale     ScaleReliability   `json:"scale"`
+	Subscales []ScaleReliability `json:"subscales"`
+	Items     []TestItemReport   `json:"items"`
+}
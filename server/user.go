@@ -42,10 +42,21 @@ type PlayerStat struct {
 	ParSets     []ParameterSet `json:"par_sets"`
 }
 
+// Codes of the events in PlayerEvent.Name, the i18n catalog translates them.
+const (
+	EventCrash             = "crash"
+	EventUsefulAiSignal    = "useful_ai_signal"
+	EventDeceptiveAiSignal = "deceptive_ai_signal"
+	EventStop              = "stop"
+	EventPause             = "pause"
+	EventCheck             = "check"
+	EventRejectAdvice      = "reject_advice"
+)
+
 type PlayerEvent struct {
 	Name      []string `json:"name"`
 	Y         float64  `json:"x" db:"x"`
-	CheckInfo *string  `json:"check_info"`
+	RiskLevel *string  `json:"risk_level"`
 }
 
 type RegisterUserInput struct {